@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Notification channel types
+const (
+	AlertChannelWebhook = "webhook"
+	AlertChannelEmail   = "email"
+	AlertChannelNtfy    = "ntfy"
+	AlertChannelGotify  = "gotify"
+)
+
+// AlertChannel is a notification target. Which fields are used depends on the type.
+type AlertChannel struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"` // webhook, email, ntfy, gotify
+	Enabled bool   `json:"enabled"`
+
+	// webhook, ntfy (server + topic), gotify (server)
+	URL          string            `json:"url,omitempty"`
+	Method       string            `json:"method,omitempty"`       // webhook: HTTP method (default POST)
+	Headers      map[string]string `json:"headers,omitempty"`      // webhook: additional headers
+	BodyTemplate string            `json:"bodyTemplate,omitempty"` // webhook: Go template, default is the alert as JSON
+	Token        string            `json:"token,omitempty"`        // ntfy access token / gotify app token
+	Priority     int               `json:"priority,omitempty"`     // ntfy (1-5) / gotify (0-10), 0 = by severity
+
+	// email
+	SMTPHost string   `json:"smtpHost,omitempty"`
+	SMTPPort int      `json:"smtpPort,omitempty"` // 465 = implicit TLS, otherwise STARTTLS if offered
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+}
+
+var alertHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+func (c *AlertChannel) validate() error {
+	switch c.Type {
+	case AlertChannelWebhook, AlertChannelNtfy, AlertChannelGotify:
+		u, err := url.Parse(c.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid URL %q", c.URL)
+		}
+		if c.Type == AlertChannelGotify && c.Token == "" {
+			return fmt.Errorf("gotify requires an application token")
+		}
+		if c.BodyTemplate != "" {
+			if _, err := parseAlertTemplate(c.BodyTemplate); err != nil {
+				return fmt.Errorf("invalid body template: %v", err)
+			}
+		}
+	case AlertChannelEmail:
+		if c.SMTPHost == "" || c.From == "" || len(c.To) == 0 {
+			return fmt.Errorf("email requires SMTP host, sender and recipients")
+		}
+	default:
+		return fmt.Errorf("invalid type %q", c.Type)
+	}
+	return nil
+}
+
+// Send delivers an alert through the channel
+func (c *AlertChannel) Send(alert Alert) error {
+	switch c.Type {
+	case AlertChannelWebhook:
+		return c.sendWebhook(alert)
+	case AlertChannelEmail:
+		return c.sendEmail(alert)
+	case AlertChannelNtfy:
+		return c.sendNtfy(alert)
+	case AlertChannelGotify:
+		return c.sendGotify(alert)
+	}
+	return fmt.Errorf("unknown channel type %q", c.Type)
+}
+
+func parseAlertTemplate(text string) (*template.Template, error) {
+	return template.New("body").Funcs(template.FuncMap{
+		// json encodes a value, e.g. {"text": {{json .Message}}}
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+}
+
+func (c *AlertChannel) sendWebhook(alert Alert) error {
+	var body []byte
+	if c.BodyTemplate == "" {
+		var err error
+		if body, err = json.Marshal(alert); err != nil {
+			return err
+		}
+	} else {
+		tmpl, err := parseAlertTemplate(c.BodyTemplate)
+		if err != nil {
+			return fmt.Errorf("invalid body template: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, alert); err != nil {
+			return fmt.Errorf("failed to render body template: %v", err)
+		}
+		body = buf.Bytes()
+	}
+
+	method := strings.ToUpper(c.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range c.Headers {
+		req.Header.Set(name, value)
+	}
+	return doAlertRequest(req)
+}
+
+func (c *AlertChannel) sendNtfy(alert Alert) error {
+	req, err := http.NewRequest(http.MethodPost, c.URL, strings.NewReader(alert.Message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", mime.QEncoding.Encode("utf-8", alert.Title))
+	priority := c.Priority
+	if priority == 0 {
+		priority = map[string]int{"error": 5, "warning": 4, "info": 3}[alert.Severity]
+		if priority == 0 || alert.Status == AlertResolved {
+			priority = 3
+		}
+	}
+	req.Header.Set("Priority", strconv.Itoa(priority))
+	if alert.Status == AlertResolved {
+		req.Header.Set("Tags", "white_check_mark")
+	} else {
+		req.Header.Set("Tags", "rotating_light")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return doAlertRequest(req)
+}
+
+func (c *AlertChannel) sendGotify(alert Alert) error {
+	priority := c.Priority
+	if priority == 0 {
+		priority = map[string]int{"error": 8, "warning": 5, "info": 2}[alert.Severity]
+		if priority == 0 || alert.Status == AlertResolved {
+			priority = 2
+		}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"title":    alert.Title,
+		"message":  alert.Message,
+		"priority": priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(c.URL, "/")+"/message", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gotify-Key", c.Token)
+	return doAlertRequest(req)
+}
+
+func doAlertRequest(req *http.Request) error {
+	resp, err := alertHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (c *AlertChannel) sendEmail(alert Alert) error {
+	port := c.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(c.SMTPHost, strconv.Itoa(port))
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", c.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", alert.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(alert.Message, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.SMTPHost)
+	}
+
+	if port != 465 {
+		// net/smtp upgrades to STARTTLS when the server offers it
+		return smtp.SendMail(addr, auth, c.From, c.To, msg.Bytes())
+	}
+
+	// Implicit TLS (SMTPS)
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 15 * time.Second}, "tcp", addr, &tls.Config{ServerName: c.SMTPHost})
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, c.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(c.From); err != nil {
+		return err
+	}
+	for _, to := range c.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,779 @@
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AlertSettings holds the notification rules and channels
+type AlertSettings struct {
+	Enabled  bool            `json:"enabled"`
+	Rules    []*AlertRule    `json:"rules"`
+	Channels []*AlertChannel `json:"channels"`
+}
+
+// Alert rule types
+const (
+	AlertRuleEvent     = "event"     // Matches newly archived events
+	AlertRuleThreshold = "threshold" // Compares a temperature snapshot field against a threshold
+)
+
+// AlertRule decides which events or sensor values trigger a notification.
+// Empty filter lists match everything.
+type AlertRule struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Type    string `json:"type"` // event or threshold
+
+	// Filters (both rule types)
+	InstallationIDs []string `json:"installationIds,omitempty"`
+	DeviceIDs       []string `json:"deviceIds,omitempty"`
+
+	// Event rules
+	EventTypes     []string `json:"eventTypes,omitempty"`     // e.g. device-error, gateway-offline
+	ErrorCodes     []string `json:"errorCodes,omitempty"`     // e.g. F.160
+	Severities     []string `json:"severities,omitempty"`     // error, warning, info
+	CodeCategories []string `json:"codeCategories,omitempty"` // fault, maintenance, status, ...
+
+	// Threshold rules
+	Field      string  `json:"field,omitempty"`      // JSON name of the TemperatureSnapshot field, e.g. dhw_temp, pressure_supply
+	Operator   string  `json:"operator,omitempty"`   // <, <=, >, >=
+	Threshold  float64 `json:"threshold,omitempty"`  // Limit value
+	Hysteresis float64 `json:"hysteresis,omitempty"` // Distance from the threshold before the alert is resolved
+	Severity   string  `json:"severity,omitempty"`   // Severity of threshold alerts (default: warning)
+
+	CooldownMinutes int      `json:"cooldownMinutes"`    // Minimum time between notifications of the same alert
+	NotifyResolved  bool     `json:"notifyResolved"`     // Send a notice when the alert is resolved
+	Channels        []string `json:"channels,omitempty"` // Channel IDs, empty = all enabled channels
+}
+
+// Alert status values
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// Alert is a single notification as passed to the channels and webhook templates
+type Alert struct {
+	RuleID         string    `json:"ruleId"`
+	RuleName       string    `json:"ruleName"`
+	Status         string    `json:"status"` // firing or resolved
+	Severity       string    `json:"severity"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	InstallationID string    `json:"installationId,omitempty"`
+	GatewaySerial  string    `json:"gatewaySerial,omitempty"`
+	DeviceID       string    `json:"deviceId,omitempty"`
+	AccountName    string    `json:"accountName,omitempty"`
+	EventType      string    `json:"eventType,omitempty"`
+	ErrorCode      string    `json:"errorCode,omitempty"`
+	CodeCategory   string    `json:"codeCategory,omitempty"`
+	Field          string    `json:"field,omitempty"`
+	Value          *float64  `json:"value,omitempty"`
+	Threshold      *float64  `json:"threshold,omitempty"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// AlertState is the persisted state of an alert (deduplication, cooldown, resolution)
+type AlertState struct {
+	Key            string     `json:"key"`
+	RuleID         string     `json:"ruleId"`
+	Active         bool       `json:"active"`
+	Notified       bool       `json:"notified"` // Firing notification was sent (not suppressed by cooldown)
+	Severity       string     `json:"severity"`
+	Title          string     `json:"title"`
+	Message        string     `json:"message"`
+	InstallationID string     `json:"installationId"`
+	DeviceID       string     `json:"deviceId"`
+	TriggeredAt    time.Time  `json:"triggeredAt"`
+	LastNotifiedAt *time.Time `json:"lastNotifiedAt,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+}
+
+const (
+	defaultAlertCooldownMinutes = 60
+
+	// Older events (initial archive run, full sync) only update the alert state without notifying
+	alertMaxEventAge = 24 * time.Hour
+)
+
+// alertMutex serializes rule evaluation so state transitions aren't evaluated twice
+var alertMutex sync.Mutex
+
+// GetAlertSettings retrieves the alert settings (default rules if not configured)
+func GetAlertSettings() (*AlertSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.AlertSettings
+	if settings == nil {
+		settings = defaultAlertSettings()
+	}
+	applyAlertDefaults(settings)
+	return settings, nil
+}
+
+// SetAlertSettings updates the alert settings
+func SetAlertSettings(settings *AlertSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	applyAlertDefaults(settings)
+	store.AlertSettings = settings
+	return SaveAccounts(store)
+}
+
+// defaultAlertSettings covers the two cases nobody notices without opening the events page
+func defaultAlertSettings() *AlertSettings {
+	return &AlertSettings{
+		Rules: []*AlertRule{
+			{
+				ID:              "faults",
+				Name:            "Störungen",
+				Enabled:         true,
+				Type:            AlertRuleEvent,
+				Severities:      []string{"error"},
+				CodeCategories:  []string{"fault"},
+				CooldownMinutes: defaultAlertCooldownMinutes,
+				NotifyResolved:  true,
+			},
+			{
+				ID:              "gateway-offline",
+				Name:            "Gateway offline",
+				Enabled:         true,
+				Type:            AlertRuleEvent,
+				EventTypes:      []string{"gateway-offline"},
+				CooldownMinutes: defaultAlertCooldownMinutes,
+				NotifyResolved:  true,
+			},
+		},
+		Channels: []*AlertChannel{},
+	}
+}
+
+func applyAlertDefaults(settings *AlertSettings) {
+	if settings.Rules == nil {
+		settings.Rules = []*AlertRule{}
+	}
+	if settings.Channels == nil {
+		settings.Channels = []*AlertChannel{}
+	}
+	for _, rule := range settings.Rules {
+		if rule.ID == "" {
+			rule.ID = newAlertID()
+		}
+		if rule.Type == "" {
+			rule.Type = AlertRuleEvent
+		}
+		if rule.CooldownMinutes < 0 {
+			rule.CooldownMinutes = 0
+		}
+		if rule.Type == AlertRuleThreshold && rule.Severity == "" {
+			rule.Severity = "warning"
+		}
+	}
+	for _, channel := range settings.Channels {
+		if channel.ID == "" {
+			channel.ID = newAlertID()
+		}
+	}
+}
+
+// validateAlertSettings checks rules and channels before saving
+func validateAlertSettings(settings *AlertSettings) error {
+	channelIDs := make(map[string]bool)
+	for _, channel := range settings.Channels {
+		if err := channel.validate(); err != nil {
+			return fmt.Errorf("channel %q: %v", channel.Name, err)
+		}
+		channelIDs[channel.ID] = true
+	}
+
+	for _, rule := range settings.Rules {
+		switch rule.Type {
+		case AlertRuleEvent, "":
+		case AlertRuleThreshold:
+			if _, ok := snapshotFieldIndex(rule.Field); !ok {
+				return fmt.Errorf("rule %q: unknown snapshot field %q", rule.Name, rule.Field)
+			}
+			switch rule.Operator {
+			case "<", "<=", ">", ">=":
+			default:
+				return fmt.Errorf("rule %q: invalid operator %q", rule.Name, rule.Operator)
+			}
+			if rule.Hysteresis < 0 {
+				return fmt.Errorf("rule %q: hysteresis must not be negative", rule.Name)
+			}
+		default:
+			return fmt.Errorf("rule %q: invalid type %q", rule.Name, rule.Type)
+		}
+		for _, id := range rule.Channels {
+			if id != "" && !channelIDs[id] {
+				return fmt.Errorf("rule %q: unknown channel %q", rule.Name, id)
+			}
+		}
+	}
+	return nil
+}
+
+func newAlertID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// evaluateEventAlerts runs the event rules against newly archived events
+func evaluateEventAlerts(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	settings, err := GetAlertSettings()
+	if err != nil {
+		log.Printf("Alerting: error loading settings: %v", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	alertMutex.Lock()
+	defer alertMutex.Unlock()
+
+	// Oldest first, so an error and its clearing within one batch end up resolved
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].EventTimestamp < sorted[j].EventTimestamp })
+
+	for _, rule := range settings.Rules {
+		if !rule.Enabled || rule.Type != AlertRuleEvent {
+			continue
+		}
+		for i := range sorted {
+			event := &sorted[i]
+			eventType, firing, stateful := alertEventState(event)
+			if !rule.matchesEvent(event, eventType) {
+				continue
+			}
+
+			alert := eventAlert(rule, event, eventType, firing)
+			key := rule.ID + "|" + event.InstallationID + "|" + event.GatewaySerial
+			if eventType != "gateway-offline" {
+				key += "|" + event.DeviceID + "|" + eventType + "|" + event.ErrorCode
+			}
+			silent := time.Since(alert.Timestamp) > alertMaxEventAge
+			processAlert(settings, rule, key, firing, stateful, silent, alert)
+		}
+	}
+}
+
+// alertEventState returns the event type used for matching and whether the event raises or clears an alert.
+// gateway-online clears gateway-offline, events with an active flag are stateful, all others fire once.
+func alertEventState(event *Event) (eventType string, firing bool, stateful bool) {
+	switch event.EventType {
+	case "gateway-offline":
+		return "gateway-offline", true, true
+	case "gateway-online":
+		return "gateway-offline", false, true
+	}
+	if event.Active != nil {
+		return event.EventType, *event.Active, true
+	}
+	return event.EventType, true, false
+}
+
+func (rule *AlertRule) matchesEvent(event *Event, eventType string) bool {
+	return matchesFilter(rule.InstallationIDs, event.InstallationID) &&
+		matchesFilter(rule.DeviceIDs, event.DeviceID) &&
+		matchesFilter(rule.EventTypes, eventType) &&
+		matchesFilter(rule.ErrorCodes, event.ErrorCode) &&
+		matchesFilter(rule.Severities, event.Severity) &&
+		matchesFilter(rule.CodeCategories, event.CodeCategory)
+}
+
+// matchesFilter reports whether value is in the filter list (case-insensitive), an empty list matches all
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if strings.EqualFold(strings.TrimSpace(f), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func eventAlert(rule *AlertRule, event *Event, eventType string, firing bool) Alert {
+	alert := Alert{
+		RuleID:         rule.ID,
+		RuleName:       rule.Name,
+		Status:         AlertFiring,
+		Severity:       event.Severity,
+		InstallationID: event.InstallationID,
+		GatewaySerial:  event.GatewaySerial,
+		DeviceID:       event.DeviceID,
+		AccountName:    event.AccountName,
+		EventType:      eventType,
+		ErrorCode:      event.ErrorCode,
+		CodeCategory:   event.CodeCategory,
+		Timestamp:      time.Now(),
+	}
+	if ts, err := time.Parse(time.RFC3339, event.EventTimestamp); err == nil {
+		alert.Timestamp = ts
+	}
+	if !firing {
+		alert.Status = AlertResolved
+	}
+
+	var subject string
+	switch {
+	case eventType == "gateway-offline" && firing:
+		subject = fmt.Sprintf("Gateway %s ist offline", event.GatewaySerial)
+		if alert.Severity == "" {
+			alert.Severity = "warning"
+		}
+	case eventType == "gateway-offline":
+		subject = fmt.Sprintf("Gateway %s ist wieder online", event.GatewaySerial)
+	case event.ErrorCode != "":
+		description := event.ErrorDescription
+		if description == "" {
+			description = event.HumanReadable
+		}
+		subject = strings.TrimSpace(event.ErrorCode + " " + description)
+	case event.FeatureName != "":
+		subject = strings.TrimSpace(event.FeatureName + " " + event.FeatureValue)
+	case event.HumanReadable != "":
+		subject = event.HumanReadable
+	default:
+		subject = eventType
+	}
+
+	if firing {
+		alert.Title = fmt.Sprintf("%s: %s", rule.Name, subject)
+	} else {
+		alert.Title = fmt.Sprintf("Behoben – %s: %s", rule.Name, subject)
+	}
+
+	var lines []string
+	lines = append(lines, subject)
+	if event.AccountName != "" {
+		lines = append(lines, "Account: "+event.AccountName)
+	}
+	lines = append(lines, "Installation: "+event.InstallationID)
+	if event.DeviceID != "" {
+		lines = append(lines, fmt.Sprintf("Gerät: %s (%s)", event.DeviceID, event.ModelID))
+	}
+	if event.CodeCategory != "" || event.Severity != "" {
+		lines = append(lines, fmt.Sprintf("Kategorie: %s / %s", event.CodeCategory, event.Severity))
+	}
+	lines = append(lines, "Zeitpunkt: "+alert.Timestamp.Local().Format("02.01.2006 15:04:05"))
+	alert.Message = strings.Join(lines, "\n")
+	return alert
+}
+
+// evaluateSnapshotAlerts runs the threshold rules against the snapshots of a temperature logging run
+func evaluateSnapshotAlerts(snapshots []*TemperatureSnapshot) {
+	if len(snapshots) == 0 {
+		return
+	}
+	settings, err := GetAlertSettings()
+	if err != nil {
+		log.Printf("Alerting: error loading settings: %v", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+
+	alertMutex.Lock()
+	defer alertMutex.Unlock()
+
+	for _, rule := range settings.Rules {
+		if !rule.Enabled || rule.Type != AlertRuleThreshold {
+			continue
+		}
+		for _, snapshot := range snapshots {
+			if !matchesFilter(rule.InstallationIDs, snapshot.InstallationID) || !matchesFilter(rule.DeviceIDs, snapshot.DeviceID) {
+				continue
+			}
+			value, ok := snapshotFieldValue(snapshot, rule.Field)
+			if !ok {
+				continue
+			}
+
+			var firing bool
+			switch {
+			case rule.violated(value):
+				firing = true
+			case rule.recovered(value):
+				firing = false
+			default:
+				continue // Within hysteresis, keep the current state
+			}
+
+			key := rule.ID + "|" + snapshot.InstallationID + "|" + snapshot.GatewayID + "|" + snapshot.DeviceID
+			processAlert(settings, rule, key, firing, true, false, thresholdAlert(rule, snapshot, value, firing))
+		}
+	}
+}
+
+func (rule *AlertRule) violated(value float64) bool {
+	switch rule.Operator {
+	case "<":
+		return value < rule.Threshold
+	case "<=":
+		return value <= rule.Threshold
+	case ">":
+		return value > rule.Threshold
+	case ">=":
+		return value >= rule.Threshold
+	}
+	return false
+}
+
+func (rule *AlertRule) recovered(value float64) bool {
+	if rule.violated(value) {
+		return false
+	}
+	switch rule.Operator {
+	case "<", "<=":
+		return value >= rule.Threshold+rule.Hysteresis
+	default:
+		return value <= rule.Threshold-rule.Hysteresis
+	}
+}
+
+func thresholdAlert(rule *AlertRule, snapshot *TemperatureSnapshot, value float64, firing bool) Alert {
+	threshold := rule.Threshold
+	alert := Alert{
+		RuleID:         rule.ID,
+		RuleName:       rule.Name,
+		Status:         AlertFiring,
+		Severity:       rule.Severity,
+		InstallationID: snapshot.InstallationID,
+		GatewaySerial:  snapshot.GatewayID,
+		DeviceID:       snapshot.DeviceID,
+		AccountName:    snapshot.AccountName,
+		Field:          rule.Field,
+		Value:          &value,
+		Threshold:      &threshold,
+		Timestamp:      snapshot.Timestamp,
+	}
+
+	subject := fmt.Sprintf("%s = %g (Grenzwert %s %g)", rule.Field, value, rule.Operator, rule.Threshold)
+	if firing {
+		alert.Title = fmt.Sprintf("%s: %s", rule.Name, subject)
+	} else {
+		alert.Status = AlertResolved
+		alert.Title = fmt.Sprintf("Behoben – %s: %s = %g", rule.Name, rule.Field, value)
+	}
+	alert.Message = strings.Join([]string{
+		subject,
+		"Account: " + snapshot.AccountName,
+		"Installation: " + snapshot.InstallationID,
+		"Gerät: " + snapshot.DeviceID,
+		"Zeitpunkt: " + snapshot.Timestamp.Local().Format("02.01.2006 15:04:05"),
+	}, "\n")
+	return alert
+}
+
+// snapshotFieldIndex returns the struct field index of a TemperatureSnapshot field by its JSON name
+func snapshotFieldIndex(name string) (int, bool) {
+	if name == "" {
+		return 0, false
+	}
+	t := reflect.TypeOf(TemperatureSnapshot{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		if strings.Split(field.Tag.Get("json"), ",")[0] == name {
+			elem := field.Type.Elem().Kind()
+			return i, elem == reflect.Float64 || elem == reflect.Bool
+		}
+	}
+	return 0, false
+}
+
+// snapshotNumericFields lists the JSON names of the numeric and boolean snapshot fields usable in threshold rules
+func snapshotNumericFields() []string {
+	t := reflect.TypeOf(TemperatureSnapshot{})
+	fields := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := snapshotFieldIndex(name); ok {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// snapshotFieldValue returns a numeric snapshot field by JSON name (booleans as 0/1)
+func snapshotFieldValue(snapshot *TemperatureSnapshot, name string) (float64, bool) {
+	index, ok := snapshotFieldIndex(name)
+	if !ok {
+		return 0, false
+	}
+	value := reflect.ValueOf(snapshot).Elem().Field(index)
+	if value.IsNil() {
+		return 0, false
+	}
+	switch v := value.Elem().Interface().(type) {
+	case float64:
+		return v, true
+	case bool:
+		return boolToFloat(v), true
+	}
+	return 0, false
+}
+
+// processAlert applies deduplication, cooldown and resolution to an alert and sends notifications.
+// Stateless alerts (events without active flag) only fire and are subject to the cooldown.
+// Silent alerts only update the state.
+func processAlert(settings *AlertSettings, rule *AlertRule, key string, firing, stateful, silent bool, alert Alert) {
+	state, err := getAlertState(key)
+	if err != nil {
+		log.Printf("Alerting: %v", err)
+		return
+	}
+	now := time.Now()
+
+	if !firing {
+		if state == nil || !state.Active {
+			return
+		}
+		state.Active = false
+		state.ResolvedAt = &now
+		if err := saveAlertState(state); err != nil {
+			log.Printf("Alerting: %v", err)
+		}
+		if silent {
+			return
+		}
+		log.Printf("Alert resolved: %s", alert.Title)
+		// Only announce the resolution of alerts the user was told about
+		if rule.NotifyResolved && state.Notified {
+			sendAlert(settings, rule, alert)
+		}
+		return
+	}
+
+	if stateful && state != nil && state.Active {
+		return // Already active, deduplicated
+	}
+
+	if state == nil {
+		state = &AlertState{Key: key, RuleID: rule.ID}
+	}
+	cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+	notify := !silent && (state.LastNotifiedAt == nil || now.Sub(*state.LastNotifiedAt) >= cooldown)
+
+	state.Active = stateful
+	state.Notified = notify
+	state.Severity = alert.Severity
+	state.Title = alert.Title
+	state.Message = alert.Message
+	state.InstallationID = alert.InstallationID
+	state.DeviceID = alert.DeviceID
+	state.TriggeredAt = alert.Timestamp
+	state.ResolvedAt = nil
+	if notify {
+		state.LastNotifiedAt = &now
+	}
+	if err := saveAlertState(state); err != nil {
+		log.Printf("Alerting: %v", err)
+	}
+
+	if silent {
+		return
+	}
+	if !notify {
+		log.Printf("Alert suppressed by cooldown (%d min): %s", rule.CooldownMinutes, alert.Title)
+		return
+	}
+	log.Printf("Alert firing: %s", alert.Title)
+	sendAlert(settings, rule, alert)
+}
+
+type alertDelivery struct {
+	channel *AlertChannel
+	alert   Alert
+}
+
+var (
+	// Notifications are delivered in order by a single worker, so a resolution never overtakes its alert
+	alertQueue     = make(chan alertDelivery, 100)
+	alertQueueOnce sync.Once
+)
+
+// sendAlert queues an alert for the channels of the rule
+func sendAlert(settings *AlertSettings, rule *AlertRule, alert Alert) {
+	alertQueueOnce.Do(func() { go alertDeliveryWorker() })
+
+	for _, channel := range settings.Channels {
+		if !channel.Enabled {
+			continue
+		}
+		if len(rule.Channels) > 0 && !matchesFilter(rule.Channels, channel.ID) {
+			continue
+		}
+		select {
+		case alertQueue <- alertDelivery{channel: channel, alert: alert}:
+		default:
+			log.Printf("Alerting: delivery queue full, dropping %q for channel %q", alert.Title, channel.Name)
+		}
+	}
+}
+
+func alertDeliveryWorker() {
+	for d := range alertQueue {
+		if err := d.channel.Send(d.alert); err != nil {
+			log.Printf("Alerting: failed to send %q via %s channel %q: %v", d.alert.Title, d.channel.Type, d.channel.Name, err)
+		}
+	}
+}
+
+// getAlertState loads the state of an alert, nil if it never fired
+func getAlertState(key string) (*AlertState, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var state AlertState
+	var active, notified int
+	var triggeredAt string
+	var lastNotifiedAt, resolvedAt sql.NullString
+	err := eventDB.QueryRow(`
+		SELECT alert_key, rule_id, active, notified, severity, title, message, installation_id, device_id,
+			triggered_at, last_notified_at, resolved_at
+		FROM alert_state WHERE alert_key = ?
+	`, key).Scan(&state.Key, &state.RuleID, &active, &notified, &state.Severity, &state.Title, &state.Message,
+		&state.InstallationID, &state.DeviceID, &triggeredAt, &lastNotifiedAt, &resolvedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert state: %v", err)
+	}
+
+	state.Active = active == 1
+	state.Notified = notified == 1
+	state.TriggeredAt, _ = time.Parse(time.RFC3339, triggeredAt)
+	state.LastNotifiedAt = parseNullTime(lastNotifiedAt)
+	state.ResolvedAt = parseNullTime(resolvedAt)
+	return &state, nil
+}
+
+// saveAlertState inserts or updates the state of an alert
+func saveAlertState(state *AlertState) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	_, err := eventDB.Exec(`
+		INSERT INTO alert_state (alert_key, rule_id, active, notified, severity, title, message, installation_id, device_id,
+			triggered_at, last_notified_at, resolved_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(alert_key) DO UPDATE SET
+			rule_id = excluded.rule_id, active = excluded.active, notified = excluded.notified,
+			severity = excluded.severity, title = excluded.title, message = excluded.message,
+			installation_id = excluded.installation_id, device_id = excluded.device_id,
+			triggered_at = excluded.triggered_at, last_notified_at = excluded.last_notified_at,
+			resolved_at = excluded.resolved_at
+	`, state.Key, state.RuleID, boolToInt(state.Active), boolToInt(state.Notified), state.Severity, state.Title, state.Message,
+		state.InstallationID, state.DeviceID, state.TriggeredAt.UTC().Format(time.RFC3339),
+		formatNullTime(state.LastNotifiedAt), formatNullTime(state.ResolvedAt))
+	if err != nil {
+		return fmt.Errorf("failed to save alert state: %v", err)
+	}
+	return nil
+}
+
+// GetAlertStates returns the alert states, active alerts first
+func GetAlertStates(onlyActive bool, limit int) ([]AlertState, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	query := `
+		SELECT alert_key, rule_id, active, notified, severity, title, message, installation_id, device_id,
+			triggered_at, last_notified_at, resolved_at
+		FROM alert_state`
+	if onlyActive {
+		query += " WHERE active = 1"
+	}
+	query += " ORDER BY active DESC, triggered_at DESC LIMIT ?"
+
+	rows, err := eventDB.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert states: %v", err)
+	}
+	defer rows.Close()
+
+	states := make([]AlertState, 0)
+	for rows.Next() {
+		var state AlertState
+		var active, notified int
+		var triggeredAt string
+		var lastNotifiedAt, resolvedAt sql.NullString
+		if err := rows.Scan(&state.Key, &state.RuleID, &active, &notified, &state.Severity, &state.Title, &state.Message,
+			&state.InstallationID, &state.DeviceID, &triggeredAt, &lastNotifiedAt, &resolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan alert state: %v", err)
+		}
+		state.Active = active == 1
+		state.Notified = notified == 1
+		state.TriggeredAt, _ = time.Parse(time.RFC3339, triggeredAt)
+		state.LastNotifiedAt = parseNullTime(lastNotifiedAt)
+		state.ResolvedAt = parseNullTime(resolvedAt)
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func formatNullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseNullTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestPriority classifies outbound Viessmann API requests for the rate limit budget
+type RequestPriority int
+
+const (
+	PriorityBackground  RequestPriority = iota // Scheduled jobs (temperature logging, event archive)
+	PriorityDashboard                          // Reads triggered by the web UI
+	PriorityInteractive                        // Control commands triggered by the user
+)
+
+func (p RequestPriority) String() string {
+	switch p {
+	case PriorityBackground:
+		return "background"
+	case PriorityDashboard:
+		return "dashboard"
+	case PriorityInteractive:
+		return "interactive"
+	}
+	return "unknown"
+}
+
+// Share of the budget a priority class may use, the rest is reserved for higher classes.
+// Background jobs can't starve the dashboard, the dashboard can't block control commands.
+var priorityBudgetShare = map[RequestPriority]float64{
+	PriorityBackground:  0.7,
+	PriorityDashboard:   0.9,
+	PriorityInteractive: 1.0,
+}
+
+// How long a request may wait in the queue for a free slot in the 10-minute window
+var priorityMaxWait = map[RequestPriority]time.Duration{
+	PriorityBackground:  0, // Background jobs skip and retry at their next run
+	PriorityDashboard:   15 * time.Second,
+	PriorityInteractive: 60 * time.Second,
+}
+
+type requestPriorityKey struct{}
+
+// withRequestPriority attaches a priority class to a request context
+func withRequestPriority(ctx context.Context, priority RequestPriority) context.Context {
+	return context.WithValue(ctx, requestPriorityKey{}, priority)
+}
+
+// requestPriority returns the priority of a request. Requests without explicit priority
+// are classified by method: commands (POST) are interactive, reads are dashboard requests.
+func requestPriority(req *http.Request) RequestPriority {
+	if p, ok := req.Context().Value(requestPriorityKey{}).(RequestPriority); ok {
+		return p
+	}
+	if req.Method == http.MethodGet {
+		return PriorityDashboard
+	}
+	return PriorityInteractive
+}
+
+// RateLimitBudgetError is returned when the API budget of a client ID is exhausted
+type RateLimitBudgetError struct {
+	ClientID   string
+	Window     string // "10min", "24h" or "server" (429 of the API)
+	Used       int
+	Limit      int
+	Priority   RequestPriority
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitBudgetError) Error() string {
+	if e.Window == "server" {
+		return fmt.Sprintf("API rate limit reported by Viessmann for client %s, retry in %s",
+			maskClientID(e.ClientID), e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("API rate limit budget exhausted for client %s (%s window: %d/%d used, %s requests), retry in %s",
+		maskClientID(e.ClientID), e.Window, e.Used, e.Limit, e.Priority, e.RetryAfter.Round(time.Second))
+}
+
+// maskClientID shortens a client ID for logs and API responses
+func maskClientID(clientID string) string {
+	if len(clientID) <= 6 {
+		return clientID
+	}
+	return clientID[:6] + "…"
+}
+
+// apiCall is a single request counted against a budget
+type apiCall struct {
+	At        time.Time
+	Priority  RequestPriority
+	Persisted bool // Already stored in SQLite
+}
+
+// tokenClient maps an access token to the client ID it was issued for
+type tokenClient struct {
+	ClientID   string
+	Registered time.Time
+}
+
+// APIBroker keeps per-client-ID budgets for the Viessmann 10-minute and 24-hour limits
+type APIBroker struct {
+	mu           sync.Mutex
+	calls        map[string][]apiCall // Key: client ID, sorted by time
+	tokenClients map[string]tokenClient
+	blockedUntil map[string]time.Time // Set when the API itself answered 429
+	inserts      int                  // Inserts since last cleanup of the persisted log
+}
+
+var apiBroker = &APIBroker{
+	calls:        make(map[string][]apiCall),
+	tokenClients: make(map[string]tokenClient),
+	blockedUntil: make(map[string]time.Time),
+}
+
+// Budget key for requests whose client ID is unknown (e.g. API test page with foreign token)
+const unknownClientID = "unknown"
+
+// registerTokenClient remembers which client ID an access token belongs to
+func registerTokenClient(accessToken, clientID string) {
+	if accessToken == "" || clientID == "" {
+		return
+	}
+
+	apiBroker.mu.Lock()
+	defer apiBroker.mu.Unlock()
+
+	// Tokens live one hour, forget old ones
+	for token, tc := range apiBroker.tokenClients {
+		if time.Since(tc.Registered) > 48*time.Hour {
+			delete(apiBroker.tokenClients, token)
+		}
+	}
+	apiBroker.tokenClients[accessToken] = tokenClient{ClientID: clientID, Registered: time.Now()}
+}
+
+// clientForRequest determines the budget key of a request from its bearer token
+func (b *APIBroker) clientForRequest(req *http.Request) string {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if tc, ok := b.tokenClients[token]; ok {
+		return tc.ClientID
+	}
+	return unknownClientID
+}
+
+// pruneCalls drops calls outside the 24-hour window. Must be called with b.mu held.
+func (b *APIBroker) pruneCalls(clientID string, now time.Time) []apiCall {
+	calls := b.calls[clientID]
+	cutoff := now.Add(-24 * time.Hour)
+	i := 0
+	for i < len(calls) && !calls[i].At.After(cutoff) {
+		i++
+	}
+	calls = calls[i:]
+	b.calls[clientID] = calls
+	return calls
+}
+
+// windowCalls returns the calls within the last 10 minutes of a sorted call list
+func windowCalls(calls []apiCall, now time.Time) []apiCall {
+	cutoff := now.Add(-10 * time.Minute)
+	i := sort.Search(len(calls), func(i int) bool { return calls[i].At.After(cutoff) })
+	return calls[i:]
+}
+
+// tryReserve counts a request against the budget if the priority class still has room.
+// Must be called with b.mu held.
+func (b *APIBroker) tryReserve(clientID string, priority RequestPriority, now time.Time) *RateLimitBudgetError {
+	if until, ok := b.blockedUntil[clientID]; ok {
+		if now.Before(until) {
+			return &RateLimitBudgetError{
+				ClientID:   clientID,
+				Window:     "server",
+				Priority:   priority,
+				RetryAfter: until.Sub(now),
+			}
+		}
+		delete(b.blockedUntil, clientID)
+	}
+
+	calls := b.pruneCalls(clientID, now)
+	recent := windowCalls(calls, now)
+	share := priorityBudgetShare[priority]
+
+	limit24 := int(float64(apiLimit24Hr) * share)
+	if len(calls) >= limit24 {
+		return &RateLimitBudgetError{
+			ClientID:   clientID,
+			Window:     "24h",
+			Used:       len(calls),
+			Limit:      limit24,
+			Priority:   priority,
+			RetryAfter: calls[len(calls)-limit24].At.Add(24 * time.Hour).Sub(now),
+		}
+	}
+
+	limit10 := int(float64(apiLimit10Min) * share)
+	if len(recent) >= limit10 {
+		return &RateLimitBudgetError{
+			ClientID:   clientID,
+			Window:     "10min",
+			Used:       len(recent),
+			Limit:      limit10,
+			Priority:   priority,
+			RetryAfter: recent[len(recent)-limit10].At.Add(10 * time.Minute).Sub(now),
+		}
+	}
+
+	b.calls[clientID] = append(calls, apiCall{At: now, Priority: priority})
+	return nil
+}
+
+// acquire waits for a free slot in the budget of a client ID or returns a RateLimitBudgetError.
+// Only the 10-minute window is waited for; an exhausted 24-hour budget is rejected immediately.
+func (b *APIBroker) acquire(ctx context.Context, clientID string, priority RequestPriority) error {
+	deadline := time.Now().Add(priorityMaxWait[priority])
+
+	for {
+		now := time.Now()
+		b.mu.Lock()
+		budgetErr := b.tryReserve(clientID, priority, now)
+		b.mu.Unlock()
+
+		if budgetErr == nil {
+			b.persistCall(clientID, now, priority)
+			return nil
+		}
+
+		if budgetErr.Window == "24h" || now.Add(budgetErr.RetryAfter).After(deadline) {
+			log.Printf("WARNING: %v", budgetErr)
+			return budgetErr
+		}
+
+		select {
+		case <-time.After(budgetErr.RetryAfter):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// blockClient stops requests of a client ID until the given time, used after a 429 of the API
+func (b *APIBroker) blockClient(clientID string, until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until.After(b.blockedUntil[clientID]) {
+		b.blockedUntil[clientID] = until
+		log.Printf("API rate limit reported by Viessmann for client %s, pausing requests until %s", maskClientID(clientID), until.Format("15:04:05"))
+	}
+}
+
+// canAcquire reports whether a request of the given priority would currently be accepted
+func (b *APIBroker) canAcquire(clientID string, priority RequestPriority) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(b.blockedUntil[clientID]) {
+		return false
+	}
+	calls := b.pruneCalls(clientID, now)
+	share := priorityBudgetShare[priority]
+	return len(calls) < int(float64(apiLimit24Hr)*share) &&
+		len(windowCalls(calls, now)) < int(float64(apiLimit10Min)*share)
+}
+
+// brokerTransport routes requests to the Viessmann API through the broker
+type brokerTransport struct {
+	base http.RoundTripper
+}
+
+// isViessmannAPIRequest checks whether a request targets the configured IoT API.
+// IAM requests (login/token refresh) are not counted and never throttled.
+func isViessmannAPIRequest(req *http.Request) bool {
+	api, err := url.Parse(apiBaseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(req.URL.Host, api.Host) && strings.HasPrefix(req.URL.Path, "/iot/")
+}
+
+func (t *brokerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isViessmannAPIRequest(req) {
+		if err := apiBroker.acquire(req.Context(), apiBroker.clientForRequest(req), requestPriority(req)); err != nil {
+			return nil, err
+		}
+		setAPICallsCount()
+	}
+	return t.base.RoundTrip(req)
+}
+
+// --- Persistence ---
+
+// persistCall stores a counted request in SQLite so budgets survive restarts
+func (b *APIBroker) persistCall(clientID string, at time.Time, priority RequestPriority) {
+	if !dbInitialized || eventDB == nil {
+		return
+	}
+
+	dbMutex.Lock()
+	if !dbInitialized || eventDB == nil {
+		dbMutex.Unlock()
+		return
+	}
+	_, err := eventDB.Exec("INSERT INTO api_request_log (client_id, timestamp, priority) VALUES (?, ?, ?)",
+		clientID, at.UnixMilli(), priority.String())
+	dbMutex.Unlock()
+	if err != nil {
+		log.Printf("Warning: Could not persist API call: %v", err)
+		return
+	}
+
+	b.mu.Lock()
+	calls := b.calls[clientID]
+	for i := len(calls) - 1; i >= 0; i-- {
+		if calls[i].At.Equal(at) {
+			calls[i].Persisted = true
+			break
+		}
+	}
+	b.inserts++
+	cleanup := b.inserts >= 100
+	if cleanup {
+		b.inserts = 0
+	}
+	b.mu.Unlock()
+
+	if cleanup {
+		cleanupAPIRequestLog()
+	}
+}
+
+// cleanupAPIRequestLog removes persisted calls outside the 24-hour window
+func cleanupAPIRequestLog() {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-24 * time.Hour).UnixMilli()
+	if _, err := eventDB.Exec("DELETE FROM api_request_log WHERE timestamp < ?", cutoff); err != nil {
+		log.Printf("Warning: Could not clean up API request log: %v", err)
+	}
+}
+
+// restoreAPIBudgetsLocked loads the persisted calls of the last 24 hours after the database was opened.
+// Calls counted before the database was available are written first, as are calls newer than the
+// newest row of the database: after a backup restore these are missing in the restored file.
+// dbMutex must be held.
+func restoreAPIBudgetsLocked() {
+	apiBroker.mu.Lock()
+	defer apiBroker.mu.Unlock()
+
+	for clientID, calls := range apiBroker.calls {
+		var newest sql.NullInt64
+		eventDB.QueryRow("SELECT MAX(timestamp) FROM api_request_log WHERE client_id = ?", clientID).Scan(&newest)
+		for _, call := range calls {
+			if call.Persisted && call.At.UnixMilli() <= newest.Int64 {
+				continue
+			}
+			_, err := eventDB.Exec("INSERT INTO api_request_log (client_id, timestamp, priority) VALUES (?, ?, ?)",
+				clientID, call.At.UnixMilli(), call.Priority.String())
+			if err != nil {
+				log.Printf("Warning: Could not persist API call: %v", err)
+			}
+		}
+	}
+
+	cutoff := time.Now().Add(-24 * time.Hour).UnixMilli()
+	if _, err := eventDB.Exec("DELETE FROM api_request_log WHERE timestamp < ?", cutoff); err != nil {
+		log.Printf("Warning: Could not clean up API request log: %v", err)
+	}
+
+	rows, err := eventDB.Query("SELECT client_id, timestamp, priority FROM api_request_log ORDER BY timestamp ASC")
+	if err != nil {
+		log.Printf("Warning: Could not restore API budgets: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	restored := make(map[string][]apiCall)
+	count := 0
+	for rows.Next() {
+		var clientID, priority string
+		var ts int64
+		if err := rows.Scan(&clientID, &ts, &priority); err != nil {
+			continue
+		}
+		call := apiCall{At: time.UnixMilli(ts), Priority: PriorityDashboard, Persisted: true}
+		for _, p := range []RequestPriority{PriorityBackground, PriorityDashboard, PriorityInteractive} {
+			if p.String() == priority {
+				call.Priority = p
+			}
+		}
+		restored[clientID] = append(restored[clientID], call)
+		count++
+	}
+
+	apiBroker.calls = restored
+	log.Printf("Restored API budgets: %d calls in the last 24h for %d client ID(s)", count, len(restored))
+}
+
+// --- Statistics ---
+
+// APIBudgetStatus is the budget state of one client ID
+type APIBudgetStatus struct {
+	ClientID      string         `json:"clientId"` // Masked
+	Used10Min     int            `json:"used10Min"`
+	Used24Hr      int            `json:"used24Hr"`
+	Limit10Min    int            `json:"limit10Min"`
+	Limit24Hr     int            `json:"limit24Hr"`
+	ByPriority24h map[string]int `json:"byPriority24h"`
+	BlockedUntil  *time.Time     `json:"blockedUntil,omitempty"` // After a 429 of the API
+}
+
+// GetAPIBudgetStatus returns the budget usage of all known client IDs
+func GetAPIBudgetStatus() []APIBudgetStatus {
+	apiBroker.mu.Lock()
+	defer apiBroker.mu.Unlock()
+
+	now := time.Now()
+	result := make([]APIBudgetStatus, 0, len(apiBroker.calls))
+	for clientID := range apiBroker.calls {
+		calls := apiBroker.pruneCalls(clientID, now)
+		status := APIBudgetStatus{
+			ClientID:      maskClientID(clientID),
+			Used10Min:     len(windowCalls(calls, now)),
+			Used24Hr:      len(calls),
+			Limit10Min:    apiLimit10Min,
+			Limit24Hr:     apiLimit24Hr,
+			ByPriority24h: make(map[string]int),
+		}
+		for _, call := range calls {
+			status.ByPriority24h[call.Priority.String()]++
+		}
+		if until, ok := apiBroker.blockedUntil[clientID]; ok && now.Before(until) {
+			status.BlockedUntil = &until
+		}
+		result = append(result, status)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ClientID < result[j].ClientID })
+	return result
+}
+
+// getAPIUsage returns the usage of the most loaded client ID budget
+func getAPIUsage() (int, int) {
+	usage10min, usage24hr := 0, 0
+	for _, status := range GetAPIBudgetStatus() {
+		if status.Used10Min > usage10min {
+			usage10min = status.Used10Min
+		}
+		if status.Used24Hr > usage24hr {
+			usage24hr = status.Used24Hr
+		}
+	}
+	return usage10min, usage24hr
+}
+
+// checkAPIRateLimit checks if a background request for the client ID is within the budget
+func checkAPIRateLimit(clientID string) bool {
+	if !apiBroker.canAcquire(clientID, PriorityBackground) {
+		log.Printf("WARNING: API budget for background requests reached (client %s)", maskClientID(clientID))
+		return false
+	}
+	return true
+}
+
+// apiBudgetStatusHandler returns the budget usage per client ID
+// GET /api/rate-limit/status
+func apiBudgetStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	shares := make(map[string]float64)
+	for p, share := range priorityBudgetShare {
+		shares[p.String()] = share
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":       true,
+		"clients":       GetAPIBudgetStatus(),
+		"priorityShare": shares,
+	})
+}
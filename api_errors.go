@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIErrorKind classifies failed Viessmann API requests
+type APIErrorKind string
+
+const (
+	APIErrorRateLimited    APIErrorKind = "rate_limited"
+	APIErrorGatewayOffline APIErrorKind = "gateway_offline"
+	APIErrorTokenExpired   APIErrorKind = "token_expired"
+	APIErrorValidation     APIErrorKind = "validation_error"
+	APIErrorServer         APIErrorKind = "server_error"
+	APIErrorNetwork        APIErrorKind = "network_error"
+	APIErrorUnknown        APIErrorKind = "unknown_error"
+)
+
+// APIError is a classified error of the Viessmann API
+type APIError struct {
+	Kind       APIErrorKind
+	StatusCode int           // HTTP status, 0 for network errors and local budget rejections
+	ErrorType  string        // errorType of the Viessmann error body, e.g. DEVICE_COMMUNICATION_ERROR
+	Message    string        // message of the Viessmann error body
+	RetryAfter time.Duration // Retry-After of rate limited requests
+	Body       string
+	Err        error // Underlying transport or budget error
+}
+
+func (e *APIError) Error() string {
+	var detail string
+	switch {
+	case e.Message != "":
+		detail = e.Message
+	case e.Err != nil:
+		detail = e.Err.Error()
+	default:
+		detail = e.Body
+	}
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("Viessmann API error (%s, status %d): %s", e.Kind, e.StatusCode, detail)
+	}
+	return fmt.Sprintf("Viessmann API error (%s): %s", e.Kind, detail)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether repeating the request later may succeed
+func (e *APIError) Retryable() bool {
+	switch e.Kind {
+	case APIErrorRateLimited, APIErrorServer, APIErrorNetwork:
+		return true
+	}
+	return false
+}
+
+// LocalizedMessage returns the user facing message in the given language (see i18n/*.json)
+func (e *APIError) LocalizedMessage(lang string) string {
+	key := "api.error." + string(e.Kind)
+	if !hasTranslation(key) {
+		key = "api.error." + string(APIErrorUnknown)
+	}
+
+	msg := translate(lang, key)
+	if e.Kind == APIErrorValidation && e.Message != "" {
+		msg += " (" + e.Message + ")"
+	}
+	if e.Kind == APIErrorRateLimited && e.RetryAfter > 0 {
+		msg += " " + translate(lang, "api.error.retry_hint", formatRetryAfter(e.RetryAfter))
+	}
+	return msg
+}
+
+// formatRetryAfter rounds a waiting time for display
+func formatRetryAfter(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return d.Round(time.Minute).String()
+}
+
+// viessmannErrorBody is the error payload of the Viessmann API
+type viessmannErrorBody struct {
+	ViErrorID       string `json:"viErrorId"`
+	StatusCode      int    `json:"statusCode"`
+	ErrorType       string `json:"errorType"`
+	Message         string `json:"message"`
+	ExtendedPayload struct {
+		LimitReset int64 `json:"limitReset"` // Unix millis, sent with 429
+	} `json:"extendedPayload"`
+}
+
+// classifyAPIError builds an APIError from a non-successful API response
+func classifyAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		Kind:       APIErrorUnknown,
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var errBody viessmannErrorBody
+	if json.Unmarshal(body, &errBody) == nil {
+		apiErr.ErrorType = errBody.ErrorType
+		apiErr.Message = errBody.Message
+	}
+
+	errorType := strings.ToUpper(apiErr.ErrorType)
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		apiErr.Kind = APIErrorRateLimited
+		apiErr.RetryAfter = parseRetryAfter(header.Get("Retry-After"))
+		if apiErr.RetryAfter == 0 && errBody.ExtendedPayload.LimitReset > 0 {
+			apiErr.RetryAfter = time.Until(time.UnixMilli(errBody.ExtendedPayload.LimitReset))
+		}
+		if apiErr.RetryAfter < 0 {
+			apiErr.RetryAfter = 0
+		}
+	case strings.Contains(errorType, "OFFLINE") || strings.Contains(errorType, "DEVICE_COMMUNICATION"):
+		apiErr.Kind = APIErrorGatewayOffline
+	case statusCode == http.StatusUnauthorized:
+		apiErr.Kind = APIErrorTokenExpired
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity || strings.Contains(errorType, "VALIDATION"):
+		apiErr.Kind = APIErrorValidation
+	case statusCode >= 500:
+		apiErr.Kind = APIErrorServer
+	}
+	return apiErr
+}
+
+// apiErrorFromResponse reads the body of a failed response and classifies it.
+// The caller still has to close the body.
+func apiErrorFromResponse(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	return classifyAPIError(resp.StatusCode, resp.Header, body)
+}
+
+// checkAPIResponse returns nil for successful responses and an *APIError otherwise
+func checkAPIResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return apiErrorFromResponse(resp)
+}
+
+// parseRetryAfter parses a Retry-After header (seconds or HTTP date)
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// asAPIError extracts an *APIError from an error chain. Budget rejections of the
+// request broker are reported as rate limited. Returns nil for other errors.
+func asAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	var budgetErr *RateLimitBudgetError
+	if errors.As(err, &budgetErr) {
+		return &APIError{Kind: APIErrorRateLimited, RetryAfter: budgetErr.RetryAfter, Err: budgetErr}
+	}
+	return nil
+}
+
+// apiErrorHTTPStatus maps an error to the HTTP status returned to the web UI
+func apiErrorHTTPStatus(err error) int {
+	apiErr := asAPIError(err)
+	if apiErr == nil {
+		return http.StatusInternalServerError
+	}
+	switch apiErr.Kind {
+	case APIErrorRateLimited:
+		return http.StatusTooManyRequests
+	case APIErrorTokenExpired:
+		return http.StatusUnauthorized
+	case APIErrorValidation:
+		return http.StatusBadRequest
+	case APIErrorGatewayOffline, APIErrorServer, APIErrorNetwork:
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// apiErrorText returns the localized message for API errors and the plain error text otherwise
+func apiErrorText(r *http.Request, err error) string {
+	if apiErr := asAPIError(err); apiErr != nil {
+		return apiErr.LocalizedMessage(requestLanguage(r))
+	}
+	return err.Error()
+}
+
+// writeAPIError writes the JSON error response used by the control handlers
+func writeAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	response := map[string]interface{}{
+		"success": false,
+		"error":   apiErrorText(r, err),
+	}
+
+	if apiErr := asAPIError(err); apiErr != nil {
+		response["errorCode"] = apiErr.Kind
+		response["retryable"] = apiErr.Retryable()
+		if apiErr.StatusCode > 0 {
+			response["statusCode"] = apiErr.StatusCode
+		}
+		if apiErr.ErrorType != "" {
+			response["errorType"] = apiErr.ErrorType
+		}
+		if apiErr.RetryAfter > 0 {
+			response["retryAfter"] = int(apiErr.RetryAfter.Round(time.Second).Seconds())
+		}
+		response["details"] = apiErr.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Retry behaviour for Viessmann API requests
+const (
+	apiMaxRetries     = 3
+	apiRetryBaseDelay = 1 * time.Second
+	apiRetryMaxDelay  = 30 * time.Second
+	apiMaxRetryAfter  = 2 * time.Minute // Longer Retry-After values are returned to the caller instead of waiting
+)
+
+type noReauthKey struct{}
+
+// NewRequestWithoutReauth creates an API request that is not re-authenticated on 401.
+// Used for requests made while the token of the account is being created (accountsMutex held).
+func NewRequestWithoutReauth(method, url string, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(context.WithValue(context.Background(), noReauthKey{}, true), method, url, body)
+}
+
+func reauthAllowed(req *http.Request) bool {
+	noReauth, _ := req.Context().Value(noReauthKey{}).(bool)
+	return !noReauth
+}
+
+// retryTransport sits in front of the broker and handles failed Viessmann API requests:
+//   - idempotent requests (GET) are retried with jittered exponential backoff on
+//     network errors, 5xx responses and 429 (respecting Retry-After)
+//   - a 401 triggers one re-authentication of the account and a repeat of the request
+//   - network errors are returned as *APIError
+//
+// Every attempt passes the broker and counts against the budget.
+type retryTransport struct {
+	base http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isViessmannAPIRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	current := req
+	reauthenticated := false
+	retries := 0
+
+	for {
+		resp, err := t.base.RoundTrip(current)
+		if err != nil {
+			var budgetErr *RateLimitBudgetError
+			if errors.As(err, &budgetErr) || ctx.Err() != nil {
+				return nil, err
+			}
+			if !idempotent || retries >= apiMaxRetries {
+				return nil, &APIError{Kind: APIErrorNetwork, Err: err}
+			}
+			delay := retryBackoff(retries)
+			log.Printf("API request %s %s failed (%v), retry %d/%d in %s", req.Method, req.URL.Path, err, retries+1, apiMaxRetries, delay.Round(time.Millisecond))
+			if !sleepContext(ctx, delay) {
+				return nil, ctx.Err()
+			}
+			retries++
+			continue
+		}
+
+		var delay time.Duration
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && !reauthenticated && reauthAllowed(req):
+			newToken, reauthErr := reauthenticateAccessToken(strings.TrimPrefix(current.Header.Get("Authorization"), "Bearer "))
+			if reauthErr != nil {
+				log.Printf("API request %s %s: re-authentication after 401 failed: %v", req.Method, req.URL.Path, reauthErr)
+				return resp, nil
+			}
+			next, err := cloneRequest(req)
+			if err != nil {
+				return resp, nil
+			}
+			discardResponse(resp)
+			next.Header.Set("Authorization", "Bearer "+newToken)
+			current = next
+			reauthenticated = true
+			log.Printf("API request %s %s: token rejected, re-authenticated and repeating request", req.Method, req.URL.Path)
+			continue
+
+		case resp.StatusCode == http.StatusTooManyRequests:
+			apiErr, restored := peekAPIError(resp)
+			resp = restored
+			if apiErr.RetryAfter > 0 {
+				apiBroker.blockClient(apiBroker.clientForRequest(current), time.Now().Add(apiErr.RetryAfter))
+			}
+			if !idempotent || retries >= apiMaxRetries || apiErr.RetryAfter > apiMaxRetryAfter {
+				return resp, nil
+			}
+			delay = apiErr.RetryAfter
+			if delay == 0 {
+				delay = retryBackoff(retries)
+			}
+
+		case resp.StatusCode >= 500 && idempotent && retries < apiMaxRetries:
+			apiErr, restored := peekAPIError(resp)
+			resp = restored
+			// An offline gateway won't come back within seconds, don't waste budget
+			if apiErr.Kind == APIErrorGatewayOffline {
+				return resp, nil
+			}
+			delay = retryBackoff(retries)
+
+		default:
+			return resp, nil
+		}
+
+		log.Printf("API request %s %s returned %d, retry %d/%d in %s", req.Method, req.URL.Path, resp.StatusCode, retries+1, apiMaxRetries, delay.Round(time.Millisecond))
+		discardResponse(resp)
+		if !sleepContext(ctx, delay) {
+			return nil, ctx.Err()
+		}
+		next, err := cloneRequest(current)
+		if err != nil {
+			return nil, err
+		}
+		current = next
+		retries++
+	}
+}
+
+// retryBackoff returns the jittered exponential backoff for the given retry
+func retryBackoff(retry int) time.Duration {
+	delay := apiRetryBaseDelay << retry
+	if delay > apiRetryMaxDelay {
+		delay = apiRetryMaxDelay
+	}
+	// Full jitter between 50% and 100% so parallel requests don't retry in lockstep
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// cloneRequest copies a request for another attempt, including a fresh body
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body can't be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// peekAPIError classifies a failed response and returns it with a re-readable body
+func peekAPIError(resp *http.Response) (*APIError, *http.Response) {
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return classifyAPIError(resp.StatusCode, resp.Header, body), resp
+}
+
+// discardResponse drains and closes a response that is replaced by a retry
+func discardResponse(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
+
+// sleepContext waits for the given duration, returns false if the context ends first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -30,13 +30,13 @@ func testCredentials(creds *Credentials) error {
 	}
 
 	// Try to fetch installations to verify the token works
-	req, err := NewRequest("GET", "https://api.viessmann-climatesolutions.com/iot/v2/equipment/installations", nil)
+	req, err := NewRequestWithoutReauth("GET", apiBaseURL+"/iot/v2/equipment/installations", nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+tokenResp.AccessToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := viessmannHTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to verify token: %w", err)
 	}
@@ -64,6 +64,14 @@ func testCredentials(creds *Credentials) error {
 	return nil
 }
 
+// tokenRefreshMargin renews access tokens shortly before they expire
+const tokenRefreshMargin = 60 * time.Second
+
+// tokenValid reports whether an access token can still be used
+func tokenValid(accessToken string, expiry time.Time) bool {
+	return accessToken != "" && time.Now().Add(tokenRefreshMargin).Before(expiry)
+}
+
 // ensureAccountAuthenticated ensures a specific account is authenticated and returns its token
 func ensureAccountAuthenticated(account *Account) (*AccountToken, error) {
 	accountsMutex.RLock()
@@ -71,7 +79,7 @@ func ensureAccountAuthenticated(account *Account) (*AccountToken, error) {
 	accountsMutex.RUnlock()
 
 	// Check if token is still valid
-	if exists && token.AccessToken != "" && time.Now().Before(token.TokenExpiry) {
+	if exists && token.Installations != nil && tokenValid(token.AccessToken, token.TokenExpiry) {
 		return token, nil
 	}
 
@@ -81,35 +89,185 @@ func ensureAccountAuthenticated(account *Account) (*AccountToken, error) {
 
 	// Double-check after acquiring write lock
 	token, exists = accountTokens[account.ID]
-	if exists && token.AccessToken != "" && time.Now().Before(token.TokenExpiry) {
+	if exists && token.Installations != nil && tokenValid(token.AccessToken, token.TokenExpiry) {
 		return token, nil
 	}
 
-	// Authenticate
-	tokenResp, err := AuthenticateWithViCare(account.Email, account.Password, account.ClientID)
-	if err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
+	// After a restart, continue with the persisted tokens
+	if !exists {
+		token = loadPersistedAccountToken(account.ID)
+		registerTokenClient(token.AccessToken, account.ClientID)
+		accountTokens[account.ID] = token
+	}
+
+	if !tokenValid(token.AccessToken, token.TokenExpiry) {
+		if err := renewAccountToken(account, token); err != nil {
+			return nil, err
+		}
 	}
 
 	// Fetch installation IDs for this account
-	installationIDs, installations, err := fetchInstallationIDsForAccount(tokenResp.AccessToken)
+	if token.Installations == nil {
+		installationIDs, installations, err := fetchInstallationIDsForAccount(token.AccessToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch installations: %w", err)
+		}
+		token.InstallationIDs = installationIDs
+		token.Installations = installations
+
+		log.Printf("Authenticated account %s, found %d installations\n", account.Email, len(installationIDs))
+	}
+
+	return token, nil
+}
+
+// loadPersistedAccountToken restores the token state of an account from the credential storage
+func loadPersistedAccountToken(accountID string) *AccountToken {
+	token := &AccountToken{}
+
+	stored, err := LoadAccountToken(accountID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch installations: %w", err)
+		log.Printf("Could not load stored token for account %s: %v\n", accountID, err)
+		return token
+	}
+	if stored == nil {
+		return token
+	}
+
+	token.AccessToken = stored.AccessToken
+	token.RefreshToken = stored.RefreshToken
+	token.TokenExpiry = stored.TokenExpiry
+	token.LastLogin = stored.LastLogin
+	token.LastRefresh = stored.LastRefresh
+	return token
+}
+
+// renewAccountToken obtains a new access token for an account. The refresh_token grant is
+// tried first, a full login with the stored password is the fallback.
+// Must be called with accountsMutex held.
+func renewAccountToken(account *Account, token *AccountToken) error {
+	var tokenResp *TokenResponse
+
+	if token.RefreshToken != "" {
+		resp, err := RefreshAccessToken(account.ClientID, token.RefreshToken)
+		if err == nil {
+			tokenResp = resp
+			token.LastRefresh = time.Now()
+			token.RefreshFailures = 0
+			log.Printf("Refreshed access token for account %s\n", account.Email)
+		} else {
+			token.RefreshFailures++
+			token.LastError = err.Error()
+			log.Printf("Token refresh failed for account %s (%d consecutive failures): %v\n", account.Email, token.RefreshFailures, err)
+		}
 	}
 
-	// Store token
-	token = &AccountToken{
-		AccessToken:     tokenResp.AccessToken,
-		RefreshToken:    tokenResp.RefreshToken,
-		TokenExpiry:     time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
-		InstallationIDs: installationIDs,
-		Installations:   installations,
+	if tokenResp == nil {
+		if account.Password == "" {
+			token.LastError = "no valid refresh token and no password stored, please log in again"
+			return fmt.Errorf("authentication failed: %s", token.LastError)
+		}
+
+		resp, err := AuthenticateWithViCare(account.Email, account.Password, account.ClientID)
+		if err != nil {
+			token.LastError = err.Error()
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		tokenResp = resp
+		token.LastLogin = time.Now()
 	}
-	accountTokens[account.ID] = token
 
-	log.Printf("Authenticated account %s, found %d installations\n", account.Email, len(installationIDs))
+	token.AccessToken = tokenResp.AccessToken
+	// The IAM doesn't necessarily rotate the refresh token
+	if tokenResp.RefreshToken != "" {
+		token.RefreshToken = tokenResp.RefreshToken
+	}
+	token.TokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	token.LastError = ""
+
+	err := SaveAccountToken(account.ID, &StoredToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenExpiry:  token.TokenExpiry,
+		LastLogin:    token.LastLogin,
+		LastRefresh:  token.LastRefresh,
+	})
+	if err != nil {
+		log.Printf("Could not persist token for account %s: %v\n", account.Email, err)
+	}
 
-	return token, nil
+	return nil
+}
+
+// getAccountTokenState returns the token state of an account for the API (nil if never authenticated)
+func getAccountTokenState(accountID string) *AccountTokenState {
+	accountsMutex.RLock()
+	token, exists := accountTokens[accountID]
+	accountsMutex.RUnlock()
+
+	if !exists {
+		token = loadPersistedAccountToken(accountID)
+		if token.AccessToken == "" && token.RefreshToken == "" {
+			return nil
+		}
+	}
+
+	accountsMutex.RLock()
+	defer accountsMutex.RUnlock()
+
+	state := &AccountTokenState{
+		HasAccessToken:  token.AccessToken != "",
+		HasRefreshToken: token.RefreshToken != "",
+		Expired:         !token.TokenExpiry.IsZero() && time.Now().After(token.TokenExpiry),
+		RefreshFailures: token.RefreshFailures,
+		LastError:       token.LastError,
+	}
+	if !token.TokenExpiry.IsZero() {
+		state.TokenExpiry = token.TokenExpiry.Format(time.RFC3339)
+	}
+	if !token.LastLogin.IsZero() {
+		state.LastLogin = token.LastLogin.Format(time.RFC3339)
+	}
+	if !token.LastRefresh.IsZero() {
+		state.LastRefresh = token.LastRefresh.Format(time.RFC3339)
+	}
+	return state
+}
+
+// reauthenticateAccessToken renews the token of the account an access token belongs to,
+// after the API rejected it with 401. Returns the new access token.
+func reauthenticateAccessToken(rejected string) (string, error) {
+	if rejected == "" {
+		return "", fmt.Errorf("request has no access token")
+	}
+
+	accountsMutex.Lock()
+	defer accountsMutex.Unlock()
+
+	for accountID, token := range accountTokens {
+		if token.AccessToken != rejected {
+			continue
+		}
+		account, err := GetAccount(accountID)
+		if err != nil {
+			return "", err
+		}
+		if err := renewAccountToken(account, token); err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}
+
+	// Legacy single credential mode
+	if currentCreds != nil && accessToken == rejected {
+		tokenExpiry = time.Time{}
+		if err := ensureAuthenticated(); err != nil {
+			return "", err
+		}
+		return accessToken, nil
+	}
+
+	return "", fmt.Errorf("access token belongs to no known account")
 }
 
 // ensureAuthenticated ensures the current credentials are authenticated (legacy support)
@@ -130,14 +288,29 @@ func ensureAuthenticated() error {
 		}
 	}
 
-	// Authenticate using ViCare Authorization Code flow with PKCE
-	tokenResp, err := AuthenticateWithViCare(currentCreds.Email, currentCreds.Password, currentCreds.ClientID)
-	if err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
+	// Try the refresh token first, then fall back to the ViCare Authorization Code flow with PKCE
+	var tokenResp *TokenResponse
+	if refreshToken != "" {
+		resp, err := RefreshAccessToken(currentCreds.ClientID, refreshToken)
+		if err != nil {
+			log.Printf("Token refresh failed, logging in again: %v", err)
+		} else {
+			tokenResp = resp
+		}
+	}
+
+	if tokenResp == nil {
+		resp, err := AuthenticateWithViCare(currentCreds.Email, currentCreds.Password, currentCreds.ClientID)
+		if err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		tokenResp = resp
 	}
 
 	accessToken = tokenResp.AccessToken
-	refreshToken = tokenResp.RefreshToken
+	if tokenResp.RefreshToken != "" {
+		refreshToken = tokenResp.RefreshToken
+	}
 	tokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
 
 	log.Println("Successfully authenticated")
@@ -157,8 +330,8 @@ func fetchInstallationIDsForAccount(accessToken string) ([]string, map[string]*I
 		pageCount++
 
 		// Build URL with cursor and includeGateways parameter
-		baseURL := "https://api.viessmann-climatesolutions.com/iot/v2/equipment/installations"
-		req, err := NewRequest("GET", baseURL, nil)
+		baseURL := apiBaseURL + "/iot/v2/equipment/installations"
+		req, err := NewRequestWithoutReauth("GET", baseURL, nil)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -173,7 +346,7 @@ func fetchInstallationIDsForAccount(accessToken string) ([]string, map[string]*I
 
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := viessmannHTTPClient.Do(req)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -360,8 +533,8 @@ func fetchInstallationIDs() error {
 		pageCount++
 
 		// Build URL with cursor parameter
-		baseURL := "https://api.viessmann-climatesolutions.com/iot/v2/equipment/installations"
-		req, err := NewRequest("GET", baseURL, nil)
+		baseURL := apiBaseURL + "/iot/v2/equipment/installations"
+		req, err := NewRequestWithoutReauth("GET", baseURL, nil)
 		if err != nil {
 			return err
 		}
@@ -375,7 +548,7 @@ func fetchInstallationIDs() error {
 
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := viessmannHTTPClient.Do(req)
 		if err != nil {
 			return err
 		}
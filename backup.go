@@ -0,0 +1,415 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	backupFilePrefix = "vieventlog-"
+	backupFileSuffix = ".db"
+)
+
+// BackupSettings configures the scheduled database backups
+type BackupSettings struct {
+	Enabled       bool   `json:"enabled"`
+	Directory     string `json:"directory"`     // Target directory, default <config dir>/backups
+	IntervalHours int    `json:"intervalHours"` // Hours between backups
+	Keep          int    `json:"keep"`          // Number of backups to keep, older ones are deleted
+}
+
+// BackupInfo describes a backup file
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+var (
+	backupSchedulerRunning bool
+	backupSchedulerMutex   sync.Mutex
+	backupSchedulerStop    chan bool
+
+	// Serializes backups and restores
+	backupMutex sync.Mutex
+)
+
+// GetBackupSettings returns the backup settings with defaults applied
+func GetBackupSettings() (*BackupSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.BackupSettings
+	if settings == nil {
+		settings = &BackupSettings{}
+	}
+	applyBackupDefaults(settings)
+	return settings, nil
+}
+
+// SetBackupSettings updates the backup settings
+func SetBackupSettings(settings *BackupSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	store.BackupSettings = settings
+	return SaveAccounts(store)
+}
+
+func applyBackupDefaults(settings *BackupSettings) {
+	if settings.Directory == "" {
+		settings.Directory = filepath.Join(getDefaultConfigDir(), "backups")
+	}
+	if settings.IntervalHours < 1 {
+		settings.IntervalHours = 24
+	}
+	if settings.Keep < 1 {
+		settings.Keep = 7
+	}
+}
+
+// StartBackupScheduler starts the periodic backup job if enabled
+func StartBackupScheduler() error {
+	backupSchedulerMutex.Lock()
+	defer backupSchedulerMutex.Unlock()
+
+	if backupSchedulerRunning {
+		return nil
+	}
+
+	settings, err := GetBackupSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		log.Println("Database backups are disabled, scheduler not started")
+		return nil
+	}
+
+	interval := time.Duration(settings.IntervalHours) * time.Hour
+	backupSchedulerStop = make(chan bool)
+	backupSchedulerRunning = true
+	stop := backupSchedulerStop
+
+	log.Printf("Database backup scheduler started: every %d hour(s) to %s, keeping %d", settings.IntervalHours, settings.Directory, settings.Keep)
+
+	go func() {
+		// Continue the schedule of the previous run instead of backing up at every start
+		wait := time.Minute
+		if backups, err := ListDatabaseBackups(settings.Directory); err == nil && len(backups) > 0 {
+			if next := time.Until(backups[0].CreatedAt.Add(interval)); next > wait {
+				wait = next
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				backupJob()
+				timer.Reset(interval)
+			case <-stop:
+				log.Println("Database backup scheduler stopped")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopBackupScheduler stops the backup job
+func StopBackupScheduler() {
+	backupSchedulerMutex.Lock()
+	defer backupSchedulerMutex.Unlock()
+
+	if !backupSchedulerRunning {
+		return
+	}
+	close(backupSchedulerStop)
+	backupSchedulerRunning = false
+}
+
+// RestartBackupScheduler restarts the scheduler with new settings
+func RestartBackupScheduler() error {
+	StopBackupScheduler()
+	return StartBackupScheduler()
+}
+
+// IsBackupSchedulerRunning returns whether the backup scheduler is running
+func IsBackupSchedulerRunning() bool {
+	backupSchedulerMutex.Lock()
+	defer backupSchedulerMutex.Unlock()
+	return backupSchedulerRunning
+}
+
+// backupJob creates a backup and removes old ones
+func backupJob() {
+	settings, err := GetBackupSettings()
+	if err != nil {
+		log.Printf("Error getting backup settings: %v", err)
+		return
+	}
+	if !dbInitialized {
+		log.Println("Database not initialized, skipping backup")
+		return
+	}
+
+	start := time.Now()
+	var jobErr error
+	defer func() { recordJobRun("db_backup", start, jobErr) }()
+
+	if _, jobErr = CreateDatabaseBackup(settings.Directory, ""); jobErr != nil {
+		log.Printf("Database backup failed: %v", jobErr)
+		return
+	}
+	if jobErr = RotateDatabaseBackups(settings.Directory, settings.Keep); jobErr != nil {
+		log.Printf("Backup rotation failed: %v", jobErr)
+	}
+}
+
+// CreateDatabaseBackup writes a consistent copy of the running database with VACUUM INTO.
+// This works while the database is in use (WAL mode) and also compacts the copy.
+func CreateDatabaseBackup(dir, label string) (*BackupInfo, error) {
+	backupMutex.Lock()
+	defer backupMutex.Unlock()
+
+	return createDatabaseBackup(dir, label)
+}
+
+func createDatabaseBackup(dir, label string) (*BackupInfo, error) {
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	name := backupFilePrefix + time.Now().Format("20060102-150405")
+	if label != "" {
+		name += "-" + label
+	}
+	name += backupFileSuffix
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("backup %s already exists", name)
+	}
+
+	// Write to a temporary file first, so a listing never shows an incomplete backup
+	tmpPath := path + ".tmp"
+	os.Remove(tmpPath)
+
+	start := time.Now()
+	dbMutex.RLock()
+	_, err := eventDB.Exec("VACUUM INTO ?", tmpPath)
+	dbMutex.RUnlock()
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to create backup: %v", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to create backup: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Database backup created: %s (%d bytes, %v)", path, info.Size(), time.Since(start).Round(time.Millisecond))
+	return &BackupInfo{Name: name, Size: info.Size(), CreatedAt: info.ModTime()}, nil
+}
+
+// ListDatabaseBackups returns the backups in dir, newest first
+func ListDatabaseBackups(dir string) ([]BackupInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []BackupInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %v", err)
+	}
+
+	backups := []BackupInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackupFileName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, BackupInfo{Name: entry.Name(), Size: info.Size(), CreatedAt: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+func isBackupFileName(name string) bool {
+	return strings.HasPrefix(name, backupFilePrefix) && strings.HasSuffix(name, backupFileSuffix) && filepath.Base(name) == name
+}
+
+// RotateDatabaseBackups deletes all but the newest keep backups
+func RotateDatabaseBackups(dir string, keep int) error {
+	backups, err := ListDatabaseBackups(dir)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(backups); i++ {
+		if err := os.Remove(filepath.Join(dir, backups[i].Name)); err != nil {
+			return fmt.Errorf("failed to delete old backup: %v", err)
+		}
+		log.Printf("Deleted old database backup: %s", backups[i].Name)
+	}
+	return nil
+}
+
+// RestoreDatabaseBackup replaces the running database with a backup.
+// The current database is saved as a "pre-restore" backup first, then the connection is
+// closed, the file replaced and the database reopened.
+func RestoreDatabaseBackup(dir, name string) error {
+	if !isBackupFileName(name) {
+		return fmt.Errorf("invalid backup name %q", name)
+	}
+	backupPath := filepath.Join(dir, name)
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("backup not found: %s", name)
+	}
+	if err := verifyDatabaseBackup(backupPath); err != nil {
+		return err
+	}
+
+	backupMutex.Lock()
+	defer backupMutex.Unlock()
+
+	if !dbInitialized || eventDBPath == "" {
+		return fmt.Errorf("database not initialized")
+	}
+	dbPath := eventDBPath
+
+	if _, err := createDatabaseBackup(dir, "pre-restore"); err != nil {
+		return fmt.Errorf("failed to save current database before restore: %v", err)
+	}
+
+	// Copy next to the target first, the database stays usable if this fails
+	tmpPath := dbPath + ".restore"
+	if err := copyFile(backupPath, tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to copy backup: %v", err)
+	}
+
+	// Readers wait on the lock and find the reopened database afterwards. The closed handle
+	// stays in eventDB, so a reader that checked before the restore gets an error, not a nil pointer.
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	eventDB.Close()
+	dbInitialized = false
+
+	// WAL and shared memory belong to the old file. It is kept until the backup is open,
+	// so a failed restore can go back to it.
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+	previousPath := dbPath + ".previous"
+	os.Remove(previousPath)
+	if err := os.Rename(dbPath, previousPath); err != nil {
+		os.Remove(tmpPath)
+		if reopenErr := initEventDatabaseLocked(dbPath); reopenErr != nil {
+			return fmt.Errorf("failed to replace database (%v) and to reopen it: %v", err, reopenErr)
+		}
+		return fmt.Errorf("failed to replace database: %v", err)
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		os.Remove(tmpPath)
+		return reopenPreviousDatabaseLocked(dbPath, previousPath, fmt.Errorf("failed to replace database: %v", err))
+	}
+
+	// Reopening also runs the schema migrations for backups of older versions
+	if err := initEventDatabaseLocked(dbPath); err != nil {
+		return reopenPreviousDatabaseLocked(dbPath, previousPath, fmt.Errorf("failed to open restored database: %v", err))
+	}
+	os.Remove(previousPath)
+
+	log.Printf("Database restored from backup %s", name)
+	return nil
+}
+
+// reopenPreviousDatabaseLocked puts the database saved before a failed restore back in place
+// and opens it again. Returns restoreErr, or both errors if the previous database can't be opened either.
+func reopenPreviousDatabaseLocked(dbPath, previousPath string, restoreErr error) error {
+	log.Printf("Restore failed, reopening previous database: %v", restoreErr)
+
+	if eventDB != nil {
+		eventDB.Close()
+	}
+	dbInitialized = false
+	os.Remove(dbPath)
+	os.Remove(dbPath + "-wal")
+	os.Remove(dbPath + "-shm")
+	if err := os.Rename(previousPath, dbPath); err != nil {
+		return fmt.Errorf("%v; previous database left at %s: %v", restoreErr, previousPath, err)
+	}
+	if err := initEventDatabaseLocked(dbPath); err != nil {
+		return fmt.Errorf("%v; failed to reopen previous database: %v", restoreErr, err)
+	}
+	return restoreErr
+}
+
+// verifyDatabaseBackup checks that a file is an intact vieventlog database
+func verifyDatabaseBackup(path string) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %v", err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("backup is not a valid database: %v", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup is damaged: %s", result)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'events'").Scan(&count); err != nil || count == 0 {
+		return fmt.Errorf("backup does not contain an event archive")
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
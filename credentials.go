@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
@@ -11,6 +12,7 @@ const (
 	credKey        = "credentials"
 	accountsKey    = "accounts"        // New key for multiple accounts
 	activeAcctsKey = "active-accounts" // New key for active account IDs
+	tokenKeyPrefix = "token:"          // Prefix for persisted OAuth tokens (one entry per account)
 )
 
 // CredentialStorage is the interface for credential persistence
@@ -20,6 +22,9 @@ type CredentialStorage interface {
 	DeleteCredentials() error
 	SaveAccounts(store *AccountStore) error
 	LoadAccounts() (*AccountStore, error)
+	SaveToken(accountID string, token *StoredToken) error
+	LoadToken(accountID string) (*StoredToken, error)
+	DeleteToken(accountID string) error
 }
 
 var storage CredentialStorage
@@ -35,12 +40,23 @@ type Credentials struct {
 	ClientSecret string `json:"clientSecret"`
 }
 
+// StoredToken is the persisted OAuth token state of an account,
+// so that a restart doesn't require a new login
+type StoredToken struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	TokenExpiry  time.Time `json:"tokenExpiry"`
+	LastLogin    time.Time `json:"lastLogin,omitempty"`
+	LastRefresh  time.Time `json:"lastRefresh,omitempty"`
+}
+
 type DeviceSettings struct {
 	Name                            string                    `json:"name,omitempty"` // User-defined device name (e.g., "Wohnzimmer TRV", "Klimasensor Bad")
 	CompressorRpmMin                int                       `json:"compressorRpmMin,omitempty"`
 	CompressorRpmMax                int                       `json:"compressorRpmMax,omitempty"`
 	CompressorPowerCorrectionFactor float64                   `json:"compressorPowerCorrectionFactor,omitempty"` // Correction factor for compressor power (default: 1.00)
 	ElectricityPrice                float64                   `json:"electricityPrice,omitempty"`                // Electricity price in EUR/kWh for consumption cost calculations (default: 0.30)
+	TariffID                        string                    `json:"tariffId,omitempty"`                        // Time-of-use tariff for cost calculations, overrides ElectricityPrice (see tariffs.go)
 	HybridProControl                *HybridProControlSettings `json:"hybridProControl,omitempty"`
 	UseAirIntakeTemperatureLabel    *bool                     `json:"useAirIntakeTemperatureLabel,omitempty"` // Override label for primary supply temp (nil = auto-detect, true = Lufteintrittstemperatur, false = Primärkreisvorlauf)
 	HasHotWaterBuffer               *bool                     `json:"hasHotWaterBuffer,omitempty"`            // Override spreizung calculation (nil = auto-detect, true = mit HW-Puffer, false = ohne HW-Puffer)
@@ -79,10 +95,26 @@ type Account struct {
 	ClientID       string                     `json:"clientId"`
 	ClientSecret   string                     `json:"clientSecret"`
 	Active         bool                       `json:"active"`                   // Whether this account is currently active
+	AuthMethod     string                     `json:"authMethod,omitempty"`     // "password" (default) or "oauth" (browser login, only the refresh token is stored)
 	DeviceSettings map[string]*DeviceSettings `json:"deviceSettings,omitempty"` // Key: "{installationId}_{deviceId}"
 	RoomSettings   map[string]*RoomSettings   `json:"roomSettings,omitempty"`   // Key: "{installationId}:{roomId}"
 }
 
+// Authentication methods of an account
+const (
+	AuthMethodPassword = "password"
+	AuthMethodOAuth    = "oauth"
+)
+
+// accountAuthMethod returns the authentication method of an account
+// (accounts created before the browser login have no AuthMethod set)
+func accountAuthMethod(a *Account) string {
+	if a.AuthMethod == "" {
+		return AuthMethodPassword
+	}
+	return a.AuthMethod
+}
+
 type EventArchiveSettings struct {
 	Enabled         bool   `json:"enabled"`         // Whether event archiving is enabled
 	RetentionDays   int    `json:"retentionDays"`   // How many days to keep events (e.g., 30, 365)
@@ -91,8 +123,15 @@ type EventArchiveSettings struct {
 }
 
 type AccountStore struct {
-	Accounts             map[string]*Account   `json:"accounts"`             // Key is account ID
-	EventArchiveSettings *EventArchiveSettings `json:"eventArchiveSettings"` // Global event archive settings
+	Accounts             map[string]*Account   `json:"accounts"`                     // Key is account ID
+	EventArchiveSettings *EventArchiveSettings `json:"eventArchiveSettings"`         // Global event archive settings
+	MQTTSettings         *MQTTSettings         `json:"mqttSettings,omitempty"`       // MQTT / Home Assistant integration
+	AlertSettings        *AlertSettings        `json:"alertSettings,omitempty"`      // Notification rules and channels
+	BackupSettings       *BackupSettings       `json:"backupSettings,omitempty"`     // Scheduled database backups
+	FeatureLogSettings   *FeatureLogSettings   `json:"featureLogSettings,omitempty"` // Generic feature time series
+	PVSurplusSettings    *PVSurplusSettings    `json:"pvSurplusSettings,omitempty"`  // PV surplus heat pump automation
+	TariffSettings       *TariffSettings       `json:"tariffSettings,omitempty"`     // Time-of-use electricity tariffs
+	Language             string                `json:"language,omitempty"`           // UI language (de, en), empty = browser language
 }
 
 // SaveCredentials stores credentials using the configured storage backend
@@ -116,6 +155,23 @@ func HasStoredCredentials() bool {
 	return err == nil && creds != nil && creds.Email != ""
 }
 
+// --- Token Functions ---
+
+// SaveAccountToken persists the OAuth tokens of an account
+func SaveAccountToken(accountID string, token *StoredToken) error {
+	return storage.SaveToken(accountID, token)
+}
+
+// LoadAccountToken retrieves the persisted OAuth tokens of an account (nil if none stored)
+func LoadAccountToken(accountID string) (*StoredToken, error) {
+	return storage.LoadToken(accountID)
+}
+
+// DeleteAccountToken removes the persisted OAuth tokens of an account
+func DeleteAccountToken(accountID string) error {
+	return storage.DeleteToken(accountID)
+}
+
 // --- New Multi-Account Functions ---
 
 // LoadAccounts retrieves all accounts from the configured storage backend
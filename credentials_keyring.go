@@ -109,6 +109,45 @@ func (k *KeyringStorage) LoadAccounts() (*AccountStore, error) {
 	return &store, nil
 }
 
+func (k *KeyringStorage) SaveToken(accountID string, token *StoredToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	err = keyring.Set(serviceName, tokenKeyPrefix+accountID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save token to keyring: %w", err)
+	}
+
+	return nil
+}
+
+func (k *KeyringStorage) LoadToken(accountID string) (*StoredToken, error) {
+	data, err := keyring.Get(serviceName, tokenKeyPrefix+accountID)
+	if err != nil {
+		if err == keyring.ErrNotFound {
+			return nil, nil // No token stored
+		}
+		return nil, fmt.Errorf("failed to load token from keyring: %w", err)
+	}
+
+	var token StoredToken
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (k *KeyringStorage) DeleteToken(accountID string) error {
+	err := keyring.Delete(serviceName, tokenKeyPrefix+accountID)
+	if err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("failed to delete token from keyring: %w", err)
+	}
+	return nil
+}
+
 func newCredentialStorage() CredentialStorage {
 	return &KeyringStorage{}
 }
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 // SimpleStorage implements credential storage without keyring (env/file only)
-type SimpleStorage struct{}
+type SimpleStorage struct {
+	tokenMutex sync.Mutex // Serializes read-modify-write of tokens.json
+}
 
 func (s *SimpleStorage) SaveCredentials(creds Credentials) error {
 	return fmt.Errorf("credential saving not supported in nokeyring build - use environment variables or file storage")
@@ -155,6 +158,80 @@ func (s *SimpleStorage) LoadAccounts() (*AccountStore, error) {
 	return &AccountStore{Accounts: make(map[string]*Account)}, nil
 }
 
+// loadTokenFile reads all persisted tokens from tokens.json
+func (s *SimpleStorage) loadTokenFile() (map[string]*StoredToken, error) {
+	tokens := make(map[string]*StoredToken)
+
+	data, err := os.ReadFile(filepath.Join(getConfigPath(), "tokens.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tokens, nil
+		}
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tokens file: %w", err)
+	}
+	return tokens, nil
+}
+
+// saveTokenFile writes all tokens to tokens.json (readable only by the owner)
+func (s *SimpleStorage) saveTokenFile(tokens map[string]*StoredToken) error {
+	configPath := getConfigPath()
+	if err := os.MkdirAll(configPath, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(configPath, "tokens.json"), data, 0600); err != nil {
+		return fmt.Errorf("failed to write tokens file: %w", err)
+	}
+	return nil
+}
+
+func (s *SimpleStorage) SaveToken(accountID string, token *StoredToken) error {
+	s.tokenMutex.Lock()
+	defer s.tokenMutex.Unlock()
+
+	tokens, err := s.loadTokenFile()
+	if err != nil {
+		return err
+	}
+	tokens[accountID] = token
+	return s.saveTokenFile(tokens)
+}
+
+func (s *SimpleStorage) LoadToken(accountID string) (*StoredToken, error) {
+	s.tokenMutex.Lock()
+	defer s.tokenMutex.Unlock()
+
+	tokens, err := s.loadTokenFile()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[accountID], nil
+}
+
+func (s *SimpleStorage) DeleteToken(accountID string) error {
+	s.tokenMutex.Lock()
+	defer s.tokenMutex.Unlock()
+
+	tokens, err := s.loadTokenFile()
+	if err != nil {
+		return err
+	}
+	if _, exists := tokens[accountID]; !exists {
+		return nil
+	}
+	delete(tokens, accountID)
+	return s.saveTokenFile(tokens)
+}
+
 func getConfigPath() string {
 	// Default to /config for container use, or current dir for testing
 	if configDir := os.Getenv("VICARE_CONFIG_DIR"); configDir != "" {
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"0 13 * *",
+		"0 13 * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@never",
+	} {
+		if _, err := parseCronSchedule(expr); err == nil {
+			t.Errorf("parseCronSchedule(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Saturday, 12:00
+	from := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	at := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"later today", "0 13 * * *", at(2026, 10, 17, 13, 0)},
+		{"strictly after", "0 12 * * *", at(2026, 10, 18, 12, 0)},
+		{"minute step", "*/15 * * * *", at(2026, 10, 17, 12, 15)},
+		{"hour range with step", "0 8-18/2 * * *", at(2026, 10, 17, 14, 0)},
+		{"sunday as 0", "0 0 * * 0", at(2026, 10, 18, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", at(2026, 10, 18, 0, 0)},
+		{"weekdays", "30 6 * * 1-5", at(2026, 10, 19, 6, 30)},
+		{"weekday list", "0 0 * * 3,5", at(2026, 10, 21, 0, 0)},
+		{"day of month only", "0 0 20 * *", at(2026, 10, 20, 0, 0)},
+		{"both days restricted, weekday first", "0 0 1 * 1", at(2026, 10, 19, 0, 0)},
+		{"both days restricted, day first", "0 0 18 * 3", at(2026, 10, 18, 0, 0)},
+		{"next month", "0 0 1 * *", at(2026, 11, 1, 0, 0)},
+		{"next leap day", "0 0 29 2 *", at(2028, 2, 29, 0, 0)},
+		{"macro weekly", "@weekly", at(2026, 10, 18, 0, 0)},
+		{"macro monthly", "@MONTHLY", at(2026, 11, 1, 0, 0)},
+		{"never", "0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseCronSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCronSchedule(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 	"strconv"
+	"strings"
 
 	_ "modernc.org/sqlite"
 )
@@ -19,6 +20,7 @@ var (
 	eventDB       *sql.DB
 	dbMutex       sync.RWMutex
 	dbInitialized bool
+	eventDBPath   string // Path of the open database, used for backups and restore
 )
 
 // InitEventDatabase initializes the SQLite database for event archiving
@@ -26,6 +28,11 @@ func InitEventDatabase(dbPath string) error {
 	dbMutex.Lock()
 	defer dbMutex.Unlock()
 
+	return initEventDatabaseLocked(dbPath)
+}
+
+// initEventDatabaseLocked opens the database, dbMutex must be held
+func initEventDatabaseLocked(dbPath string) error {
 	// If already initialized with the same path, reuse the connection
 	if dbInitialized && eventDB != nil {
 		log.Printf("Database already initialized, reusing connection")
@@ -96,6 +103,11 @@ func InitEventDatabase(dbPath string) error {
 	CREATE INDEX IF NOT EXISTS idx_device_id ON events(device_id);
 	CREATE INDEX IF NOT EXISTS idx_hash ON events(hash);
 	CREATE INDEX IF NOT EXISTS idx_indexed_at ON events(indexed_at);
+	CREATE INDEX IF NOT EXISTS idx_event_timestamp_hash ON events(event_timestamp, hash);
+	CREATE INDEX IF NOT EXISTS idx_installation_timestamp ON events(installation_id, event_timestamp);
+	CREATE INDEX IF NOT EXISTS idx_event_type_timestamp ON events(event_type, event_timestamp);
+	CREATE INDEX IF NOT EXISTS idx_severity_timestamp ON events(severity, event_timestamp);
+	CREATE INDEX IF NOT EXISTS idx_error_code ON events(error_code);
 	`
 
 	_, err = eventDB.Exec(createTableSQL)
@@ -177,6 +189,20 @@ func InitEventDatabase(dbPath string) error {
 		return fmt.Errorf("failed to create temperature_log_settings table: %v", err)
 	}
 
+	// Create temperature_log_devices table (per-device selection, see TemperatureLogSettings.Devices)
+	_, err = eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS temperature_log_devices (
+		installation_id TEXT NOT NULL,
+		gateway_id TEXT NOT NULL,
+		device_id TEXT NOT NULL,
+		enabled INTEGER NOT NULL DEFAULT 1,
+		PRIMARY KEY (installation_id, gateway_id, device_id)
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create temperature_log_devices table: %v", err)
+	}
+
 	// Create schema_migrations table to track applied migrations
 	createMigrationsTableSQL := `
 	CREATE TABLE IF NOT EXISTS schema_migrations (
@@ -192,6 +218,85 @@ func InitEventDatabase(dbPath string) error {
 		return fmt.Errorf("failed to create schema_migrations table: %v", err)
 	}
 
+	// Create api_request_log table (persisted rate limit budget, see api_broker.go)
+	createAPIRequestLogSQL := `
+	CREATE TABLE IF NOT EXISTS api_request_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		client_id TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		priority TEXT NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_api_request_log_client_ts ON api_request_log(client_id, timestamp);
+	`
+
+	_, err = eventDB.Exec(createAPIRequestLogSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create api_request_log table: %v", err)
+	}
+
+	// Create alert_state table (deduplication, cooldown and resolution of alerts, see alerting.go)
+	createAlertStateSQL := `
+	CREATE TABLE IF NOT EXISTS alert_state (
+		alert_key TEXT PRIMARY KEY,
+		rule_id TEXT NOT NULL,
+		active INTEGER NOT NULL DEFAULT 0,
+		notified INTEGER NOT NULL DEFAULT 0,
+		severity TEXT,
+		title TEXT,
+		message TEXT,
+		installation_id TEXT,
+		device_id TEXT,
+		triggered_at TEXT NOT NULL,
+		last_notified_at TEXT,
+		resolved_at TEXT
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_alert_state_active ON alert_state(active, triggered_at);
+	`
+
+	_, err = eventDB.Exec(createAlertStateSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create alert_state table: %v", err)
+	}
+
+	// Create hourly/daily rollup tables for long-term history (see rollups.go)
+	if err := createRollupTables(); err != nil {
+		return err
+	}
+
+	// Create generic feature log tables (see feature_log.go)
+	if err := createFeatureLogTables(); err != nil {
+		return err
+	}
+
+	// Create fault episode table derived from the events (see fault_episodes.go)
+	if err := createFaultEpisodeTables(); err != nil {
+		return err
+	}
+
+	// Create table of codes missing in the error code catalogue (see unknown_error_codes.go)
+	if err := createUnknownErrorCodeTables(); err != nil {
+		return err
+	}
+
+	if err := createScheduledActionTables(); err != nil {
+		return err
+	}
+
+	if err := createPVSurplusTables(); err != nil {
+		return err
+	}
+
+	if err := createTariffTables(); err != nil {
+		return err
+	}
+
+	// Create table of detected defrost cycles (see defrost.go)
+	if err := createDefrostTables(); err != nil {
+		return err
+	}
+
 	// Run schema migrations for existing databases
 	err = runSchemaMigrations()
 	if err != nil {
@@ -199,7 +304,11 @@ func InitEventDatabase(dbPath string) error {
 	}
 
 	dbInitialized = true
+	eventDBPath = dbPath
 	log.Printf("Event database initialized at: %s", dbPath)
+
+	// Restore rate limit budgets before anyone else can use (or close) the database
+	restoreAPIBudgetsLocked()
 	return nil
 }
 
@@ -612,6 +721,113 @@ func runSchemaMigrations() error {
 		}
 		log.Println("Migration 7 completed: Added fields 4/3 valve, pressure")	
 	}
+
+	// Migration 8: Add retention settings for the hourly and daily rollups
+	if !migrationApplied("add_rollup_retention") {
+		log.Println("Running migration 8: Adding rollup retention settings")
+
+		if !columnExists("temperature_log_settings", "hourly_rollup_retention_days") {
+			_, err := eventDB.Exec("ALTER TABLE temperature_log_settings ADD COLUMN hourly_rollup_retention_days INTEGER NOT NULL DEFAULT 730")
+			if err != nil {
+				return fmt.Errorf("migration 8 failed (hourly rollup retention): %v", err)
+			}
+		}
+		if !columnExists("temperature_log_settings", "daily_rollup_retention_days") {
+			_, err := eventDB.Exec("ALTER TABLE temperature_log_settings ADD COLUMN daily_rollup_retention_days INTEGER NOT NULL DEFAULT 0")
+			if err != nil {
+				return fmt.Errorf("migration 8 failed (daily rollup retention): %v", err)
+			}
+		}
+
+		if err := recordMigration(8, "add_rollup_retention", "Add retention settings for hourly and daily rollups"); err != nil {
+			return fmt.Errorf("failed to record migration 8: %v", err)
+		}
+		log.Println("Migration 8 completed: Added rollup retention settings")
+	}
+
+	// Migration 9: Add room climate fields for room controllers and climate sensors
+	if !migrationApplied("add_room_climate") {
+		log.Println("Running migration 9: Adding room climate fields")
+
+		if !columnExists("temperature_snapshots", "room_temp") {
+			_, err := eventDB.Exec("ALTER TABLE temperature_snapshots ADD COLUMN room_temp REAL")
+			if err != nil {
+				return fmt.Errorf("migration 9 failed (room temp): %v", err)
+			}
+		}
+		if !columnExists("temperature_snapshots", "room_humidity") {
+			_, err := eventDB.Exec("ALTER TABLE temperature_snapshots ADD COLUMN room_humidity REAL")
+			if err != nil {
+				return fmt.Errorf("migration 9 failed (room humidity): %v", err)
+			}
+		}
+
+		if err := recordMigration(9, "add_room_climate", "Add room temperature and humidity fields"); err != nil {
+			return fmt.Errorf("failed to record migration 9: %v", err)
+		}
+		log.Println("Migration 9 completed: Added room climate fields")
+	}
+
+	// Migration 10: Build fault episodes from the already archived events
+	if !migrationApplied("build_fault_episodes") {
+		log.Println("Running migration 10: Building fault episodes from archived events")
+
+		n, err := rebuildFaultEpisodesLocked()
+		if err != nil {
+			return fmt.Errorf("migration 10 failed (fault episodes): %v", err)
+		}
+
+		if err := recordMigration(10, "build_fault_episodes", "Build fault episodes from active/inactive event pairs"); err != nil {
+			return fmt.Errorf("failed to record migration 10: %v", err)
+		}
+		log.Printf("Migration 10 completed: Built %d fault episodes", n)
+	}
+
+	// Migration 11: Collect the unknown error codes of the already archived events
+	if !migrationApplied("collect_unknown_error_codes") {
+		log.Println("Running migration 11: Collecting unknown error codes from archived events")
+
+		n, err := backfillUnknownErrorCodesLocked()
+		if err != nil {
+			return fmt.Errorf("migration 11 failed (unknown error codes): %v", err)
+		}
+
+		if err := recordMigration(11, "collect_unknown_error_codes", "Collect error codes missing in the catalogue"); err != nil {
+			return fmt.Errorf("failed to record migration 11: %v", err)
+		}
+		log.Printf("Migration 11 completed: Found %d unknown error code(s)", n)
+	}
+
+	// Migration 12: Detect the defrost cycles of the already logged snapshots and events
+	if !migrationApplied("build_defrost_cycles") {
+		log.Println("Running migration 12: Detecting defrost cycles")
+
+		n, err := rebuildDefrostCyclesLocked(defrostSampleIntervalLocked())
+		if err != nil {
+			return fmt.Errorf("migration 12 failed (defrost cycles): %v", err)
+		}
+
+		if err := recordMigration(12, "build_defrost_cycles", "Detect defrost cycles from snapshots and events"); err != nil {
+			return fmt.Errorf("failed to record migration 12: %v", err)
+		}
+		log.Printf("Migration 12 completed: Detected %d defrost cycles", n)
+	}
+
+	// Migration 13: Re-detect the defrost cycles, hot water heating now comes from the valve position and
+	// the humidity only from an outside sensor (before: DHW circulation pump and indoor humidity)
+	if !migrationApplied("redetect_defrost_cycles") {
+		log.Println("Running migration 13: Re-detecting defrost cycles")
+
+		n, err := rebuildDefrostCyclesLocked(defrostSampleIntervalLocked())
+		if err != nil {
+			return fmt.Errorf("migration 13 failed (defrost cycles): %v", err)
+		}
+
+		if err := recordMigration(13, "redetect_defrost_cycles", "Re-detect defrost cycles with valve based hot water detection and outside humidity"); err != nil {
+			return fmt.Errorf("failed to record migration 13: %v", err)
+		}
+		log.Printf("Migration 13 completed: Detected %d defrost cycles", n)
+	}
 	
 	return nil
 }
@@ -676,17 +892,23 @@ func SaveEventToDB(event *Event) error {
 
 // SaveEventsToDB batch inserts events into the database
 func SaveEventsToDB(events []Event) error {
-	if !dbInitialized || eventDB == nil {
-		return fmt.Errorf("database not initialized")
-	}
+	_, err := SaveNewEventsToDB(events)
+	return err
+}
 
+// SaveNewEventsToDB batch inserts events and returns the events that weren't archived before
+func SaveNewEventsToDB(events []Event) ([]Event, error) {
 	// Use a single lock and transaction for better performance
 	dbMutex.Lock()
 	defer dbMutex.Unlock()
 
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
 	tx, err := eventDB.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %v", err)
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
 	}
 	defer tx.Rollback() // Will be no-op if committed
 
@@ -702,10 +924,11 @@ func SaveEventsToDB(events []Event) error {
 
 	stmt, err := tx.Prepare(insertSQL)
 	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %v", err)
+		return nil, fmt.Errorf("failed to prepare statement: %v", err)
 	}
 	defer stmt.Close()
 
+	newEvents := make([]Event, 0)
 	for i := range events {
 		event := &events[i]
 		hash := ComputeEventHash(event)
@@ -726,7 +949,7 @@ func SaveEventsToDB(events []Event) error {
 			activeInt = &val
 		}
 
-		_, err = stmt.Exec(
+		result, err := stmt.Exec(
 			hash,
 			event.EventTimestamp,
 			event.CreatedAt,
@@ -752,25 +975,40 @@ func SaveEventsToDB(events []Event) error {
 
 		if err != nil {
 			log.Printf("Warning: failed to insert event: %v", err)
+			continue
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			newEvents = append(newEvents, *event)
 		}
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %v", err)
+		return nil, fmt.Errorf("failed to commit transaction: %v", err)
 	}
 
-	return nil
+	// Keep the derived fault episodes up to date, the events are saved either way
+	if err := updateFaultEpisodesLocked(newEvents); err != nil {
+		log.Printf("Warning: failed to update fault episodes: %v", err)
+	}
+	if err := recordUnknownErrorCodesLocked(newEvents); err != nil {
+		log.Printf("Warning: failed to record unknown error codes: %v", err)
+	}
+	if err := updateDefrostCyclesForEventsLocked(newEvents); err != nil {
+		log.Printf("Warning: failed to update defrost cycles: %v", err)
+	}
+
+	return newEvents, nil
 }
 
 // GetEventsFromDB retrieves events from the database with optional filters
 func GetEventsFromDB(startTime, endTime time.Time, limit int) ([]Event, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	query := `
 		SELECT
 			event_timestamp, created_at, formatted_time, event_type,
@@ -792,13 +1030,122 @@ func GetEventsFromDB(startTime, endTime time.Time, limit int) ([]Event, error) {
 	}
 	defer rows.Close()
 
+	events, _ := scanEventRows(rows)
+	return events, nil
+}
+
+// QueryEventsFromDB retrieves one page of events matching the query.
+// Filtering, sorting and keyset pagination on (event_timestamp, hash) are done in SQL.
+func QueryEventsFromDB(q *EventQuery) (*EventsPage, error) {
+	// Event timestamps of the API are stored in UTC
+	where := []string{"event_timestamp >= ?", "event_timestamp <= ?"}
+	args := []interface{}{q.From.UTC().Format(time.RFC3339), q.To.UTC().Format(time.RFC3339)}
+
+	addIn := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = "?"
+			args = append(args, v)
+		}
+		where = append(where, column+" IN ("+strings.Join(placeholders, ",")+")")
+	}
+	addIn("installation_id", q.InstallationIDs)
+	addIn("gateway_serial", q.GatewaySerials)
+	addIn("device_id", q.DeviceIDs)
+	addIn("event_type", q.EventTypes)
+	addIn("error_code", q.ErrorCodes)
+	addIn("severity", q.Severities)
+	addIn("code_category", q.CodeCategories)
+
+	if q.Active != nil {
+		where = append(where, "active = ?")
+		args = append(args, boolToInt(*q.Active))
+	}
+	if q.Search != "" {
+		// LIKE is case-insensitive for ASCII in SQLite
+		pattern := "%" + likeEscaper.Replace(q.Search) + "%"
+		where = append(where, `(human_readable LIKE ? ESCAPE '\' OR feature_name LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	direction, cmp := "DESC", "<"
+	if q.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+	if q.Cursor != "" {
+		cursorTS, cursorHash, err := decodeEventCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, "(event_timestamp "+cmp+" ? OR (event_timestamp = ? AND hash "+cmp+" ?))")
+		args = append(args, cursorTS, cursorTS, cursorHash)
+	}
+
+	query := `
+		SELECT
+			event_timestamp, created_at, formatted_time, event_type,
+			feature_name, feature_value, device_id, model_id, gateway_serial,
+			error_code, error_description, human_readable, code_category, severity,
+			active, body, raw, installation_id, account_id, account_name, hash
+		FROM events
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY event_timestamp ` + direction + `, hash ` + direction
+
+	// One extra row tells whether there is a next page
+	if q.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", q.Limit+1)
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %v", err)
+	}
+	defer rows.Close()
+
+	events, hashes := scanEventRows(rows)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %v", err)
+	}
+
+	page := &EventsPage{Events: events}
+	if page.Events == nil {
+		page.Events = []Event{}
+	}
+	if q.Limit > 0 && len(events) > q.Limit {
+		page.Events = events[:q.Limit]
+		page.HasMore = true
+		page.NextCursor = encodeEventCursor(events[q.Limit-1].EventTimestamp, hashes[q.Limit-1])
+	}
+	return page, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// scanEventRows reads the event columns of a query; if the query also selects
+// the hash as last column, the hashes are returned alongside the events
+func scanEventRows(rows *sql.Rows) ([]Event, []string) {
+	columns, _ := rows.Columns()
+	withHash := len(columns) > 0 && columns[len(columns)-1] == "hash"
+
 	var events []Event
+	var hashes []string
 	for rows.Next() {
 		var event Event
 		var bodyJSON string
 		var activeInt *int
+		var hash string
 
-		err := rows.Scan(
+		dest := []interface{}{
 			&event.EventTimestamp,
 			&event.CreatedAt,
 			&event.FormattedTime,
@@ -819,9 +1166,12 @@ func GetEventsFromDB(startTime, endTime time.Time, limit int) ([]Event, error) {
 			&event.InstallationID,
 			&event.AccountID,
 			&event.AccountName,
-		)
+		}
+		if withHash {
+			dest = append(dest, &hash)
+		}
 
-		if err != nil {
+		if err := rows.Scan(dest...); err != nil {
 			log.Printf("Warning: failed to scan event row: %v", err)
 			continue
 		}
@@ -841,20 +1191,21 @@ func GetEventsFromDB(startTime, endTime time.Time, limit int) ([]Event, error) {
 		}
 
 		events = append(events, event)
+		hashes = append(hashes, hash)
 	}
 
-	return events, nil
+	return events, hashes
 }
 
 // CleanupOldEvents removes events older than the retention period
 func CleanupOldEvents(retentionDays int) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
 	if !dbInitialized || eventDB == nil {
 		return fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.Lock()
-	defer dbMutex.Unlock()
-
 	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
 
 	result, err := eventDB.Exec("DELETE FROM events WHERE event_timestamp < ?", cutoffTime.Format(time.RFC3339))
@@ -867,18 +1218,22 @@ func CleanupOldEvents(retentionDays int) error {
 		log.Printf("Cleaned up %d old events (retention: %d days)", rowsAffected, retentionDays)
 	}
 
+	if err := cleanupFaultEpisodesLocked(cutoffTime); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // GetEventCount returns the total number of events in the database
 func GetEventCount() (int64, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return 0, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	var count int64
 	err := eventDB.QueryRow("SELECT COUNT(*) FROM events").Scan(&count)
 	if err != nil {
@@ -890,13 +1245,13 @@ func GetEventCount() (int64, error) {
 
 // GetOldestEventTimestamp returns the timestamp of the oldest event in the database
 func GetOldestEventTimestamp() (string, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return "", fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	var timestamp string
 	err := eventDB.QueryRow("SELECT event_timestamp FROM events ORDER BY event_timestamp ASC LIMIT 1").Scan(&timestamp)
 	if err == sql.ErrNoRows {
@@ -909,15 +1264,73 @@ func GetOldestEventTimestamp() (string, error) {
 	return timestamp, nil
 }
 
-// SaveTemperatureSnapshot inserts a temperature snapshot into the database
-func SaveTemperatureSnapshot(snapshot *TemperatureSnapshot) error {
+// EventCategoryCount is the number of stored events per severity and code category
+type EventCategoryCount struct {
+	Severity     string
+	CodeCategory string
+	Count        int64
+}
+
+// GetEventCountsByCategory returns the number of stored events grouped by severity and code category
+func GetEventCountsByCategory() ([]EventCategoryCount, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
-		return fmt.Errorf("database not initialized")
+		return nil, fmt.Errorf("database not initialized")
 	}
 
+	rows, err := eventDB.Query(`
+		SELECT COALESCE(severity, ''), COALESCE(code_category, ''), COUNT(*)
+		FROM events
+		GROUP BY 1, 2
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events by category: %v", err)
+	}
+	defer rows.Close()
+
+	var counts []EventCategoryCount
+	for rows.Next() {
+		var c EventCategoryCount
+		if err := rows.Scan(&c.Severity, &c.CodeCategory, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan event count: %v", err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// GetDatabaseSize returns the size of the database file in bytes (without WAL)
+func GetDatabaseSize() (int64, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	var pageCount, pageSize int64
+	if err := eventDB.QueryRow("PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to get page count: %v", err)
+	}
+	if err := eventDB.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to get page size: %v", err)
+	}
+
+	return pageCount * pageSize, nil
+}
+
+// SaveTemperatureSnapshot inserts a temperature snapshot into the database
+func SaveTemperatureSnapshot(snapshot *TemperatureSnapshot) error {
 	dbMutex.Lock()
 	defer dbMutex.Unlock()
 
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
 	// Convert bool pointers to nullable ints
 	var compressorActiveInt, circulationPumpActiveInt, dhwPumpActiveInt, internalPumpActiveInt *int
 	if snapshot.CompressorActive != nil {
@@ -965,8 +1378,9 @@ func SaveTemperatureSnapshot(snapshot *TemperatureSnapshot) error {
 			circulation_pump_active, dhw_pump_active, internal_pump_active,
 			volumetric_flow, thermal_power, cop,
 			heating_circuit_0_delta_t, heating_circuit_1_delta_t, heating_circuit_2_delta_t, heating_circuit_3_delta_t,
-			four_way_valve, burner_modulation, secondary_heat_generator_status,four_way_valve_current,four_way_valve_target,pressure_supply
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			four_way_valve, burner_modulation, secondary_heat_generator_status,four_way_valve_current,four_way_valve_target,pressure_supply,
+			room_temp, room_humidity
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := eventDB.Exec(insertSQL,
@@ -1023,6 +1437,8 @@ func SaveTemperatureSnapshot(snapshot *TemperatureSnapshot) error {
 		snapshot.FourWayValveCurrent,
 		snapshot.FourWayValveTarget,
 		snapshot.PressureSupply,
+		snapshot.RoomTemp,
+		snapshot.RoomHumidity,
 	)
 
 	if err != nil {
@@ -1032,15 +1448,29 @@ func SaveTemperatureSnapshot(snapshot *TemperatureSnapshot) error {
 	return nil
 }
 
-// GetTemperatureSnapshots retrieves temperature snapshots from the database with optional filters
-func GetTemperatureSnapshots(installationID, gatewayID, deviceID string, startTime, endTime time.Time, limit int) ([]TemperatureSnapshot, error) {
-	if !dbInitialized || eventDB == nil {
-		return nil, fmt.Errorf("database not initialized")
+// appendDeviceFilter restricts a query to one or more device IDs, an empty list matches all devices
+func appendDeviceFilter(query string, args []interface{}, deviceIDs []string) (string, []interface{}) {
+	if len(deviceIDs) == 0 {
+		return query, args
+	}
+	placeholders := make([]string, len(deviceIDs))
+	for i, id := range deviceIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
 	}
+	return query + " AND device_id IN (" + strings.Join(placeholders, ", ") + ")", args
+}
 
+// GetTemperatureSnapshots retrieves temperature snapshots from the database with optional filters.
+// Several device IDs return the snapshots of all these devices, e.g. to overlay them in a chart.
+func GetTemperatureSnapshots(installationID, gatewayID string, deviceIDs []string, startTime, endTime time.Time, limit int) ([]TemperatureSnapshot, error) {
 	dbMutex.RLock()
 	defer dbMutex.RUnlock()
 
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
 	// Build query with optional filters
 	query := `
 		SELECT
@@ -1056,7 +1486,8 @@ func GetTemperatureSnapshots(installationID, gatewayID, deviceID string, startTi
 			circulation_pump_active, dhw_pump_active, internal_pump_active,
 			volumetric_flow, thermal_power, cop,
 			heating_circuit_0_delta_t, heating_circuit_1_delta_t, heating_circuit_2_delta_t, heating_circuit_3_delta_t,
-			four_way_valve, burner_modulation, secondary_heat_generator_status,four_way_valve_current,four_way_valve_target,pressure_supply
+			four_way_valve, burner_modulation, secondary_heat_generator_status,four_way_valve_current,four_way_valve_target,pressure_supply,
+			room_temp, room_humidity
 		FROM temperature_snapshots
 		WHERE installation_id = ? AND timestamp >= ? AND timestamp <= ?
 	`
@@ -1070,10 +1501,7 @@ func GetTemperatureSnapshots(installationID, gatewayID, deviceID string, startTi
 	}
 
 	// Add optional device filter
-	if deviceID != "" {
-		query += " AND device_id = ?"
-		args = append(args, deviceID)
-	}
+	query, args = appendDeviceFilter(query, args, deviceIDs)
 
 	query += " ORDER BY timestamp ASC"
 
@@ -1146,6 +1574,8 @@ func GetTemperatureSnapshots(installationID, gatewayID, deviceID string, startTi
 			&snapshot.FourWayValveCurrent,
 			&snapshot.FourWayValveTarget,
 			&snapshot.PressureSupply,
+			&snapshot.RoomTemp,
+			&snapshot.RoomHumidity,
 		)
 
 		if err != nil {
@@ -1185,15 +1615,205 @@ func GetTemperatureSnapshots(installationID, gatewayID, deviceID string, startTi
 	return snapshots, nil
 }
 
-// GetTemperatureSnapshotCount returns the total number of temperature snapshots in the database
-func GetTemperatureSnapshotCount() (int64, error) {
+// TemperatureExportFilter selects the snapshots of an export
+type TemperatureExportFilter struct {
+	InstallationIDs []string // Empty = all installations
+	GatewayID       string
+	DeviceID        string
+	StartTime       time.Time
+	EndTime         time.Time
+}
+
+// ExportTemperatureSnapshots reads the given columns of all matching snapshots ordered by time and
+// calls fn for each row. Rows are read in batches, the database lock is not held while fn runs.
+// Column names are the JSON names of TemperatureSnapshot, which are also the column names in the table.
+func ExportTemperatureSnapshots(filter TemperatureExportFilter, columns []exportColumn, fn func(values []interface{}) error) error {
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+
+	where := []string{"timestamp >= ?", "timestamp <= ?"}
+	args := []interface{}{filter.StartTime.UTC().Format(time.RFC3339), filter.EndTime.UTC().Format(time.RFC3339)}
+	if len(filter.InstallationIDs) > 0 {
+		where = append(where, "installation_id IN (?"+strings.Repeat(",?", len(filter.InstallationIDs)-1)+")")
+		for _, id := range filter.InstallationIDs {
+			args = append(args, id)
+		}
+	}
+	if filter.GatewayID != "" {
+		where = append(where, "gateway_id = ?")
+		args = append(args, filter.GatewayID)
+	}
+	if filter.DeviceID != "" {
+		where = append(where, "device_id = ?")
+		args = append(args, filter.DeviceID)
+	}
+
+	query := `SELECT id, timestamp, ` + strings.Join(names, ", ") + `
+		FROM temperature_snapshots
+		WHERE ` + strings.Join(where, " AND ") + `
+			AND (timestamp > ? OR (timestamp = ? AND id > ?))
+		ORDER BY timestamp, id
+		LIMIT ` + strconv.Itoa(exportBatchSize)
+
+	lastTimestamp, lastID := "", int64(0)
+	for {
+		batch, err := readTemperatureExportBatch(query, append(args, lastTimestamp, lastTimestamp, lastID), columns, &lastTimestamp, &lastID)
+		if err != nil {
+			return err
+		}
+		for _, values := range batch {
+			if err := fn(values); err != nil {
+				return err
+			}
+		}
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// ImportTemperatureRows inserts snapshot rows in one transaction. Rows that already exist
+// (same timestamp, installation, gateway and device) are left untouched.
+// Returns the number of inserted rows.
+func ImportTemperatureRows(columns []string, rows [][]interface{}) (int, error) {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
 	if !dbInitialized || eventDB == nil {
 		return 0, fmt.Errorf("database not initialized")
 	}
 
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback() // Will be no-op if committed
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO temperature_snapshots (` + strings.Join(columns, ", ") + `)
+		VALUES (?` + strings.Repeat(", ?", len(columns)-1) + `)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %v", err)
+	}
+	defer stmt.Close()
+
+	timestampColumn := -1
+	for i, c := range columns {
+		if c == "timestamp" {
+			timestampColumn = i
+		}
+	}
+
+	inserted := 0
+	oldest := ""
+	for _, values := range rows {
+		result, err := stmt.Exec(values...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert temperature snapshot: %v", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			inserted++
+			if timestampColumn < 0 {
+				continue
+			}
+			if ts, ok := values[timestampColumn].(string); ok && (oldest == "" || ts < oldest) {
+				oldest = ts
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	// Rollups of the imported period are recalculated on the next update
+	if ts, err := time.Parse(time.RFC3339, oldest); err == nil {
+		if err := invalidateTemperatureRollupsLocked(ts); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return inserted, nil
+}
+
+func readTemperatureExportBatch(query string, args []interface{}, columns []exportColumn, lastTimestamp *string, lastID *int64) ([][]interface{}, error) {
 	dbMutex.RLock()
 	defer dbMutex.RUnlock()
 
+	// The database may have been closed or restored between two batches
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query temperature snapshots: %v", err)
+	}
+	defer rows.Close()
+
+	var batch [][]interface{}
+	dest := make([]interface{}, len(columns)+2)
+	dest[0] = lastID
+	dest[1] = lastTimestamp
+	for rows.Next() {
+		for i, c := range columns {
+			switch c.Kind {
+			case exportFloat:
+				dest[i+2] = new(sql.NullFloat64)
+			case exportInt, exportBool:
+				dest[i+2] = new(sql.NullInt64)
+			default:
+				dest[i+2] = new(sql.NullString)
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan temperature snapshot row: %v", err)
+		}
+
+		values := make([]interface{}, len(columns))
+		for i, c := range columns {
+			switch v := dest[i+2].(type) {
+			case *sql.NullFloat64:
+				if v.Valid {
+					values[i] = v.Float64
+				}
+			case *sql.NullInt64:
+				if v.Valid && c.Kind == exportBool {
+					values[i] = v.Int64 == 1
+				} else if v.Valid {
+					values[i] = v.Int64
+				}
+			case *sql.NullString:
+				if v.Valid && c.Kind == exportTime {
+					if ts, err := time.Parse(time.RFC3339, v.String); err == nil {
+						values[i] = ts
+					}
+				} else if v.Valid {
+					values[i] = v.String
+				}
+			}
+		}
+		batch = append(batch, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read temperature snapshots: %v", err)
+	}
+	return batch, nil
+}
+
+// GetTemperatureSnapshotCount returns the total number of temperature snapshots in the database
+func GetTemperatureSnapshotCount() (int64, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
 	var count int64
 	err := eventDB.QueryRow("SELECT COUNT(*) FROM temperature_snapshots").Scan(&count)
 	if err != nil {
@@ -1203,15 +1823,50 @@ func GetTemperatureSnapshotCount() (int64, error) {
 	return count, nil
 }
 
-// CleanupOldTemperatureSnapshots removes temperature snapshots older than the retention period
-func CleanupOldTemperatureSnapshots(retentionDays int) error {
+// GetTemperatureSnapshotDeviceStats returns the number and time of the last snapshot per device,
+// keyed by installation/gateway/device
+func GetTemperatureSnapshotDeviceStats() (map[string]TemperatureLogDeviceInfo, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
-		return fmt.Errorf("database not initialized")
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(`
+		SELECT installation_id, gateway_id, device_id, COUNT(*), MAX(timestamp)
+		FROM temperature_snapshots
+		GROUP BY installation_id, gateway_id, device_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query temperature snapshot devices: %v", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]TemperatureLogDeviceInfo)
+	for rows.Next() {
+		var d TemperatureLogDeviceInfo
+		var last string
+		if err := rows.Scan(&d.InstallationID, &d.GatewayID, &d.DeviceID, &d.Snapshots, &last); err != nil {
+			return nil, fmt.Errorf("failed to scan temperature snapshot device: %v", err)
+		}
+		if t, err := time.Parse(time.RFC3339, last); err == nil {
+			d.LastSnapshot = &t
+		}
+		stats[d.InstallationID+"/"+d.GatewayID+"/"+d.DeviceID] = d
 	}
+	return stats, rows.Err()
+}
 
+// CleanupOldTemperatureSnapshots removes temperature snapshots older than the retention period
+func CleanupOldTemperatureSnapshots(retentionDays int) error {
 	dbMutex.Lock()
 	defer dbMutex.Unlock()
 
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
 	cutoffTime := time.Now().UTC().AddDate(0, 0, -retentionDays)
 
 	result, err := eventDB.Exec("DELETE FROM temperature_snapshots WHERE timestamp < ?", cutoffTime.Format(time.RFC3339))
@@ -1229,68 +1884,122 @@ func CleanupOldTemperatureSnapshots(retentionDays int) error {
 
 // GetTemperatureLogSettings retrieves the temperature logging settings
 func GetTemperatureLogSettings() (*TemperatureLogSettings, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	var settings TemperatureLogSettings
 	var enabledInt int
 
 	err := eventDB.QueryRow(`
-		SELECT enabled, sample_interval, retention_days, database_path
+		SELECT enabled, sample_interval, retention_days, database_path,
+			hourly_rollup_retention_days, daily_rollup_retention_days
 		FROM temperature_log_settings
 		WHERE id = 1
-	`).Scan(&enabledInt, &settings.SampleInterval, &settings.RetentionDays, &settings.DatabasePath)
+	`).Scan(&enabledInt, &settings.SampleInterval, &settings.RetentionDays, &settings.DatabasePath,
+		&settings.HourlyRollupRetentionDays, &settings.DailyRollupRetentionDays)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get temperature log settings: %v", err)
 	}
-
 	settings.Enabled = enabledInt == 1
-	return &settings, nil
+
+	rows, err := eventDB.Query(`
+		SELECT installation_id, gateway_id, device_id, enabled
+		FROM temperature_log_devices
+		ORDER BY installation_id, gateway_id, device_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get temperature log devices: %v", err)
+	}
+	defer rows.Close()
+
+	settings.Devices = []TemperatureLogDevice{}
+	for rows.Next() {
+		var d TemperatureLogDevice
+		var deviceEnabled int
+		if err := rows.Scan(&d.InstallationID, &d.GatewayID, &d.DeviceID, &deviceEnabled); err != nil {
+			return nil, fmt.Errorf("failed to scan temperature log device: %v", err)
+		}
+		d.Enabled = deviceEnabled == 1
+		settings.Devices = append(settings.Devices, d)
+	}
+
+	return &settings, rows.Err()
 }
 
 // SetTemperatureLogSettings updates the temperature logging settings
 func SetTemperatureLogSettings(settings *TemperatureLogSettings) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
 	if !dbInitialized || eventDB == nil {
 		return fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.Lock()
-	defer dbMutex.Unlock()
-
 	enabledInt := 0
 	if settings.Enabled {
 		enabledInt = 1
 	}
 
-	_, err := eventDB.Exec(`
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback() // Will be no-op if committed
+
+	_, err = tx.Exec(`
 		UPDATE temperature_log_settings
-		SET enabled = ?, sample_interval = ?, retention_days = ?, database_path = ?
+		SET enabled = ?, sample_interval = ?, retention_days = ?, database_path = ?,
+			hourly_rollup_retention_days = ?, daily_rollup_retention_days = ?
 		WHERE id = 1
-	`, enabledInt, settings.SampleInterval, settings.RetentionDays, settings.DatabasePath)
+	`, enabledInt, settings.SampleInterval, settings.RetentionDays, settings.DatabasePath,
+		settings.HourlyRollupRetentionDays, settings.DailyRollupRetentionDays)
 
 	if err != nil {
 		return fmt.Errorf("failed to update temperature log settings: %v", err)
 	}
 
-	log.Printf("Temperature log settings updated: enabled=%v, interval=%dm, retention=%dd",
-		settings.Enabled, settings.SampleInterval, settings.RetentionDays)
+	// Replace the device selection if one was sent
+	if settings.Devices != nil {
+		if _, err := tx.Exec("DELETE FROM temperature_log_devices"); err != nil {
+			return fmt.Errorf("failed to update temperature log devices: %v", err)
+		}
+		for _, d := range settings.Devices {
+			deviceEnabled := 0
+			if d.Enabled {
+				deviceEnabled = 1
+			}
+			_, err := tx.Exec(`INSERT OR REPLACE INTO temperature_log_devices (installation_id, gateway_id, device_id, enabled) VALUES (?, ?, ?, ?)`,
+				d.InstallationID, d.GatewayID, d.DeviceID, deviceEnabled)
+			if err != nil {
+				return fmt.Errorf("failed to update temperature log devices: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	log.Printf("Temperature log settings updated: enabled=%v, interval=%dm, retention=%dd, rollup retention=%dd/%dd",
+		settings.Enabled, settings.SampleInterval, settings.RetentionDays,
+		settings.HourlyRollupRetentionDays, settings.DailyRollupRetentionDays)
 	return nil
 }
 
 // GetConsumptionStats calculates aggregated consumption statistics for a given time period
 func GetConsumptionStats(installationID, gatewayID, deviceID string, startTime, endTime time.Time) (*ConsumptionStats, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	// Get current sample interval as fallback for old records
 	settings, err := GetTemperatureLogSettings()
 	if err != nil {
@@ -1298,6 +2007,36 @@ func GetConsumptionStats(installationID, gatewayID, deviceID string, startTime,
 	}
 	fallbackInterval := settings.SampleInterval
 
+	// Long ranges are read from the rollups, the edges not covered by them from the snapshots
+	level := ""
+	if useRollups(startTime, endTime, settings.RetentionDays) {
+		level = RollupDaily
+	}
+	totals, err := sumConsumptionLocked(level, installationID, gatewayID, deviceID, startTime, endTime, fallbackInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	avgCOP := 0.0
+	if totals.copCount > 0 {
+		avgCOP = totals.copSum / float64(totals.copCount)
+	}
+
+	stats := &ConsumptionStats{
+		StartTime:      startTime,
+		EndTime:        endTime,
+		ElectricityKWh: totals.electricityWh / 1000.0, // Wh -> kWh
+		ThermalKWh:     totals.thermalWh / 1000.0,     // Wh -> kWh
+		AvgCOP:         avgCOP,
+		RuntimeHours:   totals.runtimeMinutes / 60.0,
+		Samples:        totals.samples,
+	}
+
+	return stats, nil
+}
+
+// rawConsumptionLocked integrates the consumption of [startTime, endTime) from the snapshots, dbMutex must be held
+func rawConsumptionLocked(installationID, gatewayID, deviceID string, startTime, endTime time.Time, fallbackInterval int) (consumptionTotals, error) {
 	// Query to get snapshots in time range
 	query := `
 		SELECT
@@ -1330,17 +2069,11 @@ func GetConsumptionStats(installationID, gatewayID, deviceID string, startTime,
 	rows, err := eventDB.Query(query, fallbackInterval, installationID, gatewayID, deviceID,
 		startTime.UTC().Format(time.RFC3339), endTime.UTC().Format(time.RFC3339))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query consumption data: %v", err)
+		return consumptionTotals{}, fmt.Errorf("failed to query consumption data: %v", err)
 	}
 	defer rows.Close()
 
-	var totalElectricityWh float64
-	var totalThermalWh float64
-	var copSum float64
-	var copCount int
-	var runtimeMinutes float64
-	var samples int
-
+	var totals consumptionTotals
 	for rows.Next() {
 		var timestampStr string
 		var compressorPower, thermalPower, cop *float64
@@ -1353,7 +2086,7 @@ func GetConsumptionStats(installationID, gatewayID, deviceID string, startTime,
 			continue
 		}
 
-		samples++
+		totals.samples++
 
 		// Use the actual sample interval from this specific row
 		intervalMinutes := float64(sampleIntervalMinutes)
@@ -1362,56 +2095,41 @@ func GetConsumptionStats(installationID, gatewayID, deviceID string, startTime,
 		// compressor_power is in Watts, thermal_power is in kW
 		if compressorPower != nil {
 			electricityWh := (*compressorPower) * (intervalMinutes / 60.0) // Wh
-			totalElectricityWh += electricityWh
+			totals.electricityWh += electricityWh
 		}
 
 		if thermalPower != nil {
 			thermalWh := (*thermalPower) * 1000.0 * (intervalMinutes / 60.0) // kW -> Wh
-			totalThermalWh += thermalWh
+			totals.thermalWh += thermalWh
 		}
 
 		if cop != nil && *cop > 0 {
-			copSum += *cop
-			copCount++
+			totals.copSum += *cop
+			totals.copCount++
 		}
 
 		// Count runtime if compressor was active
 		if compressorActiveInt != nil && *compressorActiveInt == 1 {
-			runtimeMinutes += intervalMinutes
+			totals.runtimeMinutes += intervalMinutes
 		}
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating consumption rows: %v", err)
+	if err := rows.Err(); err != nil {
+		return totals, fmt.Errorf("error iterating consumption rows: %v", err)
 	}
 
-	avgCOP := 0.0
-	if copCount > 0 {
-		avgCOP = copSum / float64(copCount)
-	}
-
-	stats := &ConsumptionStats{
-		StartTime:      startTime,
-		EndTime:        endTime,
-		ElectricityKWh: totalElectricityWh / 1000.0, // Wh -> kWh
-		ThermalKWh:     totalThermalWh / 1000.0,     // Wh -> kWh
-		AvgCOP:         avgCOP,
-		RuntimeHours:   runtimeMinutes / 60.0,
-		Samples:        samples,
-	}
-
-	return stats, nil
+	return totals, nil
 }
 
 // GetHourlyConsumptionBreakdown returns hourly consumption data for a given day
 func GetHourlyConsumptionBreakdown(installationID, gatewayID, deviceID string, date time.Time) ([]ConsumptionDataPoint, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	// Get current sample interval as fallback for old records
 	settings, err := GetTemperatureLogSettings()
 	if err != nil {
@@ -1424,6 +2142,16 @@ func GetHourlyConsumptionBreakdown(installationID, gatewayID, deviceID string, d
 	startTime := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, DefaultLocation)
 	endTime := startTime.Add(24 * time.Hour)
 
+	// Days beyond the retention of the snapshots are read from the hourly rollups
+	if useRollups(startTime, endTime, settings.RetentionDays) {
+		dataPoints, err := rollupBreakdownLocked(RollupHourly, installationID, gatewayID, deviceID, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		applyHourlyCostsLocked(dataPoints, installationID, deviceID)
+		return dataPoints, nil
+	}
+
 	query := `
 		SELECT
 			STRFTIME('%H', timestamp, 'localtime') as hour,
@@ -1501,18 +2229,21 @@ func GetHourlyConsumptionBreakdown(installationID, gatewayID, deviceID string, d
 		dataPoints = append(dataPoints, dataPoint)
 	}
 
+	// Cost per hour from the tariff of the device (see tariffs.go)
+	applyHourlyCostsLocked(dataPoints, installationID, deviceID)
+
 	return dataPoints, nil
 }
 
 // GetDailyConsumptionBreakdown returns daily consumption data for a given period
 func GetDailyConsumptionBreakdown(installationID, gatewayID, deviceID string, startDate, endDate time.Time) ([]ConsumptionDataPoint, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
 	if !dbInitialized || eventDB == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	dbMutex.RLock()
-	defer dbMutex.RUnlock()
-
 	// Get current sample interval as fallback for old records
 	settings, err := GetTemperatureLogSettings()
 	if err != nil {
@@ -1525,6 +2256,61 @@ func GetDailyConsumptionBreakdown(installationID, gatewayID, deviceID string, st
 	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, DefaultLocation)
 	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, DefaultLocation).Add(24 * time.Hour)
 
+	dataPoints, err := dailyBreakdownLocked(installationID, gatewayID, deviceID, start, end, fallbackInterval, settings.RetentionDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cost per day from the tariff of the device (see tariffs.go)
+	applyDailyCostsLocked(dataPoints, installationID, gatewayID, deviceID, fallbackInterval)
+	return dataPoints, nil
+}
+
+// dailyBreakdownLocked returns the daily points of the local days [start, end), dbMutex must be held
+func dailyBreakdownLocked(installationID, gatewayID, deviceID string, start, end time.Time, fallbackInterval, rawRetentionDays int) ([]ConsumptionDataPoint, error) {
+	// Long ranges are read from the daily rollups, days not covered by them from the snapshots
+	if !useRollups(start, end, rawRetentionDays) {
+		return rawDailyBreakdownLocked(installationID, gatewayID, deviceID, start, end, fallbackInterval)
+	}
+	rollupStart, rollupEnd := start, start
+	if from, until, ok := rollupCoverageLocked(RollupDaily); ok {
+		rollupStart, rollupEnd = from, until
+		if rollupStart.Before(start) {
+			rollupStart = start
+		}
+		if rollupEnd.After(end) {
+			rollupEnd = end
+		}
+	}
+	if !rollupStart.Before(rollupEnd) {
+		return rawDailyBreakdownLocked(installationID, gatewayID, deviceID, start, end, fallbackInterval)
+	}
+
+	dataPoints, err := rawDailyBreakdownLocked(installationID, gatewayID, deviceID, start, rollupStart, fallbackInterval)
+	if err != nil {
+		return nil, err
+	}
+	rollupPoints, err := rollupBreakdownLocked(RollupDaily, installationID, gatewayID, deviceID, rollupStart, rollupEnd)
+	if err != nil {
+		return nil, err
+	}
+	dataPoints = append(dataPoints, rollupPoints...)
+	if rollupEnd.Before(end) {
+		rawPoints, err := rawDailyBreakdownLocked(installationID, gatewayID, deviceID, rollupEnd, end, fallbackInterval)
+		if err != nil {
+			return nil, err
+		}
+		dataPoints = append(dataPoints, rawPoints...)
+	}
+	return dataPoints, nil
+}
+
+// rawDailyBreakdownLocked groups the snapshots of [start, end) by day, dbMutex must be held
+func rawDailyBreakdownLocked(installationID, gatewayID, deviceID string, start, end time.Time, fallbackInterval int) ([]ConsumptionDataPoint, error) {
+	if !start.Before(end) {
+		return nil, nil
+	}
+
 	query := `
 		SELECT
 			DATE(timestamp, 'localtime') as day,
@@ -0,0 +1,1036 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Defrost cycle detection
+//
+// A defrost is recognized from three sources which are merged into one cycle if they overlap:
+//   - valve:     the 4/3-way valve position (heating.valves.fourThreeWay.position) reports defrost
+//   - event:     defrost status codes (S.13 etc.) between activation and deactivation
+//   - heuristic: compressor running below defrostMaxOutsideTemp while heat is taken from the circuit
+//     (negative thermal power) or the compressor outlet temperature drops sharply. Only used for
+//     devices that do not report the valve position.
+// Cycles are kept in defrost_cycles and re-detected for the last hours after every snapshot and
+// after new defrost events, so late events still end up in the right cycle.
+
+// defrostStatusCodes are the status/information codes reported while the outdoor unit defrosts
+var defrostStatusCodes = []string{"S.13", "S.61", "S.63", "S.128", "I.134", "I.135"}
+
+// defrostValvePositions are the positions of heating.valves.fourThreeWay.position during a defrost
+var defrostValvePositions = map[string]bool{
+	"defrost":                 true,
+	"climatCircuitTwoDefrost": true,
+}
+
+const (
+	defrostMaxOutsideTemp    = 7.0              // Above, the heuristic does not report defrosts
+	defrostOutletDrop        = 10.0             // Drop of the compressor outlet temperature in K between two samples
+	defrostRedetectWindow    = 6 * time.Hour    // Re-detected after each snapshot
+	defrostEventMaxDuration  = 30 * time.Minute // Activations without deactivation within this time count one sample interval
+	defrostMergeGap          = 2 * time.Minute  // Spans closer than this belong to one cycle
+	defrostOutsideBinSize    = 2.0              // K per outside temperature bin of the statistics
+	defrostFanRingFeature    = "heating.heater.fanRing"
+	defrostOutsideHumidities = "**.sensors.humidity.outside"
+)
+
+// DefrostCycle is one detected defrost of a heat pump
+type DefrostCycle struct {
+	ID              int64    `json:"id"`
+	InstallationID  string   `json:"installationId"`
+	GatewaySerial   string   `json:"gatewaySerial"`
+	DeviceID        string   `json:"deviceId"`
+	StartedAt       string   `json:"startedAt"`
+	EndedAt         string   `json:"endedAt"`
+	DurationSeconds int64    `json:"durationSeconds"`
+	OutsideTemp     *float64 `json:"outsideTemp"`
+	Humidity        *float64 `json:"humidity"`      // Outside humidity from the feature log, nil without an outside humidity series
+	ElectricityWh   *float64 `json:"electricityWh"` // Compressor energy during the defrost
+	HeatWh          *float64 `json:"heatWh"`        // Heat taken from the heating circuit
+	EnergyLostWh    *float64 `json:"energyLostWh"`  // Electricity + heat taken, nil for cycles known from events only
+	FanRingActive   *bool    `json:"fanRingActive"` // Fan ring heating state, if logged in the feature log
+	Sources         []string `json:"sources"`       // valve, event, heuristic
+	Samples         int      `json:"samples"`       // Snapshots within the cycle
+}
+
+// DefrostFilter selects the cycles of a device starting in [StartTime, EndTime)
+type DefrostFilter struct {
+	InstallationID string
+	GatewaySerial  string
+	DeviceID       string
+	StartTime      time.Time
+	EndTime        time.Time
+	Limit          int
+}
+
+// DefrostGroupStats summarizes a group of cycles
+type DefrostGroupStats struct {
+	Defrosts               int      `json:"defrosts"`
+	RuntimeHours           float64  `json:"runtimeHours"`           // Compressor runtime in the group
+	DefrostsPerRuntimeHour *float64 `json:"defrostsPerRuntimeHour"` // Icing indicator independent of the heat demand
+	AvgDurationSeconds     float64  `json:"avgDurationSeconds"`
+	EnergyLostKWh          float64  `json:"energyLostKWh"`
+	AvgEnergyLostWh        *float64 `json:"avgEnergyLostWh"`
+}
+
+// DefrostDay are the defrosts of one local day
+type DefrostDay struct {
+	Date string `json:"date"`
+	DefrostGroupStats
+	TotalDurationSeconds int64    `json:"totalDurationSeconds"`
+	AvgOutsideTemp       *float64 `json:"avgOutsideTemp"` // Day average, not only during defrosts
+	AvgHumidity          *float64 `json:"avgHumidity"`    // Average during the defrosts
+}
+
+// DefrostOutsideBin are the defrosts within an outside temperature range, split by fan ring state
+type DefrostOutsideBin struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+	DefrostGroupStats
+	FanRingOn  *DefrostGroupStats `json:"fanRingOn,omitempty"`
+	FanRingOff *DefrostGroupStats `json:"fanRingOff,omitempty"`
+}
+
+// DefrostStats is the result of GetDefrostStats
+type DefrostStats struct {
+	InstallationID string              `json:"installationId"`
+	GatewaySerial  string              `json:"gatewaySerial"`
+	DeviceID       string              `json:"deviceId"`
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	Total          DefrostGroupStats   `json:"total"`
+	FanRingOn      *DefrostGroupStats  `json:"fanRingOn,omitempty"`
+	FanRingOff     *DefrostGroupStats  `json:"fanRingOff,omitempty"`
+	Days           []DefrostDay        `json:"days"`
+	OutsideBins    []DefrostOutsideBin `json:"outsideBins"`
+}
+
+func createDefrostTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS defrost_cycles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		installation_id TEXT NOT NULL,
+		gateway_serial TEXT NOT NULL,
+		device_id TEXT NOT NULL,
+		started_at TEXT NOT NULL,
+		ended_at TEXT NOT NULL,
+		duration_seconds INTEGER NOT NULL,
+		outside_temp REAL,
+		humidity REAL,
+		electricity_wh REAL,
+		heat_wh REAL,
+		energy_lost_wh REAL,
+		fan_ring_active INTEGER,
+		sources TEXT NOT NULL,
+		samples INTEGER NOT NULL DEFAULT 0,
+		UNIQUE (installation_id, gateway_serial, device_id, started_at)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_defrost_cycles_started ON defrost_cycles(started_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create defrost tables: %v", err)
+	}
+	return nil
+}
+
+// defrostSnapshot are the snapshot values used by the detection
+type defrostSnapshot struct {
+	t                time.Time
+	interval         float64 // Minutes
+	outside          *float64
+	compressorActive bool
+	compressorPower  *float64 // W
+	thermalPower     *float64 // kW
+	outletTemp       *float64
+	dhwActive        bool // Valve in a hot water position, without valve position hot water heating is not recognisable
+	valve            *string
+}
+
+// defrostSpan is a time span in which one source reported a defrost
+type defrostSpan struct {
+	start, end time.Time
+	source     string
+}
+
+// timedValue is a feature log sample
+type timedValue struct {
+	t     time.Time
+	value interface{}
+}
+
+func loadDefrostSnapshotsLocked(installationID, gatewaySerial, deviceID string, start, end time.Time, fallbackInterval int) ([]defrostSnapshot, error) {
+	rows, err := eventDB.Query(`
+		SELECT timestamp, COALESCE(sample_interval, ?), outside_temp,
+			IFNULL(compressor_active, 0), compressor_power, thermal_power, compressor_outlet_temp,
+			CASE WHEN `+snapshotDHWActiveSQL+` THEN 1 ELSE 0 END, four_way_valve
+		FROM temperature_snapshots
+		WHERE installation_id = ? AND gateway_id = ? AND device_id = ? AND timestamp >= ? AND timestamp < ?
+		ORDER BY timestamp ASC
+	`, fallbackInterval, installationID, gatewaySerial, deviceID, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshots: %v", err)
+	}
+	defer rows.Close()
+
+	var snapshots []defrostSnapshot
+	for rows.Next() {
+		var ts string
+		var s defrostSnapshot
+		var compressor, dhw int
+		if err := rows.Scan(&ts, &s.interval, &s.outside, &compressor, &s.compressorPower,
+			&s.thermalPower, &s.outletTemp, &dhw, &s.valve); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %v", err)
+		}
+		if s.t, err = time.Parse(time.RFC3339, ts); err != nil {
+			continue
+		}
+		if s.interval <= 0 {
+			s.interval = float64(fallbackInterval)
+		}
+		s.compressorActive = compressor == 1 || (s.compressorPower != nil && *s.compressorPower > 0)
+		s.dhwActive = dhw == 1
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
+
+// loadDefrostEventSpansLocked pairs the activations and deactivations of the defrost status codes
+func loadDefrostEventSpansLocked(installationID, gatewaySerial, deviceID string, start, end time.Time, fallbackInterval int) ([]defrostSpan, error) {
+	placeholders := make([]string, len(defrostStatusCodes))
+	args := []interface{}{installationID, gatewaySerial, deviceID, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)}
+	for i, code := range defrostStatusCodes {
+		placeholders[i] = "?"
+		args = append(args, code)
+	}
+	rows, err := eventDB.Query(`
+		SELECT event_timestamp, error_code, active FROM events
+		WHERE installation_id = ? AND gateway_serial = ? AND device_id = ?
+			AND event_timestamp >= ? AND event_timestamp < ? AND active IS NOT NULL
+			AND error_code IN (`+strings.Join(placeholders, ",")+`)
+		ORDER BY event_timestamp, id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query defrost events: %v", err)
+	}
+	defer rows.Close()
+
+	fallback := time.Duration(fallbackInterval) * time.Minute
+	open := make(map[string]time.Time)
+	var spans []defrostSpan
+	// Without a deactivation within defrostEventMaxDuration the end is unknown, one sample interval is assumed
+	closeSpan := func(code string, end time.Time, deactivated bool) {
+		started := open[code]
+		delete(open, code)
+		if !deactivated || end.Sub(started) > defrostEventMaxDuration {
+			end = started.Add(fallback)
+		}
+		spans = append(spans, defrostSpan{start: started, end: end, source: "event"})
+	}
+	for rows.Next() {
+		var ts, code string
+		var active int
+		if err := rows.Scan(&ts, &code, &active); err != nil {
+			return nil, fmt.Errorf("failed to scan defrost event: %v", err)
+		}
+		t, ok := parseEventTime(ts)
+		if !ok {
+			continue
+		}
+		_, isOpen := open[code]
+		switch {
+		case active == 1 && !isOpen:
+			open[code] = t
+		case active == 1 && isOpen:
+			// Repeated activation: the previous defrost ended without a deactivation event
+			closeSpan(code, t, false)
+			open[code] = t
+		case active == 0 && isOpen:
+			closeSpan(code, t, true)
+		}
+	}
+	for code, started := range open {
+		closeSpan(code, started, false)
+	}
+	return spans, rows.Err()
+}
+
+// loadFeatureValuesLocked returns the logged samples of a feature property sorted by time, dbMutex must be held
+func loadFeatureValuesLocked(filter FeatureSeriesFilter, start, end time.Time) []timedValue {
+	series, err := getFeatureSamplesLocked(filter, start, end, 0)
+	if err != nil {
+		return nil
+	}
+	var values []timedValue
+	for _, s := range series {
+		for _, sample := range s.Data {
+			values = append(values, timedValue{t: sample.Timestamp, value: sample.Value})
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].t.Before(values[j].t) })
+	return values
+}
+
+// valueAt returns the last value at or before t that is at most maxAge old
+func valueAt(values []timedValue, t time.Time, maxAge time.Duration) (interface{}, bool) {
+	i := sort.Search(len(values), func(i int) bool { return values[i].t.After(t) }) - 1
+	if i < 0 || t.Sub(values[i].t) > maxAge {
+		return nil, false
+	}
+	return values[i].value, true
+}
+
+// detectDefrostCycles merges the defrost spans of the snapshots and events into cycles and adds
+// the outside temperature, humidity, energy and fan ring context
+func detectDefrostCycles(snapshots []defrostSnapshot, eventSpans []defrostSpan, fanRing, humidity []timedValue) []DefrostCycle {
+	// The heuristic is only needed for devices without valve position
+	hasValve := false
+	for _, s := range snapshots {
+		if s.valve != nil && *s.valve != "" {
+			hasValve = true
+			break
+		}
+	}
+
+	spans := append([]defrostSpan{}, eventSpans...)
+	for i, s := range snapshots {
+		source := ""
+		switch {
+		case hasValve:
+			if s.valve != nil && defrostValvePositions[*s.valve] {
+				source = "valve"
+			}
+		case s.compressorActive && !s.dhwActive && s.outside != nil && *s.outside < defrostMaxOutsideTemp:
+			if s.thermalPower != nil && *s.thermalPower < 0 {
+				source = "heuristic"
+			} else if i > 0 && s.outletTemp != nil {
+				prev := snapshots[i-1]
+				if prev.outletTemp != nil && !prev.dhwActive && s.t.Sub(prev.t) <= time.Duration(2*s.interval)*time.Minute &&
+					*prev.outletTemp-*s.outletTemp >= defrostOutletDrop {
+					source = "heuristic"
+				}
+			}
+		}
+		if source == "" {
+			continue
+		}
+
+		// Until the next sample, if it follows regularly, else one sample interval
+		interval := time.Duration(s.interval * float64(time.Minute))
+		end := s.t.Add(interval)
+		if i+1 < len(snapshots) && snapshots[i+1].t.Sub(s.t) <= 2*interval {
+			end = snapshots[i+1].t
+		}
+		spans = append(spans, defrostSpan{start: s.t, end: end, source: source})
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	var cycles []DefrostCycle
+	current := []defrostSpan{spans[0]}
+	end := spans[0].end
+	flush := func() {
+		cycles = append(cycles, buildDefrostCycle(current, end, snapshots, fanRing, humidity))
+	}
+	for _, span := range spans[1:] {
+		if !span.start.After(end.Add(defrostMergeGap)) {
+			current = append(current, span)
+			if span.end.After(end) {
+				end = span.end
+			}
+			continue
+		}
+		flush()
+		current = []defrostSpan{span}
+		end = span.end
+	}
+	flush()
+	return cycles
+}
+
+// buildDefrostCycle creates a cycle from merged spans
+func buildDefrostCycle(spans []defrostSpan, end time.Time, snapshots []defrostSnapshot, fanRing, humidity []timedValue) DefrostCycle {
+	start := spans[0].start
+	cycle := DefrostCycle{
+		StartedAt:       start.UTC().Format(time.RFC3339),
+		EndedAt:         end.UTC().Format(time.RFC3339),
+		DurationSeconds: int64(end.Sub(start).Seconds()),
+		Sources:         []string{},
+	}
+	seen := make(map[string]bool)
+	for _, span := range spans {
+		if !seen[span.source] {
+			seen[span.source] = true
+			cycle.Sources = append(cycle.Sources, span.source)
+		}
+	}
+	sort.Strings(cycle.Sources)
+
+	var outsideSum, electricity, heat float64
+	var outsideCount int
+	var nearest *defrostSnapshot
+	for i := range snapshots {
+		s := &snapshots[i]
+		// Outside temperature also from the sample right before the defrost
+		if !s.t.Before(start.Add(-time.Duration(s.interval*float64(time.Minute)))) && s.t.Before(end) && s.outside != nil {
+			outsideSum += *s.outside
+			outsideCount++
+		}
+		if !s.t.Before(start) && s.t.Before(end) {
+			cycle.Samples++
+			if s.compressorPower != nil {
+				electricity += *s.compressorPower * s.interval / 60.0
+			}
+			if s.thermalPower != nil && *s.thermalPower < 0 {
+				heat += -*s.thermalPower * 1000.0 * s.interval / 60.0
+			}
+		}
+		if s.outside != nil && (nearest == nil || absDuration(s.t.Sub(start)) < absDuration(nearest.t.Sub(start))) {
+			nearest = s
+		}
+	}
+	if outsideCount > 0 {
+		v := round2(outsideSum / float64(outsideCount))
+		cycle.OutsideTemp = &v
+	} else if nearest != nil && absDuration(nearest.t.Sub(start)) <= 30*time.Minute {
+		v := *nearest.outside
+		cycle.OutsideTemp = &v
+	}
+
+	// Only an outside sensor describes the air at the evaporator, the humidity sensor of the device is indoors
+	if v, ok := valueAt(humidity, end, time.Hour); ok {
+		if f, ok := v.(float64); ok {
+			cycle.Humidity = &f
+		}
+	}
+
+	if cycle.Samples > 0 {
+		electricity, heat = round2(electricity), round2(heat)
+		lost := round2(electricity + heat)
+		cycle.ElectricityWh, cycle.HeatWh, cycle.EnergyLostWh = &electricity, &heat, &lost
+	}
+
+	if v, ok := valueAt(fanRing, end, 24*time.Hour); ok {
+		if b, ok := v.(bool); ok {
+			cycle.FanRingActive = &b
+		}
+	}
+	return cycle
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// updateDefrostCyclesLocked re-detects the cycles of a device from since on, dbMutex must be held.
+// A cycle running at since is re-detected completely.
+func updateDefrostCyclesLocked(installationID, gatewaySerial, deviceID string, since time.Time, fallbackInterval int) (int, error) {
+	var earliest sql.NullString
+	err := eventDB.QueryRow(`
+		SELECT MIN(started_at) FROM defrost_cycles
+		WHERE installation_id = ? AND gateway_serial = ? AND device_id = ? AND ended_at >= ?
+	`, installationID, gatewaySerial, deviceID, since.UTC().Format(time.RFC3339)).Scan(&earliest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query defrost cycles: %v", err)
+	}
+	if earliest.Valid {
+		if t, err := time.Parse(time.RFC3339, earliest.String); err == nil && t.Before(since) {
+			since = t
+		}
+	}
+
+	// One hour ahead for the outlet temperature of the previous sample and the fan ring state
+	end := time.Now().Add(time.Minute)
+	loadStart := since.Add(-time.Hour)
+	snapshots, err := loadDefrostSnapshotsLocked(installationID, gatewaySerial, deviceID, loadStart, end, fallbackInterval)
+	if err != nil {
+		return 0, err
+	}
+	eventSpans, err := loadDefrostEventSpansLocked(installationID, gatewaySerial, deviceID, loadStart, end, fallbackInterval)
+	if err != nil {
+		return 0, err
+	}
+	fanRing := loadFeatureValuesLocked(FeatureSeriesFilter{
+		InstallationID: installationID, GatewayID: gatewaySerial, DeviceID: deviceID,
+		Feature: defrostFanRingFeature, Property: "active",
+	}, since.Add(-24*time.Hour), end)
+	humidity := loadFeatureValuesLocked(FeatureSeriesFilter{
+		InstallationID: installationID, Feature: defrostOutsideHumidities, Property: "value",
+	}, loadStart, end)
+
+	var cycles []DefrostCycle
+	for _, cycle := range detectDefrostCycles(snapshots, eventSpans, fanRing, humidity) {
+		if cycle.StartedAt >= since.UTC().Format(time.RFC3339) {
+			cycles = append(cycles, cycle)
+		}
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM defrost_cycles WHERE installation_id = ? AND gateway_serial = ? AND device_id = ? AND started_at >= ?`,
+		installationID, gatewaySerial, deviceID, since.UTC().Format(time.RFC3339)); err != nil {
+		return 0, fmt.Errorf("failed to delete defrost cycles: %v", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO defrost_cycles (
+			installation_id, gateway_serial, device_id, started_at, ended_at, duration_seconds, outside_temp,
+			humidity, electricity_wh, heat_wh, energy_lost_wh, fan_ring_active, sources, samples
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, c := range cycles {
+		var fanRingActive *int
+		if c.FanRingActive != nil {
+			v := 0
+			if *c.FanRingActive {
+				v = 1
+			}
+			fanRingActive = &v
+		}
+		if _, err := stmt.Exec(installationID, gatewaySerial, deviceID, c.StartedAt, c.EndedAt, c.DurationSeconds,
+			c.OutsideTemp, c.Humidity, c.ElectricityWh, c.HeatWh, c.EnergyLostWh, fanRingActive,
+			strings.Join(c.Sources, ","), c.Samples); err != nil {
+			return 0, fmt.Errorf("failed to insert defrost cycle: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return len(cycles), nil
+}
+
+// rebuildDefrostCyclesLocked detects the cycles of all logged devices from scratch, dbMutex must be held
+func rebuildDefrostCyclesLocked(fallbackInterval int) (int, error) {
+	placeholders := make([]string, len(defrostStatusCodes))
+	args := make([]interface{}, len(defrostStatusCodes))
+	for i, code := range defrostStatusCodes {
+		placeholders[i] = "?"
+		args[i] = code
+	}
+	rows, err := eventDB.Query(`
+		SELECT DISTINCT installation_id, gateway_id, device_id FROM temperature_snapshots
+		UNION
+		SELECT DISTINCT installation_id, gateway_serial, device_id FROM events
+		WHERE installation_id IS NOT NULL AND gateway_serial IS NOT NULL AND device_id IS NOT NULL
+			AND error_code IN (`+strings.Join(placeholders, ",")+`)
+	`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query devices: %v", err)
+	}
+	var devices [][3]string
+	for rows.Next() {
+		var d [3]string
+		if err := rows.Scan(&d[0], &d[1], &d[2]); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan device: %v", err)
+		}
+		devices = append(devices, d)
+	}
+	rows.Close()
+
+	if _, err := eventDB.Exec("DELETE FROM defrost_cycles"); err != nil {
+		return 0, fmt.Errorf("failed to clear defrost cycles: %v", err)
+	}
+	total := 0
+	for _, d := range devices {
+		n, err := updateDefrostCyclesLocked(d[0], d[1], d[2], time.Unix(0, 0), fallbackInterval)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// UpdateDefrostCycles re-detects the recent cycles of the devices of newly saved snapshots
+func UpdateDefrostCycles(snapshots []*TemperatureSnapshot, fallbackInterval int) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	since := time.Now().Add(-defrostRedetectWindow)
+	done := make(map[string]bool)
+	for _, s := range snapshots {
+		key := s.InstallationID + "|" + s.GatewayID + "|" + s.DeviceID
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		if _, err := updateDefrostCyclesLocked(s.InstallationID, s.GatewayID, s.DeviceID, since, fallbackInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateDefrostCyclesForEventsLocked re-detects the cycles from the earliest newly archived defrost
+// event of each device on, dbMutex must be held
+func updateDefrostCyclesForEventsLocked(newEvents []Event) error {
+	earliest := make(map[[3]string]time.Time)
+	for i := range newEvents {
+		event := &newEvents[i]
+		if event.Active == nil || !isDefrostStatusCode(event.ErrorCode) {
+			continue
+		}
+		t, ok := parseEventTime(event.EventTimestamp)
+		if !ok {
+			continue
+		}
+		key := [3]string{event.InstallationID, event.GatewaySerial, event.DeviceID}
+		if current, ok := earliest[key]; !ok || t.Before(current) {
+			earliest[key] = t
+		}
+	}
+	if len(earliest) == 0 {
+		return nil
+	}
+
+	fallbackInterval := defrostSampleIntervalLocked()
+	for key, t := range earliest {
+		// The activation may already be paired with an older event
+		if _, err := updateDefrostCyclesLocked(key[0], key[1], key[2], t.Add(-defrostEventMaxDuration), fallbackInterval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defrostSampleIntervalLocked returns the configured sample interval for snapshots without one, dbMutex must be held
+func defrostSampleIntervalLocked() int {
+	var interval int
+	if err := eventDB.QueryRow("SELECT sample_interval FROM temperature_log_settings WHERE id = 1").Scan(&interval); err != nil || interval <= 0 {
+		return 10
+	}
+	return interval
+}
+
+func isDefrostStatusCode(code string) bool {
+	for _, c := range defrostStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanupOldDefrostCycles removes cycles older than the retention (0 = keep forever)
+func CleanupOldDefrostCycles(retentionDays int) error {
+	if retentionDays <= 0 {
+		return nil
+	}
+
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+	result, err := eventDB.Exec("DELETE FROM defrost_cycles WHERE started_at < ?", cutoff.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to cleanup old defrost cycles: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("Cleaned up %d old defrost cycles (retention: %d days)", n, retentionDays)
+	}
+	return nil
+}
+
+// GetDefrostCycles returns the cycles of the filter, oldest first
+func GetDefrostCycles(filter DefrostFilter) ([]DefrostCycle, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	return getDefrostCyclesLocked(filter)
+}
+
+func getDefrostCyclesLocked(filter DefrostFilter) ([]DefrostCycle, error) {
+	where := []string{"started_at >= ?", "started_at < ?"}
+	args := []interface{}{filter.StartTime.UTC().Format(time.RFC3339), filter.EndTime.UTC().Format(time.RFC3339)}
+	for column, value := range map[string]string{
+		"installation_id": filter.InstallationID,
+		"gateway_serial":  filter.GatewaySerial,
+		"device_id":       filter.DeviceID,
+	} {
+		if value != "" {
+			where = append(where, column+" = ?")
+			args = append(args, value)
+		}
+	}
+	query := `
+		SELECT id, installation_id, gateway_serial, device_id, started_at, ended_at, duration_seconds,
+			outside_temp, humidity, electricity_wh, heat_wh, energy_lost_wh, fan_ring_active, sources, samples
+		FROM defrost_cycles
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY started_at, id`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query defrost cycles: %v", err)
+	}
+	defer rows.Close()
+
+	cycles := []DefrostCycle{}
+	for rows.Next() {
+		var c DefrostCycle
+		var fanRing *int
+		var sources string
+		if err := rows.Scan(&c.ID, &c.InstallationID, &c.GatewaySerial, &c.DeviceID, &c.StartedAt, &c.EndedAt,
+			&c.DurationSeconds, &c.OutsideTemp, &c.Humidity, &c.ElectricityWh, &c.HeatWh, &c.EnergyLostWh,
+			&fanRing, &sources, &c.Samples); err != nil {
+			return nil, fmt.Errorf("failed to scan defrost cycle: %v", err)
+		}
+		if fanRing != nil {
+			active := *fanRing == 1
+			c.FanRingActive = &active
+		}
+		c.Sources = strings.Split(sources, ",")
+		cycles = append(cycles, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read defrost cycles: %v", err)
+	}
+	return cycles, nil
+}
+
+// defrostHour is the outside temperature and compressor runtime of one hour
+type defrostHour struct {
+	outside        *float64
+	runtimeMinutes float64
+}
+
+// hourlyDefrostContextLocked returns outside temperature and compressor runtime per unix hour, from the
+// hourly rollups within their coverage and from the snapshots outside of it
+func hourlyDefrostContextLocked(installationID, gatewaySerial, deviceID string, start, end time.Time, fallbackInterval int) (map[int64]defrostHour, error) {
+	hours := make(map[int64]defrostHour)
+	rawRanges := [][2]time.Time{{start, end}}
+
+	if from, until, ok := rollupCoverageLocked(RollupHourly); ok && from.Before(end) && until.After(start) {
+		innerStart, innerEnd := from, until
+		if innerStart.Before(start) {
+			innerStart = start
+		}
+		if innerEnd.After(end) {
+			innerEnd = end
+		}
+		rawRanges = [][2]time.Time{{start, innerStart}, {innerEnd, end}}
+
+		rows, err := eventDB.Query(`
+			SELECT bucket_start, outside_temp_avg, runtime_minutes FROM `+rollupTable(RollupHourly)+`
+			WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+				AND bucket_start >= ? AND bucket_start < ?
+		`, installationID, gatewaySerial, deviceID, innerStart.UTC().Format(time.RFC3339), innerEnd.UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hourly rollups: %v", err)
+		}
+		for rows.Next() {
+			var bucket string
+			var h defrostHour
+			if err := rows.Scan(&bucket, &h.outside, &h.runtimeMinutes); err != nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, bucket); err == nil {
+				hours[t.Unix()] = h
+			}
+		}
+		rows.Close()
+	}
+
+	for _, r := range rawRanges {
+		if !r[0].Before(r[1]) {
+			continue
+		}
+		rows, err := eventDB.Query(`
+			SELECT STRFTIME('%Y-%m-%dT%H:00:00Z', timestamp) as hour, AVG(outside_temp),
+				SUM(CASE WHEN compressor_active = 1 THEN COALESCE(sample_interval, ?) ELSE 0 END)
+			FROM temperature_snapshots
+			WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+				AND timestamp >= ? AND timestamp < ?
+			GROUP BY hour
+		`, fallbackInterval, installationID, gatewaySerial, deviceID, r[0].UTC().Format(time.RFC3339), r[1].UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hourly snapshots: %v", err)
+		}
+		for rows.Next() {
+			var hour string
+			var h defrostHour
+			var runtime *float64
+			if err := rows.Scan(&hour, &h.outside, &runtime); err != nil {
+				continue
+			}
+			if runtime != nil {
+				h.runtimeMinutes = *runtime
+			}
+			if t, err := time.Parse(time.RFC3339, hour); err == nil {
+				hours[t.Unix()] = h
+			}
+		}
+		rows.Close()
+	}
+	return hours, nil
+}
+
+// defrostAccumulator collects the values of DefrostGroupStats
+type defrostAccumulator struct {
+	defrosts       int
+	runtimeMinutes float64
+	durationSum    int64
+	lostSum        float64
+	lostCount      int
+}
+
+func (a *defrostAccumulator) addCycle(c *DefrostCycle) {
+	a.defrosts++
+	a.durationSum += c.DurationSeconds
+	if c.EnergyLostWh != nil {
+		a.lostSum += *c.EnergyLostWh
+		a.lostCount++
+	}
+}
+
+func (a *defrostAccumulator) stats() DefrostGroupStats {
+	s := DefrostGroupStats{
+		Defrosts:      a.defrosts,
+		RuntimeHours:  round2(a.runtimeMinutes / 60.0),
+		EnergyLostKWh: round2(a.lostSum / 1000.0),
+	}
+	if a.defrosts > 0 {
+		s.AvgDurationSeconds = math.Round(float64(a.durationSum) / float64(a.defrosts))
+	}
+	if a.runtimeMinutes >= 30 {
+		v := round2(float64(a.defrosts) / (a.runtimeMinutes / 60.0))
+		s.DefrostsPerRuntimeHour = &v
+	}
+	if a.lostCount > 0 {
+		v := round2(a.lostSum / float64(a.lostCount))
+		s.AvgEnergyLostWh = &v
+	}
+	return s
+}
+
+func (a *defrostAccumulator) statsOrNil() *DefrostGroupStats {
+	if a == nil || (a.defrosts == 0 && a.runtimeMinutes == 0) {
+		return nil
+	}
+	s := a.stats()
+	return &s
+}
+
+// defrostOutsideBin returns the lower bound of the outside temperature bin
+func defrostOutsideBin(outside float64) float64 {
+	return math.Floor(outside/defrostOutsideBinSize) * defrostOutsideBinSize
+}
+
+// GetDefrostStats groups the cycles of a device in [start, end) per local day and per outside
+// temperature bin. Runtime hours per bin and fan ring state give the defrosts per compressor hour,
+// which is comparable between cold and mild days and with and without fan ring heating.
+func GetDefrostStats(installationID, gatewaySerial, deviceID string, start, end time.Time) (*DefrostStats, error) {
+	logSettings, err := GetTemperatureLogSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get temperature log settings: %v", err)
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	cycles, err := getDefrostCyclesLocked(DefrostFilter{
+		InstallationID: installationID, GatewaySerial: gatewaySerial, DeviceID: deviceID, StartTime: start, EndTime: end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hours, err := hourlyDefrostContextLocked(installationID, gatewaySerial, deviceID, start, end, logSettings.SampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	fanRing := loadFeatureValuesLocked(FeatureSeriesFilter{
+		InstallationID: installationID, GatewayID: gatewaySerial, DeviceID: deviceID,
+		Feature: defrostFanRingFeature, Property: "active",
+	}, start.Add(-24*time.Hour), end)
+
+	stats := &DefrostStats{
+		InstallationID: installationID,
+		GatewaySerial:  gatewaySerial,
+		DeviceID:       deviceID,
+		From:           start.Format(time.RFC3339),
+		To:             end.Format(time.RFC3339),
+		Days:           []DefrostDay{},
+		OutsideBins:    []DefrostOutsideBin{},
+	}
+
+	var total defrostAccumulator
+	var fanOn, fanOff *defrostAccumulator
+	type dayAcc struct {
+		defrostAccumulator
+		outsideSum, humiditySum     float64
+		outsideCount, humidityCount int
+	}
+	days := make(map[string]*dayAcc)
+	type binAcc struct {
+		all, on, off  defrostAccumulator
+		hasOn, hasOff bool
+	}
+	bins := make(map[float64]*binAcc)
+	dayOf := func(t time.Time) *dayAcc {
+		key := t.In(DefaultLocation).Format("2006-01-02")
+		if days[key] == nil {
+			days[key] = &dayAcc{}
+		}
+		return days[key]
+	}
+	binOf := func(outside float64) *binAcc {
+		key := defrostOutsideBin(outside)
+		if bins[key] == nil {
+			bins[key] = &binAcc{}
+		}
+		return bins[key]
+	}
+	fanRingAt := func(t time.Time) *bool {
+		if v, ok := valueAt(fanRing, t, 24*time.Hour); ok {
+			if b, ok := v.(bool); ok {
+				return &b
+			}
+		}
+		return nil
+	}
+	fanGroup := func(active *bool) **defrostAccumulator {
+		if active == nil {
+			return nil
+		}
+		if *active {
+			return &fanOn
+		}
+		return &fanOff
+	}
+
+	// Runtime and outside temperature of every hour
+	for unix, h := range hours {
+		t := time.Unix(unix, 0)
+		total.runtimeMinutes += h.runtimeMinutes
+		day := dayOf(t)
+		day.runtimeMinutes += h.runtimeMinutes
+		if h.outside != nil {
+			day.outsideSum += *h.outside
+			day.outsideCount++
+		}
+		active := fanRingAt(t.Add(30 * time.Minute))
+		if g := fanGroup(active); g != nil {
+			if *g == nil {
+				*g = &defrostAccumulator{}
+			}
+			(*g).runtimeMinutes += h.runtimeMinutes
+		}
+		if h.outside != nil && h.runtimeMinutes > 0 {
+			bin := binOf(*h.outside)
+			bin.all.runtimeMinutes += h.runtimeMinutes
+			if active != nil && *active {
+				bin.on.runtimeMinutes += h.runtimeMinutes
+				bin.hasOn = true
+			} else if active != nil {
+				bin.off.runtimeMinutes += h.runtimeMinutes
+				bin.hasOff = true
+			}
+		}
+	}
+
+	for i := range cycles {
+		c := &cycles[i]
+		t, err := time.Parse(time.RFC3339, c.StartedAt)
+		if err != nil {
+			continue
+		}
+		total.addCycle(c)
+		day := dayOf(t)
+		day.addCycle(c)
+		if c.Humidity != nil {
+			day.humiditySum += *c.Humidity
+			day.humidityCount++
+		}
+		if g := fanGroup(c.FanRingActive); g != nil {
+			if *g == nil {
+				*g = &defrostAccumulator{}
+			}
+			(*g).addCycle(c)
+		}
+		if c.OutsideTemp != nil {
+			bin := binOf(*c.OutsideTemp)
+			bin.all.addCycle(c)
+			if c.FanRingActive != nil && *c.FanRingActive {
+				bin.on.addCycle(c)
+				bin.hasOn = true
+			} else if c.FanRingActive != nil {
+				bin.off.addCycle(c)
+				bin.hasOff = true
+			}
+		}
+	}
+
+	stats.Total = total.stats()
+	stats.FanRingOn = fanOn.statsOrNil()
+	stats.FanRingOff = fanOff.statsOrNil()
+
+	for date, acc := range days {
+		day := DefrostDay{Date: date, DefrostGroupStats: acc.stats(), TotalDurationSeconds: acc.durationSum}
+		if acc.outsideCount > 0 {
+			v := round2(acc.outsideSum / float64(acc.outsideCount))
+			day.AvgOutsideTemp = &v
+		}
+		if acc.humidityCount > 0 {
+			v := round2(acc.humiditySum / float64(acc.humidityCount))
+			day.AvgHumidity = &v
+		}
+		stats.Days = append(stats.Days, day)
+	}
+	sort.Slice(stats.Days, func(i, j int) bool { return stats.Days[i].Date < stats.Days[j].Date })
+
+	for from, acc := range bins {
+		bin := DefrostOutsideBin{From: from, To: from + defrostOutsideBinSize, DefrostGroupStats: acc.all.stats()}
+		if acc.hasOn {
+			s := acc.on.stats()
+			bin.FanRingOn = &s
+		}
+		if acc.hasOff {
+			s := acc.off.stats()
+			bin.FanRingOff = &s
+		}
+		stats.OutsideBins = append(stats.OutsideBins, bin)
+	}
+	sort.Slice(stats.OutsideBins, func(i, j int) bool { return stats.OutsideBins[i].From < stats.OutsideBins[j].From })
+	return stats, nil
+}
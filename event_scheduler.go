@@ -130,24 +130,36 @@ func archiveEventsJob() {
 		return
 	}
 
+	// Track duration and errors for /metrics
+	start := time.Now()
+	var jobErr error
+	defer func() { recordJobRun("event_archive", start, jobErr) }()
+
 	// Fetch events from API (using default 7 days)
-	events, err := fetchEvents(7)
+	events, err := fetchEventsWithPriority(7, PriorityBackground)
 	if err != nil {
 		log.Printf("Error fetching events: %v", err)
+		jobErr = err
 		return
 	}
 
 	// Save events to database (with deduplication)
-	err = SaveEventsToDB(events)
+	newEvents, err := SaveNewEventsToDB(events)
 	if err != nil {
 		log.Printf("Error saving events to database: %v", err)
+		jobErr = err
 		return
 	}
 
+	// Forward newly archived events to MQTT and the alert rules
+	mqttPublishEvents(newEvents)
+	evaluateEventAlerts(newEvents)
+
 	// Cleanup old events based on retention policy
 	err = CleanupOldEvents(settings.RetentionDays)
 	if err != nil {
 		log.Printf("Error cleaning up old events: %v", err)
+		jobErr = err
 		return
 	}
 
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxEventsPageSize = 1000
+)
+
+// EventQuery holds the filters, sort order and page of an /api/events request.
+// Empty filters match everything, list filters match any of their values.
+type EventQuery struct {
+	Days int // Window fetched from the API
+	From time.Time
+	To   time.Time
+
+	InstallationIDs []string
+	GatewaySerials  []string
+	DeviceIDs       []string
+	EventTypes      []string
+	ErrorCodes      []string
+	Severities      []string
+	CodeCategories  []string
+	Active          *bool
+	Search          string // Free text over HumanReadable and FeatureName
+
+	Ascending bool   // Sort by event timestamp ascending instead of newest first
+	Limit     int    // Page size, 0 = no paging
+	Cursor    string // Opaque cursor returned as nextCursor
+}
+
+// EventsPage is the paginated response of /api/events
+type EventsPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+	HasMore    bool    `json:"hasMore"`
+}
+
+// paged reports whether the client asked for a paginated response
+func (q *EventQuery) paged() bool {
+	return q.Limit > 0 || q.Cursor != ""
+}
+
+// parseEventQuery reads the query parameters of /api/events
+func parseEventQuery(r *http.Request) (*EventQuery, error) {
+	values := r.URL.Query()
+	q := &EventQuery{
+		To: time.Now(),
+	}
+
+	q.Days = 7
+	if daysStr := values.Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil {
+			q.Days = d
+		}
+	}
+	q.From = q.To.AddDate(0, 0, -q.Days)
+
+	if from := values.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %v", err)
+		}
+		q.From = t
+	}
+	if to := values.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %v", err)
+		}
+		q.To = t
+	}
+
+	q.InstallationIDs = splitQueryList(values.Get("installationId"))
+	q.GatewaySerials = splitQueryList(values.Get("gatewaySerial"))
+	q.DeviceIDs = splitQueryList(values.Get("deviceId"))
+	q.EventTypes = splitQueryList(values.Get("eventType"))
+	q.ErrorCodes = splitQueryList(values.Get("errorCode"))
+	q.Severities = splitQueryList(values.Get("severity"))
+	q.CodeCategories = splitQueryList(values.Get("category"))
+	q.Search = strings.TrimSpace(values.Get("q"))
+
+	if active := values.Get("active"); active != "" {
+		b, err := strconv.ParseBool(active)
+		if err != nil {
+			return nil, fmt.Errorf("invalid active: %v", err)
+		}
+		q.Active = &b
+	}
+
+	switch strings.ToLower(values.Get("order")) {
+	case "", "desc":
+	case "asc":
+		q.Ascending = true
+	default:
+		return nil, fmt.Errorf("invalid order %q (asc or desc)", values.Get("order"))
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+		if l > maxEventsPageSize {
+			l = maxEventsPageSize
+		}
+		q.Limit = l
+	}
+	q.Cursor = values.Get("cursor")
+	if q.Cursor != "" && q.Limit == 0 {
+		q.Limit = 100
+	}
+	if q.Cursor != "" {
+		if _, _, err := decodeEventCursor(q.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+func splitQueryList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// The cursor is the sort key (timestamp, hash) of the last event of a page
+func encodeEventCursor(timestamp, hash string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(timestamp + "|" + hash))
+}
+
+func decodeEventCursor(cursor string) (string, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid cursor")
+	}
+	return parts[0], parts[1], nil
+}
+
+// matches applies the filters of the query to an event (used when archiving is disabled)
+func (q *EventQuery) matches(event *Event) bool {
+	if ts, err := time.Parse(time.RFC3339, event.EventTimestamp); err == nil {
+		if ts.Before(q.From) || ts.After(q.To) {
+			return false
+		}
+	}
+	if !matchesFilter(q.InstallationIDs, event.InstallationID) ||
+		!matchesFilter(q.GatewaySerials, event.GatewaySerial) ||
+		!matchesFilter(q.DeviceIDs, event.DeviceID) ||
+		!matchesFilter(q.EventTypes, event.EventType) ||
+		!matchesFilter(q.ErrorCodes, event.ErrorCode) ||
+		!matchesFilter(q.Severities, event.Severity) ||
+		!matchesFilter(q.CodeCategories, event.CodeCategory) {
+		return false
+	}
+	if q.Active != nil && (event.Active == nil || *event.Active != *q.Active) {
+		return false
+	}
+	if q.Search != "" {
+		search := strings.ToLower(q.Search)
+		if !strings.Contains(strings.ToLower(event.HumanReadable), search) &&
+			!strings.Contains(strings.ToLower(event.FeatureName), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// queryEventsInMemory filters, sorts and pages events fetched from the API
+func queryEventsInMemory(events []Event, q *EventQuery) *EventsPage {
+	type keyedEvent struct {
+		event Event
+		hash  string
+	}
+
+	// Duplicates are dropped like the unique hash of the archive does, so the cursor stays unambiguous
+	keyed := make([]keyedEvent, 0, len(events))
+	seen := make(map[string]bool, len(events))
+	for i := range events {
+		if !q.matches(&events[i]) {
+			continue
+		}
+		hash := ComputeEventHash(&events[i])
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		keyed = append(keyed, keyedEvent{event: events[i], hash: hash})
+	}
+
+	// Same order as the SQL query: event timestamp, then hash as tie breaker
+	sort.Slice(keyed, func(i, j int) bool {
+		a, b := keyed[i], keyed[j]
+		if a.event.EventTimestamp != b.event.EventTimestamp {
+			return (a.event.EventTimestamp < b.event.EventTimestamp) == q.Ascending
+		}
+		return a.hash != b.hash && (a.hash < b.hash) == q.Ascending
+	})
+
+	start := 0
+	if q.Cursor != "" {
+		cursorTS, cursorHash, _ := decodeEventCursor(q.Cursor)
+		start = sort.Search(len(keyed), func(i int) bool {
+			ts, hash := keyed[i].event.EventTimestamp, keyed[i].hash
+			if q.Ascending {
+				return ts > cursorTS || (ts == cursorTS && hash > cursorHash)
+			}
+			return ts < cursorTS || (ts == cursorTS && hash < cursorHash)
+		})
+	}
+
+	end := len(keyed)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+	}
+
+	page := &EventsPage{Events: make([]Event, 0, end-start)}
+	for _, k := range keyed[start:end] {
+		page.Events = append(page.Events, k.event)
+	}
+	if end < len(keyed) {
+		last := keyed[end-1]
+		page.HasMore = true
+		page.NextCursor = encodeEventCursor(last.event.EventTimestamp, last.hash)
+	}
+	return page
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openTestEventDB opens a fresh event database in a temporary directory
+func openTestEventDB(t *testing.T) {
+	t.Helper()
+	if err := InitEventDatabase(filepath.Join(t.TempDir(), "events.db")); err != nil {
+		t.Fatalf("InitEventDatabase: %v", err)
+	}
+	t.Cleanup(func() {
+		CloseEventDatabase()
+		eventDB = nil
+	})
+}
+
+// pagingTestEvents returns events with several identical timestamps, so the hash tie breaker matters
+func pagingTestEvents() []Event {
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	var events []Event
+	for i := 0; i < 23; i++ {
+		events = append(events, Event{
+			EventTimestamp: base.Add(time.Duration(i/3) * time.Minute).Format(time.RFC3339),
+			EventType:      "device-error",
+			ErrorCode:      fmt.Sprintf("F.%d", i),
+			InstallationID: "1234567",
+			GatewaySerial:  "7736172200000001",
+			DeviceID:       "0",
+		})
+	}
+	return events
+}
+
+// collectPages reads all pages of a query and returns the error codes in page order
+func collectPages(t *testing.T, q EventQuery, query func(*EventQuery) (*EventsPage, error)) ([]string, int) {
+	t.Helper()
+	var codes []string
+	pages := 0
+	for {
+		page, err := query(&q)
+		if err != nil {
+			t.Fatalf("page %d: %v", pages+1, err)
+		}
+		pages++
+		if len(page.Events) > q.Limit {
+			t.Fatalf("page %d has %d events, limit is %d", pages, len(page.Events), q.Limit)
+		}
+		for _, e := range page.Events {
+			codes = append(codes, e.ErrorCode)
+		}
+		if !page.HasMore {
+			if page.NextCursor != "" {
+				t.Fatalf("last page has a cursor")
+			}
+			return codes, pages
+		}
+		if pages > 100 {
+			t.Fatalf("paging does not terminate")
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+func TestEventCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		timestamp, hash string
+	}{
+		{"2026-01-10T12:00:00Z", "abc123"},
+		{"2026-01-10T12:00:00Z", ""},
+		{"", "abc|def"}, // Only the first separator splits
+	}
+	for _, tt := range tests {
+		ts, hash, err := decodeEventCursor(encodeEventCursor(tt.timestamp, tt.hash))
+		if err != nil {
+			t.Errorf("decode(encode(%q, %q)): %v", tt.timestamp, tt.hash, err)
+			continue
+		}
+		if ts != tt.timestamp || hash != tt.hash {
+			t.Errorf("round trip of (%q, %q) = (%q, %q)", tt.timestamp, tt.hash, ts, hash)
+		}
+	}
+}
+
+func TestDecodeEventCursorInvalid(t *testing.T) {
+	for _, cursor := range []string{"not base64!", "bm8tc2VwYXJhdG9y" /* "no-separator" */} {
+		if _, _, err := decodeEventCursor(cursor); err == nil {
+			t.Errorf("decodeEventCursor(%q) succeeded, want error", cursor)
+		}
+	}
+}
+
+func TestKeysetPagination(t *testing.T) {
+	openTestEventDB(t)
+	events := pagingTestEvents()
+	if err := SaveEventsToDB(events); err != nil {
+		t.Fatalf("SaveEventsToDB: %v", err)
+	}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		limit     int
+		ascending bool
+		pages     int
+	}{
+		{"page size 1", 1, false, 23},
+		{"page boundary inside equal timestamps", 4, false, 6},
+		{"ascending", 5, true, 5},
+		{"exact multiple", 23, false, 1},
+		{"larger than result", 100, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := EventQuery{From: from, To: to, Limit: tt.limit, Ascending: tt.ascending}
+
+			sqlCodes, sqlPages := collectPages(t, q, QueryEventsFromDB)
+			memCodes, _ := collectPages(t, q, func(q *EventQuery) (*EventsPage, error) {
+				return queryEventsInMemory(events, q), nil
+			})
+
+			if sqlPages != tt.pages {
+				t.Errorf("got %d pages, want %d", sqlPages, tt.pages)
+			}
+			if len(sqlCodes) != len(events) {
+				t.Fatalf("got %d events over all pages, want %d", len(sqlCodes), len(events))
+			}
+			seen := make(map[string]bool)
+			for _, code := range sqlCodes {
+				if seen[code] {
+					t.Fatalf("event %s returned twice", code)
+				}
+				seen[code] = true
+			}
+			// The in-memory path (archive disabled) must page in the same order
+			if fmt.Sprint(sqlCodes) != fmt.Sprint(memCodes) {
+				t.Errorf("SQL order %v differs from in-memory order %v", sqlCodes, memCodes)
+			}
+		})
+	}
+}
+
+func TestQueryEventsInMemoryDuplicates(t *testing.T) {
+	events := pagingTestEvents()
+	// The API can return the same event twice, e.g. from two accounts of one installation
+	duplicated := append(append([]Event{}, events...), events...)
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, ascending := range []bool{false, true} {
+		q := EventQuery{From: from, To: to, Limit: 4, Ascending: ascending}
+		codes, _ := collectPages(t, q, func(q *EventQuery) (*EventsPage, error) {
+			return queryEventsInMemory(duplicated, q), nil
+		})
+		if len(codes) != len(events) {
+			t.Errorf("ascending=%v: got %d events over all pages, want %d", ascending, len(codes), len(events))
+		}
+	}
+}
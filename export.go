@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Export formats
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatJSONL   = "jsonl"
+	ExportFormatParquet = "parquet"
+)
+
+// Rows are read from the database in batches of this size, so an export never
+// holds more than one batch (or one Parquet row group) in memory
+const (
+	exportBatchSize    = 5000
+	exportRowGroupSize = 50000
+)
+
+type exportKind int
+
+const (
+	exportString exportKind = iota
+	exportFloat
+	exportInt
+	exportBool
+	exportTime
+)
+
+// exportColumn describes one column of an export
+type exportColumn struct {
+	Name string
+	Kind exportKind
+}
+
+// exportWriter writes rows in one of the export formats.
+// Values are nil (NULL), string, float64, int64, bool or time.Time according to the column kind.
+type exportWriter interface {
+	WriteRow(values []interface{}) error
+	Flush() error
+	Close() error
+}
+
+// exportContentType returns the MIME type and file extension of a format
+func exportContentType(format string) (string, string) {
+	switch format {
+	case ExportFormatJSONL:
+		return "application/x-ndjson", "jsonl"
+	case ExportFormatParquet:
+		return "application/vnd.apache.parquet", "parquet"
+	}
+	return "text/csv; charset=utf-8", "csv"
+}
+
+func newExportWriter(format string, w io.Writer, columns []exportColumn) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(w, columns)
+	case ExportFormatJSONL:
+		return &jsonlExportWriter{w: w, columns: columns}, nil
+	case ExportFormatParquet:
+		return newParquetExportWriter(w, columns)
+	}
+	return nil, fmt.Errorf("invalid format %q (csv, jsonl or parquet)", format)
+}
+
+// selectExportColumns returns the requested columns (comma-separated) in the given order, or all columns
+func selectExportColumns(available []exportColumn, requested string) ([]exportColumn, error) {
+	names := splitQueryList(requested)
+	if len(names) == 0 || (len(names) == 1 && names[0] == "all") {
+		return available, nil
+	}
+
+	byName := make(map[string]exportColumn, len(available))
+	for _, c := range available {
+		byName[c.Name] = c
+	}
+	selected := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// CSV
+
+type csvExportWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVExportWriter(w io.Writer, columns []exportColumn) (*csvExportWriter, error) {
+	cw := &csvExportWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, c := range columns {
+		cw.record[i] = c.Name
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (c *csvExportWriter) WriteRow(values []interface{}) error {
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+			c.record[i] = ""
+		case string:
+			c.record[i] = v
+		case float64:
+			c.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int64:
+			c.record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			c.record[i] = strconv.FormatBool(v)
+		case time.Time:
+			c.record[i] = v.UTC().Format(time.RFC3339)
+		default:
+			c.record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvExportWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvExportWriter) Close() error {
+	return c.Flush()
+}
+
+// Newline-delimited JSON, keys in column order
+
+type jsonlExportWriter struct {
+	w       io.Writer
+	columns []exportColumn
+	buf     bytes.Buffer
+}
+
+func (j *jsonlExportWriter) WriteRow(values []interface{}) error {
+	j.buf.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			j.buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(j.columns[i].Name)
+		j.buf.Write(name)
+		j.buf.WriteByte(':')
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC().Format(time.RFC3339)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		j.buf.Write(value)
+	}
+	j.buf.WriteString("}\n")
+
+	// Write in chunks instead of per row
+	if j.buf.Len() >= 64*1024 {
+		return j.Flush()
+	}
+	return nil
+}
+
+func (j *jsonlExportWriter) Flush() error {
+	_, err := j.w.Write(j.buf.Bytes())
+	j.buf.Reset()
+	return err
+}
+
+func (j *jsonlExportWriter) Close() error {
+	return j.Flush()
+}
+
+// Parquet, using a struct type built from the columns so the column order is kept.
+// All columns are optional, timestamps are stored as UTC milliseconds.
+
+type parquetExportWriter struct {
+	w       *parquet.Writer
+	rowType reflect.Type
+}
+
+func newParquetExportWriter(w io.Writer, columns []exportColumn) (*parquetExportWriter, error) {
+	fields := make([]reflect.StructField, len(columns))
+	for i, c := range columns {
+		tag := c.Name + ",optional"
+		var typ reflect.Type
+		switch c.Kind {
+		case exportFloat:
+			typ = reflect.TypeOf((*float64)(nil))
+		case exportInt:
+			typ = reflect.TypeOf((*int64)(nil))
+		case exportBool:
+			typ = reflect.TypeOf((*bool)(nil))
+		case exportTime:
+			// Optional non-pointer fields are written as NULL when zero
+			typ = reflect.TypeOf(time.Time{})
+			tag += ",timestamp(millisecond)"
+		default:
+			typ = reflect.TypeOf((*string)(nil))
+		}
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("C%d", i),
+			Type: typ,
+			Tag:  reflect.StructTag(`parquet:"` + strings.ReplaceAll(tag, `"`, "") + `"`),
+		}
+	}
+	rowType := reflect.StructOf(fields)
+
+	schema := parquet.SchemaOf(reflect.New(rowType).Interface())
+	writer := parquet.NewWriter(w, schema,
+		parquet.Compression(&parquet.Snappy),
+		parquet.MaxRowsPerRowGroup(exportRowGroupSize),
+	)
+	return &parquetExportWriter{w: writer, rowType: rowType}, nil
+}
+
+func (p *parquetExportWriter) WriteRow(values []interface{}) error {
+	row := reflect.New(p.rowType)
+	elem := row.Elem()
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if t, ok := v.(time.Time); ok {
+			elem.Field(i).Set(reflect.ValueOf(t.UTC()))
+			continue
+		}
+		ptr := reflect.New(elem.Field(i).Type().Elem())
+		ptr.Elem().Set(reflect.ValueOf(v))
+		elem.Field(i).Set(ptr)
+	}
+	return p.w.Write(row.Interface())
+}
+
+// Flush is a no-op, row groups are written when they are full
+func (p *parquetExportWriter) Flush() error {
+	return nil
+}
+
+func (p *parquetExportWriter) Close() error {
+	return p.w.Close()
+}
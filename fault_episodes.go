@@ -0,0 +1,575 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// faultEpisodeCategories are the code categories that are paired into episodes. Status codes
+// are left out, they describe operating states which the timeline already reconstructs.
+var faultEpisodeCategories = []string{"fault", "alert", "maintenance"}
+
+// FaultEpisode is the time span between the activation and the deactivation of an error code
+// on a device. Repeated activations while the code is active are counted, EndedAt is nil while
+// the episode is still open.
+type FaultEpisode struct {
+	ID               int64   `json:"id"`
+	InstallationID   string  `json:"installationId"`
+	GatewaySerial    string  `json:"gatewaySerial"`
+	DeviceID         string  `json:"deviceId"`
+	ModelID          string  `json:"modelId"`
+	ErrorCode        string  `json:"errorCode"`
+	ErrorDescription string  `json:"errorDescription"`
+	CodeCategory     string  `json:"codeCategory"`
+	Severity         string  `json:"severity"`
+	AccountID        string  `json:"accountId"`
+	StartedAt        string  `json:"startedAt"`
+	EndedAt          *string `json:"endedAt"`
+	LastSeenAt       string  `json:"lastSeenAt"`
+	DurationSeconds  int64   `json:"durationSeconds"` // Open episodes: duration until now
+	Activations      int     `json:"activations"`
+	Open             bool    `json:"open"`
+}
+
+// FaultEpisodeStats summarizes the episodes of one error code on one device in a time range
+type FaultEpisodeStats struct {
+	InstallationID       string         `json:"installationId"`
+	GatewaySerial        string         `json:"gatewaySerial"`
+	DeviceID             string         `json:"deviceId"`
+	ModelID              string         `json:"modelId"`
+	ErrorCode            string         `json:"errorCode"`
+	ErrorDescription     string         `json:"errorDescription"`
+	CodeCategory         string         `json:"codeCategory"`
+	Severity             string         `json:"severity"`
+	Episodes             int            `json:"episodes"`
+	OpenEpisodes         int            `json:"openEpisodes"`
+	Activations          int            `json:"activations"`
+	TotalDurationSeconds int64          `json:"totalDurationSeconds"` // Clipped to the time range
+	AvgDurationSeconds   float64        `json:"avgDurationSeconds"`
+	MaxDurationSeconds   int64          `json:"maxDurationSeconds"`
+	MTBFSeconds          *float64       `json:"mtbfSeconds"` // Mean time from the end of an episode to the start of the next one
+	FirstStartedAt       string         `json:"firstStartedAt"`
+	LastStartedAt        string         `json:"lastStartedAt"`
+	History              []FaultEpisode `json:"history"`
+}
+
+// FaultEpisodeFilter selects episodes overlapping [StartTime, EndTime], empty fields match everything
+type FaultEpisodeFilter struct {
+	InstallationID string
+	GatewaySerial  string
+	DeviceID       string
+	ErrorCodes     []string
+	OpenOnly       bool
+	StartTime      time.Time
+	EndTime        time.Time
+	Limit          int
+}
+
+// faultEpisodeEvent is an archived activation or deactivation
+type faultEpisodeEvent struct {
+	Timestamp        string
+	InstallationID   string
+	GatewaySerial    string
+	DeviceID         string
+	ModelID          string
+	ErrorCode        string
+	ErrorDescription string
+	CodeCategory     string
+	Severity         string
+	AccountID        string
+	Active           bool
+}
+
+// faultEpisodeKey identifies the episodes of an error code on a device
+func faultEpisodeKey(installationID, gatewaySerial, deviceID, errorCode string) string {
+	return installationID + "|" + gatewaySerial + "|" + deviceID + "|" + errorCode
+}
+
+func (e *faultEpisodeEvent) key() string {
+	return faultEpisodeKey(e.InstallationID, e.GatewaySerial, e.DeviceID, e.ErrorCode)
+}
+
+// isFaultEpisodeEvent reports whether an event takes part in the episode reconstruction
+func isFaultEpisodeEvent(event *Event) bool {
+	if event.Active == nil || event.ErrorCode == "" {
+		return false
+	}
+	for _, category := range faultEpisodeCategories {
+		if event.CodeCategory == category {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEventTime parses the RFC3339 timestamps of the Viessmann API (with or without milliseconds)
+func parseEventTime(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// episodeSeconds returns the seconds between two event timestamps
+func episodeSeconds(start, end string) int64 {
+	startTime, ok1 := parseEventTime(start)
+	endTime, ok2 := parseEventTime(end)
+	if !ok1 || !ok2 || endTime.Before(startTime) {
+		return 0
+	}
+	return int64(endTime.Sub(startTime).Seconds())
+}
+
+// replayFaultEpisodes pairs the events of one key (sorted by time) into episodes. open is the
+// episode that is still running before the first event, or nil.
+func replayFaultEpisodes(events []faultEpisodeEvent, open *FaultEpisode) []FaultEpisode {
+	var episodes []FaultEpisode
+	for i := range events {
+		event := &events[i]
+		if event.Active {
+			if open == nil {
+				open = &FaultEpisode{
+					InstallationID:   event.InstallationID,
+					GatewaySerial:    event.GatewaySerial,
+					DeviceID:         event.DeviceID,
+					ModelID:          event.ModelID,
+					ErrorCode:        event.ErrorCode,
+					ErrorDescription: event.ErrorDescription,
+					CodeCategory:     event.CodeCategory,
+					Severity:         event.Severity,
+					AccountID:        event.AccountID,
+					StartedAt:        event.Timestamp,
+				}
+			}
+			// The API repeats the activation while the code stays active
+			open.Activations++
+			open.LastSeenAt = event.Timestamp
+			continue
+		}
+
+		// Deactivation without a preceding activation (e.g. the activation is older than the archive)
+		if open == nil {
+			continue
+		}
+		endedAt := event.Timestamp
+		open.EndedAt = &endedAt
+		open.LastSeenAt = event.Timestamp
+		open.DurationSeconds = episodeSeconds(open.StartedAt, endedAt)
+		episodes = append(episodes, *open)
+		open = nil
+	}
+	if open != nil {
+		episodes = append(episodes, *open)
+	}
+	return episodes
+}
+
+func createFaultEpisodeTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS fault_episodes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		installation_id TEXT NOT NULL,
+		gateway_serial TEXT NOT NULL,
+		device_id TEXT NOT NULL,
+		model_id TEXT,
+		error_code TEXT NOT NULL,
+		error_description TEXT,
+		code_category TEXT,
+		severity TEXT,
+		account_id TEXT,
+		started_at TEXT NOT NULL,
+		ended_at TEXT,
+		last_seen_at TEXT NOT NULL,
+		duration_seconds INTEGER,
+		activations INTEGER NOT NULL DEFAULT 1,
+		UNIQUE (installation_id, gateway_serial, device_id, error_code, started_at)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_fault_episodes_started ON fault_episodes(started_at);
+	CREATE INDEX IF NOT EXISTS idx_fault_episodes_code ON fault_episodes(error_code, started_at);
+	CREATE INDEX IF NOT EXISTS idx_fault_episodes_open ON fault_episodes(ended_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create fault episode tables: %v", err)
+	}
+	return nil
+}
+
+// faultEpisodeEventsQuery selects the archived activations and deactivations
+func faultEpisodeEventsQuery(where string) (string, []interface{}) {
+	placeholders := make([]string, len(faultEpisodeCategories))
+	args := make([]interface{}, 0, len(faultEpisodeCategories))
+	for i, category := range faultEpisodeCategories {
+		placeholders[i] = "?"
+		args = append(args, category)
+	}
+	query := `
+		SELECT event_timestamp, COALESCE(installation_id, ''), COALESCE(gateway_serial, ''),
+			COALESCE(device_id, ''), COALESCE(model_id, ''), error_code, COALESCE(error_description, ''),
+			code_category, COALESCE(severity, ''), COALESCE(account_id, ''), active
+		FROM events
+		WHERE active IS NOT NULL AND error_code IS NOT NULL AND error_code != ''
+			AND code_category IN (` + strings.Join(placeholders, ",") + `)`
+	if where != "" {
+		query += " AND " + where
+	}
+	return query, args
+}
+
+func scanFaultEpisodeEvents(rows *sql.Rows) ([]faultEpisodeEvent, error) {
+	var events []faultEpisodeEvent
+	for rows.Next() {
+		var event faultEpisodeEvent
+		var active int
+		if err := rows.Scan(&event.Timestamp, &event.InstallationID, &event.GatewaySerial, &event.DeviceID,
+			&event.ModelID, &event.ErrorCode, &event.ErrorDescription, &event.CodeCategory, &event.Severity,
+			&event.AccountID, &active); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %v", err)
+		}
+		event.Active = active == 1
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func insertFaultEpisodes(tx *sql.Tx, episodes []FaultEpisode) error {
+	stmt, err := tx.Prepare(`
+		INSERT INTO fault_episodes (
+			installation_id, gateway_serial, device_id, model_id, error_code, error_description,
+			code_category, severity, account_id, started_at, ended_at, last_seen_at,
+			duration_seconds, activations
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, ep := range episodes {
+		var duration *int64
+		if ep.EndedAt != nil {
+			duration = &ep.DurationSeconds
+		}
+		if _, err := stmt.Exec(ep.InstallationID, ep.GatewaySerial, ep.DeviceID, ep.ModelID, ep.ErrorCode,
+			ep.ErrorDescription, ep.CodeCategory, ep.Severity, ep.AccountID, ep.StartedAt, ep.EndedAt,
+			ep.LastSeenAt, duration, ep.Activations); err != nil {
+			return fmt.Errorf("failed to insert fault episode: %v", err)
+		}
+	}
+	return nil
+}
+
+// rebuildFaultEpisodesLocked rebuilds the whole fault_episodes table from the archived events,
+// dbMutex must be held
+func rebuildFaultEpisodesLocked() (int, error) {
+	query, args := faultEpisodeEventsQuery("")
+	query += " ORDER BY installation_id, gateway_serial, device_id, error_code, event_timestamp, id"
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query events: %v", err)
+	}
+	events, err := scanFaultEpisodeEvents(rows)
+	rows.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	var episodes []FaultEpisode
+	for start := 0; start < len(events); {
+		end := start + 1
+		for end < len(events) && events[end].key() == events[start].key() {
+			end++
+		}
+		episodes = append(episodes, replayFaultEpisodes(events[start:end], nil)...)
+		start = end
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM fault_episodes"); err != nil {
+		return 0, fmt.Errorf("failed to clear fault episodes: %v", err)
+	}
+	if err := insertFaultEpisodes(tx, episodes); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return len(episodes), nil
+}
+
+// updateFaultEpisodesLocked re-pairs the episodes affected by newly archived events, dbMutex must be held.
+// Per installation/device/code the events are replayed from the episode that covers the earliest new
+// event, so late or out-of-order events end up in the right episode.
+func updateFaultEpisodesLocked(newEvents []Event) error {
+	earliest := make(map[string]*Event)
+	for i := range newEvents {
+		event := &newEvents[i]
+		if !isFaultEpisodeEvent(event) {
+			continue
+		}
+		key := faultEpisodeKey(event.InstallationID, event.GatewaySerial, event.DeviceID, event.ErrorCode)
+		if current, ok := earliest[key]; !ok || event.EventTimestamp < current.EventTimestamp {
+			earliest[key] = event
+		}
+	}
+	if len(earliest) == 0 {
+		return nil
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range earliest {
+		keyArgs := []interface{}{event.InstallationID, event.GatewaySerial, event.DeviceID, event.ErrorCode}
+
+		// Episode that is running at the time of the earliest new event
+		var covering *FaultEpisode
+		var ep FaultEpisode
+		var endedAt sql.NullString
+		err := tx.QueryRow(`
+			SELECT installation_id, gateway_serial, device_id, COALESCE(model_id, ''), error_code,
+				COALESCE(error_description, ''), COALESCE(code_category, ''), COALESCE(severity, ''),
+				COALESCE(account_id, ''), started_at, ended_at
+			FROM fault_episodes
+			WHERE installation_id = ? AND gateway_serial = ? AND device_id = ? AND error_code = ? AND started_at <= ?
+			ORDER BY started_at DESC LIMIT 1
+		`, append(keyArgs, event.EventTimestamp)...).Scan(&ep.InstallationID, &ep.GatewaySerial, &ep.DeviceID,
+			&ep.ModelID, &ep.ErrorCode, &ep.ErrorDescription, &ep.CodeCategory, &ep.Severity, &ep.AccountID,
+			&ep.StartedAt, &endedAt)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to query fault episode: %v", err)
+		}
+		if err == nil && (!endedAt.Valid || endedAt.String >= event.EventTimestamp) {
+			covering = &ep
+		}
+
+		// Replay after the start of the covering episode: its activation may already be
+		// removed by the event retention, so the episode itself is the starting state.
+		from, cmp := event.EventTimestamp, ">="
+		if covering != nil {
+			from, cmp = covering.StartedAt, ">"
+			covering.Activations = 1
+			covering.LastSeenAt = covering.StartedAt
+		}
+
+		deleteArgs := append(append([]interface{}{}, keyArgs...), from)
+		if _, err := tx.Exec(`
+			DELETE FROM fault_episodes
+			WHERE installation_id = ? AND gateway_serial = ? AND device_id = ? AND error_code = ? AND started_at >= ?
+		`, deleteArgs...); err != nil {
+			return fmt.Errorf("failed to delete fault episodes: %v", err)
+		}
+
+		query, args := faultEpisodeEventsQuery(
+			"installation_id = ? AND gateway_serial = ? AND device_id = ? AND error_code = ? AND event_timestamp " + cmp + " ?")
+		query += " ORDER BY event_timestamp, id"
+		rows, err := tx.Query(query, append(args, deleteArgs...)...)
+		if err != nil {
+			return fmt.Errorf("failed to query events: %v", err)
+		}
+		events, err := scanFaultEpisodeEvents(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		if err := insertFaultEpisodes(tx, replayFaultEpisodes(events, covering)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
+
+// cleanupFaultEpisodesLocked removes episodes that ended before the cutoff, dbMutex must be held.
+// Open episodes are kept even if their activation is older than the event retention.
+func cleanupFaultEpisodesLocked(cutoffTime time.Time) error {
+	result, err := eventDB.Exec("DELETE FROM fault_episodes WHERE ended_at IS NOT NULL AND ended_at < ?", cutoffTime.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to cleanup old fault episodes: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("Cleaned up %d old fault episodes", n)
+	}
+	return nil
+}
+
+// GetFaultEpisodes returns the episodes overlapping the filter's time range, oldest first
+func GetFaultEpisodes(filter FaultEpisodeFilter) ([]FaultEpisode, error) {
+	where := []string{"started_at <= ?", "(ended_at IS NULL OR ended_at >= ?)"}
+	args := []interface{}{filter.EndTime.UTC().Format(time.RFC3339), filter.StartTime.UTC().Format(time.RFC3339)}
+	if filter.InstallationID != "" {
+		where = append(where, "installation_id = ?")
+		args = append(args, filter.InstallationID)
+	}
+	if filter.GatewaySerial != "" {
+		where = append(where, "gateway_serial = ?")
+		args = append(args, filter.GatewaySerial)
+	}
+	if filter.DeviceID != "" {
+		where = append(where, "device_id = ?")
+		args = append(args, filter.DeviceID)
+	}
+	if len(filter.ErrorCodes) > 0 {
+		placeholders := make([]string, len(filter.ErrorCodes))
+		for i, code := range filter.ErrorCodes {
+			placeholders[i] = "?"
+			args = append(args, code)
+		}
+		where = append(where, "error_code IN ("+strings.Join(placeholders, ",")+")")
+	}
+	if filter.OpenOnly {
+		where = append(where, "ended_at IS NULL")
+	}
+
+	query := `
+		SELECT id, installation_id, gateway_serial, device_id, COALESCE(model_id, ''), error_code,
+			COALESCE(error_description, ''), COALESCE(code_category, ''), COALESCE(severity, ''),
+			COALESCE(account_id, ''), started_at, ended_at, last_seen_at, COALESCE(duration_seconds, 0), activations
+		FROM fault_episodes
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY started_at, id`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fault episodes: %v", err)
+	}
+	defer rows.Close()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	var episodes []FaultEpisode
+	for rows.Next() {
+		var ep FaultEpisode
+		var endedAt sql.NullString
+		if err := rows.Scan(&ep.ID, &ep.InstallationID, &ep.GatewaySerial, &ep.DeviceID, &ep.ModelID, &ep.ErrorCode,
+			&ep.ErrorDescription, &ep.CodeCategory, &ep.Severity, &ep.AccountID, &ep.StartedAt, &endedAt,
+			&ep.LastSeenAt, &ep.DurationSeconds, &ep.Activations); err != nil {
+			return nil, fmt.Errorf("failed to scan fault episode: %v", err)
+		}
+		if endedAt.Valid {
+			ep.EndedAt = &endedAt.String
+		} else {
+			ep.Open = true
+			ep.DurationSeconds = episodeSeconds(ep.StartedAt, now)
+		}
+		episodes = append(episodes, ep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fault episodes: %v", err)
+	}
+	return episodes, nil
+}
+
+// GetFaultEpisodeStats groups the episodes of the filter per device and error code
+func GetFaultEpisodeStats(filter FaultEpisodeFilter) ([]FaultEpisodeStats, error) {
+	episodes, err := GetFaultEpisodes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeStart := filter.StartTime.UTC().Format(time.RFC3339)
+	rangeEnd := filter.EndTime.UTC().Format(time.RFC3339)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	byKey := make(map[string]*FaultEpisodeStats)
+	var keys []string
+	for _, ep := range episodes {
+		key := faultEpisodeKey(ep.InstallationID, ep.GatewaySerial, ep.DeviceID, ep.ErrorCode)
+		stats, ok := byKey[key]
+		if !ok {
+			stats = &FaultEpisodeStats{
+				InstallationID: ep.InstallationID,
+				GatewaySerial:  ep.GatewaySerial,
+				DeviceID:       ep.DeviceID,
+				ModelID:        ep.ModelID,
+				ErrorCode:      ep.ErrorCode,
+				CodeCategory:   ep.CodeCategory,
+				Severity:       ep.Severity,
+				FirstStartedAt: ep.StartedAt,
+			}
+			byKey[key] = stats
+			keys = append(keys, key)
+		}
+		// The newest description wins (descriptions may be improved in later versions)
+		stats.ErrorDescription = ep.ErrorDescription
+		stats.Episodes++
+		stats.Activations += ep.Activations
+		stats.LastStartedAt = ep.StartedAt
+		if ep.Open {
+			stats.OpenEpisodes++
+		}
+		if ep.DurationSeconds > stats.MaxDurationSeconds {
+			stats.MaxDurationSeconds = ep.DurationSeconds
+		}
+
+		// Only the part inside the time range counts towards the total
+		start, end := ep.StartedAt, now
+		if ep.EndedAt != nil {
+			end = *ep.EndedAt
+		}
+		if start < rangeStart {
+			start = rangeStart
+		}
+		if end > rangeEnd {
+			end = rangeEnd
+		}
+		stats.TotalDurationSeconds += episodeSeconds(start, end)
+		stats.History = append(stats.History, ep)
+	}
+
+	result := make([]FaultEpisodeStats, 0, len(keys))
+	for _, key := range keys {
+		stats := byKey[key]
+		var sum int64
+		var gaps []int64
+		for i, ep := range stats.History {
+			sum += ep.DurationSeconds
+			if i > 0 && stats.History[i-1].EndedAt != nil {
+				gaps = append(gaps, episodeSeconds(*stats.History[i-1].EndedAt, ep.StartedAt))
+			}
+		}
+		stats.AvgDurationSeconds = float64(sum) / float64(stats.Episodes)
+		if len(gaps) > 0 {
+			var gapSum int64
+			for _, gap := range gaps {
+				gapSum += gap
+			}
+			mtbf := float64(gapSum) / float64(len(gaps))
+			stats.MTBFSeconds = &mtbf
+		}
+		result = append(result, *stats)
+	}
+
+	// Most frequent codes first
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Episodes != result[j].Episodes {
+			return result[i].Episodes > result[j].Episodes
+		}
+		return result[i].TotalDurationSeconds > result[j].TotalDurationSeconds
+	})
+	return result, nil
+}
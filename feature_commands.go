@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeatureCommandRequest is a request to execute a command advertised by a device feature
+type FeatureCommandRequest struct {
+	AccountID      string                 `json:"accountId"`
+	InstallationID string                 `json:"installationId"`
+	GatewaySerial  string                 `json:"gatewaySerial"`
+	DeviceID       string                 `json:"deviceId"`
+	Feature        string                 `json:"feature"`
+	Command        string                 `json:"command"`
+	Params         map[string]interface{} `json:"params"`
+}
+
+// invalidateFeaturesCache removes the cached features of a device
+func invalidateFeaturesCache(installationID, gatewaySerial, deviceID string) {
+	featuresCacheMutex.Lock()
+	delete(featuresCache, fmt.Sprintf("%s:%s:%s", installationID, gatewaySerial, deviceID))
+	featuresCacheMutex.Unlock()
+}
+
+// fetchFeaturesForAccount authenticates the account and fetches the (cached) features of a device
+func fetchFeaturesForAccount(accountID, installationID, gatewaySerial, deviceID string) (*DeviceFeatures, error) {
+	account, err := GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	token, err := ensureAccountAuthenticated(account)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := fetchFeaturesWithCache(installationID, gatewaySerial, deviceID, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch features: %w", err)
+	}
+	return features, nil
+}
+
+// findFeatureCommand looks up the command metadata of a feature
+func findFeatureCommand(features *DeviceFeatures, featureName, commandName string) (*FeatureCommand, error) {
+	for _, f := range features.RawFeatures {
+		if f.Feature != featureName {
+			continue
+		}
+		cmd, ok := f.Commands[commandName]
+		if !ok {
+			return nil, fmt.Errorf("feature %s has no command %s", featureName, commandName)
+		}
+		if cmd.Name == "" {
+			cmd.Name = commandName
+		}
+		return &cmd, nil
+	}
+	return nil, fmt.Errorf("feature %s not found on device", featureName)
+}
+
+// validateCommandParams checks the parameters against the constraints advertised by the device.
+// Numbers are normalized to float64, so integer values from JSON and Go callers behave the same.
+func validateCommandParams(cmd *FeatureCommand, params map[string]interface{}) error {
+	for name := range params {
+		if _, ok := cmd.Params[name]; !ok {
+			return fmt.Errorf("unknown parameter %q for command %s", name, cmd.Name)
+		}
+	}
+
+	for name, def := range cmd.Params {
+		value, present := params[name]
+		if !present {
+			if def.Required {
+				return fmt.Errorf("missing required parameter %q", name)
+			}
+			continue
+		}
+
+		c := def.Constraints
+		switch def.Type {
+		case "number":
+			num, ok := toFloat64(value)
+			if !ok {
+				return fmt.Errorf("parameter %q must be a number", name)
+			}
+			if c.Min != nil && num < *c.Min {
+				return fmt.Errorf("parameter %q must be at least %v", name, *c.Min)
+			}
+			if c.Max != nil && num > *c.Max {
+				return fmt.Errorf("parameter %q must be at most %v", name, *c.Max)
+			}
+			if c.Stepping != nil && *c.Stepping > 0 {
+				base := 0.0
+				if c.Min != nil {
+					base = *c.Min
+				}
+				steps := (num - base) / *c.Stepping
+				if math.Abs(steps-math.Round(steps)) > 1e-6 {
+					return fmt.Errorf("parameter %q must be a multiple of %v", name, *c.Stepping)
+				}
+			}
+			params[name] = num
+		case "string":
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("parameter %q must be a string", name)
+			}
+			if len(c.Enum) > 0 {
+				valid := false
+				for _, e := range c.Enum {
+					if e == str {
+						valid = true
+						break
+					}
+				}
+				if !valid {
+					return fmt.Errorf("invalid value %q for parameter %q. Must be one of: %s", str, name, strings.Join(c.Enum, ", "))
+				}
+			}
+			if c.MinLength != nil && len(str) < *c.MinLength {
+				return fmt.Errorf("parameter %q must have at least %d characters", name, *c.MinLength)
+			}
+			if c.MaxLength != nil && len(str) > *c.MaxLength {
+				return fmt.Errorf("parameter %q must have at most %d characters", name, *c.MaxLength)
+			}
+			if c.Regex != "" {
+				if re, err := regexp.Compile(c.Regex); err == nil && !re.MatchString(str) {
+					return fmt.Errorf("parameter %q has an invalid format", name)
+				}
+			}
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("parameter %q must be a boolean", name)
+			}
+		case "array":
+			if _, ok := value.([]interface{}); !ok {
+				return fmt.Errorf("parameter %q must be an array", name)
+			}
+		case "object", "Schedule":
+			if _, ok := value.(map[string]interface{}); !ok {
+				return fmt.Errorf("parameter %q must be an object", name)
+			}
+		default:
+			// Other types are passed through unchanged, the device validates them
+		}
+	}
+
+	return nil
+}
+
+// toFloat64 converts JSON and Go numeric values to float64
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// executeFeatureCommand validates and executes a feature command using the command metadata
+// of the device. This is the common path for all feature commands; schedulers pass
+// PriorityBackground so their commands can't use up the budget reserved for the user.
+func executeFeatureCommand(req FeatureCommandRequest, priority RequestPriority) error {
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Feature == "" || req.Command == "" {
+		return fmt.Errorf("accountId, installationId, gatewaySerial, deviceId, feature and command are required")
+	}
+	if req.Params == nil {
+		req.Params = make(map[string]interface{})
+	}
+
+	account, err := GetAccount(req.AccountID)
+	if err != nil {
+		return fmt.Errorf("account not found: %w", err)
+	}
+
+	token, err := ensureAccountAuthenticated(account)
+	if err != nil {
+		return err
+	}
+
+	features, err := fetchFeaturesWithCache(req.InstallationID, req.GatewaySerial, req.DeviceID, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("failed to fetch features: %w", err)
+	}
+
+	cmd, err := findFeatureCommand(features, req.Feature, req.Command)
+	if err != nil {
+		return err
+	}
+	if !cmd.IsExecutable {
+		return fmt.Errorf("command %s of %s is currently not executable", req.Command, req.Feature)
+	}
+	if err := validateCommandParams(cmd, req.Params); err != nil {
+		return err
+	}
+
+	commandURL := cmd.URI
+	if commandURL == "" {
+		commandURL = fmt.Sprintf("%s/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/%s/commands/%s", apiBaseURL,
+			req.InstallationID, req.GatewaySerial, req.DeviceID, req.Feature, req.Command)
+	}
+
+	jsonBody, err := json.Marshal(req.Params)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second, Transport: viessmannTransport}
+	httpReq, err := NewRequestWithPriority(priority, http.MethodPost, rewriteAPIURL(commandURL), bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call Viessmann API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkAPIResponse(resp); err != nil {
+		log.Printf("Viessmann API error: %v", err)
+		return err
+	}
+
+	log.Printf("Command %s.%s executed with %v for device %s (account: %s)", req.Feature, req.Command, req.Params, req.DeviceID, req.AccountID)
+
+	// Clear features cache to force refresh
+	invalidateFeaturesCache(req.InstallationID, req.GatewaySerial, req.DeviceID)
+	return nil
+}
+
+// featureCommandHandler executes any command advertised by a device feature
+// POST /api/features/command
+func featureCommandHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req FeatureCommandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	writeFeatureCommandResult(w, r, req)
+}
+
+// writeFeatureCommandResult executes a feature command and writes the JSON result of a control handler.
+// Allowed values and ranges come from the command metadata of the device, so the control handlers
+// only map their request to the feature and command.
+func writeFeatureCommandResult(w http.ResponseWriter, r *http.Request, req FeatureCommandRequest) {
+	if err := executeFeatureCommand(req, PriorityInteractive); err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
+
+// featureCommandsListHandler lists all commands advertised by the features of a device
+// GET /api/features/commands?accountId=...&installationId=...&gatewaySerial=...&deviceId=...
+func featureCommandsListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	accountID := q.Get("accountId")
+	installationID := q.Get("installationId")
+	gatewaySerial := q.Get("gatewaySerial")
+	deviceID := q.Get("deviceId")
+
+	if accountID == "" || installationID == "" || gatewaySerial == "" || deviceID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "accountId, installationId, gatewaySerial and deviceId are required",
+		})
+		return
+	}
+
+	features, err := fetchFeaturesForAccount(accountID, installationID, gatewaySerial, deviceID)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+
+	type commandInfo struct {
+		Feature string `json:"feature"`
+		FeatureCommand
+	}
+	commands := make([]commandInfo, 0)
+	for _, f := range features.RawFeatures {
+		for name, cmd := range f.Commands {
+			if cmd.Name == "" {
+				cmd.Name = name
+			}
+			commands = append(commands, commandInfo{Feature: f.Feature, FeatureCommand: cmd})
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		if commands[i].Feature != commands[j].Feature {
+			return commands[i].Feature < commands[j].Feature
+		}
+		return commands[i].Name < commands[j].Name
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"commands": commands,
+	})
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeatureLogSettings configures the generic logging of feature values. Values are taken from the
+// features the temperature logging job fetches anyway, so no additional API calls are made.
+type FeatureLogSettings struct {
+	Enabled       bool              `json:"enabled"`
+	RetentionDays int               `json:"retentionDays"` // How long samples are kept
+	Rules         []*FeatureLogRule `json:"rules"`
+}
+
+// FeatureLogRule selects the features to log for a device. Empty IDs match every installation,
+// gateway or device.
+type FeatureLogRule struct {
+	InstallationID string   `json:"installationId,omitempty"`
+	GatewaySerial  string   `json:"gatewaySerial,omitempty"`
+	DeviceID       string   `json:"deviceId,omitempty"`
+	Patterns       []string `json:"patterns"` // Feature paths, * matches one path segment, ** any number of segments
+}
+
+// FeatureSeries is a logged property of a feature
+type FeatureSeries struct {
+	ID             int64      `json:"id"`
+	InstallationID string     `json:"installationId"`
+	GatewayID      string     `json:"gatewayId"`
+	DeviceID       string     `json:"deviceId"`
+	Feature        string     `json:"feature"`
+	Property       string     `json:"property"`
+	Type           string     `json:"type"` // number, boolean or string
+	Unit           string     `json:"unit,omitempty"`
+	Samples        int64      `json:"samples"`
+	FirstSample    *time.Time `json:"firstSample,omitempty"`
+	LastSample     *time.Time `json:"lastSample,omitempty"`
+}
+
+// FeatureSample is one logged value, Value is float64, bool or string according to the series type
+type FeatureSample struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Value     interface{} `json:"value"`
+}
+
+// FeatureSeriesData is a series with its samples in a time range
+type FeatureSeriesData struct {
+	FeatureSeries
+	Data []FeatureSample `json:"data"`
+}
+
+// FeatureSeriesFilter selects series, Feature may be a glob pattern
+type FeatureSeriesFilter struct {
+	InstallationID string
+	GatewayID      string
+	DeviceID       string
+	Feature        string
+	Property       string
+}
+
+// GetFeatureLogSettings returns the feature logging settings with defaults applied
+func GetFeatureLogSettings() (*FeatureLogSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.FeatureLogSettings
+	if settings == nil {
+		settings = &FeatureLogSettings{}
+	}
+	applyFeatureLogDefaults(settings)
+	return settings, nil
+}
+
+// SetFeatureLogSettings updates the feature logging settings
+func SetFeatureLogSettings(settings *FeatureLogSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	applyFeatureLogDefaults(settings)
+	store.FeatureLogSettings = settings
+	return SaveAccounts(store)
+}
+
+func applyFeatureLogDefaults(settings *FeatureLogSettings) {
+	if settings.RetentionDays < 1 {
+		settings.RetentionDays = 90
+	}
+	if settings.Rules == nil {
+		settings.Rules = []*FeatureLogRule{}
+	}
+	for _, rule := range settings.Rules {
+		patterns := make([]string, 0, len(rule.Patterns))
+		for _, p := range rule.Patterns {
+			if p = strings.TrimSpace(p); p != "" {
+				patterns = append(patterns, p)
+			}
+		}
+		rule.Patterns = patterns
+		rule.InstallationID = strings.TrimSpace(rule.InstallationID)
+		rule.GatewaySerial = strings.TrimSpace(rule.GatewaySerial)
+		rule.DeviceID = strings.TrimSpace(rule.DeviceID)
+	}
+}
+
+// validateFeatureLogSettings checks the patterns before saving
+func validateFeatureLogSettings(settings *FeatureLogSettings) error {
+	if settings.RetentionDays > 3650 {
+		return fmt.Errorf("retention days must be between 1 and 3650")
+	}
+	for _, rule := range settings.Rules {
+		if len(rule.Patterns) == 0 {
+			return fmt.Errorf("rule without feature patterns")
+		}
+		for _, p := range rule.Patterns {
+			for _, segment := range strings.Split(p, ".") {
+				if _, err := path.Match(segment, ""); err != nil || segment == "" {
+					return fmt.Errorf("invalid feature pattern %q", p)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matchFeaturePattern matches a feature path against a pattern. Path segments are separated by dots,
+// * and ? match within one segment, ** matches any number of segments.
+func matchFeaturePattern(pattern, feature string) bool {
+	return matchFeatureSegments(strings.Split(pattern, "."), strings.Split(feature, "."))
+}
+
+func matchFeatureSegments(pattern, feature []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(feature); i++ {
+				if matchFeatureSegments(pattern[1:], feature[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(feature) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], feature[0]); !ok {
+			return false
+		}
+		pattern, feature = pattern[1:], feature[1:]
+	}
+	return len(feature) == 0
+}
+
+// patternsForDevice returns the patterns of all rules matching a device
+func (s *FeatureLogSettings) patternsForDevice(installationID, gatewaySerial, deviceID string) []string {
+	var patterns []string
+	for _, rule := range s.Rules {
+		if (rule.InstallationID == "" || rule.InstallationID == installationID) &&
+			(rule.GatewaySerial == "" || rule.GatewaySerial == gatewaySerial) &&
+			(rule.DeviceID == "" || rule.DeviceID == deviceID) {
+			patterns = append(patterns, rule.Patterns...)
+		}
+	}
+	return patterns
+}
+
+// featureSampleValue is a scalar property value ready to be stored
+type featureSampleValue struct {
+	feature   string
+	property  string
+	valueType string
+	unit      string
+	num       *float64
+	text      *string
+}
+
+// extractFeatureSampleValues returns the number, boolean and string properties of the features
+// matching one of the patterns. Arrays and objects (schedules, curves) are skipped.
+func extractFeatureSampleValues(features []Feature, patterns []string) []featureSampleValue {
+	var values []featureSampleValue
+	for _, feature := range features {
+		matched := false
+		for _, p := range patterns {
+			if matchFeaturePattern(p, feature.Feature) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		for name, raw := range feature.Properties {
+			prop, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v := featureSampleValue{feature: feature.Feature, property: name}
+			if unit, ok := prop["unit"].(string); ok {
+				v.unit = unit
+			}
+			switch val := prop["value"].(type) {
+			case float64:
+				v.valueType = "number"
+				v.num = &val
+			case bool:
+				f := 0.0
+				if val {
+					f = 1
+				}
+				v.valueType = "boolean"
+				v.num = &f
+			case string:
+				v.valueType = "string"
+				v.text = &val
+			default:
+				continue
+			}
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// logFeatureSamples stores the values of the features selected for a device
+func logFeatureSamples(settings *FeatureLogSettings, features *DeviceFeatures, installationID, gatewayID, deviceID string, timestamp time.Time) {
+	if settings == nil || !settings.Enabled || features == nil {
+		return
+	}
+	patterns := settings.patternsForDevice(installationID, gatewayID, deviceID)
+	if len(patterns) == 0 {
+		return
+	}
+
+	values := extractFeatureSampleValues(features.RawFeatures, patterns)
+	if len(values) == 0 {
+		return
+	}
+	n, err := SaveFeatureSamples(installationID, gatewayID, deviceID, timestamp, values)
+	if err != nil {
+		log.Printf("Error saving feature samples: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("Saved %d feature values for installation %s device %s", n, installationID, deviceID)
+	}
+}
+
+// createFeatureLogTables creates the tables of the generic feature log, dbMutex must be held.
+// Samples reference their series, so the feature path is stored only once per series.
+func createFeatureLogTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS feature_series (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		installation_id TEXT NOT NULL,
+		gateway_id TEXT NOT NULL,
+		device_id TEXT NOT NULL,
+		feature TEXT NOT NULL,
+		property TEXT NOT NULL,
+		value_type TEXT NOT NULL,
+		unit TEXT,
+		UNIQUE (installation_id, gateway_id, device_id, feature, property)
+	);
+
+	CREATE TABLE IF NOT EXISTS feature_samples (
+		series_id INTEGER NOT NULL,
+		timestamp TEXT NOT NULL,
+		num_value REAL,
+		text_value TEXT,
+		PRIMARY KEY (series_id, timestamp)
+	) WITHOUT ROWID;
+
+	CREATE INDEX IF NOT EXISTS idx_feature_samples_timestamp ON feature_samples(timestamp);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create feature log tables: %v", err)
+	}
+	return nil
+}
+
+// SaveFeatureSamples stores the values of one device at one point in time and returns the number of new samples
+func SaveFeatureSamples(installationID, gatewayID, deviceID string, timestamp time.Time, values []featureSampleValue) (int, error) {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback() // Will be no-op if committed
+
+	ts := timestamp.UTC().Format(time.RFC3339)
+	inserted := 0
+	for _, v := range values {
+		// The type and unit of a series follow the latest value
+		var seriesID int64
+		err := tx.QueryRow(`
+			INSERT INTO feature_series (installation_id, gateway_id, device_id, feature, property, value_type, unit)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(installation_id, gateway_id, device_id, feature, property)
+			DO UPDATE SET value_type = excluded.value_type, unit = excluded.unit
+			RETURNING id
+		`, installationID, gatewayID, deviceID, v.feature, v.property, v.valueType, v.unit).Scan(&seriesID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save feature series: %v", err)
+		}
+
+		result, err := tx.Exec(`INSERT OR IGNORE INTO feature_samples (series_id, timestamp, num_value, text_value) VALUES (?, ?, ?, ?)`,
+			seriesID, ts, v.num, v.text)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save feature sample: %v", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			inserted++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return inserted, nil
+}
+
+// CleanupOldFeatureSamples removes samples older than the retention period and series without samples
+func CleanupOldFeatureSamples(retentionDays int) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	cutoffTime := time.Now().UTC().AddDate(0, 0, -retentionDays)
+	result, err := eventDB.Exec("DELETE FROM feature_samples WHERE timestamp < ?", cutoffTime.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to cleanup old feature samples: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("Cleaned up %d old feature samples (retention: %d days)", n, retentionDays)
+		if _, err := eventDB.Exec("DELETE FROM feature_series WHERE id NOT IN (SELECT DISTINCT series_id FROM feature_samples)"); err != nil {
+			return fmt.Errorf("failed to cleanup empty feature series: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetFeatureSeries lists the logged series matching the filter, with number and time of their samples
+func GetFeatureSeries(filter FeatureSeriesFilter) ([]FeatureSeries, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	return getFeatureSeriesLocked(filter, true)
+}
+
+func getFeatureSeriesLocked(filter FeatureSeriesFilter, withStats bool) ([]FeatureSeries, error) {
+	query := `SELECT id, installation_id, gateway_id, device_id, feature, property, value_type, IFNULL(unit, '') FROM feature_series WHERE 1 = 1`
+	var args []interface{}
+	for column, value := range map[string]string{
+		"installation_id": filter.InstallationID,
+		"gateway_id":      filter.GatewayID,
+		"device_id":       filter.DeviceID,
+		"property":        filter.Property,
+	} {
+		if value != "" {
+			query += " AND " + column + " = ?"
+			args = append(args, value)
+		}
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature series: %v", err)
+	}
+
+	var series []FeatureSeries
+	for rows.Next() {
+		var s FeatureSeries
+		if err := rows.Scan(&s.ID, &s.InstallationID, &s.GatewayID, &s.DeviceID, &s.Feature, &s.Property, &s.Type, &s.Unit); err != nil {
+			log.Printf("Warning: failed to scan feature series row: %v", err)
+			continue
+		}
+		if filter.Feature != "" && !matchFeaturePattern(filter.Feature, s.Feature) {
+			continue
+		}
+		series = append(series, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating feature series: %v", err)
+	}
+
+	if withStats {
+		for i := range series {
+			var first, last *string
+			err := eventDB.QueryRow(`SELECT COUNT(*), MIN(timestamp), MAX(timestamp) FROM feature_samples WHERE series_id = ?`, series[i].ID).
+				Scan(&series[i].Samples, &first, &last)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query feature samples: %v", err)
+			}
+			if first != nil {
+				if t, err := time.Parse(time.RFC3339, *first); err == nil {
+					series[i].FirstSample = &t
+				}
+			}
+			if last != nil {
+				if t, err := time.Parse(time.RFC3339, *last); err == nil {
+					series[i].LastSample = &t
+				}
+			}
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		a, b := series[i], series[j]
+		if a.InstallationID+a.GatewayID+a.DeviceID != b.InstallationID+b.GatewayID+b.DeviceID {
+			return a.InstallationID+a.GatewayID+a.DeviceID < b.InstallationID+b.GatewayID+b.DeviceID
+		}
+		if a.Feature != b.Feature {
+			return a.Feature < b.Feature
+		}
+		return a.Property < b.Property
+	})
+	return series, nil
+}
+
+// GetFeatureSamples returns the samples of all series matching the filter in a time range.
+// limit applies per series.
+func GetFeatureSamples(filter FeatureSeriesFilter, startTime, endTime time.Time, limit int) ([]FeatureSeriesData, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	return getFeatureSamplesLocked(filter, startTime, endTime, limit)
+}
+
+// getFeatureSamplesLocked is GetFeatureSamples without locking, dbMutex must be held
+func getFeatureSamplesLocked(filter FeatureSeriesFilter, startTime, endTime time.Time, limit int) ([]FeatureSeriesData, error) {
+	series, err := getFeatureSeriesLocked(filter, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]FeatureSeriesData, 0, len(series))
+	for _, s := range series {
+		query := `SELECT timestamp, num_value, text_value FROM feature_samples
+			WHERE series_id = ? AND timestamp >= ? AND timestamp <= ? ORDER BY timestamp ASC`
+		if limit > 0 {
+			query += fmt.Sprintf(" LIMIT %d", limit)
+		}
+		rows, err := eventDB.Query(query, s.ID, startTime.UTC().Format(time.RFC3339), endTime.UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query feature samples: %v", err)
+		}
+
+		data := FeatureSeriesData{FeatureSeries: s, Data: []FeatureSample{}}
+		for rows.Next() {
+			var ts string
+			var num *float64
+			var text *string
+			if err := rows.Scan(&ts, &num, &text); err != nil {
+				log.Printf("Warning: failed to scan feature sample row: %v", err)
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, ts)
+			if err != nil {
+				continue
+			}
+
+			sample := FeatureSample{Timestamp: t}
+			switch {
+			case s.Type == "boolean" && num != nil:
+				sample.Value = *num != 0
+			case num != nil:
+				sample.Value = *num
+			case text != nil:
+				sample.Value = *text
+			}
+			data.Data = append(data.Data, sample)
+		}
+		rows.Close()
+
+		data.Samples = int64(len(data.Data))
+		if len(data.Data) > 0 {
+			data.FirstSample = &data.Data[0].Timestamp
+			data.LastSample = &data.Data[len(data.Data)-1].Timestamp
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"path"
 	"time"
 )
 
@@ -14,20 +15,42 @@ import (
 
 // TemplateData holds common data passed to all templates
 type TemplateData struct {
-	Version string
-	Commit  string
-	Date    string
+	Version  string
+	Commit   string
+	Date     string
+	Lang     string            // Language of the page (de, en)
+	Messages map[string]string // Message bundle for the JavaScript of the page (window.I18N)
 }
 
 // newTemplateData creates a new TemplateData with version information
-func newTemplateData() TemplateData {
+func newTemplateData(lang string) TemplateData {
 	return TemplateData{
-		Version: version,
-		Commit:  commit,
-		Date:    date,
+		Version:  version,
+		Commit:   commit,
+		Date:     date,
+		Lang:     lang,
+		Messages: i18nMessages(lang),
 	}
 }
 
+// renderTemplate renders a page in the language of the request. The templates translate
+// texts with {{t "key"}}. A ?lang= parameter is remembered in a cookie for the API calls of the page.
+func renderTemplate(w http.ResponseWriter, r *http.Request, name string) {
+	lang := requestLanguage(r)
+	if queryLang := r.URL.Query().Get("lang"); queryLang == lang {
+		http.SetCookie(w, &http.Cookie{Name: languageCookie, Value: lang, Path: "/", MaxAge: 365 * 24 * 3600, SameSite: http.SameSiteLaxMode})
+	}
+
+	tmpl, err := template.New(path.Base(name)).Funcs(template.FuncMap{
+		"t": func(key string, args ...interface{}) string { return translate(lang, key, args...) },
+	}).ParseFS(templatesFS, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tmpl.Execute(w, newTemplateData(lang))
+}
+
 // ============================================================================
 // Page Handlers
 // ============================================================================
@@ -39,66 +62,74 @@ func indexHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tmpl, err := template.ParseFS(templatesFS, "templates/index.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/index.html")
 }
 
 func loginPageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/login.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/login.html")
 }
 
 func dashboardPageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/dashboard.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/dashboard.html")
 }
 
 func accountsPageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/accounts.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/accounts.html")
 }
 
 func smartClimatePageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/smartclimate.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/smartclimate.html")
 }
 
 func vitochargePageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/vitocharge.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	tmpl.Execute(w, newTemplateData())
+	renderTemplate(w, r, "templates/vitocharge.html")
 }
 
 func apiTestPageHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.ParseFS(templatesFS, "templates/apitest.html")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	renderTemplate(w, r, "templates/apitest.html")
+}
+
+// languageSettingsGetHandler handles GET /api/language/settings
+func languageSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"language":  GetLanguageSetting(),
+		"current":   requestLanguage(r),
+		"available": supportedLanguages(),
+	})
+}
+
+// languageSettingsSetHandler handles POST /api/language/settings/set
+// Body: {"language": "en"}, an empty language selects the browser language
+func languageSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Language string `json:"language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 		return
 	}
-	tmpl.Execute(w, newTemplateData())
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := SetLanguageSetting(req.Language); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// A language chosen earlier in this browser would hide the new setting
+	http.SetCookie(w, &http.Cookie{Name: languageCookie, Value: "", Path: "/", MaxAge: -1})
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"language": req.Language,
+	})
 }
 
 // ============================================================================
@@ -125,7 +156,7 @@ func loginHandler(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(LoginResponse{
 			Success: false,
-			Error:   "Email, password, and client ID are required",
+			Error:   translate(requestLanguage(r), "login.fields_required"),
 		})
 		return
 	}
@@ -229,6 +260,8 @@ func accountsHandler(w http.ResponseWriter, r *http.Request) {
 			ClientID:    acc.ClientID,
 			Active:      acc.Active,
 			HasPassword: acc.Password != "",
+			AuthMethod:  accountAuthMethod(acc),
+			TokenState:  getAccountTokenState(acc.ID),
 		})
 	}
 
@@ -257,7 +290,7 @@ func accountAddHandler(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(AccountActionResponse{
 			Success: false,
-			Error:   "Email, password, and client ID are required",
+			Error:   translate(requestLanguage(r), "login.fields_required"),
 		})
 		return
 	}
@@ -342,6 +375,10 @@ func accountUpdateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only new credentials invalidate the token, not a new name
+	credentialsChanged := (req.Password != "" && req.Password != existing.Password) ||
+		(req.ClientID != "" && req.ClientID != existing.ClientID)
+
 	// Update fields
 	if req.Name != "" {
 		existing.Name = req.Name
@@ -364,10 +401,16 @@ func accountUpdateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Clear token for this account (will re-authenticate with new credentials)
-	accountsMutex.Lock()
-	delete(accountTokens, existing.ID)
-	accountsMutex.Unlock()
+	// Clear token for this account (will re-authenticate with new credentials). The refresh token
+	// of a browser login account is its only credential and is kept.
+	if credentialsChanged && accountAuthMethod(existing) != AuthMethodOAuth {
+		accountsMutex.Lock()
+		delete(accountTokens, existing.ID)
+		accountsMutex.Unlock()
+		if err := DeleteAccountToken(existing.ID); err != nil {
+			log.Printf("Could not delete stored token for account %s: %v\n", existing.ID, err)
+		}
+	}
 
 	// Clear cache to force refresh
 	fetchMutex.Lock()
@@ -412,6 +455,9 @@ func accountDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	accountsMutex.Lock()
 	delete(accountTokens, req.ID)
 	accountsMutex.Unlock()
+	if err := DeleteAccountToken(req.ID); err != nil {
+		log.Printf("Could not delete stored token for account %s: %v\n", req.ID, err)
+	}
 
 	log.Printf("Account deleted: %s\n", req.ID)
 
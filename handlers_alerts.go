@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// alertSettingsGetHandler handles GET /api/alerts/settings
+func alertSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetAlertSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Secrets are never sent to the browser
+	secretsSet := make(map[string]bool)
+	for _, channel := range settings.Channels {
+		secretsSet[channel.ID] = channel.Password != "" || channel.Token != ""
+		channel.Password = ""
+		channel.Token = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"settings":        settings,
+		"secretsSet":      secretsSet,
+		"snapshotFields":  snapshotNumericFields(),
+		"databaseEnabled": dbInitialized,
+	})
+}
+
+// alertSettingsSetHandler handles POST /api/alerts/settings/set
+func alertSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings AlertSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	oldSettings, err := GetAlertSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Keep stored secrets if the field was left empty
+	oldChannels := make(map[string]*AlertChannel)
+	for _, channel := range oldSettings.Channels {
+		oldChannels[channel.ID] = channel
+	}
+	for _, channel := range settings.Channels {
+		if old, ok := oldChannels[channel.ID]; ok && channel.ID != "" {
+			if channel.Password == "" {
+				channel.Password = old.Password
+			}
+			if channel.Token == "" {
+				channel.Token = old.Token
+			}
+		}
+	}
+
+	applyAlertDefaults(&settings)
+	if err := validateAlertSettings(&settings); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := SetAlertSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Alert settings updated: %d rule(s), %d channel(s), enabled=%v", len(settings.Rules), len(settings.Channels), settings.Enabled)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Alert settings updated successfully",
+	})
+}
+
+// alertTestHandler handles POST /api/alerts/test and sends a test notification through a saved channel
+func alertTestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ChannelID string `json:"channelId"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings, err := GetAlertSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var channel *AlertChannel
+	for _, c := range settings.Channels {
+		if c.ID == req.ChannelID {
+			channel = c
+			break
+		}
+	}
+	if channel == nil {
+		http.Error(w, "Channel not found", http.StatusNotFound)
+		return
+	}
+
+	alert := Alert{
+		RuleID:    "test",
+		RuleName:  "Test",
+		Status:    AlertFiring,
+		Severity:  "info",
+		Title:     translate(requestLanguage(r), "alerts.test_title"),
+		Message:   translate(requestLanguage(r), "alerts.test_message", channel.Name),
+		Timestamp: time.Now(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := channel.Send(alert); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
+
+// alertStatesHandler handles GET /api/alerts/active (?all=true includes resolved alerts)
+func alertStatesHandler(w http.ResponseWriter, r *http.Request) {
+	states, err := GetAlertStates(r.URL.Query().Get("all") != "true", 200)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"alerts":  states,
+	})
+}
@@ -6,26 +6,23 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"strconv"
 	"time"
 )
 
 // eventsHandler handles GET /api/events
-// Returns events for the last N days (default: 7)
-// Merges events from API and database (if archiving is enabled)
+// Returns events for the last N days (default: 7), optionally filtered, sorted and paginated.
+// If archiving is enabled, the first page saves fresh API events and the query runs on the database;
+// follow-up pages (with cursor) only query the database.
+// Without limit/cursor the response is a plain array, otherwise an EventsPage with nextCursor.
 func eventsHandler(w http.ResponseWriter, r *http.Request) {
-	daysStr := r.URL.Query().Get("days")
-	days := 7
-	if daysStr != "" {
-		if d, err := strconv.Atoi(daysStr); err == nil {
-			days = d
-		}
-	}
-
-	// Fetch events from API
-	apiEvents, err := fetchEvents(days)
+	q, err := parseEventQuery(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
 		return
 	}
 
@@ -34,92 +31,60 @@ func eventsHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Printf("Warning: failed to get archive settings: %v", err)
 	}
+	archiveEnabled := archiveSettings != nil && archiveSettings.Enabled && dbInitialized
 
-	// If archiving is enabled, save API events to DB and merge with DB events
-	var allEvents []Event
-	if archiveSettings != nil && archiveSettings.Enabled {
-		// Save fresh API events to database (with deduplication)
-		if len(apiEvents) > 0 {
-			go func() {
-				err := SaveEventsToDB(apiEvents)
-				if err != nil {
-					log.Printf("Warning: failed to save events to DB: %v", err)
-				}
-			}()
+	var page *EventsPage
+	if archiveEnabled {
+		// Only the first page syncs the API events, the archive scheduler keeps it current while paging
+		if q.Cursor == "" {
+			syncAPIEventsToDB(q.Days)
 		}
 
-		// Fetch events from database for the requested time range
-		endTime := time.Now()
-		startTime := endTime.AddDate(0, 0, -days)
-
-		dbEvents, err := GetEventsFromDB(startTime, endTime, 0)
+		page, err = QueryEventsFromDB(q)
 		if err != nil {
-			log.Printf("Warning: failed to fetch events from DB: %v", err)
-			allEvents = apiEvents
-		} else {
-			// Merge and deduplicate events
-			allEvents = mergeAndDeduplicateEvents(apiEvents, dbEvents)
+			log.Printf("Warning: failed to query events from DB: %v", err)
+			page = nil
 		}
-	} else {
-		allEvents = apiEvents
+	}
+	if page == nil {
+		apiEvents, err := fetchEvents(q.Days)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		page = queryEventsInMemory(apiEvents, q)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(allEvents)
+	if q.paged() {
+		json.NewEncoder(w).Encode(page)
+		return
+	}
+	json.NewEncoder(w).Encode(page.Events)
 }
 
-// mergeAndDeduplicateEvents merges events from API and DB, removes duplicates
-// and sorts by timestamp descending
-func mergeAndDeduplicateEvents(apiEvents, dbEvents []Event) []Event {
-	// Use a map to track unique events by hash
-	eventMap := make(map[string]Event)
-
-	// Add API events first (they are more recent/authoritative)
-	for i := range apiEvents {
-		hash := ComputeEventHash(&apiEvents[i])
-		eventMap[hash] = apiEvents[i]
+// syncAPIEventsToDB saves fresh API events to the database (with deduplication)
+// and publishes the new ones. Errors are logged, the archive can still answer the query.
+func syncAPIEventsToDB(days int) {
+	apiEvents, err := fetchEvents(days)
+	if err != nil {
+		log.Printf("Warning: failed to fetch events from API: %v", err)
+		return
 	}
-
-	// Add DB events (only if not already present)
-	for i := range dbEvents {
-		hash := ComputeEventHash(&dbEvents[i])
-		if _, exists := eventMap[hash]; !exists {
-			eventMap[hash] = dbEvents[i]
-		}
+	if len(apiEvents) == 0 {
+		return
 	}
 
-	// Convert map back to slice
-	merged := make([]Event, 0, len(eventMap))
-	for _, event := range eventMap {
-		merged = append(merged, event)
+	newEvents, err := SaveNewEventsToDB(apiEvents)
+	if err != nil {
+		log.Printf("Warning: failed to save events to DB: %v", err)
+		return
 	}
-
-	// Sort by EventTimestamp descending (newest first)
-	sortEventsByTimestamp(merged)
-
-	return merged
-}
-
-// sortEventsByTimestamp sorts events by EventTimestamp descending
-func sortEventsByTimestamp(events []Event) {
-	// Simple bubble sort (good enough for event lists)
-	n := len(events)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			// Parse timestamps for comparison
-			t1, err1 := time.Parse(time.RFC3339, events[j].EventTimestamp)
-			t2, err2 := time.Parse(time.RFC3339, events[j+1].EventTimestamp)
-
-			// If parsing fails, skip
-			if err1 != nil || err2 != nil {
-				continue
-			}
-
-			// Sort descending (newer first)
-			if t1.Before(t2) {
-				events[j], events[j+1] = events[j+1], events[j]
-			}
-		}
+	if len(newEvents) > 0 {
+		go func() {
+			mqttPublishEvents(newEvents)
+			evaluateEventAlerts(newEvents)
+		}()
 	}
 }
 
@@ -434,7 +399,7 @@ func featuresHandler(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Force refresh - bypassing cache for %s:%s:%s\n", installationID, gatewayID, deviceID)
 		features, err = fetchFeaturesForDevice(installationID, gatewayID, deviceID, accessToken)
 		if err != nil {
-			http.Error(w, "Failed to fetch features: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Failed to fetch features: "+apiErrorText(r, err), apiErrorHTTPStatus(err))
 			return
 		}
 		// Update cache with fresh data
@@ -445,7 +410,7 @@ func featuresHandler(w http.ResponseWriter, r *http.Request) {
 	} else {
 		features, err = fetchFeaturesWithCache(installationID, gatewayID, deviceID, accessToken)
 		if err != nil {
-			http.Error(w, "Failed to fetch features: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Failed to fetch features: "+apiErrorText(r, err), apiErrorHTTPStatus(err))
 			return
 		}
 	}
@@ -1157,6 +1122,9 @@ func HandleConsumptionStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Total cost from the breakdown, priced with the tariff of the device
+	stats.CostEUR = sumConsumptionCosts(append(stats.HourlyBreakdown, stats.DailyBreakdown...))
+
 	// Return the stats
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success": true,
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+)
+
+// backupsListHandler handles GET /api/db/backups
+func backupsListHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetBackupSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	backups, err := ListDatabaseBackups(settings.Directory)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	response := map[string]interface{}{
+		"success":          true,
+		"backups":          backups,
+		"directory":        settings.Directory,
+		"schedulerRunning": IsBackupSchedulerRunning(),
+		"databaseEnabled":  dbInitialized,
+	}
+	if stats, ok := getJobStats("db_backup"); ok {
+		response["lastRun"] = stats.LastRun
+		response["lastError"] = stats.LastError
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// backupCreateHandler handles POST /api/db/backups/create
+func backupCreateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	settings, err := GetBackupSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	backup, err := CreateDatabaseBackup(settings.Directory, "")
+	if err == nil {
+		err = RotateDatabaseBackups(settings.Directory, settings.Keep)
+	}
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"backup":  backup,
+	})
+}
+
+// backupRestoreHandler handles POST /api/db/backups/restore
+func backupRestoreHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings, err := GetBackupSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := RestoreDatabaseBackup(settings.Directory, req.Name); err != nil {
+		log.Printf("Database restore failed: %v", err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Database restored from %s", req.Name),
+	})
+}
+
+// backupDownloadHandler handles GET /api/db/backups/download?name=
+func backupDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if !isBackupFileName(name) {
+		http.Error(w, "Invalid backup name", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := GetBackupSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.sqlite3")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	http.ServeFile(w, r, filepath.Join(settings.Directory, name))
+}
+
+// backupSettingsGetHandler handles GET /api/db/backups/settings
+func backupSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetBackupSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// backupSettingsSetHandler handles POST /api/db/backups/settings/set
+func backupSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings BackupSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	applyBackupDefaults(&settings)
+
+	if err := SetBackupSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := RestartBackupScheduler(); err != nil {
+		log.Printf("Failed to restart backup scheduler: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Backup settings updated successfully",
+	})
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// defrostCyclesHandler lists the detected defrost cycles of a device
+// GET /api/defrosts?installationId=...&gatewaySerial=...&deviceId=...&from=YYYY-MM-DD&to=YYYY-MM-DD&limit=1000
+func defrostCyclesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	filter := DefrostFilter{
+		InstallationID: q.Get("installationId"),
+		GatewaySerial:  q.Get("gatewaySerial"),
+		DeviceID:       q.Get("deviceId"),
+		Limit:          5000,
+	}
+	if filter.InstallationID == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Missing required parameter: installationId",
+		})
+		return
+	}
+	if parsedLimit, err := strconv.Atoi(q.Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 50000 {
+		filter.Limit = parsedLimit
+	}
+
+	start, end, err := parseLocalDateRange(q.Get("from"), q.Get("to"), -7)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	filter.StartTime, filter.EndTime = start, end
+
+	cycles, err := GetDefrostCycles(filter)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"defrosts": cycles,
+		"count":    len(cycles),
+	})
+}
+
+// defrostStatsHandler returns the defrosts of a device per day and per outside temperature
+// GET /api/defrosts/stats?installationId=...&gatewaySerial=...&deviceId=...&from=YYYY-MM-DD&to=YYYY-MM-DD
+func defrostStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	installationID, gatewaySerial, deviceID := q.Get("installationId"), q.Get("gatewaySerial"), q.Get("deviceId")
+	if installationID == "" || gatewaySerial == "" || deviceID == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Missing required parameters: installationId, gatewaySerial, deviceId",
+		})
+		return
+	}
+
+	start, end, err := parseLocalDateRange(q.Get("from"), q.Get("to"), -30)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	stats, err := GetDefrostStats(installationID, gatewaySerial, deviceID, start, end)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"stats":   stats,
+	})
+}
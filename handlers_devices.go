@@ -3,11 +3,9 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
+	"math"
 	"net/http"
-	"strings"
-	"time"
 )
 
 // Device Settings Handlers
@@ -369,856 +367,73 @@ func dhwModeSetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate mode
-	validModes := map[string]bool{
-		"efficient":               true,
-		"efficientWithMinComfort": true,
-		"balanced":                true,
-		"off":                     true,
-	}
-	if !validModes[req.Mode] {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid mode. Must be one of: efficient, efficientWithMinComfort, balanced, off",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.dhw.operating.modes.active/commands/setMode",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	requestBody := map[string]string{
-		"mode": req.Mode,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("DHW mode changed to '%s' for device %s (account: %s)", req.Mode, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-	})
-}
-
-func dhwTemperatureSetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string  `json:"accountId"`
-		InstallationID string  `json:"installationId"`
-		GatewaySerial  string  `json:"gatewaySerial"`
-		DeviceID       string  `json:"deviceId"`
-		Temperature    float64 `json:"temperature"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId, and temperature are required",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.dhw.temperature.main/commands/setTargetTemperature",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	requestBody := map[string]int{
-		"temperature": int(req.Temperature),
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("DHW temperature changed to %.1f°C for device %s (account: %s)", req.Temperature, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-	})
-}
-
-func dhwTemperature2SetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string  `json:"accountId"`
-		InstallationID string  `json:"installationId"`
-		GatewaySerial  string  `json:"gatewaySerial"`
-		DeviceID       string  `json:"deviceId"`
-		Temperature    float64 `json:"temperature"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId, and temperature are required",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.dhw.temperature.temp2/commands/setTargetTemperature",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	requestBody := map[string]int{
-		"temperature": int(req.Temperature),
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("DHW temperature 2 changed to %.1f°C for device %s (account: %s)", req.Temperature, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-	})
-}
-
-func dhwHysteresisSetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string  `json:"accountId"`
-		InstallationID string  `json:"installationId"`
-		GatewaySerial  string  `json:"gatewaySerial"`
-		DeviceID       string  `json:"deviceId"`
-		Type           string  `json:"type"` // "on" or "off"
-		Value          float64 `json:"value"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Type == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId, type, and value are required",
-		})
-		return
-	}
-
-	// Validate type
-	var command string
-	if req.Type == "on" {
-		command = "setHysteresisSwitchOnValue"
-	} else if req.Type == "off" {
-		command = "setHysteresisSwitchOffValue"
-	} else {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid type. Must be 'on' or 'off'",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.dhw.temperature.hysteresis/commands/%s",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, command)
-
-	// Prepare request body
-	requestBody := map[string]float64{
-		"hysteresis": req.Value,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("DHW hysteresis %s changed to %.1fK for device %s (account: %s)", req.Type, req.Value, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-	})
-}
-
-func dhwOneTimeChargeHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string `json:"accountId"`
-		InstallationID string `json:"installationId"`
-		GatewaySerial  string `json:"gatewaySerial"`
-		DeviceID       string `json:"deviceId"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, and deviceId are required",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.dhw.oneTimeCharge/commands/activate",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare empty request body
-	requestBody := map[string]interface{}{}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("DHW one-time charge activated for device %s (account: %s)", req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-	})
-}
-
-// Heating Control Handlers
-
-func heatingCurveSetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string  `json:"accountId"`
-		InstallationID string  `json:"installationId"`
-		GatewaySerial  string  `json:"gatewaySerial"`
-		DeviceID       string  `json:"deviceId"`
-		Circuit        int     `json:"circuit"` // Circuit number, usually 0
-		Shift          int     `json:"shift"`
-		Slope          float64 `json:"slope"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId are required",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.circuits.%d.heating.curve/commands/setCurve",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, req.Circuit)
-
-	// Prepare request body - shift as int, slope as float rounded to 1 decimal
-	requestBody := map[string]interface{}{
-		"shift": req.Shift,
-		"slope": float64(int(req.Slope*10+0.5)) / 10, // Round to 1 decimal
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
-		})
-		return
-	}
-
-	log.Printf("Heating curve changed to shift=%d, slope=%.1f for device %s (account: %s)", req.Shift, req.Slope, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.dhw.operating.modes.active",
+		Command:        "setMode",
+		Params:         map[string]interface{}{"mode": req.Mode},
 	})
-}
-
-func heatingModeSetHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req struct {
-		AccountID      string `json:"accountId"`
-		InstallationID string `json:"installationId"`
-		GatewaySerial  string `json:"gatewaySerial"`
-		DeviceID       string `json:"deviceId"`
-		Circuit        int    `json:"circuit"` // Circuit number, usually 0
-		Mode           string `json:"mode"`    // heating, standby
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid request: " + err.Error(),
-		})
-		return
-	}
-
-	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Mode == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId, and mode are required",
-		})
-		return
-	}
-
-	// Validate mode
-	validModes := map[string]bool{
-		"heating":        true,
-		"standby":        true,
-		"cooling":        true,
-		"heatingCooling": true,
-	}
-	if !validModes[req.Mode] {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid mode. Must be one of: heating, standby, cooling, heatingCooling",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
-
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.circuits.%d.operating.modes.active/commands/setMode",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, req.Circuit)
+}
 
-	// Prepare request body
-	requestBody := map[string]string{
-		"mode": req.Mode,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
+func dhwTemperatureSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
+	var req struct {
+		AccountID      string  `json:"accountId"`
+		InstallationID string  `json:"installationId"`
+		GatewaySerial  string  `json:"gatewaySerial"`
+		DeviceID       string  `json:"deviceId"`
+		Temperature    float64 `json:"temperature"`
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
+			"error":   "accountId, installationId, gatewaySerial, deviceId, and temperature are required",
 		})
 		return
 	}
 
-	log.Printf("Heating mode changed to '%s' for device %s (account: %s)", req.Mode, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.dhw.temperature.main",
+		Command:        "setTargetTemperature",
+		Params:         map[string]interface{}{"temperature": int(req.Temperature)},
 	})
 }
 
-func supplyTempMaxSetHandler(w http.ResponseWriter, r *http.Request) {
+func dhwTemperature2SetHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
 	var req struct {
-		AccountID      string `json:"accountId"`
-		InstallationID string `json:"installationId"`
-		GatewaySerial  string `json:"gatewaySerial"`
-		DeviceID       string `json:"deviceId"`
-		Circuit        int    `json:"circuit"` // Circuit number, usually 0
-		Temperature    int    `json:"temperature"`
+		AccountID      string  `json:"accountId"`
+		InstallationID string  `json:"installationId"`
+		GatewaySerial  string  `json:"gatewaySerial"`
+		DeviceID       string  `json:"deviceId"`
+		Temperature    float64 `json:"temperature"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1240,91 +455,76 @@ func supplyTempMaxSetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.dhw.temperature.temp2",
+		Command:        "setTargetTemperature",
+		Params:         map[string]interface{}{"temperature": int(req.Temperature)},
+	})
+}
 
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
+func dhwHysteresisSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Build Viessmann API URL - NOTE: using v2 API!
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.circuits.%d.temperature.levels/commands/setMax",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, req.Circuit)
-
-	// Prepare request body
-	requestBody := map[string]int{
-		"temperature": req.Temperature,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
+	var req struct {
+		AccountID      string  `json:"accountId"`
+		InstallationID string  `json:"installationId"`
+		GatewaySerial  string  `json:"gatewaySerial"`
+		DeviceID       string  `json:"deviceId"`
+		Type           string  `json:"type"` // "on" or "off"
+		Value          float64 `json:"value"`
 	}
 
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Type == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
+			"error":   "accountId, installationId, gatewaySerial, deviceId, type, and value are required",
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+	// "on" and "off" map to the two hysteresis commands of the feature
+	command := "setHysteresisSwitchOnValue"
+	if req.Type == "off" {
+		command = "setHysteresisSwitchOffValue"
+	} else if req.Type != "on" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
+			"error":   "Invalid type. Must be 'on' or 'off'",
 		})
 		return
 	}
 
-	log.Printf("Supply temperature max changed to %d°C for device %s (account: %s)", req.Temperature, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.dhw.temperature.hysteresis",
+		Command:        command,
+		Params:         map[string]interface{}{"hysteresis": req.Value},
 	})
 }
 
-func roomTempSetHandler(w http.ResponseWriter, r *http.Request) {
+func dhwOneTimeChargeHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -1335,9 +535,6 @@ func roomTempSetHandler(w http.ResponseWriter, r *http.Request) {
 		InstallationID string `json:"installationId"`
 		GatewaySerial  string `json:"gatewaySerial"`
 		DeviceID       string `json:"deviceId"`
-		Circuit        int    `json:"circuit"` // Circuit number, usually 0
-		Temperature    int    `json:"temperature"`
-		Program        string `json:"program"` // Program: normal, comfort, reduced
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1350,135 +547,78 @@ func roomTempSetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Program == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, deviceId, program, and temperature are required",
-		})
-		return
-	}
-
-	// Validate program - accept various program types
-	validPrograms := map[string]bool{
-		"normal":                     true,
-		"normalHeating":              true,
-		"normalCooling":              true,
-		"normalEnergySaving":         true,
-		"normalCoolingEnergySaving":  true,
-		"comfort":                    true,
-		"comfortHeating":             true,
-		"comfortCooling":             true,
-		"comfortEnergySaving":        true,
-		"comfortCoolingEnergySaving": true,
-		"reduced":                    true,
-		"reducedHeating":             true,
-		"reducedCooling":             true,
-		"reducedEnergySaving":        true,
-		"reducedCoolingEnergySaving": true,
-		"eco":                        true,
-		"fixed":                      true,
-		"standby":                    true,
-		"frostprotection":            true,
-		"forcedLastFromSchedule":     true,
-		"summerEco":                  true,
-	}
-	if !validPrograms[req.Program] {
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Invalid program: %s", req.Program),
+			"error":   "accountId, installationId, gatewaySerial, and deviceId are required",
 		})
 		return
 	}
 
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found or not authenticated",
-		})
-		return
-	}
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.dhw.oneTimeCharge",
+		Command:        "activate",
+		Params:         map[string]interface{}{},
+	})
+}
 
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.circuits.%d.operating.programs.%s/commands/setTemperature",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, req.Circuit, req.Program)
+// Heating Control Handlers
 
-	// Prepare request body
-	requestBody := map[string]int{
-		"targetTemperature": req.Temperature,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
+func heatingCurveSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
+	var req struct {
+		AccountID      string  `json:"accountId"`
+		InstallationID string  `json:"installationId"`
+		GatewaySerial  string  `json:"gatewaySerial"`
+		DeviceID       string  `json:"deviceId"`
+		Circuit        int     `json:"circuit"` // Circuit number, usually 0
+		Shift          int     `json:"shift"`
+		Slope          float64 `json:"slope"`
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
+			"error":   "accountId, installationId, gatewaySerial, deviceId are required",
 		})
 		return
 	}
 
-	log.Printf("Room temperature for program %s changed to %d°C for circuit %d, device %s (account: %s)", req.Program, req.Temperature, req.Circuit, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        fmt.Sprintf("heating.circuits.%d.heating.curve", req.Circuit),
+		Command:        "setCurve",
+		Params: map[string]interface{}{
+			"shift": req.Shift,
+			"slope": math.Round(req.Slope*10) / 10, // Round to 1 decimal
+		},
 	})
 }
 
-// Other Device Control Handlers
-
-func noiseReductionModeSetHandler(w http.ResponseWriter, r *http.Request) {
+func heatingModeSetHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -1489,7 +629,8 @@ func noiseReductionModeSetHandler(w http.ResponseWriter, r *http.Request) {
 		InstallationID string `json:"installationId"`
 		GatewaySerial  string `json:"gatewaySerial"`
 		DeviceID       string `json:"deviceId"`
-		Mode           string `json:"mode"` // notReduced, slightlyReduced, maxReduced
+		Circuit        int    `json:"circuit"` // Circuit number, usually 0
+		Mode           string `json:"mode"`    // heating, standby
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1511,106 +652,111 @@ func noiseReductionModeSetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate mode
-	validModes := map[string]bool{
-		"notReduced":      true,
-		"slightlyReduced": true,
-		"maxReduced":      true,
-	}
-	if !validModes[req.Mode] {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid mode. Must be one of: notReduced, slightlyReduced, maxReduced",
-		})
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        fmt.Sprintf("heating.circuits.%d.operating.modes.active", req.Circuit),
+		Command:        "setMode",
+		Params:         map[string]interface{}{"mode": req.Mode},
+	})
+}
+
+func supplyTempMaxSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
+	var req struct {
+		AccountID      string `json:"accountId"`
+		InstallationID string `json:"installationId"`
+		GatewaySerial  string `json:"gatewaySerial"`
+		DeviceID       string `json:"deviceId"`
+		Circuit        int    `json:"circuit"` // Circuit number, usually 0
+		Temperature    int    `json:"temperature"`
+	}
 
-	if !exists {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Account not found or not authenticated",
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
 
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.noise.reduction.operating.programs.active/commands/setMode",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	requestBody := map[string]string{
-		"mode": req.Mode,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
+			"error":   "accountId, installationId, gatewaySerial, deviceId, and temperature are required",
 		})
 		return
 	}
 
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        fmt.Sprintf("heating.circuits.%d.temperature.levels", req.Circuit),
+		Command:        "setMax",
+		Params:         map[string]interface{}{"temperature": req.Temperature},
+	})
+}
+
+func roomTempSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
+	var req struct {
+		AccountID      string `json:"accountId"`
+		InstallationID string `json:"installationId"`
+		GatewaySerial  string `json:"gatewaySerial"`
+		DeviceID       string `json:"deviceId"`
+		Circuit        int    `json:"circuit"` // Circuit number, usually 0
+		Temperature    int    `json:"temperature"`
+		Program        string `json:"program"` // Program: normal, comfort, reduced
+	}
 
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Program == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
+			"error":   "accountId, installationId, gatewaySerial, deviceId, program, and temperature are required",
 		})
 		return
 	}
 
-	log.Printf("Noise reduction mode changed to '%s' for device %s (account: %s)", req.Mode, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        fmt.Sprintf("heating.circuits.%d.operating.programs.%s", req.Circuit, req.Program),
+		Command:        "setTemperature",
+		Params:         map[string]interface{}{"targetTemperature": req.Temperature},
 	})
 }
 
-func fanRingToggleHandler(w http.ResponseWriter, r *http.Request) {
+// Other Device Control Handlers
+
+func noiseReductionModeSetHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -1621,7 +767,7 @@ func fanRingToggleHandler(w http.ResponseWriter, r *http.Request) {
 		InstallationID string `json:"installationId"`
 		GatewaySerial  string `json:"gatewaySerial"`
 		DeviceID       string `json:"deviceId"`
-		Active         bool   `json:"active"`
+		Mode           string `json:"mode"` // notReduced, slightlyReduced, maxReduced
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1634,99 +780,66 @@ func fanRingToggleHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate required fields
-	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "accountId, installationId, gatewaySerial, and deviceId are required",
-		})
-		return
-	}
-
-	// Get access token for the account
-	accountsMutex.RLock()
-	token, exists := accountTokens[req.AccountID]
-	accountsMutex.RUnlock()
-
-	if !exists {
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Mode == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Account not found or not authenticated",
+			"error":   "accountId, installationId, gatewaySerial, deviceId, and mode are required",
 		})
 		return
 	}
 
-	// Build Viessmann API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/heating.heater.fanRing/commands/setActive",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.noise.reduction.operating.programs.active",
+		Command:        "setMode",
+		Params:         map[string]interface{}{"mode": req.Mode},
+	})
+}
 
-	// Prepare request body
-	requestBody := map[string]bool{
-		"active": req.Active,
-	}
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
+func fanRingToggleHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Make API call
-	client := &http.Client{Timeout: 30 * time.Second}
-	httpReq, err := NewRequest(http.MethodPost, url, strings.NewReader(string(jsonBody)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
+	var req struct {
+		AccountID      string `json:"accountId"`
+		InstallationID string `json:"installationId"`
+		GatewaySerial  string `json:"gatewaySerial"`
+		DeviceID       string `json:"deviceId"`
+		Active         bool   `json:"active"`
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(httpReq)
-	if err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Failed to call Viessmann API: " + err.Error(),
+			"error":   "Invalid request: " + err.Error(),
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("Viessmann API error: status=%d, body=%s", resp.StatusCode, string(bodyBytes))
+	// Validate required fields
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   fmt.Sprintf("Viessmann API returned status %d: %s", resp.StatusCode, string(bodyBytes)),
+			"error":   "accountId, installationId, gatewaySerial, and deviceId are required",
 		})
 		return
 	}
 
-	modeStr := "ein"
-	if !req.Active {
-		modeStr = "aus"
-	}
-	log.Printf("Fan ring heating turned '%s' for device %s (account: %s)", modeStr, req.DeviceID, req.AccountID)
-
-	// Clear features cache to force refresh
-	featuresCacheMutex.Lock()
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "heating.heater.fanRing",
+		Command:        "setActive",
+		Params:         map[string]interface{}{"active": req.Active},
 	})
 }
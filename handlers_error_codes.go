@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// errorCodesHandler handles GET /api/error-codes
+// Parameters: code (optional, single code), model (ModelID for model-specific texts), lang (de, en; default de)
+func errorCodesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	lang := q.Get("lang")
+	if lang == "" {
+		lang = errorCodeDefaultLanguage
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if code := q.Get("code"); code != "" {
+		json.NewEncoder(w).Encode(lookupErrorCode(code, q.Get("model"), lang))
+		return
+	}
+
+	codes := ListErrorCodes(q.Get("model"), lang)
+	catalogue := getErrorCodeCatalogue()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"lang":          lang,
+		"count":         len(codes),
+		"userOverrides": catalogue.userFile,
+		"codes":         codes,
+	})
+}
+
+// errorCodesReloadHandler handles POST /api/error-codes/reload
+// Reloads the user override file (error_codes.json in the config directory)
+func errorCodesReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := ReloadErrorCodes(); err != nil {
+		log.Printf("Error reloading error codes: %v", err)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	catalogue := getErrorCodeCatalogue()
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":       true,
+		"userOverrides": catalogue.userFile,
+		"userCodes":     len(catalogue.user.Codes),
+		"userModels":    len(catalogue.user.Models),
+	})
+}
+
+// unknownErrorCodesHandler handles GET /api/error-codes/unknown
+// Lists the codes of archived events that are missing in the catalogue
+func unknownErrorCodesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	codes, err := GetUnknownErrorCodes()
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	stillUnknown := 0
+	for _, c := range codes {
+		if !c.Known {
+			stillUnknown++
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"count":   len(codes),
+		"unknown": stillUnknown,
+		"codes":   codes,
+	})
+}
+
+// unknownErrorCodesExportHandler handles GET /api/error-codes/unknown/export
+// Downloads the still unknown codes in the format of error_codes.json
+func unknownErrorCodesExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	codes, err := GetUnknownErrorCodes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="error_codes.json"`)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(exportUnknownErrorCodes(codes))
+}
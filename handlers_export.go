@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// eventExportColumn maps an exported column to an Event field
+type eventExportColumn struct {
+	exportColumn
+	value func(e *Event) interface{}
+}
+
+// eventExportColumns are the columns of /api/export/events, named like the JSON fields of Event.
+// body and raw are only exported when requested explicitly. Timestamps are exported exactly as
+// received from the API, they are part of the event hash and a re-import has to reproduce it.
+var eventExportColumns = []eventExportColumn{
+	{exportColumn{"eventTimestamp", exportString}, func(e *Event) interface{} { return e.EventTimestamp }},
+	{exportColumn{"createdAt", exportString}, func(e *Event) interface{} { return e.CreatedAt }},
+	{exportColumn{"eventType", exportString}, func(e *Event) interface{} { return e.EventType }},
+	{exportColumn{"installationId", exportString}, func(e *Event) interface{} { return e.InstallationID }},
+	{exportColumn{"gatewaySerial", exportString}, func(e *Event) interface{} { return e.GatewaySerial }},
+	{exportColumn{"deviceId", exportString}, func(e *Event) interface{} { return e.DeviceID }},
+	{exportColumn{"modelId", exportString}, func(e *Event) interface{} { return e.ModelID }},
+	{exportColumn{"errorCode", exportString}, func(e *Event) interface{} { return e.ErrorCode }},
+	{exportColumn{"errorDescription", exportString}, func(e *Event) interface{} { return e.ErrorDescription }},
+	{exportColumn{"humanReadable", exportString}, func(e *Event) interface{} { return e.HumanReadable }},
+	{exportColumn{"codeCategory", exportString}, func(e *Event) interface{} { return e.CodeCategory }},
+	{exportColumn{"severity", exportString}, func(e *Event) interface{} { return e.Severity }},
+	{exportColumn{"active", exportBool}, func(e *Event) interface{} {
+		if e.Active == nil {
+			return nil
+		}
+		return *e.Active
+	}},
+	{exportColumn{"featureName", exportString}, func(e *Event) interface{} { return e.FeatureName }},
+	{exportColumn{"featureValue", exportString}, func(e *Event) interface{} { return e.FeatureValue }},
+	{exportColumn{"accountId", exportString}, func(e *Event) interface{} { return e.AccountID }},
+	{exportColumn{"accountName", exportString}, func(e *Event) interface{} { return e.AccountName }},
+	{exportColumn{"body", exportString}, func(e *Event) interface{} {
+		if e.Body == nil {
+			return nil
+		}
+		b, _ := json.Marshal(e.Body)
+		return string(b)
+	}},
+	{exportColumn{"raw", exportString}, func(e *Event) interface{} { return e.Raw }},
+}
+
+// temperatureExportColumns returns all fields of TemperatureSnapshot by their JSON name
+func temperatureExportColumns() []exportColumn {
+	var columns []exportColumn
+	t := reflect.TypeOf(TemperatureSnapshot{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		typ := field.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		kind := exportString
+		switch {
+		case typ == reflect.TypeOf(time.Time{}):
+			kind = exportTime
+		case typ.Kind() == reflect.Float64:
+			kind = exportFloat
+		case typ.Kind() == reflect.Int:
+			kind = exportInt
+		case typ.Kind() == reflect.Bool:
+			kind = exportBool
+		}
+		columns = append(columns, exportColumn{Name: name, Kind: kind})
+	}
+	return columns
+}
+
+func writeExportError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error":   err.Error(),
+	})
+}
+
+// startExport sets the download headers and creates the writer for the requested format
+func startExport(w http.ResponseWriter, r *http.Request, name string, columns []exportColumn) (exportWriter, error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	if format != ExportFormatCSV && format != ExportFormatJSONL && format != ExportFormatParquet {
+		return nil, fmt.Errorf("invalid format %q (csv, jsonl or parquet)", format)
+	}
+
+	contentType, ext := exportContentType(format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().Format("20060102-150405"), ext))
+	return newExportWriter(format, w, columns)
+}
+
+// flushExport sends the written rows to the client
+func flushExport(w http.ResponseWriter, ew exportWriter) error {
+	if err := ew.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// exportEventsHandler handles GET /api/export/events
+// Streams archived events as CSV, JSONL or Parquet. Filters are the same as for /api/events.
+func exportEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !dbInitialized {
+		writeExportError(w, http.StatusBadRequest, fmt.Errorf("event archive is not enabled"))
+		return
+	}
+
+	q, err := parseEventQuery(r)
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Oldest first unless order=desc is given
+	if r.URL.Query().Get("order") == "" {
+		q.Ascending = true
+	}
+	q.Limit = exportBatchSize
+	q.Cursor = ""
+
+	available := make([]exportColumn, 0, len(eventExportColumns))
+	byName := make(map[string]eventExportColumn, len(eventExportColumns))
+	for _, c := range eventExportColumns {
+		if r.URL.Query().Get("columns") != "" || (c.Name != "body" && c.Name != "raw") {
+			available = append(available, c.exportColumn)
+		}
+		byName[c.Name] = c
+	}
+	columns, err := selectExportColumns(available, r.URL.Query().Get("columns"))
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ew, err := startExport(w, r, "events", columns)
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Headers are sent at this point, errors can only abort the stream
+	rows := 0
+	values := make([]interface{}, len(columns))
+	for {
+		page, err := QueryEventsFromDB(q)
+		if err != nil {
+			log.Printf("Event export aborted: %v", err)
+			return
+		}
+		for i := range page.Events {
+			for j, c := range columns {
+				values[j] = byName[c.Name].value(&page.Events[i])
+			}
+			if err := ew.WriteRow(values); err != nil {
+				log.Printf("Event export aborted: %v", err)
+				return
+			}
+			rows++
+		}
+		if err := flushExport(w, ew); err != nil {
+			log.Printf("Event export aborted: %v", err)
+			return
+		}
+		if !page.HasMore {
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+
+	if err := ew.Close(); err != nil {
+		log.Printf("Event export aborted: %v", err)
+		return
+	}
+	log.Printf("Exported %d events", rows)
+}
+
+// exportTemperatureHandler handles GET /api/export/temperature
+// Streams temperature snapshots as CSV, JSONL or Parquet.
+// Parameters: installationId (comma-separated, optional), gatewayId, deviceId, hours or startTime/endTime, columns, format
+func exportTemperatureHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !dbInitialized {
+		writeExportError(w, http.StatusBadRequest, fmt.Errorf("database not initialized"))
+		return
+	}
+
+	startTime, endTime, err := parseTemperatureTimeRange(r)
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := TemperatureExportFilter{
+		InstallationIDs: splitQueryList(r.URL.Query().Get("installationId")),
+		GatewayID:       r.URL.Query().Get("gatewayId"),
+		DeviceID:        r.URL.Query().Get("deviceId"),
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+
+	columns, err := selectExportColumns(temperatureExportColumns(), r.URL.Query().Get("columns"))
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ew, err := startExport(w, r, "temperature", columns)
+	if err != nil {
+		writeExportError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Headers are sent at this point, errors can only abort the stream
+	rows := 0
+	err = ExportTemperatureSnapshots(filter, columns, func(values []interface{}) error {
+		if err := ew.WriteRow(values); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportBatchSize == 0 {
+			return flushExport(w, ew)
+		}
+		return nil
+	})
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		log.Printf("Temperature export aborted: %v", err)
+		return
+	}
+	log.Printf("Exported %d temperature snapshots", rows)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// faultEpisodeFilterFromRequest reads installationId, gatewaySerial, deviceId, errorCode (comma separated),
+// openOnly, limit and the time range (days, hours or startTime/endTime; default: last 30 days)
+func faultEpisodeFilterFromRequest(r *http.Request) (FaultEpisodeFilter, error) {
+	q := r.URL.Query()
+	filter := FaultEpisodeFilter{
+		InstallationID: q.Get("installationId"),
+		GatewaySerial:  q.Get("gatewaySerial"),
+		DeviceID:       q.Get("deviceId"),
+		OpenOnly:       q.Get("openOnly") == "true",
+		Limit:          5000,
+	}
+	for _, code := range strings.Split(q.Get("errorCode"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			filter.ErrorCodes = append(filter.ErrorCodes, code)
+		}
+	}
+	if parsedLimit, err := strconv.Atoi(q.Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 50000 {
+		filter.Limit = parsedLimit
+	}
+
+	switch {
+	case q.Get("days") != "":
+		days, err := strconv.Atoi(q.Get("days"))
+		if err != nil || days < 1 || days > 3650 {
+			return filter, fmt.Errorf("Invalid days parameter (must be 1-3650)")
+		}
+		filter.EndTime = time.Now().UTC()
+		filter.StartTime = filter.EndTime.AddDate(0, 0, -days)
+	case q.Get("hours") != "" || q.Get("startTime") != "":
+		startTime, endTime, err := parseTemperatureTimeRange(r)
+		if err != nil {
+			return filter, err
+		}
+		filter.StartTime, filter.EndTime = startTime, endTime
+	default:
+		filter.EndTime = time.Now().UTC()
+		filter.StartTime = filter.EndTime.AddDate(0, 0, -30)
+	}
+	return filter, nil
+}
+
+// faultEpisodesHandler handles GET /api/fault-episodes
+// Returns the fault episodes overlapping the time range, oldest first
+func faultEpisodesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := faultEpisodeFilterFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	episodes, err := GetFaultEpisodes(filter)
+	if err != nil {
+		log.Printf("Error fetching fault episodes: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch fault episodes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if episodes == nil {
+		episodes = []FaultEpisode{}
+	}
+
+	open := 0
+	for _, ep := range episodes {
+		if ep.Open {
+			open++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"startTime":    filter.StartTime.Format(time.RFC3339),
+		"endTime":      filter.EndTime.Format(time.RFC3339),
+		"count":        len(episodes),
+		"openEpisodes": open,
+		"episodes":     episodes,
+	})
+}
+
+// faultEpisodeStatsHandler handles GET /api/fault-episodes/stats
+// Returns counts, durations, MTBF and the episode history per device and error code
+func faultEpisodeStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := faultEpisodeFilterFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := GetFaultEpisodeStats(filter)
+	if err != nil {
+		log.Printf("Error fetching fault episode stats: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch fault episode stats: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"startTime": filter.StartTime.Format(time.RFC3339),
+		"endTime":   filter.EndTime.Format(time.RFC3339),
+		"count":     len(stats),
+		"codes":     stats,
+	})
+}
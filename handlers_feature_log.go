@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// featureLogSettingsGetHandler handles GET /api/feature-log/settings
+func featureLogSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	settings, err := GetFeatureLogSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Feature values are collected by the temperature logging job
+	temperatureLogging := false
+	if tempSettings, err := GetTemperatureLogSettings(); err == nil {
+		temperatureLogging = tempSettings.Enabled
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"settings":           settings,
+		"databaseEnabled":    dbInitialized,
+		"temperatureLogging": temperatureLogging,
+	})
+}
+
+// featureLogSettingsSetHandler handles POST /api/feature-log/settings/set
+func featureLogSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings FeatureLogSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	applyFeatureLogDefaults(&settings)
+	if err := validateFeatureLogSettings(&settings); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := SetFeatureLogSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Feature log settings updated: %d rule(s), enabled=%v", len(settings.Rules), settings.Enabled)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Feature log settings updated successfully",
+	})
+}
+
+// featureSeriesFilterFromRequest reads installationId, gatewayId, deviceId, feature (glob) and property
+func featureSeriesFilterFromRequest(r *http.Request) FeatureSeriesFilter {
+	q := r.URL.Query()
+	return FeatureSeriesFilter{
+		InstallationID: q.Get("installationId"),
+		GatewayID:      q.Get("gatewayId"),
+		DeviceID:       q.Get("deviceId"),
+		Feature:        q.Get("feature"),
+		Property:       q.Get("property"),
+	}
+}
+
+// featureLogSeriesHandler handles GET /api/feature-log/series
+// Lists the logged series. Parameters (all optional): installationId, gatewayId, deviceId, feature (glob), property
+func featureLogSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	series, err := GetFeatureSeries(featureSeriesFilterFromRequest(r))
+	if err != nil {
+		log.Printf("Error fetching feature series: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch series: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if series == nil {
+		series = []FeatureSeries{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"count":  len(series),
+		"series": series,
+	})
+}
+
+// featureLogDataHandler handles GET /api/feature-log/data
+// Returns the samples of all series matching the filter, ready for charting.
+// Parameters: installationId, feature (glob), gatewayId, deviceId, property (optional), hours or startTime/endTime, limit (per series)
+func featureLogDataHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter := featureSeriesFilterFromRequest(r)
+	if filter.InstallationID == "" {
+		http.Error(w, "installationId parameter is required", http.StatusBadRequest)
+		return
+	}
+	if filter.Feature == "" {
+		http.Error(w, "feature parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	startTime, endTime, err := parseTemperatureTimeRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := 50000
+	if parsedLimit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 100000 {
+		limit = parsedLimit
+	}
+
+	series, err := GetFeatureSamples(filter, startTime, endTime, limit)
+	if err != nil {
+		log.Printf("Error fetching feature samples: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"installationId": filter.InstallationID,
+		"feature":        filter.Feature,
+		"startTime":      startTime.Format(time.RFC3339),
+		"endTime":        endTime.Format(time.RFC3339),
+		"count":          len(series),
+		"limit":          limit,
+		"series":         series,
+	})
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// heatingCurveAnalysisHandler analyses the logged data of a heating circuit and suggests a curve
+// GET /api/heating-curve/analysis?accountId=...&installationId=...&gatewaySerial=...&deviceId=...&circuit=0&from=YYYY-MM-DD&to=YYYY-MM-DD&targetRoomTemp=21&tolerance=1
+func heatingCurveAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	req := HeatingCurveAnalysisRequest{
+		AccountID:      q.Get("accountId"),
+		InstallationID: q.Get("installationId"),
+		GatewaySerial:  q.Get("gatewaySerial"),
+		DeviceID:       q.Get("deviceId"),
+	}
+	if req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Missing required parameters: installationId, gatewaySerial, deviceId",
+		})
+		return
+	}
+
+	if circuit := q.Get("circuit"); circuit != "" {
+		c, err := strconv.Atoi(circuit)
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid circuit",
+			})
+			return
+		}
+		req.Circuit = c
+	}
+	for name, target := range map[string]*float64{"targetRoomTemp": &req.TargetRoomTemp, "tolerance": &req.Tolerance} {
+		if v := q.Get(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"success": false,
+					"error":   "Invalid " + name,
+				})
+				return
+			}
+			*target = f
+		}
+	}
+
+	// Default: the last 14 days
+	start, end, err := parseLocalDateRange(q.Get("from"), q.Get("to"), -14)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	req.Start, req.End = start, end
+
+	analysis, err := AnalyzeHeatingCurve(req)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"analysis": analysis,
+	})
+}
+
+// heatingCurveApplyHandler sets a (suggested) curve through the setCurve command
+// POST /api/heating-curve/apply
+func heatingCurveApplyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		AccountID      string   `json:"accountId"`
+		InstallationID string   `json:"installationId"`
+		GatewaySerial  string   `json:"gatewaySerial"`
+		DeviceID       string   `json:"deviceId"`
+		Circuit        int      `json:"circuit"`
+		Slope          *float64 `json:"slope"`
+		Shift          *float64 `json:"shift"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if req.AccountID == "" || req.InstallationID == "" || req.GatewaySerial == "" || req.DeviceID == "" || req.Slope == nil || req.Shift == nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "accountId, installationId, gatewaySerial, deviceId, slope, shift are required",
+		})
+		return
+	}
+
+	curve := HeatingCurveParams{Slope: *req.Slope, Shift: *req.Shift}
+	if err := applyHeatingCurve(req.AccountID, req.InstallationID, req.GatewaySerial, req.DeviceID, req.Circuit, curve); err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"curve":   curve,
+	})
+}
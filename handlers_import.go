@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// importHandler handles POST /api/import
+// Imports a CSV or JSONL export of events or temperature snapshots. The file is sent either as
+// multipart form field "file" or as raw request body. Optional parameters: type, format.
+func importHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !dbInitialized {
+		writeExportError(w, http.StatusBadRequest, fmt.Errorf("database not initialized, enable event archive or temperature logging first"))
+		return
+	}
+
+	var body io.Reader = r.Body
+	filename := r.URL.Query().Get("filename")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		// Stream the file part instead of buffering the whole form
+		mr, err := r.MultipartReader()
+		if err != nil {
+			writeExportError(w, http.StatusBadRequest, err)
+			return
+		}
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				writeExportError(w, http.StatusBadRequest, fmt.Errorf("missing form field \"file\""))
+				return
+			}
+			if part.FormName() == "file" {
+				body = part
+				filename = part.FileName()
+				break
+			}
+		}
+	}
+
+	result, err := ImportFile(body, filename, r.URL.Query().Get("type"), r.URL.Query().Get("format"))
+	if err != nil {
+		log.Printf("Import failed: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+			"result":  result,
+		})
+		return
+	}
+
+	log.Printf("Imported %s from %s: %d inserted, %d skipped, %d rejected", result.Type, result.Format, result.Inserted, result.Skipped, result.Rejected)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"result":  result,
+	})
+}
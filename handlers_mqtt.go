@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MQTTInstallationInfo lists an installation for the per-installation MQTT configuration
+type MQTTInstallationInfo struct {
+	InstallationID string `json:"installationId"`
+	Description    string `json:"description"`
+	AccountID      string `json:"accountId"`
+	AccountName    string `json:"accountName"`
+	MQTTInstallationSettings
+}
+
+// mqttSettingsGetHandler handles GET /api/mqtt/settings
+func mqttSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetMQTTSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Known installations of all active accounts
+	installations := make([]MQTTInstallationInfo, 0)
+	seen := make(map[string]bool)
+	activeAccounts, _ := GetActiveAccounts()
+	for _, account := range activeAccounts {
+		token, err := ensureAccountAuthenticated(account)
+		if err != nil {
+			log.Printf("Warning: Failed to authenticate account %s for MQTT settings: %v\n", account.Email, err)
+			continue
+		}
+		for _, installationID := range token.InstallationIDs {
+			if seen[installationID] {
+				continue
+			}
+			seen[installationID] = true
+
+			info := MQTTInstallationInfo{
+				InstallationID: installationID,
+				AccountID:      account.ID,
+				AccountName:    account.Name,
+			}
+			if installation, ok := token.Installations[installationID]; ok {
+				info.Description = installation.Description
+			}
+			if instSettings, ok := settings.Installations[installationID]; ok {
+				info.MQTTInstallationSettings = *instSettings
+				if instSettings.AccountID != "" {
+					info.AccountID = instSettings.AccountID
+				}
+			}
+			installations = append(installations, info)
+		}
+	}
+	sort.Slice(installations, func(i, j int) bool { return installations[i].InstallationID < installations[j].InstallationID })
+
+	passwordSet := settings.Password != ""
+	settings.Password = ""
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"settings":      settings,
+		"passwordSet":   passwordSet,
+		"installations": installations,
+		"status":        GetMQTTStatus(),
+	})
+}
+
+// mqttSettingsSetHandler handles POST /api/mqtt/settings/set
+func mqttSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings MQTTSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if settings.Enabled && settings.BrokerURL == "" {
+		http.Error(w, "BrokerURL is required", http.StatusBadRequest)
+		return
+	}
+	if settings.BrokerURL != "" && !strings.Contains(settings.BrokerURL, "://") {
+		settings.BrokerURL = "tcp://" + settings.BrokerURL
+	}
+	if strings.ContainsAny(settings.TopicPrefix, "+#") {
+		http.Error(w, "TopicPrefix must not contain wildcards", http.StatusBadRequest)
+		return
+	}
+
+	oldSettings, err := GetMQTTSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The password is never sent to the browser, keep the stored one if none was entered
+	if settings.Password == "" && settings.Username == oldSettings.Username {
+		settings.Password = oldSettings.Password
+	}
+	applyMQTTDefaults(&settings)
+
+	if err := SetMQTTSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Println("MQTT settings changed, restarting publisher...")
+	go func() {
+		if err := RestartMQTT(); err != nil {
+			log.Printf("Error restarting MQTT publisher: %v", err)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "MQTT settings updated successfully",
+	})
+}
+
+// mqttStatusHandler handles GET /api/mqtt/status
+func mqttStatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(GetMQTTStatus())
+}
+
+// mqttPublishHandler handles POST /api/mqtt/publish and publishes all values immediately
+func mqttPublishHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !IsMQTTConnected() {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "MQTT is not connected",
+		})
+		return
+	}
+
+	go mqttPublishJob()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Browser based OAuth onboarding: the user logs in on the Viessmann login page,
+// the authorization code comes back to /api/oauth/callback and only the tokens are stored.
+// The redirect URL has to be registered for the client ID in the Viessmann developer portal.
+
+const oauthStateLifetime = 10 * time.Minute
+
+// oauthPendingLogin is a started but not yet completed browser login
+type oauthPendingLogin struct {
+	AccountID    string
+	Name         string
+	Email        string
+	ClientID     string
+	Active       bool
+	CodeVerifier string
+	RedirectURL  string
+	Created      time.Time
+}
+
+var (
+	oauthPending      = make(map[string]*oauthPendingLogin) // Key: state
+	oauthPendingMutex sync.Mutex
+)
+
+// oauthRedirectURL returns the callback URL for the browser login.
+// VICARE_OAUTH_REDIRECT_URL overrides the URL derived from the request (e.g. behind a reverse proxy).
+func oauthRedirectURL(r *http.Request) string {
+	if redirect := os.Getenv("VICARE_OAUTH_REDIRECT_URL"); redirect != "" {
+		return redirect
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	host := r.Host
+	if fwdHost := r.Header.Get("X-Forwarded-Host"); fwdHost != "" {
+		host = fwdHost
+	}
+
+	return scheme + "://" + host + "/api/oauth/callback"
+}
+
+// cleanupOAuthPending removes expired login attempts. Must be called with oauthPendingMutex held.
+func cleanupOAuthPending() {
+	for state, pending := range oauthPending {
+		if time.Since(pending.Created) > oauthStateLifetime {
+			delete(oauthPending, state)
+		}
+	}
+}
+
+// oauthStartHandler starts the browser login and returns the Viessmann authorize URL
+func oauthStartHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req AccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AccountActionResponse{
+			Success: false,
+			Error:   "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	// Re-link an existing account (e.g. after the refresh token expired)
+	if req.ID != "" {
+		existing, err := GetAccount(req.ID)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(AccountActionResponse{
+				Success: false,
+				Error:   "Account not found: " + err.Error(),
+			})
+			return
+		}
+		req.Name = existing.Name
+		req.Email = existing.Email
+		req.Active = existing.Active
+		if req.ClientID == "" {
+			req.ClientID = existing.ClientID
+		}
+	}
+
+	if req.ClientID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AccountActionResponse{
+			Success: false,
+			Error:   "Client ID is required",
+		})
+		return
+	}
+
+	stateBytes := make([]byte, 16)
+	rand.Read(stateBytes)
+	state := hex.EncodeToString(stateBytes)
+
+	pending := &oauthPendingLogin{
+		AccountID:    req.ID,
+		Name:         req.Name,
+		Email:        req.Email,
+		ClientID:     req.ClientID,
+		Active:       req.Active || req.ID == "",
+		CodeVerifier: generateCodeVerifier(),
+		RedirectURL:  oauthRedirectURL(r),
+		Created:      time.Now(),
+	}
+
+	oauthPendingMutex.Lock()
+	cleanupOAuthPending()
+	oauthPending[state] = pending
+	oauthPendingMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"authorizeUrl": buildAuthorizeURL(pending.ClientID, pending.RedirectURL, generateCodeChallenge(pending.CodeVerifier), state),
+		"redirectUrl":  pending.RedirectURL,
+	})
+}
+
+// redirectToAccountsPage sends the browser back to the accounts page with the login result
+func redirectToAccountsPage(w http.ResponseWriter, r *http.Request, errMsg string) {
+	target := "/accounts?oauth=success"
+	if errMsg != "" {
+		target = "/accounts?oauth=error&message=" + url.QueryEscape(errMsg)
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// oauthCallbackHandler receives the authorization code and creates or updates the account
+func oauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		log.Printf("OAuth login failed: %s %s\n", errCode, query.Get("error_description"))
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.cancelled", errCode))
+		return
+	}
+
+	oauthPendingMutex.Lock()
+	cleanupOAuthPending()
+	pending, ok := oauthPending[query.Get("state")]
+	delete(oauthPending, query.Get("state"))
+	oauthPendingMutex.Unlock()
+
+	if !ok {
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.unknown_state"))
+		return
+	}
+
+	tokenResp, err := exchangeAuthorizationCode(pending.ClientID, pending.RedirectURL, query.Get("code"), pending.CodeVerifier)
+	if err != nil {
+		log.Printf("OAuth code exchange failed: %v\n", err)
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.token_failed", err))
+		return
+	}
+
+	installationIDs, installations, err := fetchInstallationIDsForAccount(tokenResp.AccessToken)
+	if err != nil {
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.installations_failed", err))
+		return
+	}
+	if len(installationIDs) == 0 {
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.no_installations"))
+		return
+	}
+
+	account, err := saveOAuthAccount(pending)
+	if err != nil {
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.save_account_failed", err))
+		return
+	}
+
+	token := &AccountToken{
+		AccessToken:     tokenResp.AccessToken,
+		RefreshToken:    tokenResp.RefreshToken,
+		TokenExpiry:     time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+		InstallationIDs: installationIDs,
+		Installations:   installations,
+		LastLogin:       time.Now(),
+	}
+
+	accountsMutex.Lock()
+	accountTokens[account.ID] = token
+	accountsMutex.Unlock()
+
+	err = SaveAccountToken(account.ID, &StoredToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenExpiry:  token.TokenExpiry,
+		LastLogin:    token.LastLogin,
+	})
+	if err != nil {
+		log.Printf("Could not persist token for account %s: %v\n", account.ID, err)
+		redirectToAccountsPage(w, r, translate(requestLanguage(r), "oauth.save_token_failed", err))
+		return
+	}
+
+	// Clear cache to force refresh with new account
+	fetchMutex.Lock()
+	eventsCache = nil
+	lastFetchTime = time.Time{}
+	fetchMutex.Unlock()
+
+	log.Printf("Account linked via browser login: %s, found %d installations\n", account.ID, len(installationIDs))
+	redirectToAccountsPage(w, r, "")
+}
+
+// saveOAuthAccount creates a new account or updates the account that is re-linked
+func saveOAuthAccount(pending *oauthPendingLogin) (*Account, error) {
+	if pending.AccountID != "" {
+		account, err := GetAccount(pending.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		account.ClientID = pending.ClientID
+		account.ClientSecret = defaultClientSecret
+		if err := UpdateAccount(account); err != nil {
+			return nil, err
+		}
+		return account, nil
+	}
+
+	id := strings.TrimSpace(pending.Email)
+	if id == "" {
+		idBytes := make([]byte, 4)
+		rand.Read(idBytes)
+		id = "oauth-" + hex.EncodeToString(idBytes)
+	}
+
+	if _, err := GetAccount(id); err == nil {
+		return nil, fmt.Errorf("account %s already exists", id)
+	}
+
+	account := &Account{
+		ID:           id,
+		Name:         pending.Name,
+		Email:        pending.Email,
+		ClientID:     pending.ClientID,
+		ClientSecret: defaultClientSecret,
+		Active:       pending.Active,
+		AuthMethod:   AuthMethodOAuth,
+	}
+	if account.Name == "" {
+		account.Name = id
+	}
+
+	if err := AddAccount(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// pvSurplusSettingsGetHandler handles GET /api/pv-surplus/settings
+func pvSurplusSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetPVSurplusSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// pvSurplusSettingsSetHandler handles POST /api/pv-surplus/settings/set
+func pvSurplusSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings PVSurplusSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	applyPVSurplusDefaults(&settings)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := validatePVSurplusSettings(&settings); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// An active boost is reverted with the old settings if it would no longer be controlled
+	if old, err := GetPVSurplusSettings(); err == nil && old.Enabled {
+		changed := !settings.Enabled || old.AccountID != settings.AccountID || old.InstallationID != settings.InstallationID ||
+			old.GatewaySerial != settings.GatewaySerial || old.DeviceID != settings.DeviceID || old.DHWAction != settings.DHWAction ||
+			old.HeatingCircuit != settings.HeatingCircuit || (old.HeatingBoostKelvin > 0) != (settings.HeatingBoostKelvin > 0)
+		if changed {
+			if err := StopPVSurplusBoost(old, "automation disabled or changed"); err != nil {
+				log.Printf("Failed to end PV surplus boost: %v", err)
+			}
+		}
+	}
+
+	if err := SetPVSurplusSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := RestartPVSurplusScheduler(); err != nil {
+		log.Printf("Failed to restart PV surplus scheduler: %v", err)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "PV surplus settings updated successfully",
+	})
+}
+
+// pvSurplusStatusHandler handles GET /api/pv-surplus/status
+func pvSurplusStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := GetPVSurplusStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// pvSurplusDecisionsHandler handles GET /api/pv-surplus/decisions?limit=100&changes=true
+// changes=true returns only start, stop, blocked and error decisions
+func pvSurplusDecisionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	limit := 100
+	if parsedLimit, err := strconv.Atoi(q.Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 10000 {
+		limit = parsedLimit
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	decisions, err := GetPVSurplusDecisions(limit, q.Get("changes") == "true")
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"count":     len(decisions),
+		"decisions": decisions,
+	})
+}
+
+// pvSurplusStopHandler handles POST /api/pv-surplus/stop
+// Ends an active boost immediately, the automation may start again after the pause
+func pvSurplusStopHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	settings, err := GetPVSurplusSettings()
+	if err == nil {
+		err = StopPVSurplusBoost(settings, "stopped manually")
+	}
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
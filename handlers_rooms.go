@@ -12,10 +12,11 @@ import (
 // Room represents aggregated data for a room from RoomControl
 type Room struct {
 	RoomID            int                    `json:"roomId"`
-	RoomName          string                 `json:"roomName"`   // User-defined name or default "Raum X"
-	SystemName        string                 `json:"systemName"` // Name from rooms.N.properties.name.value
-	RoomType          string                 `json:"roomType"`   // Type from rooms.N.properties.type.value (hallway, livingroom, etc.)
-	RoomTypeDE        string                 `json:"roomTypeDE"` // German translation of room type
+	RoomName          string                 `json:"roomName"`      // User-defined name or default "Raum X"
+	SystemName        string                 `json:"systemName"`    // Name from rooms.N.properties.name.value
+	RoomType          string                 `json:"roomType"`      // Type from rooms.N.properties.type.value (hallway, livingroom, etc.)
+	RoomTypeDE        string                 `json:"roomTypeDE"`    // German translation of room type
+	RoomTypeLabel     string                 `json:"roomTypeLabel"` // Room type in the language of the request
 	InstallationID    string                 `json:"installationId"`
 	AccountID         string                 `json:"accountId"`
 	GatewaySerial     string                 `json:"gatewaySerial"` // Required for API calls
@@ -57,21 +58,10 @@ type SetRoomTemperatureRequest struct {
 	TargetTemperature float64 `json:"targetTemperature"`
 }
 
-// translateRoomType translates English room types to German
-func translateRoomType(roomType string) string {
-	translations := map[string]string{
-		"hallway":    "Flur",
-		"livingroom": "Wohnzimmer",
-		"bathroom":   "Badezimmer",
-		"toilet":     "WC",
-		"kitchen":    "Küche",
-		"office":     "Büro",
-		"nursery":    "Kinderzimmer",
-		"bedroom":    "Schlafzimmer",
-		"other":      "Sonstiges",
-	}
-	if translated, ok := translations[roomType]; ok {
-		return translated
+// translateRoomType translates the room types of the API (hallway, livingroom, ...) into lang
+func translateRoomType(roomType, lang string) string {
+	if key := "room." + roomType; hasTranslation(key) {
+		return translate(lang, key)
 	}
 	return roomType
 }
@@ -122,7 +112,7 @@ func extractRoomData(installationID, accountID, gatewaySerial string, features [
 			if roomType, ok := f.Properties["type"].(map[string]interface{}); ok {
 				if typeVal, ok := roomType["value"].(string); ok {
 					room.RoomType = typeVal
-					room.RoomTypeDE = translateRoomType(typeVal)
+					room.RoomTypeDE = translateRoomType(typeVal, "de")
 				}
 			}
 			room.RawFeatures["base"] = f.Properties
@@ -259,6 +249,7 @@ func roomsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lang := requestLanguage(r)
 	var allRooms []Room
 	var installDesc string
 
@@ -301,6 +292,7 @@ func roomsHandler(w http.ResponseWriter, r *http.Request) {
 
 				// Apply user-defined room names
 				for i := range rooms {
+					rooms[i].RoomTypeLabel = translateRoomType(rooms[i].RoomType, lang)
 					roomKey := fmt.Sprintf("%s:%d", installationID, rooms[i].RoomID)
 					if account.RoomSettings != nil {
 						if settings, ok := account.RoomSettings[roomKey]; ok && settings.Name != "" {
@@ -346,7 +338,7 @@ func setRoomNameHandler(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Name must be between 1 and 40 characters",
+			"error":   translate(requestLanguage(r), "error.name_length"),
 		})
 		return
 	}
@@ -408,94 +400,13 @@ func setRoomTemperatureHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate temperature range (typical range for room temperature)
-	if req.TargetTemperature < 10 || req.TargetTemperature > 30 {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Temperature must be between 10°C and 30°C",
-		})
-		return
-	}
-
-	// Get account and ensure authenticated
-	account, err := GetAccount(req.AccountID)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found: " + err.Error(),
-		})
-		return
-	}
-
-	token, err := ensureAccountAuthenticated(account)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Authentication failed: " + err.Error(),
-		})
-		return
-	}
-
-	// Build API URL
-	// Format: /installations/{id}/gateways/{gateway}/devices/RoomControl-1/features/rooms.{roomId}.temperature.levels.normal.perceived/commands/setTemperature
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/RoomControl-1/features/rooms.%d.temperature.levels.normal.perceived/commands/setTemperature",
-		req.InstallationID, req.GatewaySerial, req.RoomID)
-
-	// Create request body
-	body := map[string]interface{}{
-		"targetTemperature": req.TargetTemperature,
-	}
-	bodyJSON, err := json.Marshal(body)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	// Make API request
-	apiReq, err := NewRequest("POST", url, strings.NewReader(string(bodyJSON)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	apiReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	apiReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(apiReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "API request failed: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("API returned status %d", resp.StatusCode),
-		})
-		return
-	}
-
-	log.Printf("Set room temperature for installation %s room %d to %.1f°C\n", req.InstallationID, req.RoomID, req.TargetTemperature)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       "RoomControl-1",
+		Feature:        fmt.Sprintf("rooms.%d.temperature.levels.normal.perceived", req.RoomID),
+		Command:        "setTemperature",
+		Params:         map[string]interface{}{"targetTemperature": req.TargetTemperature},
 	})
 }
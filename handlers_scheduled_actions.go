@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// scheduledActionsHandler handles GET /api/scheduled-actions
+func scheduledActionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	actions, err := GetScheduledActions()
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"count":    len(actions),
+		"timezone": time.Now().Format("MST -07:00"),
+		"actions":  actions,
+	})
+}
+
+// scheduledActionSaveHandler handles POST /api/scheduled-actions/create and /api/scheduled-actions/update
+func scheduledActionSaveHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var action ScheduledAction
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	isUpdate := r.URL.Path == "/api/scheduled-actions/update"
+	if isUpdate && action.ID == 0 {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "id is required",
+		})
+		return
+	}
+	if !isUpdate {
+		action.ID = 0
+	}
+
+	saved, err := SaveScheduledAction(&action)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	log.Printf("Scheduled action %d (%s) saved, next run: %v", saved.ID, saved.Name, derefString(saved.NextRun))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"action":  saved,
+	})
+}
+
+// scheduledActionDeleteHandler handles POST /api/scheduled-actions/delete
+func scheduledActionDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := DeleteScheduledAction(req.ID); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
+
+// scheduledActionRunHandler handles POST /api/scheduled-actions/run
+// Executes an action immediately, the schedule is not changed
+func scheduledActionRunHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	action, err := GetScheduledAction(req.ID)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	status, message := runScheduledAction(action, time.Time{}, true)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": status == scheduledRunSuccess,
+		"status":  status,
+		"error":   message,
+	})
+}
+
+// scheduledActionRunsHandler handles GET /api/scheduled-actions/runs?id=&limit=
+// Without id the newest runs of all actions are returned
+func scheduledActionRunsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	var actionID int64
+	if id := q.Get("id"); id != "" {
+		parsed, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid id", http.StatusBadRequest)
+			return
+		}
+		actionID = parsed
+	}
+	limit := 50
+	if parsedLimit, err := strconv.Atoi(q.Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+		limit = parsedLimit
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	runs, err := GetScheduledActionRuns(actionID, limit)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"count":   len(runs),
+		"runs":    runs,
+	})
+}
+
+// scheduledActionPreview is an upcoming run in the dry-run preview
+type scheduledActionPreview struct {
+	ActionID int64  `json:"actionId,omitempty"`
+	Name     string `json:"name"`
+	RunAt    string `json:"runAt"`
+	Feature  string `json:"feature"`
+	Command  string `json:"command"`
+}
+
+// scheduledActionPreviewHandler handles the dry run of scheduled actions (nothing is executed)
+// GET /api/scheduled-actions/preview?hours=48 lists the upcoming runs of all enabled actions.
+// POST /api/scheduled-actions/preview checks an unsaved action: schedule, next runs,
+// command and parameters against the device metadata and the gateway status.
+func scheduledActionPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	hours := 48
+	if parsedHours, err := strconv.Atoi(r.URL.Query().Get("hours")); err == nil && parsedHours > 0 && parsedHours <= 24*31 {
+		hours = parsedHours
+	}
+	now := time.Now()
+	until := now.Add(time.Duration(hours) * time.Hour)
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		actions, err := GetScheduledActions()
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		upcoming := []scheduledActionPreview{}
+		for i := range actions {
+			a := &actions[i]
+			if !a.Enabled || a.NextRun == nil {
+				continue
+			}
+			next, err := time.Parse(time.RFC3339, *a.NextRun)
+			if err != nil {
+				continue
+			}
+			for _, t := range a.upcomingRuns(next, until, 100) {
+				upcoming = append(upcoming, scheduledActionPreview{
+					ActionID: a.ID,
+					Name:     a.Name,
+					RunAt:    t.Format(time.RFC3339),
+					Feature:  a.Feature,
+					Command:  a.Command,
+				})
+			}
+		}
+		sort.Slice(upcoming, func(i, j int) bool { return upcoming[i].RunAt < upcoming[j].RunAt })
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":  true,
+			"hours":    hours,
+			"count":    len(upcoming),
+			"upcoming": upcoming,
+		})
+
+	case http.MethodPost:
+		var action ScheduledAction
+		if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := validateScheduledAction(&action); err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		runs := []string{}
+		for _, t := range action.upcomingRuns(now, until, 10) {
+			runs = append(runs, t.Format(time.RFC3339))
+		}
+
+		commandValid, commandError := true, ""
+		features, err := fetchFeaturesForAccount(action.AccountID, action.InstallationID, action.GatewaySerial, action.DeviceID)
+		if err == nil {
+			var cmd *FeatureCommand
+			if cmd, err = findFeatureCommand(features, action.Feature, action.Command); err == nil {
+				err = validateCommandParams(cmd, action.Params)
+			}
+		}
+		if err != nil {
+			commandValid, commandError = false, err.Error()
+			if apiErr := asAPIError(err); apiErr != nil {
+				commandError = apiErr.LocalizedMessage(requestLanguage(r))
+			}
+		}
+
+		gatewayOffline, offlineSince := lastGatewayStatus(action.GatewaySerial)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":        true,
+			"name":           action.Name,
+			"hours":          hours,
+			"runs":           runs,
+			"commandValid":   commandValid,
+			"commandError":   commandError,
+			"gatewayOffline": gatewayOffline,
+			"offlineSince":   offlineSince,
+		})
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return "-"
+	}
+	return *s
+}
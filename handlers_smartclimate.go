@@ -78,18 +78,10 @@ func categorizeDevice(deviceType, modelID string) string {
 	return "other"
 }
 
-// getCategoryDisplayName returns the German display name for a category
-func getCategoryDisplayName(category string) string {
-	names := map[string]string{
-		"climate_sensors":      "Klimasensoren",
-		"radiator_thermostats": "Heizkörper-Thermostate",
-		"floor_thermostats":    "Fußboden-Thermostate",
-		"repeaters":            "Repeater",
-		"room_control":         "Raumsteuerung",
-		"other":                "Sonstige Geräte",
-	}
-	if name, ok := names[category]; ok {
-		return name
+// getCategoryDisplayName returns the display name of a category in lang
+func getCategoryDisplayName(category, lang string) string {
+	if key := "category." + category; hasTranslation(key) {
+		return translate(lang, key)
 	}
 	return category
 }
@@ -352,7 +344,7 @@ func smartClimateDevicesHandler(w http.ResponseWriter, r *http.Request) {
 	for _, catKey := range categoryOrder {
 		if devices, ok := categoriesMap[catKey]; ok && len(devices) > 0 {
 			categories = append(categories, SmartClimateCategory{
-				Name:    getCategoryDisplayName(catKey),
+				Name:    getCategoryDisplayName(catKey, requestLanguage(r)),
 				Icon:    getCategoryIcon(catKey),
 				Devices: devices,
 			})
@@ -411,104 +403,14 @@ func trvSetTemperatureHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate temperature range (typical range for TRVs)
-	if req.Temperature < 5 || req.Temperature > 30 {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Temperature must be between 5°C and 30°C",
-		})
-		return
-	}
-
-	// Get account
-	account, err := GetAccount(req.AccountID)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found: " + err.Error(),
-		})
-		return
-	}
-
-	// Ensure authenticated
-	token, err := ensureAccountAuthenticated(account)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Authentication failed: " + err.Error(),
-		})
-		return
-	}
-
-	// Build API URL (ZigBee devices use v1 API)
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/trv.temperature/commands/setTargetTemperature",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	commandBody := map[string]interface{}{
-		"temperature": req.Temperature,
-	}
-
-	bodyBytes, err := json.Marshal(commandBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to marshal request: " + err.Error(),
-		})
-		return
-	}
-
-	// Create HTTP request
-	httpReq, err := NewRequest("POST", url, strings.NewReader(string(bodyBytes)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "API request failed: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("API returned status %d", resp.StatusCode),
-		})
-		return
-	}
-
-	// Invalidate features cache for this device
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	featuresCacheMutex.Lock()
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	log.Printf("Set TRV temperature for device %s to %.1f°C\n", req.DeviceID, req.Temperature)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "trv.temperature",
+		Command:        "setTargetTemperature",
+		Params:         map[string]interface{}{"temperature": req.Temperature},
 	})
 }
 
@@ -543,7 +445,7 @@ func deviceSetNameHandler(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "Name must be between 1 and 40 characters",
+			"error":   translate(requestLanguage(r), "error.name_length"),
 		})
 		return
 	}
@@ -615,84 +517,19 @@ func childLockToggleHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get account
-	account, err := GetAccount(req.AccountID)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found: " + err.Error(),
-		})
-		return
-	}
-
-	// Ensure authenticated
-	token, err := ensureAccountAuthenticated(account)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Authentication failed: " + err.Error(),
-		})
-		return
-	}
-
 	// Determine command based on desired state
 	command := "deactivate"
 	if req.Active {
 		command = "activate"
 	}
 
-	// Build API URL (ZigBee devices use v1 API)
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/trv.childLock/commands/%s",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, command)
-
-	// Create HTTP request (empty body for these commands)
-	httpReq, err := NewRequest("POST", url, strings.NewReader("{}"))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "API request failed: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("API returned status %d", resp.StatusCode),
-		})
-		return
-	}
-
-	// Invalidate features cache for this device
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	featuresCacheMutex.Lock()
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	log.Printf("Set child lock for device %s to: %v\n", req.DeviceID, req.Active)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "trv.childLock",
+		Command:        command,
+		Params:         map[string]interface{}{},
 	})
 }
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxTariffImportSize limits CSV imports (a year of quarter-hour prices is about 1.5 MB)
+const maxTariffImportSize = 5 << 20
+
+// tariffSettingsGetHandler handles GET /api/tariffs/settings
+func tariffSettingsGetHandler(w http.ResponseWriter, r *http.Request) {
+	settings, err := GetTariffSettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// tariffSettingsSetHandler handles POST /api/tariffs/settings/set
+// The whole tariff list is replaced, imported prices of removed tariffs are deleted
+func tariffSettingsSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var settings TariffSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	applyTariffDefaults(&settings)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := validateTariffSettings(&settings); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	old, _ := GetTariffSettings()
+	if err := SetTariffSettings(&settings); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if old != nil && dbInitialized {
+		kept := make(map[string]bool)
+		for _, tariff := range settings.Tariffs {
+			kept[tariff.ID] = true
+		}
+		for _, tariff := range old.Tariffs {
+			if !kept[tariff.ID] {
+				if err := DeleteTariffPrices(tariff.ID); err != nil {
+					log.Printf("Failed to delete prices of tariff %s: %v", tariff.ID, err)
+				}
+			}
+		}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Tariff settings updated successfully",
+		"tariffs": settings.Tariffs,
+	})
+}
+
+// tariffAssignment is the tariff of a device
+type tariffAssignment struct {
+	AccountID      string `json:"accountId"`
+	InstallationID string `json:"installationId"`
+	DeviceID       string `json:"deviceId"`
+	TariffID       string `json:"tariffId"` // Empty = flat ElectricityPrice
+}
+
+// tariffAssignmentsHandler handles GET /api/tariffs/assignments
+func tariffAssignmentsHandler(w http.ResponseWriter, r *http.Request) {
+	store, err := LoadAccounts()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	assignments := []tariffAssignment{}
+	for accountID, account := range store.Accounts {
+		for deviceKey, settings := range account.DeviceSettings {
+			if settings == nil || settings.TariffID == "" {
+				continue
+			}
+			// Device key is "<installationId>_<deviceId>"
+			sep := strings.LastIndex(deviceKey, "_")
+			if sep < 0 {
+				continue
+			}
+			assignments = append(assignments, tariffAssignment{
+				AccountID:      accountID,
+				InstallationID: deviceKey[:sep],
+				DeviceID:       deviceKey[sep+1:],
+				TariffID:       settings.TariffID,
+			})
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		a, b := assignments[i], assignments[j]
+		return a.AccountID+a.InstallationID+a.DeviceID < b.AccountID+b.InstallationID+b.DeviceID
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"assignments": assignments,
+	})
+}
+
+// tariffAssignHandler handles POST /api/tariffs/assign
+// An empty tariffId goes back to the flat electricity price of the device settings
+func tariffAssignHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req tariffAssignment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if req.AccountID == "" || req.InstallationID == "" || req.DeviceID == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "accountId, installationId, and deviceId are required",
+		})
+		return
+	}
+	if req.TariffID != "" {
+		if _, err := GetTariff(req.TariffID); err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	// Get or create device settings to preserve existing values
+	deviceKey := fmt.Sprintf("%s_%s", req.InstallationID, req.DeviceID)
+	settings, err := GetDeviceSettings(req.AccountID, deviceKey)
+	if err != nil {
+		settings = &DeviceSettings{}
+	}
+	settings.TariffID = req.TariffID
+	if err := SetDeviceSettings(req.AccountID, deviceKey, settings); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Failed to save settings: " + err.Error(),
+		})
+		return
+	}
+
+	log.Printf("Tariff of device %s (account: %s) set to %q", deviceKey, req.AccountID, req.TariffID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+	})
+}
+
+// tariffPricesHandler handles GET /api/tariffs/prices?tariffId=&from=YYYY-MM-DD&to=YYYY-MM-DD
+// Returns the imported prices and the effective price per hour (incl. surcharge and windows)
+func tariffPricesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	tariff, err := GetTariff(q.Get("tariffId"))
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	start, end, err := parseLocalDateRange(q.Get("from"), q.Get("to"), 2)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	prices, err := GetTariffPrices(tariff.ID, start, end)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	dbMutex.RLock()
+	pricer := newTariffPricerLocked(tariff, defaultElectricityPrice, start, end)
+	dbMutex.RUnlock()
+	hourly := []TariffPrice{}
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		hourly = append(hourly, TariffPrice{Start: t.Format(time.RFC3339), Price: math.Round(pricer.hourPrice(t)*10000) / 10000})
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"tariffId": tariff.ID,
+		"imported": prices,
+		"hourly":   hourly,
+	})
+}
+
+// tariffPricesImportHandler handles POST /api/tariffs/prices/import?tariffId=&unit=eur|ct|mwh
+// The body is a CSV file with start time and price per line
+func tariffPricesImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	tariff, err := GetTariff(q.Get("tariffId"))
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	slots, err := parseTariffPriceCSV(http.MaxBytesReader(w, r.Body, maxTariffImportSize), q.Get("unit"))
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	saveImportedTariffPrices(w, tariff, slots)
+}
+
+// tariffPricesPushHandler handles POST /api/tariffs/prices/push
+// For local scripts: {"tariffId": "...", "unit": "eur", "prices": [{"start": "...", "price": 0.25}]}
+func tariffPricesPushHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		TariffID string        `json:"tariffId"`
+		Unit     string        `json:"unit"`
+		Prices   []TariffPrice `json:"prices"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTariffImportSize)).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	tariff, err := GetTariff(req.TariffID)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	factor, ok := tariffPriceUnits[strings.ToLower(req.Unit)]
+	if !ok || len(req.Prices) == 0 {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "prices and a valid unit (eur, ct, mwh) are required",
+		})
+		return
+	}
+
+	slots := make([]tariffPriceSlot, 0, len(req.Prices))
+	for i, price := range req.Prices {
+		start, err := parseTariffTime(price.Start)
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   fmt.Sprintf("price %d: %v", i+1, err),
+			})
+			return
+		}
+		slots = append(slots, tariffPriceSlot{start: start, price: price.Price * factor})
+	}
+
+	saveImportedTariffPrices(w, tariff, slots)
+}
+
+// saveImportedTariffPrices stores the slots of an import or push and writes the response
+func saveImportedTariffPrices(w http.ResponseWriter, tariff *Tariff, slots []tariffPriceSlot) {
+	if err := SaveTariffPrices(tariff.ID, slots); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].start.Before(slots[j].start) })
+	log.Printf("Imported %d prices for tariff %s (%s)", len(slots), tariff.Name, tariff.ID)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"imported": len(slots),
+		"from":     slots[0].start.Format(time.RFC3339),
+		"to":       slots[len(slots)-1].start.Format(time.RFC3339),
+	})
+}
+
+// tariffCompareHandler handles GET /api/tariffs/compare
+// ?installationId=&gatewaySerial=&deviceId=&from=YYYY-MM-DD&to=YYYY-MM-DD&tariffs=id1,id2
+// Calculates the cost of the past consumption under each tariff and the flat device price
+func tariffCompareHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	installationID, gatewaySerial, deviceID := q.Get("installationId"), q.Get("gatewaySerial"), q.Get("deviceId")
+	if installationID == "" || gatewaySerial == "" || deviceID == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Missing required parameters: installationId, gatewaySerial, deviceId",
+		})
+		return
+	}
+
+	start, end, err := parseLocalDateRange(q.Get("from"), q.Get("to"), -30)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var tariffIDs []string
+	for _, id := range strings.Split(q.Get("tariffs"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			tariffIDs = append(tariffIDs, id)
+		}
+	}
+
+	comparison, err := CompareTariffs(installationID, gatewaySerial, deviceID, start, end.AddDate(0, 0, -1), tariffIDs)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"comparison": comparison,
+	})
+}
+
+// parseLocalDateRange parses the local days from/to (YYYY-MM-DD, inclusive) into [start, end).
+// Without from/to the range starts today and covers defaultDays days, negative values end today.
+func parseLocalDateRange(fromStr, toStr string, defaultDays int) (time.Time, time.Time, error) {
+	today := localDayStart(time.Now())
+	start, end := today, today.AddDate(0, 0, defaultDays)
+	if defaultDays < 0 {
+		start, end = today.AddDate(0, 0, defaultDays+1), today.AddDate(0, 0, 1)
+	}
+
+	if fromStr != "" {
+		from, err := time.ParseInLocation("2006-01-02", fromStr, DefaultLocation)
+		if err != nil {
+			return start, end, fmt.Errorf("Invalid from date format. Use YYYY-MM-DD")
+		}
+		start = from
+	}
+	if toStr != "" {
+		to, err := time.ParseInLocation("2006-01-02", toStr, DefaultLocation)
+		if err != nil {
+			return start, end, fmt.Errorf("Invalid to date format. Use YYYY-MM-DD")
+		}
+		end = to.AddDate(0, 0, 1)
+	}
+	if !start.Before(end) {
+		return start, end, fmt.Errorf("from must not be after to")
+	}
+	if end.Sub(start) > 400*24*time.Hour {
+		return start, end, fmt.Errorf("range is limited to 400 days")
+	}
+	return start, end, nil
+}
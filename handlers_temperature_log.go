@@ -6,7 +6,9 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -52,6 +54,20 @@ func handleSetTemperatureLogSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 0 keeps the rollups forever
+	if settings.HourlyRollupRetentionDays < 0 || settings.HourlyRollupRetentionDays > 36500 ||
+		settings.DailyRollupRetentionDays < 0 || settings.DailyRollupRetentionDays > 36500 {
+		http.Error(w, "Rollup retention days must be between 0 (forever) and 36500", http.StatusBadRequest)
+		return
+	}
+
+	for _, d := range settings.Devices {
+		if d.InstallationID == "" || d.GatewayID == "" || d.DeviceID == "" {
+			http.Error(w, "Devices need installation_id, gateway_id and device_id", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Use default database path if not provided
 	if settings.DatabasePath == "" {
 		settings.DatabasePath = filepath.Join(getDefaultConfigDir(), "viessmann_events.db")
@@ -104,6 +120,9 @@ func handleTemperatureLogStats(w http.ResponseWriter, r *http.Request) {
 		totalSnapshots = 0
 	}
 
+	hourlyRollups, _ := GetTemperatureRollupCount(RollupHourly)
+	dailyRollups, _ := GetTemperatureRollupCount(RollupDaily)
+
 	usage10min, usage24hr := getAPIUsage()
 	limit10min, limit24hr := GetAPIRateLimits()
 
@@ -118,6 +137,8 @@ func handleTemperatureLogStats(w http.ResponseWriter, r *http.Request) {
 		APIUsage24Hr:     usage24hr,
 		APILimit10Min:    limit10min,
 		APILimit24Hr:     limit24hr,
+		HourlyRollups:    hourlyRollups,
+		DailyRollups:     dailyRollups,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -138,50 +159,15 @@ func handleTemperatureLogData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Optional gateway and device filters
+	// Optional gateway and device filters, several devices (deviceId=0,1) are returned together for overlays
 	gatewayID := r.URL.Query().Get("gatewayId")
-	deviceID := r.URL.Query().Get("deviceId")
+	deviceIDs := parseDeviceIDs(r)
 
 	// Parse time range
-	var startTime, endTime time.Time
-	var err error
-
-	// Check if hours parameter is provided
-	hoursParam := r.URL.Query().Get("hours")
-	if hoursParam != "" {
-		hours, err := strconv.Atoi(hoursParam)
-		if err != nil || hours < 1 || hours > 8760 { // Max 1 year
-			http.Error(w, "Invalid hours parameter (must be 1-8760)", http.StatusBadRequest)
-			return
-		}
-		endTime = time.Now().UTC()
-		startTime = endTime.Add(-time.Duration(hours) * time.Hour)
-	} else {
-		// Parse startTime and endTime from query parameters
-		startTimeStr := r.URL.Query().Get("startTime")
-		endTimeStr := r.URL.Query().Get("endTime")
-
-		if startTimeStr != "" {
-			startTime, err = time.Parse(time.RFC3339, startTimeStr)
-			if err != nil {
-				http.Error(w, "Invalid startTime format (use RFC3339)", http.StatusBadRequest)
-				return
-			}
-		} else {
-			// Default: 24 hours ago
-			startTime = time.Now().UTC().Add(-24 * time.Hour)
-		}
-
-		if endTimeStr != "" {
-			endTime, err = time.Parse(time.RFC3339, endTimeStr)
-			if err != nil {
-				http.Error(w, "Invalid endTime format (use RFC3339)", http.StatusBadRequest)
-				return
-			}
-		} else {
-			// Default: now
-			endTime = time.Now().UTC()
-		}
+	startTime, endTime, err := parseTemperatureTimeRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	// Parse limit
@@ -194,8 +180,33 @@ func handleTemperatureLogData(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Long ranges are answered from the hourly or daily rollups (average values)
+	resolution := r.URL.Query().Get("resolution")
+	if resolution == "" || resolution == "auto" {
+		settings, err := GetTemperatureLogSettings()
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get settings: %v", err), http.StatusInternalServerError)
+			return
+		}
+		resolution = temperatureChartResolution(startTime, endTime, settings)
+	}
+
 	// Fetch data from database
-	snapshots, err := GetTemperatureSnapshots(installationID, gatewayID, deviceID, startTime, endTime, limit)
+	var snapshots []TemperatureSnapshot
+	switch resolution {
+	case "raw":
+		snapshots, err = GetTemperatureSnapshots(installationID, gatewayID, deviceIDs, startTime, endTime, limit)
+	case RollupHourly, RollupDaily:
+		var rollups []TemperatureRollup
+		rollups, err = GetTemperatureRollups(resolution, installationID, gatewayID, deviceIDs, startTime, endTime, limit)
+		snapshots = make([]TemperatureSnapshot, len(rollups))
+		for i := range rollups {
+			snapshots[i] = rollups[i].Snapshot()
+		}
+	default:
+		http.Error(w, "Invalid resolution (auto, raw, hourly or daily)", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		log.Printf("Error fetching temperature snapshots: %v", err)
 		http.Error(w, fmt.Sprintf("Failed to fetch data: %v", err), http.StatusInternalServerError)
@@ -208,9 +219,207 @@ func handleTemperatureLogData(w http.ResponseWriter, r *http.Request) {
 		"installationId": installationID,
 		"startTime":      startTime.Format(time.RFC3339),
 		"endTime":        endTime.Format(time.RFC3339),
+		"resolution":     resolution,
 		"count":          len(snapshots),
 		"limit":          limit,
 		"data":           snapshots,
 	}
 	json.NewEncoder(w).Encode(response)
 }
+
+// handleTemperatureLogRollups handles GET /api/temperature-log/rollups
+// Returns hourly or daily rollups with min/avg/max per sensor and the integrated energy.
+// Parameters: installationId, gatewayId, deviceId (optional), resolution (hourly, daily), hours or startTime/endTime, limit
+func handleTemperatureLogRollups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	installationID := r.URL.Query().Get("installationId")
+	if installationID == "" {
+		http.Error(w, "installationId parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	resolution := r.URL.Query().Get("resolution")
+	if resolution == "" {
+		resolution = RollupHourly
+	}
+	if resolution != RollupHourly && resolution != RollupDaily {
+		http.Error(w, "Invalid resolution (hourly or daily)", http.StatusBadRequest)
+		return
+	}
+
+	startTime, endTime, err := parseTemperatureTimeRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := 50000
+	if parsedLimit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 100000 {
+		limit = parsedLimit
+	}
+
+	rollups, err := GetTemperatureRollups(resolution, installationID, r.URL.Query().Get("gatewayId"), parseDeviceIDs(r), startTime, endTime, limit)
+	if err != nil {
+		log.Printf("Error fetching temperature rollups: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to fetch data: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if rollups == nil {
+		rollups = []TemperatureRollup{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"installationId": installationID,
+		"startTime":      startTime.Format(time.RFC3339),
+		"endTime":        endTime.Format(time.RFC3339),
+		"resolution":     resolution,
+		"count":          len(rollups),
+		"data":           rollups,
+	})
+}
+
+// parseDeviceIDs reads the comma separated deviceId parameter, empty means all devices
+func parseDeviceIDs(r *http.Request) []string {
+	var deviceIDs []string
+	for _, id := range strings.Split(r.URL.Query().Get("deviceId"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			deviceIDs = append(deviceIDs, id)
+		}
+	}
+	return deviceIDs
+}
+
+// handleTemperatureLogDevices handles GET /api/temperature-log/devices
+// Lists the devices of all active accounts with their logging state for the device picker.
+func handleTemperatureLogDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	settings, err := GetTemperatureLogSettings()
+	if err != nil {
+		log.Printf("Error getting temperature log settings: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to get settings: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	stats, err := GetTemperatureSnapshotDeviceStats()
+	if err != nil {
+		log.Printf("Error getting temperature snapshot device stats: %v", err)
+		stats = map[string]TemperatureLogDeviceInfo{}
+	}
+
+	activeAccounts, err := GetActiveAccounts()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get accounts: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	devices := make([]TemperatureLogDeviceInfo, 0)
+	seen := make(map[string]bool)
+	for _, account := range activeAccounts {
+		token, err := ensureAccountAuthenticated(account)
+		if err != nil {
+			log.Printf("Warning: Failed to authenticate account %s for temperature log devices: %v", account.Email, err)
+			continue
+		}
+
+		for _, installationID := range token.InstallationIDs {
+			installation, ok := token.Installations[installationID]
+			if !ok {
+				continue
+			}
+			for _, gateway := range installation.Gateways {
+				for _, device := range gateway.Devices {
+					// The gateway itself has no sensors
+					if device.DeviceType == "tcu" || device.DeviceID == "gateway" {
+						continue
+					}
+					key := installationID + "/" + gateway.Serial + "/" + device.DeviceID
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+
+					info := stats[key]
+					info.InstallationID = installationID
+					info.GatewayID = gateway.Serial
+					info.DeviceID = device.DeviceID
+					info.DeviceType = device.DeviceType
+					info.ModelID = device.ModelID
+					info.AccountID = account.ID
+					info.Enabled = settings.DeviceEnabled(installationID, gateway.Serial, device.DeviceID)
+					devices = append(devices, info)
+				}
+			}
+		}
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		a, b := devices[i], devices[j]
+		if a.InstallationID != b.InstallationID {
+			return a.InstallationID < b.InstallationID
+		}
+		if a.GatewayID != b.GatewayID {
+			return a.GatewayID < b.GatewayID
+		}
+		return a.DeviceID < b.DeviceID
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sample_interval": settings.SampleInterval,
+		"devices":         devices,
+	})
+}
+
+// parseTemperatureTimeRange reads either hours (1-8760) or startTime/endTime (RFC3339).
+// Default is the last 24 hours.
+func parseTemperatureTimeRange(r *http.Request) (time.Time, time.Time, error) {
+	var startTime, endTime time.Time
+	var err error
+
+	// Check if hours parameter is provided
+	hoursParam := r.URL.Query().Get("hours")
+	if hoursParam != "" {
+		hours, err := strconv.Atoi(hoursParam)
+		if err != nil || hours < 1 || hours > 8760 { // Max 1 year
+			return startTime, endTime, fmt.Errorf("Invalid hours parameter (must be 1-8760)")
+		}
+		endTime = time.Now().UTC()
+		startTime = endTime.Add(-time.Duration(hours) * time.Hour)
+		return startTime, endTime, nil
+	}
+
+	// Parse startTime and endTime from query parameters
+	startTimeStr := r.URL.Query().Get("startTime")
+	endTimeStr := r.URL.Query().Get("endTime")
+
+	if startTimeStr != "" {
+		startTime, err = time.Parse(time.RFC3339, startTimeStr)
+		if err != nil {
+			return startTime, endTime, fmt.Errorf("Invalid startTime format (use RFC3339)")
+		}
+	} else {
+		// Default: 24 hours ago
+		startTime = time.Now().UTC().Add(-24 * time.Hour)
+	}
+
+	if endTimeStr != "" {
+		endTime, err = time.Parse(time.RFC3339, endTimeStr)
+		if err != nil {
+			return startTime, endTime, fmt.Errorf("Invalid endTime format (use RFC3339)")
+		}
+	} else {
+		// Default: now
+		endTime = time.Now().UTC()
+	}
+
+	return startTime, endTime, nil
+}
@@ -422,16 +422,8 @@ func vitoventPageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Render the vitovent.html template
-	tmpl, err := templatesFS.ReadFile("templates/vitovent.html")
-	if err != nil {
-		http.Error(w, "Template not found", http.StatusInternalServerError)
-		log.Printf("Error reading template: %v\n", err)
-		return
-	}
-
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write(tmpl)
+	renderTemplate(w, r, "templates/vitovent.html")
 }
 
 // vitoventDevicesHandler returns Vitovent ventilation system data for an installation
@@ -598,112 +590,14 @@ func vitoventOperatingModeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate mode
-	validModes := []string{"permanent", "ventilation", "sensorOverride", "sensorDriven"}
-	modeValid := false
-	for _, m := range validModes {
-		if req.Mode == m {
-			modeValid = true
-			break
-		}
-	}
-	if !modeValid {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid mode: " + req.Mode,
-		})
-		return
-	}
-
-	// Get account
-	account, err := GetAccount(req.AccountID)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found: " + err.Error(),
-		})
-		return
-	}
-
-	// Ensure authenticated
-	token, err := ensureAccountAuthenticated(account)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Authentication failed: " + err.Error(),
-		})
-		return
-	}
-
-	// Build API URL for ventilation device
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/ventilation.operating.modes.active/commands/setMode",
-		req.InstallationID, req.GatewaySerial, req.DeviceID)
-
-	// Prepare request body
-	commandBody := map[string]interface{}{
-		"mode": req.Mode,
-	}
-
-	bodyBytes, err := json.Marshal(commandBody)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to marshal request: " + err.Error(),
-		})
-		return
-	}
-
-	// Create HTTP request
-	httpReq, err := NewRequest("POST", url, strings.NewReader(string(bodyBytes)))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "API request failed: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("API returned status %d", resp.StatusCode),
-		})
-		return
-	}
-
-	// Invalidate features cache for this device
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	featuresCacheMutex.Lock()
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	log.Printf("Set ventilation operating mode for device %s to %s\n", req.DeviceID, req.Mode)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "ventilation.operating.modes.active",
+		Command:        "setMode",
+		Params:         map[string]interface{}{"mode": req.Mode},
 	})
 }
 
@@ -734,102 +628,19 @@ func vitoventQuickModeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate mode
-	validModes := []string{"forcedLevelFour", "silent", "temporaryShutdown"}
-	modeValid := false
-	for _, m := range validModes {
-		if req.Mode == m {
-			modeValid = true
-			break
-		}
-	}
-	if !modeValid {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Invalid quick mode: " + req.Mode,
-		})
-		return
-	}
-
-	// Get account
-	account, err := GetAccount(req.AccountID)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Account not found: " + err.Error(),
-		})
-		return
-	}
-
-	// Ensure authenticated
-	token, err := ensureAccountAuthenticated(account)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Authentication failed: " + err.Error(),
-		})
-		return
-	}
-
-	// Determine command
+	// Determine command based on desired state
 	command := "deactivate"
 	if req.Active {
 		command = "activate"
 	}
 
-	// Build API URL
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features/ventilation.quickmodes.%s/commands/%s",
-		req.InstallationID, req.GatewaySerial, req.DeviceID, req.Mode, command)
-
-	// Create HTTP request (empty body for these commands)
-	httpReq, err := NewRequest("POST", url, strings.NewReader("{}"))
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "Failed to create request: " + err.Error(),
-		})
-		return
-	}
-
-	httpReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Execute request
-	client := &http.Client{}
-	resp, err := client.Do(httpReq)
-	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   "API request failed: " + err.Error(),
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success": false,
-			"error":   fmt.Sprintf("API returned status %d", resp.StatusCode),
-		})
-		return
-	}
-
-	// Invalidate features cache for this device
-	cacheKey := fmt.Sprintf("%s:%s:%s", req.InstallationID, req.GatewaySerial, req.DeviceID)
-	featuresCacheMutex.Lock()
-	delete(featuresCache, cacheKey)
-	featuresCacheMutex.Unlock()
-
-	log.Printf("Set ventilation quick mode %s for device %s to: %v\n", req.Mode, req.DeviceID, req.Active)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
+	writeFeatureCommandResult(w, r, FeatureCommandRequest{
+		AccountID:      req.AccountID,
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Feature:        "ventilation.quickmodes." + req.Mode,
+		Command:        command,
+		Params:         map[string]interface{}{},
 	})
 }
@@ -0,0 +1,625 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Heating curve analyzer
+//
+// Fits the curve the system actually ran from the logged hourly averages of outside and heating
+// circuit supply temperature, flags periods in which the rooms were too warm or too cold and derives
+// a slope/shift recommendation from the supply temperature the rooms would have needed.
+// Viessmann curve: VT = RT + shift - slope * DAR * (1.4347 + 0.021 * DAR + 247.9e-6 * DAR^2), DAR = AT - RT
+
+const (
+	heatingCurveMinHours        = 24   // Hours needed for a fit
+	heatingCurveMaxOutsideTemp  = 16.0 // Hours above are outside of the heating season
+	heatingCurveMinPeriodHours  = 3    // Shortest flagged over/under-supply period
+	heatingCurveMaxSlopeStep    = 0.2  // Largest slope change suggested at once
+	heatingCurveMaxShiftStep    = 3.0  // Largest shift change suggested at once
+	heatingCurveCOPPerKelvin    = 0.025
+	heatingCurveDefaultRoomTemp = 20.0
+)
+
+// HeatingCurveAnalysisRequest selects the data of an analysis
+type HeatingCurveAnalysisRequest struct {
+	AccountID      string // Optional, reads the configured curve and room setpoint from the device
+	InstallationID string
+	GatewaySerial  string
+	DeviceID       string
+	Circuit        int
+	Start          time.Time
+	End            time.Time
+	TargetRoomTemp float64 // Desired room temperature, 0 = room setpoint
+	Tolerance      float64 // Allowed room deviation in K
+}
+
+// HeatingCurveParams are slope and shift of a curve
+type HeatingCurveParams struct {
+	Slope float64 `json:"slope"`
+	Shift float64 `json:"shift"`
+}
+
+// HeatingCurveFit is the curve the system ran in the analysed period
+type HeatingCurveFit struct {
+	HeatingCurveParams
+	RMSE    float64 `json:"rmse"` // K
+	R2      float64 `json:"r2"`
+	Samples int     `json:"samples"`
+}
+
+// HeatingCurvePoint is one analysed hour
+type HeatingCurvePoint struct {
+	Time        string   `json:"time"`
+	OutsideTemp float64  `json:"outsideTemp"`
+	SupplyTemp  float64  `json:"supplyTemp"`
+	CurveTemp   *float64 `json:"curveTemp,omitempty"` // Supply temperature of the configured curve
+	RoomTemp    *float64 `json:"roomTemp,omitempty"`
+	COP         *float64 `json:"cop,omitempty"`
+}
+
+// HeatingCurvePeriod is a period in which the rooms deviated from the target
+type HeatingCurvePeriod struct {
+	Type           string  `json:"type"` // oversupply, undersupply
+	Start          string  `json:"start"`
+	End            string  `json:"end"`
+	Hours          int     `json:"hours"`
+	AvgRoomDelta   float64 `json:"avgRoomDelta"`
+	AvgOutsideTemp float64 `json:"avgOutsideTemp"`
+}
+
+// HeatingCurveEffect compares the supply temperature of the current and the suggested curve
+type HeatingCurveEffect struct {
+	OutsideTemp   float64 `json:"outsideTemp"`
+	CurrentSupply float64 `json:"currentSupply"`
+	NewSupply     float64 `json:"newSupply"`
+}
+
+// HeatingCurveRecommendation is the suggested curve change
+type HeatingCurveRecommendation struct {
+	HeatingCurveParams                      // Suggested next step
+	Target             HeatingCurveParams   `json:"target"` // Full correction according to the room temperatures
+	Change             bool                 `json:"change"` // False if the current curve fits
+	AvgSupplyChange    float64              `json:"avgSupplyChange"`
+	COPChange          float64              `json:"copChange"`
+	COPChangePercent   float64              `json:"copChangePercent"`
+	COPMethod          string               `json:"copMethod"` // data (regression of the period) or estimate (2.5%/K)
+	Effects            []HeatingCurveEffect `json:"effects"`
+}
+
+// HeatingCurveAnalysis is the result of AnalyzeHeatingCurve
+type HeatingCurveAnalysis struct {
+	InstallationID string                      `json:"installationId"`
+	GatewaySerial  string                      `json:"gatewaySerial"`
+	DeviceID       string                      `json:"deviceId"`
+	Circuit        int                         `json:"circuit"`
+	From           string                      `json:"from"`
+	To             string                      `json:"to"`
+	Hours          int                         `json:"hours"`
+	RoomSetpoint   float64                     `json:"roomSetpoint"`
+	TargetRoomTemp float64                     `json:"targetRoomTemp"`
+	RoomSource     string                      `json:"roomSource,omitempty"` // rooms (room sensors), device (device sensor)
+	AvgRoomTemp    *float64                    `json:"avgRoomTemp,omitempty"`
+	AvgOutsideTemp float64                     `json:"avgOutsideTemp"`
+	AvgSupplyTemp  float64                     `json:"avgSupplyTemp"`
+	AvgCOP         *float64                    `json:"avgCop,omitempty"`
+	Current        *HeatingCurveParams         `json:"current,omitempty"`
+	Fitted         *HeatingCurveFit            `json:"fitted,omitempty"`
+	Periods        []HeatingCurvePeriod        `json:"periods"`
+	Recommendation *HeatingCurveRecommendation `json:"recommendation,omitempty"`
+	Notes          []string                    `json:"notes"` // Codes why parts of the analysis are missing
+	Points         []HeatingCurvePoint         `json:"points"`
+	slopeLimits    [3]float64                  // min, max, stepping of setCurve
+	shiftLimits    [3]float64
+}
+
+// heatingCurveSupply returns the supply temperature of a curve at an outside temperature
+func heatingCurveSupply(c HeatingCurveParams, roomTemp, outsideTemp float64) float64 {
+	return roomTemp + c.Shift + c.Slope*heatingCurveTerm(roomTemp, outsideTemp)
+}
+
+// heatingCurveTerm is the factor of the slope in the curve formula
+func heatingCurveTerm(roomTemp, outsideTemp float64) float64 {
+	dar := outsideTemp - roomTemp
+	return -dar * (1.4347 + 0.021*dar + 247.9e-6*dar*dar)
+}
+
+// fitHeatingCurve fits slope and shift to (outside, supply) pairs by least squares, the formula is
+// linear in both. ok is false if the outside temperatures do not vary enough for a slope.
+func fitHeatingCurve(roomTemp float64, outside, supply []float64) (HeatingCurveFit, bool) {
+	n := float64(len(outside))
+	if len(outside) < heatingCurveMinHours {
+		return HeatingCurveFit{}, false
+	}
+	var sx, sy, sxx, sxy float64
+	for i := range outside {
+		x := heatingCurveTerm(roomTemp, outside[i])
+		y := supply[i] - roomTemp
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	varX := sxx/n - (sx/n)*(sx/n)
+	// Below about 3 K spread of the outside temperature the slope is not determined
+	if varX < 4 {
+		return HeatingCurveFit{}, false
+	}
+	slope := (sxy/n - (sx/n)*(sy/n)) / varX
+	fit := HeatingCurveFit{HeatingCurveParams: HeatingCurveParams{Slope: slope, Shift: sy/n - slope*sx/n}, Samples: len(outside)}
+
+	var ssRes, ssTot float64
+	meanY := sy/n + roomTemp
+	for i := range outside {
+		d := supply[i] - heatingCurveSupply(fit.HeatingCurveParams, roomTemp, outside[i])
+		ssRes += d * d
+		ssTot += (supply[i] - meanY) * (supply[i] - meanY)
+	}
+	fit.RMSE = round2(math.Sqrt(ssRes / n))
+	if ssTot > 0 {
+		fit.R2 = round2(1 - ssRes/ssTot)
+	}
+	fit.Slope = round2(fit.Slope)
+	fit.Shift = round2(fit.Shift)
+	return fit, true
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// heatingCurveHour are the averages of one hour in which the heating circuit ran
+type heatingCurveHour struct {
+	start   time.Time
+	outside float64
+	supply  float64
+	room    *float64
+	cop     *float64
+}
+
+// snapshotDHWActiveSQL is true for snapshots taken while the heat pump heats the hot water: the position
+// of the 4/3-way valve (heating.valves.fourThreeWay.position) is domesticHotWater or a variant of it.
+// dhw_pump_active is the DHW circulation pump and says nothing about hot water heating.
+const snapshotDHWActiveSQL = `IFNULL(four_way_valve, '') LIKE '%domesticHotWater%'`
+
+// loadHeatingCurveHours reads the hourly averages of the snapshots. Samples during hot water
+// heating and with the circuit pump off are left out, their supply temperature is not the curve's.
+// Only circuit 0 logs its pump (heating.circuits.0.circulation.pump), the other circuits are not
+// filtered by pump.
+func loadHeatingCurveHours(req HeatingCurveAnalysisRequest) ([]heatingCurveHour, error) {
+	if req.Circuit < 0 || req.Circuit > 3 {
+		return nil, fmt.Errorf("circuit must be between 0 and 3")
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	supplyColumn := fmt.Sprintf("heating_circuit_%d_supply_temp", req.Circuit)
+	pumpFilter := ""
+	if req.Circuit == 0 {
+		pumpFilter = "AND IFNULL(circulation_pump_active, 1) = 1"
+	}
+	rows, err := eventDB.Query(`
+		SELECT STRFTIME('%Y-%m-%dT%H:00:00Z', timestamp) as hour,
+			AVG(outside_temp), AVG(`+supplyColumn+`), AVG(room_temp),
+			AVG(CASE WHEN compressor_active = 1 AND cop > 0 THEN cop END)
+		FROM temperature_snapshots
+		WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+			AND timestamp >= ? AND timestamp < ?
+			AND outside_temp IS NOT NULL AND `+supplyColumn+` IS NOT NULL
+			AND NOT `+snapshotDHWActiveSQL+` `+pumpFilter+`
+		GROUP BY hour
+		ORDER BY hour ASC
+	`, req.InstallationID, req.GatewaySerial, req.DeviceID, req.Start.UTC().Format(time.RFC3339), req.End.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query heating curve data: %v", err)
+	}
+	defer rows.Close()
+
+	var hours []heatingCurveHour
+	for rows.Next() {
+		var hour string
+		var h heatingCurveHour
+		if err := rows.Scan(&hour, &h.outside, &h.supply, &h.room, &h.cop); err != nil {
+			continue
+		}
+		if h.start, err = time.Parse(time.RFC3339, hour); err != nil {
+			continue
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
+
+// loadRoomTemperatures returns the hourly mean over all logged room sensors of the installation
+// (feature log pattern rooms.*.sensors.temperature)
+func loadRoomTemperatures(installationID string, start, end time.Time) map[int64]float64 {
+	series, err := GetFeatureSamples(FeatureSeriesFilter{
+		InstallationID: installationID,
+		Feature:        "rooms.*.sensors.temperature",
+		Property:       "value",
+	}, start, end, 0)
+	if err != nil {
+		return nil
+	}
+
+	// Hourly mean per room first, so rooms with more samples do not dominate
+	sums := make(map[int64]float64)
+	counts := make(map[int64]int)
+	for _, s := range series {
+		roomSums := make(map[int64]float64)
+		roomCounts := make(map[int64]int)
+		for _, sample := range s.Data {
+			if v, ok := sample.Value.(float64); ok {
+				hour := sample.Timestamp.Truncate(time.Hour).Unix()
+				roomSums[hour] += v
+				roomCounts[hour]++
+			}
+		}
+		for hour, sum := range roomSums {
+			sums[hour] += sum / float64(roomCounts[hour])
+			counts[hour]++
+		}
+	}
+
+	result := make(map[int64]float64, len(sums))
+	for hour, sum := range sums {
+		result[hour] = sum / float64(counts[hour])
+	}
+	return result
+}
+
+// readCurrentHeatingCurve reads slope, shift, room setpoint and the setCurve limits from the device
+func (a *HeatingCurveAnalysis) readCurrentHeatingCurve(accountID string) error {
+	features, err := fetchFeaturesForAccount(accountID, a.InstallationID, a.GatewaySerial, a.DeviceID)
+	if err != nil {
+		return err
+	}
+	prefix := fmt.Sprintf("heating.circuits.%d", a.Circuit)
+	slope, okSlope := featureNumber(features, prefix+".heating.curve", "slope")
+	shift, okShift := featureNumber(features, prefix+".heating.curve", "shift")
+	if okSlope && okShift {
+		a.Current = &HeatingCurveParams{Slope: slope, Shift: shift}
+	}
+	if setpoint, ok := featureNumber(features, prefix+".operating.programs.normal", "temperature"); ok && setpoint > 0 {
+		a.RoomSetpoint = setpoint
+	}
+
+	if cmd, err := findFeatureCommand(features, prefix+".heating.curve", "setCurve"); err == nil {
+		for name, limits := range map[string]*[3]float64{"slope": &a.slopeLimits, "shift": &a.shiftLimits} {
+			c := cmd.Params[name].Constraints
+			if c.Min != nil {
+				limits[0] = *c.Min
+			}
+			if c.Max != nil {
+				limits[1] = *c.Max
+			}
+			if c.Stepping != nil && *c.Stepping > 0 {
+				limits[2] = *c.Stepping
+			}
+		}
+	}
+	return nil
+}
+
+// AnalyzeHeatingCurve analyses the heating circuit of a device over a period
+func AnalyzeHeatingCurve(req HeatingCurveAnalysisRequest) (*HeatingCurveAnalysis, error) {
+	hours, err := loadHeatingCurveHours(req)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &HeatingCurveAnalysis{
+		InstallationID: req.InstallationID,
+		GatewaySerial:  req.GatewaySerial,
+		DeviceID:       req.DeviceID,
+		Circuit:        req.Circuit,
+		From:           req.Start.Format(time.RFC3339),
+		To:             req.End.Format(time.RFC3339),
+		RoomSetpoint:   heatingCurveDefaultRoomTemp,
+		Periods:        []HeatingCurvePeriod{},
+		Notes:          []string{},
+		Points:         []HeatingCurvePoint{},
+		slopeLimits:    [3]float64{0.2, 3.5, 0.1},
+		shiftLimits:    [3]float64{-13, 40, 1},
+	}
+	if req.AccountID != "" {
+		if err := a.readCurrentHeatingCurve(req.AccountID); err != nil {
+			a.Notes = append(a.Notes, "current_curve_unavailable")
+		}
+	} else {
+		a.Notes = append(a.Notes, "current_curve_unavailable")
+	}
+	a.TargetRoomTemp = req.TargetRoomTemp
+	if a.TargetRoomTemp <= 0 {
+		a.TargetRoomTemp = a.RoomSetpoint
+	}
+	tolerance := req.Tolerance
+	if tolerance <= 0 {
+		tolerance = 1.0
+	}
+
+	// Room temperatures of the room sensors take precedence over the sensor of the device
+	rooms := loadRoomTemperatures(req.InstallationID, req.Start, req.End)
+	if len(rooms) > 0 {
+		a.RoomSource = "rooms"
+	}
+
+	var outside, supply []float64
+	var roomSum, copSum float64
+	var roomCount, copCount int
+	for _, h := range hours {
+		if h.outside > heatingCurveMaxOutsideTemp {
+			continue
+		}
+		if len(rooms) > 0 {
+			h.room = nil
+			if v, ok := rooms[h.start.Unix()]; ok {
+				h.room = &v
+			}
+		} else if h.room != nil {
+			a.RoomSource = "device"
+		}
+
+		point := HeatingCurvePoint{
+			Time:        h.start.In(DefaultLocation).Format(time.RFC3339),
+			OutsideTemp: round2(h.outside),
+			SupplyTemp:  round2(h.supply),
+			RoomTemp:    h.room,
+			COP:         h.cop,
+		}
+		if a.Current != nil {
+			curve := round2(heatingCurveSupply(*a.Current, a.RoomSetpoint, h.outside))
+			point.CurveTemp = &curve
+		}
+		a.Points = append(a.Points, point)
+
+		outside = append(outside, h.outside)
+		supply = append(supply, h.supply)
+		a.AvgOutsideTemp += h.outside
+		a.AvgSupplyTemp += h.supply
+		if h.room != nil {
+			roomSum += *h.room
+			roomCount++
+		}
+		if h.cop != nil {
+			copSum += *h.cop
+			copCount++
+		}
+	}
+
+	a.Hours = len(a.Points)
+	if a.Hours == 0 {
+		a.Notes = append(a.Notes, "no_data")
+		return a, nil
+	}
+	a.AvgOutsideTemp = round2(a.AvgOutsideTemp / float64(a.Hours))
+	a.AvgSupplyTemp = round2(a.AvgSupplyTemp / float64(a.Hours))
+	if roomCount > 0 {
+		avg := round2(roomSum / float64(roomCount))
+		a.AvgRoomTemp = &avg
+	} else {
+		a.Notes = append(a.Notes, "no_room_temperatures")
+	}
+	if copCount > 0 {
+		avg := round2(copSum / float64(copCount))
+		a.AvgCOP = &avg
+	}
+
+	if fit, ok := fitHeatingCurve(a.RoomSetpoint, outside, supply); ok {
+		a.Fitted = &fit
+	} else {
+		a.Notes = append(a.Notes, "outside_range_too_small")
+	}
+
+	a.findSupplyPeriods(tolerance)
+	a.recommend(tolerance)
+	return a, nil
+}
+
+// findSupplyPeriods flags consecutive hours with the room temperature outside the tolerance
+func (a *HeatingCurveAnalysis) findSupplyPeriods(tolerance float64) {
+	var current *HeatingCurvePeriod
+	var last time.Time
+	flush := func() {
+		if current != nil && current.Hours >= heatingCurveMinPeriodHours {
+			current.AvgRoomDelta = round2(current.AvgRoomDelta / float64(current.Hours))
+			current.AvgOutsideTemp = round2(current.AvgOutsideTemp / float64(current.Hours))
+			a.Periods = append(a.Periods, *current)
+		}
+		current = nil
+	}
+
+	for _, p := range a.Points {
+		t, _ := time.Parse(time.RFC3339, p.Time)
+		kind := ""
+		var delta float64
+		if p.RoomTemp != nil {
+			delta = *p.RoomTemp - a.TargetRoomTemp
+			if delta > tolerance {
+				kind = "oversupply"
+			} else if delta < -tolerance {
+				kind = "undersupply"
+			}
+		}
+		if current != nil && (kind != current.Type || t.Sub(last) > time.Hour) {
+			flush()
+		}
+		if kind == "" {
+			continue
+		}
+		if current == nil {
+			current = &HeatingCurvePeriod{Type: kind, Start: p.Time}
+		}
+		current.End = t.Add(time.Hour).Format(time.RFC3339)
+		current.Hours++
+		current.AvgRoomDelta += delta
+		current.AvgOutsideTemp += p.OutsideTemp
+		last = t
+	}
+	flush()
+}
+
+// recommend derives the curve the rooms would have needed. Per hour the heating system is modelled
+// linearly: supply - room = k * (room - outside). The supply for the target room temperature follows
+// from k, the difference to the actual supply is added to the current curve and a new curve is fitted.
+func (a *HeatingCurveAnalysis) recommend(tolerance float64) {
+	base := a.Current
+	if base == nil && a.Fitted != nil {
+		base = &a.Fitted.HeatingCurveParams
+	}
+	if base == nil || a.AvgRoomTemp == nil {
+		return
+	}
+
+	var outside, needed, corrections []float64
+	for _, p := range a.Points {
+		if p.RoomTemp == nil || *p.RoomTemp-p.OutsideTemp < 3 {
+			continue
+		}
+		k := (p.SupplyTemp - *p.RoomTemp) / (*p.RoomTemp - p.OutsideTemp)
+		required := a.TargetRoomTemp + k*(a.TargetRoomTemp-p.OutsideTemp)
+		correction := required - p.SupplyTemp
+		outside = append(outside, p.OutsideTemp)
+		needed = append(needed, heatingCurveSupply(*base, a.RoomSetpoint, p.OutsideTemp)+correction)
+		corrections = append(corrections, correction)
+	}
+	if len(outside) < heatingCurveMinHours {
+		a.Notes = append(a.Notes, "not_enough_room_data")
+		return
+	}
+
+	target := *base
+	if fit, ok := fitHeatingCurve(a.RoomSetpoint, outside, needed); ok {
+		target = fit.HeatingCurveParams
+	} else {
+		// Without spread in the outside temperature only the shift can be corrected
+		sum := 0.0
+		for _, c := range corrections {
+			sum += c
+		}
+		target.Shift = base.Shift + sum/float64(len(corrections))
+	}
+
+	rec := &HeatingCurveRecommendation{Target: HeatingCurveParams{
+		Slope: snapToLimits(target.Slope, a.slopeLimits),
+		Shift: snapToLimits(target.Shift, a.shiftLimits),
+	}}
+	step := HeatingCurveParams{
+		Slope: base.Slope + math.Max(-heatingCurveMaxSlopeStep, math.Min(heatingCurveMaxSlopeStep, target.Slope-base.Slope)),
+		Shift: base.Shift + math.Max(-heatingCurveMaxShiftStep, math.Min(heatingCurveMaxShiftStep, target.Shift-base.Shift)),
+	}
+	rec.Slope = snapToLimits(step.Slope, a.slopeLimits)
+	rec.Shift = snapToLimits(step.Shift, a.shiftLimits)
+
+	// Rooms within the tolerance on average: keep the curve
+	avgDelta := *a.AvgRoomTemp - a.TargetRoomTemp
+	rec.Change = math.Abs(avgDelta) > tolerance/2 || len(a.Periods) > 0
+	rec.Change = rec.Change && (math.Abs(rec.Slope-base.Slope) > 1e-6 || math.Abs(rec.Shift-base.Shift) > 1e-6)
+	if !rec.Change {
+		rec.Slope, rec.Shift = base.Slope, base.Shift
+	}
+
+	// Effect on the supply temperature
+	sum := 0.0
+	for _, p := range a.Points {
+		sum += heatingCurveSupply(rec.HeatingCurveParams, a.RoomSetpoint, p.OutsideTemp) - heatingCurveSupply(*base, a.RoomSetpoint, p.OutsideTemp)
+	}
+	rec.AvgSupplyChange = round2(sum / float64(len(a.Points)))
+	for _, at := range []float64{-10, -5, 0, 5, 10} {
+		rec.Effects = append(rec.Effects, HeatingCurveEffect{
+			OutsideTemp:   at,
+			CurrentSupply: round2(heatingCurveSupply(*base, a.RoomSetpoint, at)),
+			NewSupply:     round2(heatingCurveSupply(rec.HeatingCurveParams, a.RoomSetpoint, at)),
+		})
+	}
+	a.estimateCOPChange(rec)
+	a.Recommendation = rec
+}
+
+// estimateCOPChange estimates the COP change of the supply change. With enough data the COP of the
+// period is regressed on supply and outside temperature, otherwise 2.5% per Kelvin are assumed.
+func (a *HeatingCurveAnalysis) estimateCOPChange(rec *HeatingCurveRecommendation) {
+	if a.AvgCOP == nil {
+		return
+	}
+	rec.COPMethod = "estimate"
+	perKelvin := -heatingCurveCOPPerKelvin * *a.AvgCOP
+
+	var xs [][2]float64
+	var ys []float64
+	for _, p := range a.Points {
+		if p.COP != nil {
+			xs = append(xs, [2]float64{p.SupplyTemp, p.OutsideTemp})
+			ys = append(ys, *p.COP)
+		}
+	}
+	if len(ys) >= heatingCurveMinHours {
+		// COP and supply temperature both depend on the outside temperature, so it is a second regressor
+		if b, ok := regressSupplyEffect(xs, ys); ok && b < 0 {
+			perKelvin = b
+			rec.COPMethod = "data"
+		}
+	}
+	rec.COPChange = round2(perKelvin * rec.AvgSupplyChange)
+	rec.COPChangePercent = round2(rec.COPChange / *a.AvgCOP * 100)
+}
+
+// regressSupplyEffect fits y = c + b1*x1 + b2*x2 and returns b1
+func regressSupplyEffect(xs [][2]float64, ys []float64) (float64, bool) {
+	n := float64(len(ys))
+	var m1, m2, my float64
+	for i := range ys {
+		m1 += xs[i][0]
+		m2 += xs[i][1]
+		my += ys[i]
+	}
+	m1, m2, my = m1/n, m2/n, my/n
+
+	var s11, s22, s12, s1y, s2y float64
+	for i := range ys {
+		d1, d2, dy := xs[i][0]-m1, xs[i][1]-m2, ys[i]-my
+		s11 += d1 * d1
+		s22 += d2 * d2
+		s12 += d1 * d2
+		s1y += d1 * dy
+		s2y += d2 * dy
+	}
+	det := s11*s22 - s12*s12
+	if math.Abs(det) < 1e-9 || s11 < 1 {
+		return 0, false
+	}
+	return (s1y*s22 - s2y*s12) / det, true
+}
+
+// snapToLimits rounds a value to the stepping of a command parameter and clamps it to min/max
+func snapToLimits(v float64, limits [3]float64) float64 {
+	if limits[2] > 0 {
+		v = limits[0] + math.Round((v-limits[0])/limits[2])*limits[2]
+	}
+	v = math.Max(limits[0], math.Min(limits[1], v))
+	return math.Round(v*100) / 100
+}
+
+// applyHeatingCurve sets slope and shift through the setCurve command of the circuit.
+// Only triggered by the user from the analysis panel, so it runs as an interactive command.
+func applyHeatingCurve(accountID, installationID, gatewaySerial, deviceID string, circuit int, curve HeatingCurveParams) error {
+	return executeFeatureCommand(FeatureCommandRequest{
+		AccountID:      accountID,
+		InstallationID: installationID,
+		GatewaySerial:  gatewaySerial,
+		DeviceID:       deviceID,
+		Feature:        fmt.Sprintf("heating.circuits.%d.heating.curve", circuit),
+		Command:        "setCurve",
+		Params: map[string]interface{}{
+			"slope": curve.Slope,
+			"shift": curve.Shift,
+		},
+	}, PriorityInteractive)
+}
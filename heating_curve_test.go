@@ -0,0 +1,135 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// curvePoints returns n supply temperatures of a curve for outside temperatures evenly spread over [from, to]
+func curvePoints(c HeatingCurveParams, roomTemp, from, to float64, n int) (outside, supply []float64) {
+	for i := 0; i < n; i++ {
+		o := from
+		if n > 1 {
+			o += (to - from) * float64(i) / float64(n-1)
+		}
+		outside = append(outside, o)
+		supply = append(supply, heatingCurveSupply(c, roomTemp, o))
+	}
+	return outside, supply
+}
+
+func TestFitHeatingCurve(t *testing.T) {
+	curve := HeatingCurveParams{Slope: 1.2, Shift: 3}
+
+	tests := []struct {
+		name     string
+		from, to float64
+		n        int
+		ok       bool
+	}{
+		{"wide range", -10, 12, 48, true},
+		{"exactly the minimum hours", -5, 10, heatingCurveMinHours, true},
+		{"one hour too few", -5, 10, heatingCurveMinHours - 1, false},
+		{"no points", 0, 0, 0, false},
+		{"constant outside temperature", 5, 5, 48, false},
+		{"outside range too small for a slope", 4, 6, 48, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outside, supply := curvePoints(curve, 20, tt.from, tt.to, tt.n)
+			fit, ok := fitHeatingCurve(20, outside, supply)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if math.Abs(fit.Slope-curve.Slope) > 0.01 || math.Abs(fit.Shift-curve.Shift) > 0.01 {
+				t.Errorf("fitted slope %.2f shift %.2f, want %.2f %.2f", fit.Slope, fit.Shift, curve.Slope, curve.Shift)
+			}
+			if fit.RMSE > 0.01 || fit.R2 < 0.99 {
+				t.Errorf("RMSE %.2f R2 %.2f for exact points", fit.RMSE, fit.R2)
+			}
+			if fit.Samples != tt.n {
+				t.Errorf("samples = %d, want %d", fit.Samples, tt.n)
+			}
+		})
+	}
+}
+
+func TestLoadHeatingCurveHoursFilters(t *testing.T) {
+	openTestEventDB(t)
+
+	f := func(v float64) *float64 { return &v }
+	b := func(v bool) *bool { return &v }
+	s := func(v string) *string { return &v }
+
+	// One hour per case, the valve position and the pumps decide if the hour counts
+	base := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	snapshots := []struct {
+		valve       *string
+		circuitPump *bool
+		dhwPump     *bool
+	}{
+		{s("heating"), b(true), b(false)},                         // 00:00 heating
+		{s("domesticHotWater"), b(true), b(false)},                // 01:00 hot water heating
+		{s("climateCircuitTwoDefrostDomesticHotWater"), nil, nil}, // 02:00 hot water variant
+		{s("heating"), b(false), b(false)},                        // 03:00 circuit 0 pump off
+		{s("heating"), b(true), b(true)},                          // 04:00 DHW circulation pump on, still heating
+		{nil, nil, nil},                                           // 05:00 no valve position logged
+	}
+	for i, sn := range snapshots {
+		err := SaveTemperatureSnapshot(&TemperatureSnapshot{
+			Timestamp:                 base.Add(time.Duration(i) * time.Hour),
+			InstallationID:            "1234567",
+			GatewayID:                 "7736172200000001",
+			DeviceID:                  "0",
+			OutsideTemp:               f(2),
+			HeatingCircuit0SupplyTemp: f(35),
+			HeatingCircuit1SupplyTemp: f(30),
+			FourWayValve:              sn.valve,
+			CirculationPumpActive:     sn.circuitPump,
+			DHWPumpActive:             sn.dhwPump,
+		})
+		if err != nil {
+			t.Fatalf("SaveTemperatureSnapshot: %v", err)
+		}
+	}
+
+	tests := []struct {
+		circuit int
+		hours   []int
+	}{
+		{0, []int{0, 4, 5}},
+		{1, []int{0, 3, 4, 5}}, // The pump of circuit 0 says nothing about circuit 1
+	}
+	for _, tt := range tests {
+		hours, err := loadHeatingCurveHours(HeatingCurveAnalysisRequest{
+			InstallationID: "1234567",
+			GatewaySerial:  "7736172200000001",
+			DeviceID:       "0",
+			Circuit:        tt.circuit,
+			Start:          base,
+			End:            base.Add(24 * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("circuit %d: %v", tt.circuit, err)
+		}
+		var got []int
+		for _, h := range hours {
+			got = append(got, h.start.Hour())
+		}
+		if len(got) != len(tt.hours) {
+			t.Errorf("circuit %d: hours %v, want %v", tt.circuit, got, tt.hours)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.hours[i] {
+				t.Errorf("circuit %d: hours %v, want %v", tt.circuit, got, tt.hours)
+				break
+			}
+		}
+	}
+}
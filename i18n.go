@@ -0,0 +1,199 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message bundles of the web UI and the API responses, one file per language (see i18n/*.json).
+// Missing keys fall back to German, missing German keys to the key itself.
+//
+//go:embed i18n/*.json
+var i18nFS embed.FS
+
+// defaultLanguage is the language of the UI when nothing else is configured
+const defaultLanguage = "de"
+
+// languageCookie stores the language chosen in the browser (?lang=en)
+const languageCookie = "vieventlog_lang"
+
+var (
+	i18nOnce    sync.Once
+	i18nBundles map[string]map[string]string
+
+	languageSettingMutex  sync.RWMutex
+	languageSetting       string // Configured UI language, empty = browser language
+	languageSettingLoaded bool
+)
+
+// loadI18nBundles reads the embedded message bundles once
+func loadI18nBundles() map[string]map[string]string {
+	i18nOnce.Do(func() {
+		i18nBundles = make(map[string]map[string]string)
+		files, err := i18nFS.ReadDir("i18n")
+		if err != nil {
+			log.Printf("Warning: failed to read message bundles: %v", err)
+			return
+		}
+		for _, f := range files {
+			lang := strings.TrimSuffix(f.Name(), ".json")
+			data, err := i18nFS.ReadFile("i18n/" + f.Name())
+			if err != nil {
+				log.Printf("Warning: failed to read message bundle %s: %v", f.Name(), err)
+				continue
+			}
+			var messages map[string]string
+			if err := json.Unmarshal(data, &messages); err != nil {
+				log.Printf("Warning: invalid message bundle %s: %v", f.Name(), err)
+				continue
+			}
+			i18nBundles[lang] = messages
+		}
+	})
+	return i18nBundles
+}
+
+// supportedLanguages returns the languages with a message bundle, sorted
+func supportedLanguages() []string {
+	bundles := loadI18nBundles()
+	langs := make([]string, 0, len(bundles))
+	for lang := range bundles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+func isSupportedLanguage(lang string) bool {
+	_, ok := loadI18nBundles()[lang]
+	return ok
+}
+
+// hasTranslation reports whether the German bundle defines key
+func hasTranslation(key string) bool {
+	_, ok := loadI18nBundles()[defaultLanguage][key]
+	return ok
+}
+
+// translate returns the message for key in lang. Placeholders {0}, {1}, ... are replaced by args.
+func translate(lang, key string, args ...interface{}) string {
+	bundles := loadI18nBundles()
+	text, ok := bundles[lang][key]
+	if !ok || text == "" {
+		text, ok = bundles[defaultLanguage][key]
+	}
+	if !ok || text == "" {
+		text = key
+	}
+	for i, arg := range args {
+		text = strings.ReplaceAll(text, "{"+strconv.Itoa(i)+"}", fmt.Sprint(arg))
+	}
+	return text
+}
+
+// i18nMessages returns the complete bundle of lang including the German fallbacks (for the JavaScript of the pages)
+func i18nMessages(lang string) map[string]string {
+	bundles := loadI18nBundles()
+	messages := make(map[string]string, len(bundles[defaultLanguage]))
+	for key, text := range bundles[defaultLanguage] {
+		messages[key] = text
+	}
+	for key, text := range bundles[lang] {
+		if text != "" {
+			messages[key] = text
+		}
+	}
+	return messages
+}
+
+// GetLanguageSetting returns the configured UI language, empty if the browser language is used
+func GetLanguageSetting() string {
+	languageSettingMutex.RLock()
+	if languageSettingLoaded {
+		defer languageSettingMutex.RUnlock()
+		return languageSetting
+	}
+	languageSettingMutex.RUnlock()
+
+	languageSettingMutex.Lock()
+	defer languageSettingMutex.Unlock()
+	if !languageSettingLoaded {
+		if store, err := LoadAccounts(); err == nil {
+			languageSetting = store.Language
+		}
+		languageSettingLoaded = true
+	}
+	return languageSetting
+}
+
+// SetLanguageSetting stores the UI language, empty selects the browser language
+func SetLanguageSetting(lang string) error {
+	if lang != "" && !isSupportedLanguage(lang) {
+		return fmt.Errorf("unsupported language: %s", lang)
+	}
+
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+	store.Language = lang
+	if err := SaveAccounts(store); err != nil {
+		return err
+	}
+
+	languageSettingMutex.Lock()
+	languageSetting = lang
+	languageSettingLoaded = true
+	languageSettingMutex.Unlock()
+	return nil
+}
+
+// parseAcceptLanguage returns the supported language with the highest weight of an Accept-Language header
+func parseAcceptLanguage(header string) string {
+	best, bestWeight := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !isSupportedLanguage(lang) {
+			continue
+		}
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if weight > bestWeight {
+			best, bestWeight = lang, weight
+		}
+	}
+	return best
+}
+
+// requestLanguage picks the language of a request: ?lang= parameter, language cookie,
+// configured UI language, Accept-Language header and finally German
+func requestLanguage(r *http.Request) string {
+	if r == nil {
+		return defaultLanguage
+	}
+	if lang := r.URL.Query().Get("lang"); isSupportedLanguage(lang) {
+		return lang
+	}
+	if cookie, err := r.Cookie(languageCookie); err == nil && isSupportedLanguage(cookie.Value) {
+		return cookie.Value
+	}
+	if lang := GetLanguageSetting(); lang != "" {
+		return lang
+	}
+	if lang := parseAcceptLanguage(r.Header.Get("Accept-Language")); lang != "" {
+		return lang
+	}
+	return defaultLanguage
+}
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Import types
+const (
+	ImportTypeEvents      = "events"
+	ImportTypeTemperature = "temperature"
+)
+
+const (
+	importBatchSize = 1000
+	maxImportErrors = 20
+)
+
+// ImportResult reports the outcome of an import
+type ImportResult struct {
+	Type           string   `json:"type"`
+	Format         string   `json:"format"`
+	Inserted       int      `json:"inserted"`
+	Skipped        int      `json:"skipped"`  // Already in the database
+	Rejected       int      `json:"rejected"` // Invalid rows
+	Errors         []string `json:"errors,omitempty"`
+	IgnoredColumns []string `json:"ignoredColumns,omitempty"` // Columns unknown to this version
+	// Rows without sample_interval (exports from before migration 2) that got an interval derived from the timestamps
+	SampleIntervalInferred int `json:"sampleIntervalInferred,omitempty"`
+}
+
+func (r *ImportResult) reject(line int, format string, args ...interface{}) {
+	r.Rejected++
+	if len(r.Errors) < maxImportErrors {
+		r.Errors = append(r.Errors, fmt.Sprintf("line %d: %s", line, fmt.Sprintf(format, args...)))
+	}
+}
+
+// importRecord is one row of an export file. Missing keys and empty values are NULL.
+type importRecord map[string]string
+
+// importRecordReader reads the rows of a CSV or JSONL export
+type importRecordReader interface {
+	// Next returns the next record and its line number, io.EOF at the end
+	Next() (importRecord, int, error)
+}
+
+type csvImportReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+func (c *csvImportReader) Next() (importRecord, int, error) {
+	values, err := c.r.Read()
+	if err != nil {
+		return nil, 0, err
+	}
+	line, _ := c.r.FieldPos(0)
+	record := make(importRecord, len(values))
+	for i, v := range values {
+		if i < len(c.header) && v != "" {
+			record[c.header[i]] = v
+		}
+	}
+	return record, line, nil
+}
+
+type jsonlImportReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func (j *jsonlImportReader) Next() (importRecord, int, error) {
+	for j.s.Scan() {
+		j.line++
+		raw := bytes.TrimSpace(j.s.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var values map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&values); err != nil {
+			return nil, j.line, fmt.Errorf("invalid JSON: %v", err)
+		}
+
+		record := make(importRecord, len(values))
+		for key, v := range values {
+			switch v := v.(type) {
+			case nil:
+			case string:
+				if v != "" {
+					record[key] = v
+				}
+			case json.Number:
+				record[key] = v.String()
+			case bool:
+				record[key] = strconv.FormatBool(v)
+			default:
+				// e.g. body as object instead of JSON string
+				b, _ := json.Marshal(v)
+				record[key] = string(b)
+			}
+		}
+		return record, j.line, nil
+	}
+	if err := j.s.Err(); err != nil {
+		return nil, j.line, err
+	}
+	return nil, j.line, io.EOF
+}
+
+// detectImportFormat uses the explicit format, the file extension or the first byte of the data
+func detectImportFormat(format, filename string, br *bufio.Reader) string {
+	format = strings.ToLower(format)
+	if format == ExportFormatCSV || format == ExportFormatJSONL {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ExportFormatCSV
+	case ".jsonl", ".ndjson", ".json":
+		return ExportFormatJSONL
+	}
+	if b, err := br.Peek(1); err == nil && b[0] == '{' {
+		return ExportFormatJSONL
+	}
+	return ExportFormatCSV
+}
+
+// ImportFile imports a CSV or JSONL export of events or temperature snapshots.
+// importType and format may be empty, they are then detected from the file.
+func ImportFile(r io.Reader, filename, importType, format string) (*ImportResult, error) {
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	br := bufio.NewReaderSize(r, 64*1024)
+	// Skip a UTF-8 BOM written by spreadsheet programs
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte{0xEF, 0xBB, 0xBF}) {
+		br.Discard(3)
+	}
+
+	result := &ImportResult{Format: detectImportFormat(format, filename, br)}
+
+	var reader importRecordReader
+	var columns []string
+	if result.Format == ExportFormatJSONL {
+		s := bufio.NewScanner(br)
+		s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		reader = &jsonlImportReader{s: s}
+	} else {
+		cr := csv.NewReader(br)
+		header, err := cr.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %v", err)
+		}
+		columns = header
+		reader = &csvImportReader{r: cr, header: header}
+	}
+
+	// JSONL has no header, the first record tells the columns
+	first, firstLine, err := reader.Next()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if columns == nil {
+		for key := range first {
+			columns = append(columns, key)
+		}
+		sort.Strings(columns)
+	}
+
+	if importType == "" {
+		importType = detectImportType(columns)
+	}
+	result.Type = importType
+
+	var imp importer
+	switch importType {
+	case ImportTypeEvents:
+		imp = &eventImporter{result: result}
+	case ImportTypeTemperature:
+		imp = newTemperatureImporter(result)
+	default:
+		return nil, fmt.Errorf("cannot detect import type, use events or temperature")
+	}
+	result.IgnoredColumns = imp.ignoredColumns(columns)
+
+	record, line := first, firstLine
+	for err != io.EOF {
+		if err != nil {
+			// CSV/JSON syntax errors only affect the current row
+			result.reject(line, "%v", err)
+		} else if err := imp.add(record, line); err != nil {
+			return result, err
+		}
+		record, line, err = reader.Next()
+		if pe, ok := err.(*csv.ParseError); ok {
+			line = pe.Line
+		}
+	}
+
+	if err := imp.flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func detectImportType(columns []string) string {
+	has := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		has[c] = true
+	}
+	switch {
+	case has["eventTimestamp"]:
+		return ImportTypeEvents
+	case has["timestamp"] && has["installation_id"]:
+		return ImportTypeTemperature
+	}
+	return ""
+}
+
+type importer interface {
+	ignoredColumns(columns []string) []string
+	add(record importRecord, line int) error
+	flush() error
+}
+
+// Events are deduplicated by ComputeEventHash. The timestamps have to be exactly as exported.
+
+type eventImporter struct {
+	result *ImportResult
+	batch  []Event
+}
+
+func (e *eventImporter) ignoredColumns(columns []string) []string {
+	known := make(map[string]bool, len(eventExportColumns))
+	for _, c := range eventExportColumns {
+		known[c.Name] = true
+	}
+	var ignored []string
+	for _, c := range columns {
+		if !known[c] {
+			ignored = append(ignored, c)
+		}
+	}
+	return ignored
+}
+
+func (e *eventImporter) add(record importRecord, line int) error {
+	event := Event{
+		EventTimestamp:   record["eventTimestamp"],
+		CreatedAt:        record["createdAt"],
+		EventType:        record["eventType"],
+		InstallationID:   record["installationId"],
+		GatewaySerial:    record["gatewaySerial"],
+		DeviceID:         record["deviceId"],
+		ModelID:          record["modelId"],
+		ErrorCode:        record["errorCode"],
+		ErrorDescription: record["errorDescription"],
+		HumanReadable:    record["humanReadable"],
+		CodeCategory:     record["codeCategory"],
+		Severity:         record["severity"],
+		FeatureName:      record["featureName"],
+		FeatureValue:     record["featureValue"],
+		AccountID:        record["accountId"],
+		AccountName:      record["accountName"],
+		Raw:              record["raw"],
+	}
+
+	if _, err := time.Parse(time.RFC3339, event.EventTimestamp); err != nil {
+		e.result.reject(line, "invalid eventTimestamp %q", event.EventTimestamp)
+		return nil
+	}
+	if event.EventType == "" {
+		e.result.reject(line, "missing eventType")
+		return nil
+	}
+	if v, ok := record["active"]; ok {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			e.result.reject(line, "invalid active %q", v)
+			return nil
+		}
+		event.Active = &active
+	}
+	if v, ok := record["body"]; ok {
+		if err := json.Unmarshal([]byte(v), &event.Body); err != nil {
+			e.result.reject(line, "invalid body: %v", err)
+			return nil
+		}
+	}
+	event.FormattedTime = event.EventTimestamp
+
+	e.batch = append(e.batch, event)
+	if len(e.batch) >= importBatchSize {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *eventImporter) flush() error {
+	if len(e.batch) == 0 {
+		return nil
+	}
+	newEvents, err := SaveNewEventsToDB(e.batch)
+	if err != nil {
+		return err
+	}
+	e.result.Inserted += len(newEvents)
+	e.result.Skipped += len(e.batch) - len(newEvents)
+	e.batch = e.batch[:0]
+	return nil
+}
+
+// Snapshots are deduplicated by the unique index on (timestamp, installation_id, gateway_id, device_id).
+// Columns are the JSON names of TemperatureSnapshot, which are also the column names of the table.
+
+type temperatureImporter struct {
+	result   *ImportResult
+	columns  []exportColumn
+	nullable map[string]bool
+	batch    [][]interface{}
+
+	// Last timestamp per device to derive sample_interval for old exports
+	lastTimestamp   map[string]time.Time
+	defaultInterval int
+}
+
+func newTemperatureImporter(result *ImportResult) *temperatureImporter {
+	t := &temperatureImporter{
+		result:          result,
+		columns:         temperatureExportColumns(),
+		nullable:        make(map[string]bool),
+		lastTimestamp:   make(map[string]time.Time),
+		defaultInterval: 5,
+	}
+	if settings, err := GetTemperatureLogSettings(); err == nil && settings.SampleInterval > 0 {
+		t.defaultInterval = settings.SampleInterval
+	}
+
+	snapshotType := reflect.TypeOf(TemperatureSnapshot{})
+	for i := 0; i < snapshotType.NumField(); i++ {
+		field := snapshotType.Field(i)
+		if field.Type.Kind() == reflect.Ptr {
+			t.nullable[strings.Split(field.Tag.Get("json"), ",")[0]] = true
+		}
+	}
+	return t
+}
+
+func (t *temperatureImporter) ignoredColumns(columns []string) []string {
+	known := make(map[string]bool, len(t.columns))
+	for _, c := range t.columns {
+		known[c.Name] = true
+	}
+	var ignored []string
+	for _, c := range columns {
+		if !known[c] {
+			ignored = append(ignored, c)
+		}
+	}
+	return ignored
+}
+
+func (t *temperatureImporter) add(record importRecord, line int) error {
+	values := make([]interface{}, len(t.columns))
+	var timestamp time.Time
+	for i, c := range t.columns {
+		v, ok := record[c.Name]
+		if !ok {
+			if !t.nullable[c.Name] && c.Kind == exportString {
+				values[i] = "" // Part of the unique key, NULL would never match
+			}
+			continue
+		}
+
+		switch c.Kind {
+		case exportTime:
+			ts, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				t.result.reject(line, "invalid %s %q", c.Name, v)
+				return nil
+			}
+			timestamp = ts.UTC()
+			values[i] = timestamp.Format(time.RFC3339)
+		case exportFloat:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				t.result.reject(line, "invalid %s %q", c.Name, v)
+				return nil
+			}
+			values[i] = f
+		case exportInt:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				t.result.reject(line, "invalid %s %q", c.Name, v)
+				return nil
+			}
+			values[i] = n
+		case exportBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				t.result.reject(line, "invalid %s %q", c.Name, v)
+				return nil
+			}
+			values[i] = boolToInt(b)
+		default:
+			values[i] = v
+		}
+	}
+
+	if timestamp.IsZero() || record["installation_id"] == "" {
+		t.result.reject(line, "missing timestamp or installation_id")
+		return nil
+	}
+
+	// Exports from before the sample_interval migration: use the distance to the previous
+	// snapshot of the same device, or the configured interval for the first one
+	key := record["installation_id"] + "/" + record["gateway_id"] + "/" + record["device_id"]
+	for i, c := range t.columns {
+		if c.Name != "sample_interval" || values[i] != nil {
+			continue
+		}
+		interval := t.defaultInterval
+		if last, ok := t.lastTimestamp[key]; ok {
+			if minutes := int(timestamp.Sub(last).Round(time.Minute).Minutes()); minutes >= 1 && minutes <= 60 {
+				interval = minutes
+			}
+		}
+		values[i] = int64(interval)
+		t.result.SampleIntervalInferred++
+	}
+	t.lastTimestamp[key] = timestamp
+
+	t.batch = append(t.batch, values)
+	if len(t.batch) >= importBatchSize {
+		return t.flush()
+	}
+	return nil
+}
+
+func (t *temperatureImporter) flush() error {
+	if len(t.batch) == 0 {
+		return nil
+	}
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.Name
+	}
+	inserted, err := ImportTemperatureRows(names, t.batch)
+	if err != nil {
+		return err
+	}
+	t.result.Inserted += inserted
+	t.result.Skipped += len(t.batch) - inserted
+	t.batch = t.batch[:0]
+	return nil
+}
+
+// runImportCommand implements "vieventlog import [flags] file..." for restoring exports without the web server
+func runImportCommand(args []string) {
+	fset := flag.NewFlagSet("import", flag.ExitOnError)
+	dbPath := fset.String("db", filepath.Join(getDefaultConfigDir(), "viessmann_events.db"), "Path to the SQLite database")
+	importType := fset.String("type", "", "events or temperature (default: detected from the columns)")
+	format := fset.String("format", "", "csv or jsonl (default: detected from the file)")
+	fset.Usage = func() {
+		fmt.Fprintf(fset.Output(), "Usage: %s import [flags] file... (- for stdin)\n", os.Args[0])
+		fset.PrintDefaults()
+	}
+	fset.Parse(args)
+
+	if fset.NArg() == 0 {
+		fset.Usage()
+		os.Exit(2)
+	}
+
+	// Opening the database also runs the schema migrations
+	if err := InitEventDatabase(*dbPath); err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	failed := false
+	for _, name := range fset.Args() {
+		var r io.Reader = os.Stdin
+		if name != "-" {
+			f, err := os.Open(name)
+			if err != nil {
+				log.Printf("%s: %v", name, err)
+				failed = true
+				continue
+			}
+			defer f.Close()
+			r = f
+		}
+
+		result, err := ImportFile(r, name, *importType, *format)
+		if result != nil {
+			fmt.Printf("%s: %s (%s): %d inserted, %d skipped, %d rejected\n", name, result.Type, result.Format, result.Inserted, result.Skipped, result.Rejected)
+			if result.SampleIntervalInferred > 0 {
+				fmt.Printf("  sample_interval derived for %d rows\n", result.SampleIntervalInferred)
+			}
+			if len(result.IgnoredColumns) > 0 {
+				fmt.Printf("  ignored columns: %s\n", strings.Join(result.IgnoredColumns, ", "))
+			}
+			for _, e := range result.Errors {
+				fmt.Printf("  %s\n", e)
+			}
+		}
+		if err != nil {
+			log.Printf("%s: %v", name, err)
+			failed = true
+		}
+	}
+	CloseEventDatabase()
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const importEventsCSV = `eventTimestamp,eventType,installationId,gatewaySerial,deviceId,errorCode,active
+2026-01-10T12:00:00Z,device-error,1234567,7736172200000001,0,F.160,true
+2026-01-10T12:05:00Z,device-error,1234567,7736172200000001,0,F.160,false
+2026-01-10T12:05:00Z,device-error,1234567,7736172200000001,0,F.160,false
+`
+
+const importEventsJSONL = `{"eventTimestamp":"2026-01-10T12:00:00Z","eventType":"device-error","installationId":"1234567","gatewaySerial":"7736172200000001","deviceId":"0","errorCode":"F.160","active":true}
+{"eventTimestamp":"2026-01-10T12:05:00Z","eventType":"device-error","installationId":"1234567","gatewaySerial":"7736172200000001","deviceId":"0","errorCode":"F.160","active":false}
+
+{"eventTimestamp":"2026-01-10T12:10:00Z","eventType":"feature-changed","installationId":"1234567","gatewaySerial":"7736172200000001","deviceId":"0","featureName":"heating.dhw.pumps.primary","featureValue":"on"}
+`
+
+const importTemperatureCSV = `timestamp,installation_id,gateway_id,device_id,sample_interval,outside_temp
+2026-01-10T12:00:00Z,1234567,7736172200000001,0,5,3.5
+2026-01-10T12:05:00Z,1234567,7736172200000001,0,5,3.4
+2026-01-10T12:05:00Z,1234567,7736172200000001,0,5,3.4
+`
+
+const importTemperatureJSONL = `{"timestamp":"2026-01-10T12:05:00Z","installation_id":"1234567","gateway_id":"7736172200000001","device_id":"0","sample_interval":5,"outside_temp":3.4}
+{"timestamp":"2026-01-10T12:10:00+01:00","installation_id":"1234567","gateway_id":"7736172200000001","device_id":"0","outside_temp":3.3}
+`
+
+func TestImportDeduplication(t *testing.T) {
+	type step struct {
+		filename string
+		data     string
+		inserted int
+		skipped  int
+	}
+
+	tests := []struct {
+		name     string
+		wantType string
+		steps    []step
+	}{
+		{"events CSV imported twice", ImportTypeEvents, []step{
+			{"events.csv", importEventsCSV, 2, 1}, // The third row duplicates the second
+			{"events.csv", importEventsCSV, 0, 3},
+		}},
+		{"events JSONL imported twice", ImportTypeEvents, []step{
+			{"events.jsonl", importEventsJSONL, 3, 0},
+			{"events.jsonl", importEventsJSONL, 0, 3},
+		}},
+		{"events CSV then JSONL", ImportTypeEvents, []step{
+			{"events.csv", importEventsCSV, 2, 1},
+			{"events.jsonl", importEventsJSONL, 1, 2},
+		}},
+		{"temperature CSV imported twice", ImportTypeTemperature, []step{
+			{"temperature.csv", importTemperatureCSV, 2, 1},
+			{"temperature.csv", importTemperatureCSV, 0, 3},
+		}},
+		{"temperature CSV then JSONL", ImportTypeTemperature, []step{
+			{"temperature.csv", importTemperatureCSV, 2, 1},
+			{"temperature.jsonl", importTemperatureJSONL, 1, 1}, // Timestamps are compared in UTC
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openTestEventDB(t)
+			for i, s := range tt.steps {
+				result, err := ImportFile(strings.NewReader(s.data), s.filename, "", "")
+				if err != nil {
+					t.Fatalf("step %d: ImportFile: %v", i+1, err)
+				}
+				if result.Type != tt.wantType {
+					t.Errorf("step %d: detected type %q, want %q", i+1, result.Type, tt.wantType)
+				}
+				if result.Inserted != s.inserted || result.Skipped != s.skipped || result.Rejected != 0 {
+					t.Errorf("step %d: inserted/skipped/rejected = %d/%d/%d, want %d/%d/0 (errors: %v)",
+						i+1, result.Inserted, result.Skipped, result.Rejected, s.inserted, s.skipped, result.Errors)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRejectsInvalidRows(t *testing.T) {
+	openTestEventDB(t)
+
+	data := `eventTimestamp,eventType,active
+2026-01-10T12:00:00Z,device-error,true
+yesterday,device-error,true
+2026-01-10T12:05:00Z,,true
+2026-01-10T12:10:00Z,device-error,maybe
+`
+	result, err := ImportFile(strings.NewReader(data), "events.csv", "", "")
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if result.Inserted != 1 || result.Rejected != 3 {
+		t.Fatalf("inserted/rejected = %d/%d, want 1/3", result.Inserted, result.Rejected)
+	}
+	for i, prefix := range []string{"line 3: ", "line 4: ", "line 5: "} {
+		if !strings.HasPrefix(result.Errors[i], prefix) {
+			t.Errorf("error %d = %q, want prefix %q", i, result.Errors[i], prefix)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"embed"
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -101,6 +102,27 @@ func canBind(host string, port int) bool {
 }
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "vimock" {
+		runMockCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		runImportCommand(os.Args[2:])
+		return
+	}
+
+	mockMode := flag.Bool("mock", getEnv("VICARE_MOCK", "") == "true", "Use the built-in mock API server instead of the Viessmann cloud")
+	mockAddr := flag.String("mock-addr", getEnv("VICARE_MOCK_ADDRESS", defaultMockAddress), "Listen address of the built-in mock API server")
+	mockFixtures := flag.String("mock-fixtures", os.Getenv("VICARE_MOCK_FIXTURES"), "Directory with mock fixture files (default: embedded fixtures)")
+	flag.Parse()
+
+	if *mockMode {
+		if err := startEmbeddedMockServer(*mockAddr, *mockFixtures); err != nil {
+			log.Fatalf("Failed to start mock API server: %v", err)
+		}
+	}
+
 	// Create application-wide context for graceful shutdown coordination
 	_, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -108,6 +130,11 @@ func main() {
 	// Initialize account management
 	accountTokens = make(map[string]*AccountToken)
 
+	// Load the error code catalogue (embedded + error_codes.json in the config directory)
+	if err := ReloadErrorCodes(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
 	// Try to load credentials from keyring first
 	loadStoredCredentials()
 
@@ -137,6 +164,10 @@ func main() {
 	http.HandleFunc("/api/accounts/toggle", accountToggleHandler)
 	http.HandleFunc("/api/accounts/fullsync", accountFullSyncHandler)
 
+	// Browser login (OAuth redirect flow)
+	http.HandleFunc("/api/oauth/start", oauthStartHandler)
+	http.HandleFunc("/api/oauth/callback", oauthCallbackHandler)
+
 	// Device settings endpoints
 	http.HandleFunc("/api/device-settings/get", deviceSettingsGetHandler)
 	http.HandleFunc("/api/device-settings/set", deviceSettingsSetHandler)
@@ -170,6 +201,12 @@ func main() {
 	http.HandleFunc("/api/status", statusHandler)
 	http.HandleFunc("/api/devices", devicesHandler)
 	http.HandleFunc("/api/features", featuresHandler)
+	http.HandleFunc("/api/features/commands", featureCommandsListHandler)
+	http.HandleFunc("/api/features/command", featureCommandHandler)
+	http.HandleFunc("/api/error-codes", errorCodesHandler)
+	http.HandleFunc("/api/error-codes/reload", errorCodesReloadHandler)
+	http.HandleFunc("/api/error-codes/unknown", unknownErrorCodesHandler)
+	http.HandleFunc("/api/error-codes/unknown/export", unknownErrorCodesExportHandler)
 
 	// SmartClimate endpoints
 	http.HandleFunc("/api/smartclimate/devices", smartClimateDevicesHandler)
@@ -198,6 +235,10 @@ func main() {
 	// API test endpoint
 	http.HandleFunc("/api/test-request", testRequestHandler)
 
+	// Language endpoints (UI and API messages)
+	http.HandleFunc("/api/language/settings", languageSettingsGetHandler)
+	http.HandleFunc("/api/language/settings/set", languageSettingsSetHandler)
+
 	// Event archive endpoints
 	http.HandleFunc("/api/event-archive/settings", eventArchiveSettingsGetHandler)
 	http.HandleFunc("/api/event-archive/settings/set", eventArchiveSettingsSetHandler)
@@ -208,6 +249,83 @@ func main() {
 	http.HandleFunc("/api/temperature-log/settings/set", handleSetTemperatureLogSettings)
 	http.HandleFunc("/api/temperature-log/stats", handleTemperatureLogStats)
 	http.HandleFunc("/api/temperature-log/data", handleTemperatureLogData)
+	http.HandleFunc("/api/temperature-log/rollups", handleTemperatureLogRollups)
+	http.HandleFunc("/api/temperature-log/devices", handleTemperatureLogDevices)
+
+	// Generic feature time series
+	http.HandleFunc("/api/feature-log/settings", featureLogSettingsGetHandler)
+	http.HandleFunc("/api/feature-log/settings/set", featureLogSettingsSetHandler)
+	http.HandleFunc("/api/feature-log/series", featureLogSeriesHandler)
+	http.HandleFunc("/api/feature-log/data", featureLogDataHandler)
+
+	// Fault episodes derived from the archived events
+	http.HandleFunc("/api/fault-episodes", faultEpisodesHandler)
+	http.HandleFunc("/api/fault-episodes/stats", faultEpisodeStatsHandler)
+
+	// Export endpoints (CSV, JSONL, Parquet)
+	http.HandleFunc("/api/export/events", exportEventsHandler)
+	http.HandleFunc("/api/export/temperature", exportTemperatureHandler)
+	http.HandleFunc("/api/import", importHandler)
+
+	// Database backups
+	http.HandleFunc("/api/db/backups", backupsListHandler)
+	http.HandleFunc("/api/db/backups/create", backupCreateHandler)
+	http.HandleFunc("/api/db/backups/restore", backupRestoreHandler)
+	http.HandleFunc("/api/db/backups/download", backupDownloadHandler)
+	http.HandleFunc("/api/db/backups/settings", backupSettingsGetHandler)
+	http.HandleFunc("/api/db/backups/settings/set", backupSettingsSetHandler)
+
+	// Scheduled control actions
+	http.HandleFunc("/api/scheduled-actions", scheduledActionsHandler)
+	http.HandleFunc("/api/scheduled-actions/create", scheduledActionSaveHandler)
+	http.HandleFunc("/api/scheduled-actions/update", scheduledActionSaveHandler)
+	http.HandleFunc("/api/scheduled-actions/delete", scheduledActionDeleteHandler)
+	http.HandleFunc("/api/scheduled-actions/run", scheduledActionRunHandler)
+	http.HandleFunc("/api/scheduled-actions/runs", scheduledActionRunsHandler)
+	http.HandleFunc("/api/scheduled-actions/preview", scheduledActionPreviewHandler)
+
+	// PV surplus automation
+	http.HandleFunc("/api/pv-surplus/settings", pvSurplusSettingsGetHandler)
+	http.HandleFunc("/api/pv-surplus/settings/set", pvSurplusSettingsSetHandler)
+	http.HandleFunc("/api/pv-surplus/status", pvSurplusStatusHandler)
+	http.HandleFunc("/api/pv-surplus/decisions", pvSurplusDecisionsHandler)
+	http.HandleFunc("/api/pv-surplus/stop", pvSurplusStopHandler)
+
+	// Electricity tariffs
+	http.HandleFunc("/api/tariffs/settings", tariffSettingsGetHandler)
+	http.HandleFunc("/api/tariffs/settings/set", tariffSettingsSetHandler)
+	http.HandleFunc("/api/tariffs/assignments", tariffAssignmentsHandler)
+	http.HandleFunc("/api/tariffs/assign", tariffAssignHandler)
+	http.HandleFunc("/api/tariffs/prices", tariffPricesHandler)
+	http.HandleFunc("/api/tariffs/prices/import", tariffPricesImportHandler)
+	http.HandleFunc("/api/tariffs/prices/push", tariffPricesPushHandler)
+	http.HandleFunc("/api/tariffs/compare", tariffCompareHandler)
+
+	// Heating curve analyzer
+	http.HandleFunc("/api/heating-curve/analysis", heatingCurveAnalysisHandler)
+	http.HandleFunc("/api/heating-curve/apply", heatingCurveApplyHandler)
+
+	// Defrost cycles
+	http.HandleFunc("/api/defrosts", defrostCyclesHandler)
+	http.HandleFunc("/api/defrosts/stats", defrostStatsHandler)
+
+	// API rate limit budgets
+	http.HandleFunc("/api/rate-limit/status", apiBudgetStatusHandler)
+
+	// Prometheus metrics
+	http.HandleFunc("/metrics", metricsHandler)
+
+	// Alerting endpoints
+	http.HandleFunc("/api/alerts/settings", alertSettingsGetHandler)
+	http.HandleFunc("/api/alerts/settings/set", alertSettingsSetHandler)
+	http.HandleFunc("/api/alerts/test", alertTestHandler)
+	http.HandleFunc("/api/alerts/active", alertStatesHandler)
+
+	// MQTT / Home Assistant endpoints
+	http.HandleFunc("/api/mqtt/settings", mqttSettingsGetHandler)
+	http.HandleFunc("/api/mqtt/settings/set", mqttSettingsSetHandler)
+	http.HandleFunc("/api/mqtt/status", mqttStatusHandler)
+	http.HandleFunc("/api/mqtt/publish", mqttPublishHandler)
 
 	// Consumption statistics endpoint
 	http.HandleFunc("/api/consumption/stats", HandleConsumptionStats)
@@ -227,6 +345,27 @@ func main() {
 		if err != nil {
 			log.Printf("Temperature scheduler initialization: %v", err)
 		}
+
+		// Start database backups if enabled
+		err = StartBackupScheduler()
+		if err != nil {
+			log.Printf("Backup scheduler initialization: %v", err)
+		}
+
+		// Start scheduled control actions (idle without event database)
+		StartActionScheduler()
+
+		// Start PV surplus automation if enabled
+		err = StartPVSurplusScheduler()
+		if err != nil {
+			log.Printf("PV surplus scheduler initialization: %v", err)
+		}
+
+		// Start MQTT publisher if enabled
+		err = StartMQTT()
+		if err != nil {
+			log.Printf("MQTT initialization: %v", err)
+		}
 	}()
 
 	// Get bind address from environment, with backward compatibility for PORT
@@ -277,6 +416,18 @@ func main() {
 	log.Println("Stopping temperature scheduler...")
 	StopTemperatureScheduler()
 
+	log.Println("Stopping MQTT publisher...")
+	StopMQTT()
+
+	log.Println("Stopping backup scheduler...")
+	StopBackupScheduler()
+
+	log.Println("Stopping action scheduler...")
+	StopActionScheduler()
+
+	log.Println("Stopping PV surplus scheduler...")
+	StopPVSurplusScheduler()
+
 	// Give schedulers time to finish current operations
 	time.Sleep(500 * time.Millisecond)
 
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobStats holds the run statistics of a background job
+type JobStats struct {
+	Runs         int64
+	Errors       int64
+	LastRun      time.Time
+	LastDuration time.Duration
+	LastError    string
+}
+
+var (
+	jobStats      = make(map[string]*JobStats) // Key: job name (event_archive, temperature_log, db_backup)
+	jobStatsMutex sync.Mutex
+
+	// Latest temperature snapshot per installation/gateway/device
+	latestSnapshots      = make(map[string]*TemperatureSnapshot)
+	latestSnapshotsMutex sync.RWMutex
+)
+
+// recordJobRun stores the outcome of a background job run
+func recordJobRun(job string, start time.Time, err error) {
+	jobStatsMutex.Lock()
+	defer jobStatsMutex.Unlock()
+
+	stats, ok := jobStats[job]
+	if !ok {
+		stats = &JobStats{}
+		jobStats[job] = stats
+	}
+	stats.Runs++
+	stats.LastRun = start
+	stats.LastDuration = time.Since(start)
+	stats.LastError = ""
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err.Error()
+	}
+}
+
+// getJobStats returns a copy of the statistics of a job
+func getJobStats(job string) (JobStats, bool) {
+	jobStatsMutex.Lock()
+	defer jobStatsMutex.Unlock()
+
+	stats, ok := jobStats[job]
+	if !ok {
+		return JobStats{}, false
+	}
+	return *stats, true
+}
+
+// recordLatestSnapshot keeps the most recent snapshot of a device for the metrics endpoint
+func recordLatestSnapshot(snapshot *TemperatureSnapshot) {
+	key := snapshot.InstallationID + "/" + snapshot.GatewayID + "/" + snapshot.DeviceID
+	latestSnapshotsMutex.Lock()
+	latestSnapshots[key] = snapshot
+	latestSnapshotsMutex.Unlock()
+}
+
+// metricsWriter writes the Prometheus text exposition format
+type metricsWriter struct {
+	sb      strings.Builder
+	written map[string]bool
+}
+
+// header writes HELP and TYPE once per metric family
+func (m *metricsWriter) header(name, metricType, help string) {
+	if m.written == nil {
+		m.written = make(map[string]bool)
+	}
+	if m.written[name] {
+		return
+	}
+	m.written[name] = true
+	fmt.Fprintf(&m.sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// sample writes one sample, labels are given as name/value pairs
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	m.sb.WriteString(name)
+	if len(labels) > 0 {
+		m.sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.sb.WriteByte(',')
+			}
+			m.sb.WriteString(labels[i])
+			m.sb.WriteString(`="`)
+			m.sb.WriteString(escapeLabelValue(labels[i+1]))
+			m.sb.WriteByte('"')
+		}
+		m.sb.WriteByte('}')
+	}
+	m.sb.WriteByte(' ')
+	m.sb.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	m.sb.WriteByte('\n')
+}
+
+func (m *metricsWriter) gauge(name, help string, value float64, labels ...string) {
+	m.header(name, "gauge", help)
+	m.sample(name, value, labels...)
+}
+
+func (m *metricsWriter) counter(name, help string, value float64, labels ...string) {
+	m.header(name, "counter", help)
+	m.sample(name, value, labels...)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// metricsHandler handles GET /metrics in Prometheus text format
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m := &metricsWriter{}
+	m.gauge("vieventlog_build_info", "Build information", 1, "version", version)
+
+	writeSnapshotMetrics(m)
+	writeAPIUsageMetrics(m)
+	writeSchedulerMetrics(m)
+	writeDatabaseMetrics(m)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := w.Write([]byte(m.sb.String())); err != nil {
+		log.Printf("Error writing metrics: %v", err)
+	}
+}
+
+// writeSnapshotMetrics exposes all set fields of the latest temperature snapshot per device.
+// Numeric and boolean fields become vieventlog_snapshot_<field>, text fields vieventlog_snapshot_<field>_info.
+func writeSnapshotMetrics(m *metricsWriter) {
+	latestSnapshotsMutex.RLock()
+	keys := make([]string, 0, len(latestSnapshots))
+	for key := range latestSnapshots {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	snapshots := make([]*TemperatureSnapshot, 0, len(keys))
+	for _, key := range keys {
+		snapshots = append(snapshots, latestSnapshots[key])
+	}
+	latestSnapshotsMutex.RUnlock()
+
+	for _, snapshot := range snapshots {
+		labels := []string{
+			"installation_id", snapshot.InstallationID,
+			"gateway_id", snapshot.GatewayID,
+			"device_id", snapshot.DeviceID,
+			"account_name", snapshot.AccountName,
+		}
+		m.gauge("vieventlog_snapshot_timestamp_seconds", "Time of the latest temperature snapshot", float64(snapshot.Timestamp.Unix()), labels...)
+	}
+
+	snapshotType := reflect.TypeOf(TemperatureSnapshot{})
+	for i := 0; i < snapshotType.NumField(); i++ {
+		field := snapshotType.Field(i)
+		if field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		for _, snapshot := range snapshots {
+			value := reflect.ValueOf(snapshot).Elem().Field(i)
+			if value.IsNil() {
+				continue
+			}
+			labels := []string{
+				"installation_id", snapshot.InstallationID,
+				"gateway_id", snapshot.GatewayID,
+				"device_id", snapshot.DeviceID,
+				"account_name", snapshot.AccountName,
+			}
+
+			switch v := value.Elem().Interface().(type) {
+			case float64:
+				m.gauge("vieventlog_snapshot_"+name, "Latest snapshot value of "+name, v, labels...)
+			case bool:
+				m.gauge("vieventlog_snapshot_"+name, "Latest snapshot value of "+name+" (1 = active)", boolToFloat(v), labels...)
+			case string:
+				m.gauge("vieventlog_snapshot_"+name+"_info", "Latest snapshot state of "+name, 1, append(labels, "value", v)...)
+			}
+		}
+	}
+}
+
+// writeAPIUsageMetrics exposes the API budget usage against the limits
+func writeAPIUsageMetrics(m *metricsWriter) {
+	limit10Min, limit24Hr := GetAPIRateLimits()
+	usage10Min, usage24Hr := getAPIUsage()
+
+	m.gauge("vieventlog_api_usage", "API requests of the most loaded client ID in the window", float64(usage10Min), "window", "10m")
+	m.sample("vieventlog_api_usage", float64(usage24Hr), "window", "24h")
+	m.gauge("vieventlog_api_limit", "API request limit per client ID in the window", float64(limit10Min), "window", "10m")
+	m.sample("vieventlog_api_limit", float64(limit24Hr), "window", "24h")
+
+	budgets := GetAPIBudgetStatus()
+	for _, status := range budgets {
+		m.gauge("vieventlog_api_client_usage", "API requests per client ID in the window", float64(status.Used10Min), "client_id", status.ClientID, "window", "10m")
+		m.sample("vieventlog_api_client_usage", float64(status.Used24Hr), "client_id", status.ClientID, "window", "24h")
+	}
+	for _, status := range budgets {
+		priorities := make([]string, 0, len(status.ByPriority24h))
+		for priority := range status.ByPriority24h {
+			priorities = append(priorities, priority)
+		}
+		sort.Strings(priorities)
+		for _, priority := range priorities {
+			m.gauge("vieventlog_api_client_usage_by_priority", "API requests per client ID and priority in the last 24h", float64(status.ByPriority24h[priority]), "client_id", status.ClientID, "priority", priority)
+		}
+	}
+	now := time.Now()
+	for _, status := range budgets {
+		blocked := status.BlockedUntil != nil && now.Before(*status.BlockedUntil)
+		m.gauge("vieventlog_api_client_blocked", "Client ID is paused after a 429 of the API", boolToFloat(blocked), "client_id", status.ClientID)
+	}
+}
+
+// writeSchedulerMetrics exposes the state and job statistics of the background schedulers
+func writeSchedulerMetrics(m *metricsWriter) {
+	m.gauge("vieventlog_scheduler_running", "Scheduler is running", boolToFloat(IsSchedulerRunning()), "scheduler", "event_archive")
+	m.sample("vieventlog_scheduler_running", boolToFloat(IsTemperatureSchedulerRunning()), "scheduler", "temperature_log")
+	m.sample("vieventlog_scheduler_running", boolToFloat(IsBackupSchedulerRunning()), "scheduler", "db_backup")
+	m.gauge("vieventlog_mqtt_connected", "MQTT publisher is connected to the broker", boolToFloat(IsMQTTConnected()))
+
+	jobStatsMutex.Lock()
+	names := make([]string, 0, len(jobStats))
+	for name := range jobStats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	stats := make([]JobStats, 0, len(names))
+	for _, name := range names {
+		stats = append(stats, *jobStats[name])
+	}
+	jobStatsMutex.Unlock()
+
+	// Samples of a metric family have to be written together
+	for i, name := range names {
+		m.counter("vieventlog_job_runs_total", "Number of job runs since start", float64(stats[i].Runs), "job", name)
+	}
+	for i, name := range names {
+		m.counter("vieventlog_job_errors_total", "Number of failed job runs since start", float64(stats[i].Errors), "job", name)
+	}
+	for i, name := range names {
+		m.gauge("vieventlog_job_last_run_timestamp_seconds", "Start time of the last job run", float64(stats[i].LastRun.Unix()), "job", name)
+	}
+	for i, name := range names {
+		m.gauge("vieventlog_job_last_duration_seconds", "Duration of the last job run", stats[i].LastDuration.Seconds(), "job", name)
+	}
+	for i, name := range names {
+		m.gauge("vieventlog_job_last_success", "Last job run finished without error", boolToFloat(stats[i].LastError == ""), "job", name)
+	}
+}
+
+// writeDatabaseMetrics exposes event counts and the database size
+func writeDatabaseMetrics(m *metricsWriter) {
+	m.gauge("vieventlog_database_up", "Event database is initialized", boolToFloat(dbInitialized))
+	if !dbInitialized {
+		return
+	}
+
+	if size, err := GetDatabaseSize(); err == nil {
+		m.gauge("vieventlog_database_size_bytes", "Size of the SQLite database file", float64(size))
+	}
+
+	if counts, err := GetEventCountsByCategory(); err == nil {
+		for _, c := range counts {
+			m.gauge("vieventlog_events", "Stored events by severity and code category", float64(c.Count), "severity", c.Severity, "code_category", c.CodeCategory)
+		}
+	} else {
+		log.Printf("Metrics: %v", err)
+	}
+
+	if count, err := GetTemperatureSnapshotCount(); err == nil {
+		m.gauge("vieventlog_temperature_snapshots", "Stored temperature snapshots", float64(count))
+	}
+}
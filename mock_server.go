@@ -0,0 +1,733 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fixture files for the local mock API server (vimock)
+//
+//go:embed mock/*.json mock/features/*.json mock/events/*.json
+var mockFixturesFS embed.FS
+
+const defaultMockAddress = "127.0.0.1:5099"
+
+// MockServer emulates the parts of the Viessmann IoT API and IAM used by this application.
+// Fixtures are loaded from the embedded mock/ directory or from an external directory with the same layout:
+//
+//	installations.json                                  - response of /iot/v2/equipment/installations
+//	features/<installation>_<gateway>_<device>.json     - response of the features endpoint per device
+//	events/<installation>.json                          - events-history entries (newest first)
+type MockServer struct {
+	mu            sync.Mutex
+	installations []map[string]interface{}
+	features      map[string][]map[string]interface{} // key: installation:gateway:device
+	events        map[string][]map[string]interface{} // key: installation
+	authCodes     map[string]string                   // code -> PKCE code_challenge
+	refreshTokens map[string]bool
+	faults        []mockFault // Injected errors, see VICARE_MOCK_FAULTS
+}
+
+// mockFault makes a share of the IoT API requests fail with the given status
+type mockFault struct {
+	Status int
+	Rate   float64
+}
+
+// parseMockFaults parses a fault specification like "429=0.1,502=0.05,401=0.02"
+func parseMockFaults(spec string) ([]mockFault, error) {
+	var faults []mockFault
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		statusStr, rateStr, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fault %q, expected status=rate", part)
+		}
+		status, err := strconv.Atoi(strings.TrimSpace(statusStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid fault status %q", statusStr)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid fault rate %q, expected 0..1", rateStr)
+		}
+		faults = append(faults, mockFault{Status: status, Rate: rate})
+	}
+	return faults, nil
+}
+
+// NewMockServer loads the fixtures and prepares the in-memory state.
+// If fixturesDir is empty, the embedded fixtures are used.
+func NewMockServer(fixturesDir string) (*MockServer, error) {
+	var fixtures fs.FS = mockFixturesFS
+	root := "mock"
+	if fixturesDir != "" {
+		fixtures = os.DirFS(fixturesDir)
+		root = "."
+	}
+
+	m := &MockServer{
+		features:      make(map[string][]map[string]interface{}),
+		events:        make(map[string][]map[string]interface{}),
+		authCodes:     make(map[string]string),
+		refreshTokens: make(map[string]bool),
+	}
+
+	faults, err := parseMockFaults(os.Getenv("VICARE_MOCK_FAULTS"))
+	if err != nil {
+		return nil, err
+	}
+	m.faults = faults
+
+	var installations struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := readMockFixture(fixtures, path.Join(root, "installations.json"), &installations); err != nil {
+		return nil, err
+	}
+	m.installations = installations.Data
+
+	featureFiles, _ := fs.Glob(fixtures, path.Join(root, "features", "*.json"))
+	for _, file := range featureFiles {
+		parts := strings.SplitN(strings.TrimSuffix(path.Base(file), ".json"), "_", 3)
+		if len(parts) != 3 {
+			log.Printf("Mock: ignoring feature fixture with unexpected name: %s", file)
+			continue
+		}
+		var resp struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := readMockFixture(fixtures, file, &resp); err != nil {
+			return nil, err
+		}
+		m.features[strings.Join(parts, ":")] = resp.Data
+	}
+
+	eventFiles, _ := fs.Glob(fixtures, path.Join(root, "events", "*.json"))
+	for _, file := range eventFiles {
+		var resp struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := readMockFixture(fixtures, file, &resp); err != nil {
+			return nil, err
+		}
+		m.events[strings.TrimSuffix(path.Base(file), ".json")] = rebaseMockEvents(resp.Data)
+	}
+
+	log.Printf("Mock: loaded %d installation(s), %d device feature set(s), %d event list(s)",
+		len(m.installations), len(m.features), len(m.events))
+	for _, f := range m.faults {
+		log.Printf("Mock: injecting status %d for %.0f%% of the API requests", f.Status, f.Rate*100)
+	}
+	return m, nil
+}
+
+func readMockFixture(fixtures fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(fixtures, name)
+	if err != nil {
+		return fmt.Errorf("failed to read mock fixture %s: %v", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse mock fixture %s: %v", name, err)
+	}
+	return nil
+}
+
+// rebaseMockEvents shifts the fixture timestamps so that the newest event happened one hour ago.
+// Otherwise fixed fixture dates would quickly fall out of the lastNDays window.
+func rebaseMockEvents(events []map[string]interface{}) []map[string]interface{} {
+	var newest time.Time
+	for _, e := range events {
+		if ts, err := time.Parse(time.RFC3339, fmt.Sprint(e["eventTimestamp"])); err == nil && ts.After(newest) {
+			newest = ts
+		}
+	}
+	if newest.IsZero() {
+		return events
+	}
+
+	shift := time.Now().UTC().Add(-time.Hour).Truncate(time.Minute).Sub(newest)
+	for _, e := range events {
+		for _, key := range []string{"eventTimestamp", "createdAt"} {
+			if ts, err := time.Parse(time.RFC3339, fmt.Sprint(e[key])); err == nil {
+				e[key] = ts.Add(shift).Format("2006-01-02T15:04:05.000Z")
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return fmt.Sprint(events[i]["eventTimestamp"]) > fmt.Sprint(events[j]["eventTimestamp"])
+	})
+	return events
+}
+
+// Handler returns the HTTP handler serving the mock IoT API and IAM endpoints
+func (m *MockServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// IAM
+	mux.HandleFunc("/idp/v3/authorize", m.handleAuthorize)
+	mux.HandleFunc("POST /idp/v3/token", m.handleToken)
+
+	// IoT API
+	mux.HandleFunc("GET /iot/v2/equipment/installations", m.requireToken(m.handleInstallations))
+	mux.HandleFunc("GET /iot/v2/equipment/installations/{installation}/gateways", m.requireToken(m.handleGateways))
+	mux.HandleFunc("GET /iot/v2/features/installations/{installation}/gateways/{gateway}/devices/{device}/features",
+		m.requireToken(m.handleFeatures))
+	mux.HandleFunc("GET /iot/v2/features/installations/{installation}/gateways/{gateway}/devices/{device}/features/{feature}",
+		m.requireToken(m.handleFeature))
+	mux.HandleFunc("POST /iot/v2/features/installations/{installation}/gateways/{gateway}/devices/{device}/features/{feature}/commands/{command}",
+		m.requireToken(m.handleCommand))
+	mux.HandleFunc("GET /iot/v2/events-history/installations/{installation}/events", m.requireToken(m.handleEvents))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("Mock: no fixture for %s %s", r.Method, r.URL.Path)
+		writeMockError(w, http.StatusNotFound, "NOT_FOUND", "Resource not available in mock")
+	})
+
+	return mux
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeMockError writes an error body in the format of the Viessmann API
+func writeMockError(w http.ResponseWriter, status int, errorType, message string) {
+	writeMockJSON(w, status, map[string]interface{}{
+		"viErrorId":  randomMockToken(8),
+		"statusCode": status,
+		"errorType":  errorType,
+		"message":    message,
+	})
+}
+
+func randomMockToken(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// requireToken rejects requests without bearer token. Any token issued by a previous
+// mock instance is accepted so the application survives a mock restart.
+func (m *MockServer) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeMockError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Missing access token")
+			return
+		}
+		if m.injectFault(w, r) {
+			return
+		}
+		next(w, r)
+	}
+}
+
+// injectFault answers a request with one of the configured errors, the errors
+// mimic the bodies of the real API
+func (m *MockServer) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	for _, f := range m.faults {
+		if mathrand.Float64() >= f.Rate {
+			continue
+		}
+		log.Printf("Mock: injecting %d for %s %s", f.Status, r.Method, r.URL.Path)
+		switch f.Status {
+		case http.StatusTooManyRequests:
+			w.Header().Set("Retry-After", "2")
+			writeMockError(w, f.Status, "RATE_LIMIT_EXCEEDED", "API calls rate limit has been exceeded. Please wait until your limit will be renewed.")
+		case http.StatusUnauthorized:
+			writeMockError(w, f.Status, "EXPIRED TOKEN", "Token expired")
+		case http.StatusBadGateway:
+			writeMockError(w, f.Status, "DEVICE_COMMUNICATION_ERROR", "Gateway is offline")
+		default:
+			writeMockError(w, f.Status, "INTERNAL_SERVER_ERROR", "Injected mock error")
+		}
+		return true
+	}
+	return false
+}
+
+func (m *MockServer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	redirect := r.Form.Get("redirect_uri")
+	if redirect == "" || r.Form.Get("client_id") == "" {
+		http.Error(w, "client_id and redirect_uri are required", http.StatusBadRequest)
+		return
+	}
+
+	code := randomMockToken(16)
+	m.mu.Lock()
+	m.authCodes[code] = r.Form.Get("code_challenge")
+	m.mu.Unlock()
+
+	target, err := url.Parse(redirect)
+	if err != nil {
+		http.Error(w, "Invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	q := target.Query()
+	q.Set("code", code)
+	if state := r.Form.Get("state"); state != "" {
+		q.Set("state", state)
+	}
+	target.RawQuery = q.Encode()
+
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func (m *MockServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch r.Form.Get("grant_type") {
+	case "authorization_code":
+		challenge, ok := m.authCodes[r.Form.Get("code")]
+		if !ok {
+			writeMockJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(m.authCodes, r.Form.Get("code"))
+		if challenge != "" {
+			sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				writeMockJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+				return
+			}
+		}
+	case "refresh_token":
+		// Refresh tokens from earlier mock runs are unknown, accept them anyway
+		if r.Form.Get("refresh_token") == "" {
+			writeMockJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+	case "password":
+	default:
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	refresh := randomMockToken(24)
+	m.refreshTokens[refresh] = true
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  randomMockToken(32),
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+	})
+}
+
+// pageMockData applies the cursor/limit pagination of the Viessmann API
+func pageMockData(r *http.Request, data []map[string]interface{}, defaultLimit int) map[string]interface{} {
+	limit := defaultLimit
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		if raw, err := base64.RawURLEncoding.DecodeString(cursor); err == nil {
+			offset, _ = strconv.Atoi(string(raw))
+		}
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	end := offset + limit
+	if end > len(data) {
+		end = len(data)
+	}
+
+	cursor := map[string]interface{}{}
+	if end < len(data) {
+		cursor["next"] = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+
+	return map[string]interface{}{
+		"data":   data[offset:end],
+		"cursor": cursor,
+	}
+}
+
+func (m *MockServer) handleInstallations(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := make([]map[string]interface{}, 0, len(m.installations))
+	for _, inst := range m.installations {
+		entry := make(map[string]interface{}, len(inst))
+		for k, v := range inst {
+			if k == "gateways" && r.URL.Query().Get("includeGateways") != "true" {
+				continue
+			}
+			entry[k] = v
+		}
+		data = append(data, entry)
+	}
+
+	writeMockJSON(w, http.StatusOK, pageMockData(r, data, 1000))
+}
+
+func (m *MockServer) handleGateways(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, inst := range m.installations {
+		if fmt.Sprint(inst["id"]) != r.PathValue("installation") {
+			continue
+		}
+		gateways, _ := inst["gateways"].([]interface{})
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"data": gateways})
+		return
+	}
+	writeMockError(w, http.StatusNotFound, "INSTALLATION_NOT_FOUND", "Unknown installation")
+}
+
+func mockFeaturesKey(r *http.Request) string {
+	return r.PathValue("installation") + ":" + r.PathValue("gateway") + ":" + r.PathValue("device")
+}
+
+// withSensorDrift returns a copy of a feature where numeric sensor values vary slightly over time,
+// so charts and the temperature log get some movement
+func withSensorDrift(feature map[string]interface{}, now time.Time) map[string]interface{} {
+	name, _ := feature["feature"].(string)
+	if !strings.Contains(name, ".sensors.") {
+		return feature
+	}
+
+	props, ok := feature["properties"].(map[string]interface{})
+	if !ok {
+		return feature
+	}
+	value, ok := props["value"].(map[string]interface{})
+	if !ok {
+		return feature
+	}
+	base, ok := value["value"].(float64)
+	if !ok {
+		return feature
+	}
+
+	// Period of roughly two hours, phase depends on the feature name
+	phase := float64(len(name))
+	drift := math.Sin(float64(now.Unix())/1200+phase) * math.Max(0.5, math.Abs(base)*0.03)
+
+	newValue := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		newValue[k] = v
+	}
+	newValue["value"] = math.Round((base+drift)*10) / 10
+
+	newProps := make(map[string]interface{}, len(props))
+	for k, v := range props {
+		newProps[k] = v
+	}
+	newProps["value"] = newValue
+
+	copied := make(map[string]interface{}, len(feature))
+	for k, v := range feature {
+		copied[k] = v
+	}
+	copied["properties"] = newProps
+	return copied
+}
+
+func (m *MockServer) handleFeatures(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	features, ok := m.features[mockFeaturesKey(r)]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "DEVICE_NOT_FOUND", "No feature fixture for this device")
+		return
+	}
+
+	now := time.Now()
+	data := make([]map[string]interface{}, 0, len(features))
+	for _, f := range features {
+		data = append(data, withSensorDrift(f, now))
+	}
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (m *MockServer) findFeature(key, name string) map[string]interface{} {
+	for _, f := range m.features[key] {
+		if f["feature"] == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (m *MockServer) handleFeature(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	feature := m.findFeature(mockFeaturesKey(r), r.PathValue("feature"))
+	if feature == nil {
+		writeMockError(w, http.StatusNotFound, "FEATURE_NOT_FOUND", "Unknown feature")
+		return
+	}
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"data": withSensorDrift(feature, time.Now())})
+}
+
+// handleCommand executes a feature command against the in-memory fixture state.
+// Parameters are validated against the command metadata of the feature.
+func (m *MockServer) handleCommand(w http.ResponseWriter, r *http.Request) {
+	var params map[string]interface{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			writeMockError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid JSON body")
+			return
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	featureName := r.PathValue("feature")
+	commandName := r.PathValue("command")
+	feature := m.findFeature(mockFeaturesKey(r), featureName)
+	if feature == nil {
+		writeMockError(w, http.StatusNotFound, "FEATURE_NOT_FOUND", "Unknown feature")
+		return
+	}
+
+	commands, _ := feature["commands"].(map[string]interface{})
+	command, ok := commands[commandName].(map[string]interface{})
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "COMMAND_NOT_FOUND", "Unknown command")
+		return
+	}
+	if executable, ok := command["isExecutable"].(bool); ok && !executable {
+		writeMockError(w, http.StatusBadRequest, "COMMAND_NOT_EXECUTABLE", "Command is currently not executable")
+		return
+	}
+	if err := validateMockCommandParams(command, params); err != nil {
+		writeMockError(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	applyMockCommand(feature, commandName, params)
+	feature["timestamp"] = time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+	// Record a feature-changed event like the real API does
+	now := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+	event := map[string]interface{}{
+		"eventTimestamp": now,
+		"createdAt":      now,
+		"eventType":      "feature-changed",
+		"gatewaySerial":  r.PathValue("gateway"),
+		"body": map[string]interface{}{
+			"deviceId":    r.PathValue("device"),
+			"featureName": featureName,
+			"commandName": commandName,
+			"commandBody": params,
+		},
+	}
+	installation := r.PathValue("installation")
+	m.events[installation] = append([]map[string]interface{}{event}, m.events[installation]...)
+
+	log.Printf("Mock: executed %s/%s on %s with %v", featureName, commandName, mockFeaturesKey(r), params)
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"success": true,
+			"message": nil,
+			"reason":  "COMMAND_EXECUTION_SUCCESS",
+		},
+	})
+}
+
+func validateMockCommandParams(command map[string]interface{}, params map[string]interface{}) error {
+	defs, _ := command["params"].(map[string]interface{})
+	for name, rawDef := range defs {
+		def, _ := rawDef.(map[string]interface{})
+		value, present := params[name]
+		if !present {
+			if required, _ := def["required"].(bool); required {
+				return fmt.Errorf("missing required parameter %q", name)
+			}
+			continue
+		}
+
+		constraints, _ := def["constraints"].(map[string]interface{})
+		switch def["type"] {
+		case "number":
+			num, ok := value.(float64)
+			if !ok {
+				return fmt.Errorf("parameter %q must be a number", name)
+			}
+			if min, ok := constraints["min"].(float64); ok && num < min {
+				return fmt.Errorf("parameter %q below minimum %v", name, min)
+			}
+			if max, ok := constraints["max"].(float64); ok && num > max {
+				return fmt.Errorf("parameter %q above maximum %v", name, max)
+			}
+		case "string":
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("parameter %q must be a string", name)
+			}
+			if enum, ok := constraints["enum"].([]interface{}); ok {
+				allowed := false
+				for _, e := range enum {
+					if e == str {
+						allowed = true
+						break
+					}
+				}
+				if !allowed {
+					return fmt.Errorf("parameter %q has invalid value %q", name, str)
+				}
+			}
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("parameter %q must be a boolean", name)
+			}
+		}
+	}
+
+	for name := range params {
+		if _, ok := defs[name]; !ok {
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+	}
+	return nil
+}
+
+// applyMockCommand updates the feature properties according to the command parameters
+func applyMockCommand(feature map[string]interface{}, commandName string, params map[string]interface{}) {
+	props, ok := feature["properties"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	setProp := func(name string, value interface{}) bool {
+		prop, ok := props[name].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		prop["value"] = value
+		return true
+	}
+
+	switch commandName {
+	case "activate", "on":
+		setProp("active", true)
+		return
+	case "deactivate", "off":
+		setProp("active", false)
+		return
+	}
+
+	for name, value := range params {
+		switch {
+		case setProp(name, value):
+		case name == "targetTemperature" && setProp("temperature", value):
+		case commandName == "setMax" && setProp("max", value):
+		case commandName == "setMin" && setProp("min", value):
+		case name == "hysteresis" && commandName == "setHysteresisSwitchOnValue":
+			setProp("switchOnValue", value)
+			setProp("value", value)
+		case name == "hysteresis" && commandName == "setHysteresisSwitchOffValue":
+			setProp("switchOffValue", value)
+		default:
+			setProp("value", value)
+		}
+	}
+}
+
+func (m *MockServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := m.events[r.PathValue("installation")]
+
+	days := 7
+	if d, err := strconv.Atoi(r.URL.Query().Get("lastNDays")); err == nil && d > 0 {
+		days = d
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -days)
+
+	filtered := make([]map[string]interface{}, 0, len(events))
+	for _, e := range events {
+		ts, err := time.Parse(time.RFC3339, fmt.Sprint(e["eventTimestamp"]))
+		if err == nil && ts.Before(cutoff) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+
+	writeMockJSON(w, http.StatusOK, pageMockData(r, filtered, 1000))
+}
+
+// startEmbeddedMockServer starts the mock server in the background and points the API client at it
+func startEmbeddedMockServer(addr, fixturesDir string) error {
+	mock, err := NewMockServer(fixturesDir)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		log.Printf("Mock API server listening on http://%s", addr)
+		if err := http.ListenAndServe(addr, mock.Handler()); err != nil {
+			log.Fatalf("Mock API server error: %v", err)
+		}
+	}()
+
+	baseURL := "http://" + addr
+	setAPIBaseURLs(baseURL, baseURL)
+	return nil
+}
+
+// runMockCommand implements the "vimock" subcommand: it only serves the mock API
+func runMockCommand(args []string) {
+	fset := flag.NewFlagSet("vimock", flag.ExitOnError)
+	addr := fset.String("addr", getEnv("VICARE_MOCK_ADDRESS", defaultMockAddress), "Listen address of the mock API server")
+	fixtures := fset.String("fixtures", os.Getenv("VICARE_MOCK_FIXTURES"), "Directory with fixture files (default: embedded fixtures)")
+	fset.Parse(args)
+
+	mock, err := NewMockServer(*fixtures)
+	if err != nil {
+		log.Fatalf("Failed to start mock API server: %v", err)
+	}
+
+	log.Printf("Mock API server listening on http://%s", *addr)
+	log.Printf("Point vieventlog at it with VICARE_API_BASE_URL=http://%s VICARE_IAM_BASE_URL=http://%s", *addr, *addr)
+	if err := http.ListenAndServe(*addr, mock.Handler()); err != nil {
+		log.Fatalf("Mock API server error: %v", err)
+	}
+}
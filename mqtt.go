@@ -0,0 +1,943 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTSettings holds the broker connection and the per-installation MQTT configuration
+type MQTTSettings struct {
+	Enabled         bool                                 `json:"enabled"`
+	BrokerURL       string                               `json:"brokerUrl"` // e.g. tcp://localhost:1883 or ssl://broker:8883
+	Username        string                               `json:"username,omitempty"`
+	Password        string                               `json:"password,omitempty"`
+	ClientID        string                               `json:"clientId,omitempty"` // MQTT client ID (default: vieventlog-<hostname>)
+	TopicPrefix     string                               `json:"topicPrefix"`        // Base topic (default: vieventlog)
+	Discovery       bool                                 `json:"discovery"`          // Publish Home Assistant discovery configs
+	DiscoveryPrefix string                               `json:"discoveryPrefix"`    // Home Assistant discovery prefix (default: homeassistant)
+	PublishInterval int                                  `json:"publishInterval"`    // Minutes between publishing device values
+	Installations   map[string]*MQTTInstallationSettings `json:"installations"`      // Key: installation ID
+}
+
+// MQTTInstallationSettings controls what is published for a single installation
+type MQTTInstallationSettings struct {
+	Enabled       bool   `json:"enabled"`
+	AccountID     string `json:"accountId,omitempty"` // Account used for commands (default: first active account with this installation)
+	PublishEvents bool   `json:"publishEvents"`       // Publish newly archived events
+	AllowCommands bool   `json:"allowCommands"`       // Accept control commands on the set topics
+}
+
+const (
+	defaultMQTTTopicPrefix     = "vieventlog"
+	defaultMQTTDiscoveryPrefix = "homeassistant"
+	defaultMQTTPublishInterval = 5
+)
+
+// GetMQTTSettings retrieves the MQTT settings (defaults if not configured)
+func GetMQTTSettings() (*MQTTSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.MQTTSettings
+	if settings == nil {
+		settings = &MQTTSettings{
+			BrokerURL: "tcp://localhost:1883",
+			Discovery: true,
+		}
+	}
+	applyMQTTDefaults(settings)
+	return settings, nil
+}
+
+// SetMQTTSettings updates the MQTT settings
+func SetMQTTSettings(settings *MQTTSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	store.MQTTSettings = settings
+	return SaveAccounts(store)
+}
+
+func applyMQTTDefaults(settings *MQTTSettings) {
+	if settings.TopicPrefix == "" {
+		settings.TopicPrefix = defaultMQTTTopicPrefix
+	}
+	settings.TopicPrefix = strings.TrimSuffix(settings.TopicPrefix, "/")
+	if settings.DiscoveryPrefix == "" {
+		settings.DiscoveryPrefix = defaultMQTTDiscoveryPrefix
+	}
+	if settings.PublishInterval < 1 {
+		settings.PublishInterval = defaultMQTTPublishInterval
+	}
+	if settings.ClientID == "" {
+		hostname, _ := os.Hostname()
+		settings.ClientID = "vieventlog-" + hostname
+	}
+	if settings.Installations == nil {
+		settings.Installations = make(map[string]*MQTTInstallationSettings)
+	}
+}
+
+var (
+	mqttMutex    sync.Mutex
+	mqttClient   mqtt.Client
+	mqttSettings *MQTTSettings
+	mqttStop     chan bool
+	mqttRunning  bool
+
+	// Discovery configs already sent on the current connection
+	mqttDiscoveryMutex sync.Mutex
+	mqttDiscoverySent  map[string]bool
+
+	// Status of the publisher, written by the publish job and the connection handlers
+	mqttStatusMutex sync.Mutex
+	mqttLastPublish time.Time
+	mqttLastError   string
+)
+
+// setMQTTLastError remembers the last error for the status page
+func setMQTTLastError(err error) {
+	mqttStatusMutex.Lock()
+	mqttLastError = err.Error()
+	mqttStatusMutex.Unlock()
+}
+
+// StartMQTT connects to the broker and starts the publish loop if MQTT is enabled
+func StartMQTT() error {
+	mqttMutex.Lock()
+	defer mqttMutex.Unlock()
+
+	if mqttRunning {
+		log.Println("MQTT publisher already running")
+		return nil
+	}
+
+	settings, err := GetMQTTSettings()
+	if err != nil {
+		return err
+	}
+
+	if !settings.Enabled {
+		log.Println("MQTT is disabled, publisher not started")
+		return nil
+	}
+	if settings.BrokerURL == "" {
+		return fmt.Errorf("no MQTT broker configured")
+	}
+
+	statusTopic := settings.TopicPrefix + "/status"
+	opts := mqtt.NewClientOptions().
+		AddBroker(settings.BrokerURL).
+		SetClientID(settings.ClientID).
+		SetUsername(settings.Username).
+		SetPassword(settings.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(30*time.Second).
+		SetWill(statusTopic, "offline", 1, true)
+
+	opts.SetOnConnectHandler(func(c mqtt.Client) {
+		log.Printf("MQTT connected to %s", settings.BrokerURL)
+		mqttDiscoveryMutex.Lock()
+		mqttDiscoverySent = make(map[string]bool)
+		mqttDiscoveryMutex.Unlock()
+
+		c.Publish(statusTopic, 1, true, "online")
+
+		commandTopic := settings.TopicPrefix + "/+/+/+/set/+"
+		if token := c.Subscribe(commandTopic, 1, mqttCommandHandler); token.WaitTimeout(10*time.Second) && token.Error() != nil {
+			log.Printf("MQTT subscribe to %s failed: %v", commandTopic, token.Error())
+		}
+
+		// Publish current values right away (discovery is sent again after a reconnect)
+		go mqttPublishJob()
+	})
+	opts.SetConnectionLostHandler(func(c mqtt.Client, err error) {
+		log.Printf("MQTT connection lost: %v", err)
+		setMQTTLastError(err)
+	})
+
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if token.WaitTimeout(10*time.Second) && token.Error() != nil {
+		return fmt.Errorf("MQTT connect failed: %v", token.Error())
+	}
+	if !client.IsConnected() {
+		log.Printf("MQTT broker %s not reachable yet, retrying in background", settings.BrokerURL)
+	}
+
+	mqttClient = client
+	mqttSettings = settings
+	mqttStop = make(chan bool)
+	mqttRunning = true
+
+	interval := time.Duration(settings.PublishInterval) * time.Minute
+	log.Printf("MQTT publisher started with %d minute interval (topic prefix: %s)", settings.PublishInterval, settings.TopicPrefix)
+
+	go func(stop chan bool) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mqttPublishJob()
+			case <-stop:
+				return
+			}
+		}
+	}(mqttStop)
+
+	return nil
+}
+
+// StopMQTT stops the publish loop and disconnects from the broker
+func StopMQTT() {
+	mqttMutex.Lock()
+	defer mqttMutex.Unlock()
+
+	if !mqttRunning {
+		return
+	}
+
+	close(mqttStop)
+	if mqttClient != nil {
+		mqttClient.Publish(mqttSettings.TopicPrefix+"/status", 1, true, "offline").WaitTimeout(2 * time.Second)
+		mqttClient.Disconnect(500)
+	}
+
+	mqttClient = nil
+	mqttRunning = false
+	log.Println("MQTT publisher stopped")
+}
+
+// RestartMQTT restarts the MQTT publisher with new settings
+func RestartMQTT() error {
+	StopMQTT()
+	return StartMQTT()
+}
+
+// IsMQTTConnected returns whether the MQTT client is connected to the broker
+func IsMQTTConnected() bool {
+	mqttMutex.Lock()
+	defer mqttMutex.Unlock()
+	return mqttClient != nil && mqttClient.IsConnected()
+}
+
+// currentMQTT returns the connected client and its settings, nil if MQTT is not running
+func currentMQTT() (mqtt.Client, *MQTTSettings) {
+	mqttMutex.Lock()
+	defer mqttMutex.Unlock()
+	if !mqttRunning || mqttClient == nil || !mqttClient.IsConnected() {
+		return nil, nil
+	}
+	return mqttClient, mqttSettings
+}
+
+// mqttPublish publishes a retained message, values that aren't strings are JSON encoded
+func mqttPublish(client mqtt.Client, topic string, payload interface{}) {
+	var data []byte
+	switch v := payload.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			log.Printf("MQTT: could not encode payload for %s: %v", topic, err)
+			return
+		}
+	}
+	client.Publish(topic, 0, true, data)
+}
+
+// mqttPublishJob publishes the current values of all enabled installations
+func mqttPublishJob() {
+	client, settings := currentMQTT()
+	if client == nil {
+		return
+	}
+
+	activeAccounts, err := GetActiveAccounts()
+	if err != nil {
+		log.Printf("MQTT: error getting active accounts: %v", err)
+		return
+	}
+
+	published := make(map[string]bool)
+	for _, account := range activeAccounts {
+		token, err := ensureAccountAuthenticated(account)
+		if err != nil {
+			log.Printf("MQTT: failed to authenticate account %s: %v", account.Email, err)
+			continue
+		}
+
+		for _, installationID := range token.InstallationIDs {
+			instSettings := settings.Installations[installationID]
+			if instSettings == nil || !instSettings.Enabled || published[installationID] {
+				continue
+			}
+			if instSettings.AccountID != "" && instSettings.AccountID != account.ID {
+				continue
+			}
+			installation, ok := token.Installations[installationID]
+			if !ok {
+				continue
+			}
+
+			if err := mqttPublishInstallation(client, settings, account, token, installation); err != nil {
+				log.Printf("MQTT: %v", err)
+				setMQTTLastError(err)
+				continue
+			}
+			published[installationID] = true
+		}
+	}
+
+	if len(published) > 0 {
+		mqttStatusMutex.Lock()
+		mqttLastPublish = time.Now()
+		mqttStatusMutex.Unlock()
+		log.Printf("MQTT: published values of %d installation(s)", len(published))
+	}
+}
+
+// mqttPublishInstallation publishes the device features, temperature snapshot and rooms of an installation
+func mqttPublishInstallation(client mqtt.Client, settings *MQTTSettings, account *Account, token *AccountToken, installation *Installation) error {
+	cacheDuration := time.Duration(settings.PublishInterval)*time.Minute - 5*time.Second
+
+	for _, gateway := range installation.Gateways {
+		for _, device := range gateway.Devices {
+			if !checkAPIRateLimit(account.ClientID) {
+				return fmt.Errorf("API rate limit reached, skipping remaining devices of installation %s", installation.ID)
+			}
+
+			features, err := fetchFeaturesWithCustomCache(installation.ID, gateway.Serial, device.DeviceID, token.AccessToken, cacheDuration, PriorityBackground)
+			if err != nil {
+				log.Printf("MQTT: error fetching features for device %s: %v", device.DeviceID, err)
+				continue
+			}
+
+			base := mqttDeviceTopic(settings, installation.ID, gateway.Serial, device.DeviceID)
+			haDevice := mqttHADevice(installation, gateway.Serial, device)
+
+			// Feature values as parsed for the dashboard
+			for _, group := range []map[string]FeatureValue{features.Temperatures, features.OperatingModes, features.DHW, features.Circuits, features.Other} {
+				for name, value := range group {
+					mqttPublish(client, base+"/features/"+name, mqttFeaturePayload(value))
+					if settings.Discovery {
+						mqttFeatureDiscovery(client, settings, base, haDevice, name, value)
+					}
+				}
+			}
+
+			// Controls (command topics)
+			if settings.Discovery {
+				mqttControlDiscovery(client, settings, base, haDevice, features.RawFeatures)
+			}
+
+			// Temperature snapshot of the heat generator (same data as the temperature log)
+			if device.DeviceID == "0" {
+				if snapshot := extractTemperatureSnapshot(features, installation.ID, gateway.Serial, device.DeviceID, account); snapshot != nil {
+					mqttPublish(client, base+"/snapshot", snapshot)
+					if settings.Discovery {
+						mqttSnapshotDiscovery(client, settings, base, haDevice, snapshot)
+					}
+				}
+			}
+
+			// Rooms of the room control (ViCare rooms)
+			if device.DeviceType == "roomControl" {
+				rooms := extractRoomData(installation.ID, account.ID, gateway.Serial, features.RawFeatures)
+				for i := range rooms {
+					room := &rooms[i]
+					roomKey := fmt.Sprintf("%s:%d", installation.ID, room.RoomID)
+					if roomSettings, ok := account.RoomSettings[roomKey]; ok && roomSettings.Name != "" {
+						room.RoomName = roomSettings.Name
+					}
+					room.RawFeatures = nil
+
+					roomTopic := fmt.Sprintf("%s/%s/rooms/%d", settings.TopicPrefix, installation.ID, room.RoomID)
+					mqttPublish(client, roomTopic, room)
+					if settings.Discovery {
+						mqttRoomDiscovery(client, settings, roomTopic, installation, room)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// mqttPublishEvents publishes newly archived events of MQTT enabled installations
+func mqttPublishEvents(events []Event) {
+	client, settings := currentMQTT()
+	if client == nil || len(events) == 0 {
+		return
+	}
+
+	// Events are sorted newest first; publish oldest first so "latest" ends with the newest
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].EventTimestamp < sorted[j].EventTimestamp })
+
+	for _, event := range sorted {
+		instSettings := settings.Installations[event.InstallationID]
+		if instSettings == nil || !instSettings.Enabled || !instSettings.PublishEvents {
+			continue
+		}
+
+		eventsTopic := fmt.Sprintf("%s/%s/events", settings.TopicPrefix, event.InstallationID)
+		event.Raw = ""
+		mqttPublish(client, eventsTopic+"/latest", event)
+		mqttPublish(client, eventsTopic+"/"+mqttTopicSegment(event.EventType), event)
+
+		if settings.Discovery {
+			mqttEventDiscovery(client, settings, eventsTopic, event.InstallationID)
+		}
+	}
+}
+
+// --- Topics and payloads ---
+
+func mqttDeviceTopic(settings *MQTTSettings, installationID, gatewaySerial, deviceID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", settings.TopicPrefix, installationID, gatewaySerial, deviceID)
+}
+
+var mqttInvalidTopicChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// mqttTopicSegment makes a string safe for a single topic level
+func mqttTopicSegment(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return mqttInvalidTopicChars.ReplaceAllString(s, "_")
+}
+
+var mqttInvalidIDChars = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// mqttObjectID builds a Home Assistant compatible unique/object ID
+func mqttObjectID(parts ...string) string {
+	return mqttInvalidIDChars.ReplaceAllString(strings.Join(parts, "_"), "_")
+}
+
+// mqttFeaturePayload returns scalar values as plain text and nested values as JSON
+func mqttFeaturePayload(value FeatureValue) interface{} {
+	switch v := value.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool, float64, int:
+		return fmt.Sprint(v)
+	case map[string]FeatureValue:
+		nested := make(map[string]interface{}, len(v))
+		for name, fv := range v {
+			nested[name] = fv.Value
+		}
+		return nested
+	default:
+		return v
+	}
+}
+
+// --- Home Assistant discovery ---
+
+// mqttHADevice describes a Viessmann device for the Home Assistant device registry
+func mqttHADevice(installation *Installation, gatewaySerial string, device GatewayDevice) map[string]interface{} {
+	name := installation.Description
+	if name == "" {
+		name = "Installation " + installation.ID
+	}
+	model := device.ModelID
+	if model == "" {
+		model = device.DeviceType
+	}
+	return map[string]interface{}{
+		"identifiers":  []string{mqttObjectID("vieventlog", installation.ID, gatewaySerial, device.DeviceID)},
+		"name":         fmt.Sprintf("%s %s", name, model),
+		"manufacturer": "Viessmann",
+		"model":        model,
+	}
+}
+
+// mqttDiscover publishes a discovery config once per connection
+func mqttDiscover(client mqtt.Client, settings *MQTTSettings, component, objectID string, config map[string]interface{}) {
+	topic := fmt.Sprintf("%s/%s/%s/config", settings.DiscoveryPrefix, component, objectID)
+
+	mqttDiscoveryMutex.Lock()
+	sent := mqttDiscoverySent[topic]
+	if mqttDiscoverySent != nil {
+		mqttDiscoverySent[topic] = true
+	}
+	mqttDiscoveryMutex.Unlock()
+	if sent {
+		return
+	}
+
+	config["unique_id"] = objectID
+	config["object_id"] = objectID
+	config["availability_topic"] = settings.TopicPrefix + "/status"
+	mqttPublish(client, topic, config)
+}
+
+// haUnits maps Viessmann units to Home Assistant units and device classes
+var haUnits = map[string][2]string{
+	"celsius":      {"°C", "temperature"},
+	"kelvin":       {"K", ""},
+	"percent":      {"%", ""},
+	"bar":          {"bar", "pressure"},
+	"kilowattHour": {"kWh", "energy"},
+	"wattHour":     {"Wh", "energy"},
+	"watt":         {"W", "power"},
+	"kilowatt":     {"kW", "power"},
+	"hour":         {"h", "duration"},
+	"liter":        {"L", "volume"},
+	"ppm":          {"ppm", "carbon_dioxide"},
+}
+
+// mqttFeatureDiscovery announces numeric feature values that carry a unit. They are disabled by
+// default in Home Assistant (except temperature sensors), the snapshot covers the usual values.
+func mqttFeatureDiscovery(client mqtt.Client, settings *MQTTSettings, base string, haDevice map[string]interface{}, name string, value FeatureValue) {
+	if _, ok := value.Value.(float64); !ok || value.Unit == "" {
+		return
+	}
+
+	config := map[string]interface{}{
+		"name":                name,
+		"state_topic":         base + "/features/" + name,
+		"device":              haDevice,
+		"state_class":         "measurement",
+		"enabled_by_default":  strings.Contains(name, "sensors.temperature"),
+		"unit_of_measurement": value.Unit,
+	}
+	if ha, ok := haUnits[value.Unit]; ok {
+		config["unit_of_measurement"] = ha[0]
+		if ha[1] != "" {
+			config["device_class"] = ha[1]
+		}
+		if ha[1] == "energy" {
+			config["state_class"] = "total_increasing"
+		}
+	}
+
+	identifiers := haDevice["identifiers"].([]string)
+	mqttDiscover(client, settings, "sensor", mqttObjectID(identifiers[0], name), config)
+}
+
+// snapshotUnits assigns units to the temperature snapshot fields that aren't temperatures
+var snapshotUnits = map[string][2]string{
+	"compressor_current":  {"A", "current"},
+	"compressor_pressure": {"bar", "pressure"},
+	"pressure_supply":     {"bar", "pressure"},
+	"compressor_hours":    {"h", "duration"},
+	"compressor_power":    {"W", "power"},
+	"thermal_power":       {"kW", "power"},
+	"volumetric_flow":     {"L/h", ""},
+	"burner_modulation":   {"%", ""},
+	"room_humidity":       {"%", "humidity"},
+}
+
+// Snapshot fields that describe the sample, not the device
+var snapshotMetaFields = map[string]bool{
+	"timestamp": true, "installation_id": true, "gateway_id": true, "device_id": true,
+	"account_id": true, "account_name": true, "sample_interval": true,
+}
+
+// mqttSnapshotDiscovery announces one entity per value of the temperature snapshot
+func mqttSnapshotDiscovery(client mqtt.Client, settings *MQTTSettings, base string, haDevice map[string]interface{}, snapshot *TemperatureSnapshot) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+
+	identifiers := haDevice["identifiers"].([]string)
+	for key, value := range fields {
+		if snapshotMetaFields[key] {
+			continue
+		}
+
+		config := map[string]interface{}{
+			"name":        strings.ReplaceAll(key, "_", " "),
+			"state_topic": base + "/snapshot",
+			"device":      haDevice,
+		}
+		objectID := mqttObjectID(identifiers[0], "snapshot", key)
+
+		switch value.(type) {
+		case bool:
+			config["value_template"] = fmt.Sprintf("{{ 'ON' if value_json.%s else 'OFF' }}", key)
+			config["device_class"] = "running"
+			mqttDiscover(client, settings, "binary_sensor", objectID, config)
+		case float64:
+			config["value_template"] = fmt.Sprintf("{{ value_json.%s }}", key)
+			config["state_class"] = "measurement"
+			switch {
+			case strings.HasSuffix(key, "_temp"):
+				config["unit_of_measurement"] = "°C"
+				config["device_class"] = "temperature"
+			case strings.HasSuffix(key, "_delta_t"):
+				config["unit_of_measurement"] = "K"
+			case key == "compressor_starts":
+				config["state_class"] = "total_increasing"
+			default:
+				if unit, ok := snapshotUnits[key]; ok {
+					config["unit_of_measurement"] = unit[0]
+					if unit[1] != "" {
+						config["device_class"] = unit[1]
+					}
+				}
+			}
+			mqttDiscover(client, settings, "sensor", objectID, config)
+		case string:
+			config["value_template"] = fmt.Sprintf("{{ value_json.%s }}", key)
+			mqttDiscover(client, settings, "sensor", objectID, config)
+		}
+	}
+}
+
+// mqttLanguage is the language of the entity names announced to Home Assistant
+func mqttLanguage() string {
+	if lang := GetLanguageSetting(); lang != "" {
+		return lang
+	}
+	return defaultLanguage
+}
+
+// mqttRoomDiscovery announces the sensors of a ViCare room
+func mqttRoomDiscovery(client mqtt.Client, settings *MQTTSettings, roomTopic string, installation *Installation, room *Room) {
+	lang := mqttLanguage()
+	haDevice := map[string]interface{}{
+		"identifiers":    []string{mqttObjectID("vieventlog", installation.ID, "room", strconv.Itoa(room.RoomID))},
+		"name":           room.RoomName,
+		"manufacturer":   "Viessmann",
+		"model":          translate(lang, "mqtt.room_model"),
+		"suggested_area": room.RoomName,
+	}
+	prefix := mqttObjectID("vieventlog", installation.ID, "room", strconv.Itoa(room.RoomID))
+
+	sensors := []struct {
+		key, name, unit, class string
+		present                bool
+	}{
+		{"temperature", "mqtt.room.temperature", "°C", "temperature", room.Temperature != nil},
+		{"humidity", "mqtt.room.humidity", "%", "humidity", room.Humidity != nil},
+		{"co2", "mqtt.room.co2", "ppm", "carbon_dioxide", room.CO2 != nil},
+		{"heatingSetpoint", "mqtt.room.setpoint", "°C", "temperature", room.HeatingSetpoint != nil},
+	}
+	for _, s := range sensors {
+		if !s.present {
+			continue
+		}
+		mqttDiscover(client, settings, "sensor", prefix+"_"+s.key, map[string]interface{}{
+			"name":                translate(lang, s.name),
+			"state_topic":         roomTopic,
+			"value_template":      fmt.Sprintf("{{ value_json.%s }}", s.key),
+			"unit_of_measurement": s.unit,
+			"device_class":        s.class,
+			"state_class":         "measurement",
+			"device":              haDevice,
+		})
+	}
+
+	mqttDiscover(client, settings, "binary_sensor", prefix+"_window", map[string]interface{}{
+		"name":           translate(lang, "mqtt.room.window_open"),
+		"state_topic":    roomTopic,
+		"value_template": "{{ 'ON' if value_json.windowOpen else 'OFF' }}",
+		"device_class":   "window",
+		"device":         haDevice,
+	})
+}
+
+// mqttEventDiscovery announces the "latest event" sensor of an installation
+func mqttEventDiscovery(client mqtt.Client, settings *MQTTSettings, eventsTopic, installationID string) {
+	objectID := mqttObjectID("vieventlog", installationID, "latest_event")
+	mqttDiscover(client, settings, "sensor", objectID, map[string]interface{}{
+		"name":                  translate(mqttLanguage(), "mqtt.latest_event"),
+		"state_topic":           eventsTopic + "/latest",
+		"value_template":        "{{ (value_json.humanReadable or value_json.eventType)[:255] }}",
+		"json_attributes_topic": eventsTopic + "/latest",
+		"icon":                  "mdi:bell-alert",
+		"device": map[string]interface{}{
+			"identifiers":  []string{mqttObjectID("vieventlog", installationID)},
+			"name":         "ViEventLog " + installationID,
+			"manufacturer": "Viessmann",
+			"model":        "Installation",
+		},
+	})
+}
+
+// --- Controls ---
+
+// mqttControl maps a command topic to a feature command. Key may reference groups of Pattern ($1),
+// Name is a message key whose placeholders {0}, ... are filled with the groups.
+// Controls with an OffCommand are switches: ON executes Command, OFF executes OffCommand, both without parameters.
+type mqttControl struct {
+	Pattern    *regexp.Regexp
+	Key        string
+	Name       string
+	Command    string
+	Param      string
+	Numeric    bool
+	OffCommand string
+}
+
+// mqttControls are the controls available on the command topics, matching the controls of the web UI
+var mqttControls = []mqttControl{
+	{regexp.MustCompile(`^heating\.dhw\.operating\.modes\.active$`), "dhw_mode", "mqtt.control.dhw_mode", "setMode", "mode", false, ""},
+	{regexp.MustCompile(`^heating\.dhw\.temperature\.main$`), "dhw_temperature", "mqtt.control.dhw_temperature", "setTargetTemperature", "temperature", true, ""},
+	{regexp.MustCompile(`^heating\.circuits\.(\d+)\.operating\.modes\.active$`), "heating_mode_$1", "mqtt.control.heating_mode", "setMode", "mode", false, ""},
+	{regexp.MustCompile(`^trv\.temperature$`), "trv_temperature", "mqtt.control.trv_temperature", "setTargetTemperature", "temperature", true, ""},
+	{regexp.MustCompile(`^rooms\.(\d+)\.temperature\.levels\.normal\.perceived$`), "room_${1}_temperature", "mqtt.control.room_temperature", "setTemperature", "targetTemperature", true, ""},
+	{regexp.MustCompile(`^ventilation\.operating\.modes\.active$`), "ventilation_mode", "mqtt.control.ventilation_mode", "setMode", "mode", false, ""},
+	{regexp.MustCompile(`^ventilation\.quickmodes\.(\w+)$`), "ventilation_quickmode_$1", "mqtt.control.ventilation_quickmode", "activate", "", false, "deactivate"},
+}
+
+// mqttControlName returns the translated entity name of a control for a feature
+func mqttControlName(control *mqttControl, feature, lang string) string {
+	var args []interface{}
+	for _, group := range control.Pattern.FindStringSubmatch(feature)[1:] {
+		args = append(args, group)
+	}
+	return translate(lang, control.Name, args...)
+}
+
+// matchMQTTControl returns the control and its topic key for a feature
+func matchMQTTControl(feature string) (*mqttControl, string, bool) {
+	for i := range mqttControls {
+		c := &mqttControls[i]
+		if m := c.Pattern.FindStringSubmatchIndex(feature); m != nil {
+			key := string(c.Pattern.ExpandString(nil, c.Key, feature, m))
+			return c, key, true
+		}
+	}
+	return nil, "", false
+}
+
+// mqttControlDiscovery announces select/number entities for the controls a device offers
+func mqttControlDiscovery(client mqtt.Client, settings *MQTTSettings, base string, haDevice map[string]interface{}, features []Feature) {
+	identifiers := haDevice["identifiers"].([]string)
+	lang := mqttLanguage()
+
+	for _, f := range features {
+		control, key, ok := matchMQTTControl(f.Feature)
+		if !ok {
+			continue
+		}
+		cmd, ok := f.Commands[control.Command]
+		if !ok {
+			continue
+		}
+
+		config := map[string]interface{}{
+			"name":          mqttControlName(control, f.Feature, lang),
+			"command_topic": base + "/set/" + key,
+			"state_topic":   base + "/features/" + f.Feature,
+			"device":        haDevice,
+		}
+		objectID := mqttObjectID(identifiers[0], "set", key)
+
+		if control.OffCommand != "" {
+			if _, ok := f.Commands[control.OffCommand]; !ok {
+				continue
+			}
+			config["value_template"] = "{{ 'ON' if value_json.active else 'OFF' }}"
+			mqttDiscover(client, settings, "switch", objectID, config)
+			continue
+		}
+
+		param, ok := cmd.Params[control.Param]
+		if !ok {
+			continue
+		}
+
+		if control.Numeric {
+			c := param.Constraints
+			if c.Min != nil {
+				config["min"] = *c.Min
+			}
+			if c.Max != nil {
+				config["max"] = *c.Max
+			}
+			if c.Stepping != nil {
+				config["step"] = *c.Stepping
+			}
+			config["unit_of_measurement"] = "°C"
+			config["mode"] = "box"
+			mqttDiscover(client, settings, "number", objectID, config)
+		} else {
+			if len(param.Constraints.Enum) == 0 {
+				continue
+			}
+			config["options"] = param.Constraints.Enum
+			mqttDiscover(client, settings, "select", objectID, config)
+		}
+	}
+}
+
+// mqttCommandHandler handles messages on <prefix>/<installation>/<gateway>/<device>/set/<key>
+func mqttCommandHandler(client mqtt.Client, msg mqtt.Message) {
+	if msg.Retained() {
+		// Never replay old commands after a reconnect
+		return
+	}
+	go func() {
+		if err := handleMQTTCommand(msg.Topic(), strings.TrimSpace(string(msg.Payload()))); err != nil {
+			log.Printf("MQTT command on %s failed: %v", msg.Topic(), err)
+		}
+	}()
+}
+
+func handleMQTTCommand(topic, payload string) error {
+	client, settings := currentMQTT()
+	if client == nil {
+		return fmt.Errorf("MQTT not connected")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(topic, settings.TopicPrefix+"/"), "/")
+	if len(parts) != 5 || parts[3] != "set" {
+		return fmt.Errorf("unexpected command topic")
+	}
+	installationID, gatewaySerial, deviceID, key := parts[0], parts[1], parts[2], parts[4]
+
+	instSettings := settings.Installations[installationID]
+	if instSettings == nil || !instSettings.Enabled || !instSettings.AllowCommands {
+		return fmt.Errorf("commands are not enabled for installation %s", installationID)
+	}
+
+	accountID := instSettings.AccountID
+	if accountID == "" {
+		accountID = findAccountIDForInstallation(installationID)
+		if accountID == "" {
+			return fmt.Errorf("no active account found for installation %s", installationID)
+		}
+	}
+
+	features, err := fetchFeaturesForAccount(accountID, installationID, gatewaySerial, deviceID)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range features.RawFeatures {
+		control, featureKey, ok := matchMQTTControl(f.Feature)
+		if !ok || featureKey != key {
+			continue
+		}
+
+		command, params := control.Command, map[string]interface{}{}
+		var state interface{} = payload
+		switch {
+		case control.OffCommand != "":
+			switch strings.ToUpper(payload) {
+			case "ON":
+			case "OFF":
+				command = control.OffCommand
+			default:
+				return fmt.Errorf("invalid switch state %q, must be ON or OFF", payload)
+			}
+			state = map[string]interface{}{"active": command == control.Command}
+		case control.Numeric:
+			v, err := strconv.ParseFloat(payload, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q", payload)
+			}
+			params[control.Param] = v
+		default:
+			params[control.Param] = payload
+		}
+
+		err := executeFeatureCommand(FeatureCommandRequest{
+			AccountID:      accountID,
+			InstallationID: installationID,
+			GatewaySerial:  gatewaySerial,
+			DeviceID:       deviceID,
+			Feature:        f.Feature,
+			Command:        command,
+			Params:         params,
+		}, PriorityInteractive)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("MQTT: executed %s.%s = %s on device %s", f.Feature, command, payload, deviceID)
+		// Report the new state right away, the next publish run confirms it
+		mqttPublish(client, mqttDeviceTopic(settings, installationID, gatewaySerial, deviceID)+"/features/"+f.Feature, state)
+		return nil
+	}
+
+	return fmt.Errorf("device %s has no control %s", deviceID, key)
+}
+
+// findAccountIDForInstallation returns the first active account that has access to an installation
+func findAccountIDForInstallation(installationID string) string {
+	activeAccounts, err := GetActiveAccounts()
+	if err != nil {
+		return ""
+	}
+	for _, account := range activeAccounts {
+		token, err := ensureAccountAuthenticated(account)
+		if err != nil {
+			continue
+		}
+		if _, ok := token.Installations[installationID]; ok {
+			return account.ID
+		}
+	}
+	return ""
+}
+
+// MQTTStatus is returned by the status endpoint
+type MQTTStatus struct {
+	Enabled     bool       `json:"enabled"`
+	Running     bool       `json:"running"`
+	Connected   bool       `json:"connected"`
+	Broker      string     `json:"broker"`
+	LastPublish *time.Time `json:"lastPublish,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+// GetMQTTStatus returns the state of the MQTT publisher
+func GetMQTTStatus() MQTTStatus {
+	settings, _ := GetMQTTSettings()
+
+	mqttMutex.Lock()
+	defer mqttMutex.Unlock()
+
+	status := MQTTStatus{
+		Running:   mqttRunning,
+		Connected: mqttClient != nil && mqttClient.IsConnected(),
+	}
+	if settings != nil {
+		status.Enabled = settings.Enabled
+		status.Broker = settings.BrokerURL
+	}
+
+	mqttStatusMutex.Lock()
+	status.LastError = mqttLastError
+	if !mqttLastPublish.IsZero() {
+		t := mqttLastPublish
+		status.LastPublish = &t
+	}
+	mqttStatusMutex.Unlock()
+	return status
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestMatchMQTTControl(t *testing.T) {
+	tests := []struct {
+		feature  string
+		key      string
+		name     string
+		isSwitch bool
+	}{
+		{"heating.dhw.operating.modes.active", "dhw_mode", "Hot water operating mode", false},
+		{"heating.circuits.1.operating.modes.active", "heating_mode_1", "Heating circuit 1 operating mode", false},
+		{"rooms.3.temperature.levels.normal.perceived", "room_3_temperature", "Room 3 target temperature", false},
+		{"ventilation.quickmodes.forcedLevelFour", "ventilation_quickmode_forcedLevelFour", "Ventilation quick mode forcedLevelFour", true},
+		{"heating.sensors.temperature.outside", "", "", false},
+	}
+
+	for _, tt := range tests {
+		control, key, ok := matchMQTTControl(tt.feature)
+		if ok != (tt.key != "") {
+			t.Errorf("%s: matched = %v", tt.feature, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if key != tt.key {
+			t.Errorf("%s: key = %q, want %q", tt.feature, key, tt.key)
+		}
+		if name := mqttControlName(control, tt.feature, "en"); name != tt.name {
+			t.Errorf("%s: name = %q, want %q", tt.feature, name, tt.name)
+		}
+		if (control.OffCommand != "") != tt.isSwitch {
+			t.Errorf("%s: switch = %v, want %v", tt.feature, control.OffCommand != "", tt.isSwitch)
+		}
+	}
+}
@@ -0,0 +1,803 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// PVSurplusSettings configures the PV surplus automation: if the surplus reported by a Vitocharge
+// stays above StartThresholdW, the heat pump stores the energy in the DHW tank (and optionally the
+// building) until the surplus drops below StopThresholdW.
+type PVSurplusSettings struct {
+	Enabled         bool `json:"enabled"`
+	IntervalMinutes int  `json:"intervalMinutes"` // Evaluation interval (each evaluation reads the Vitocharge features)
+
+	// Vitocharge (electricityStorage) providing PV, battery and grid values
+	SourceAccountID      string `json:"sourceAccountId"`
+	SourceInstallationID string `json:"sourceInstallationId"`
+	SourceGatewaySerial  string `json:"sourceGatewaySerial"`
+	SourceDeviceID       string `json:"sourceDeviceId"`
+
+	// Heat pump to control
+	AccountID      string `json:"accountId"`
+	InstallationID string `json:"installationId"`
+	GatewaySerial  string `json:"gatewaySerial"`
+	DeviceID       string `json:"deviceId"`
+
+	StartThresholdW        float64 `json:"startThresholdW"`        // Surplus needed to start
+	StopThresholdW         float64 `json:"stopThresholdW"`         // Surplus below this ends the boost (hysteresis), negative = tolerated grid import
+	StartDelayMinutes      int     `json:"startDelayMinutes"`      // Surplus must stay above the start threshold this long
+	StopDelayMinutes       int     `json:"stopDelayMinutes"`       // Surplus must stay below the stop threshold this long
+	MinRunMinutes          int     `json:"minRunMinutes"`          // Minimum duration of a boost
+	MinPauseMinutes        int     `json:"minPauseMinutes"`        // Minimum pause between two boosts
+	MaxActivationsPerDay   int     `json:"maxActivationsPerDay"`   // Daily cap of boosts
+	IncludeBatteryCharging bool    `json:"includeBatteryCharging"` // Count battery charging power as surplus
+	MinBatterySOC          float64 `json:"minBatterySoc"`          // Only start above this state of charge (%), 0 = ignore
+	InvertGridSign         bool    `json:"invertGridSign"`         // Grid exchange reports feed-in as negative value
+
+	DHWAction          string  `json:"dhwAction"`          // "temperature", "oneTimeCharge" or "" (no DHW action)
+	DHWBoostTarget     float64 `json:"dhwBoostTarget"`     // DHW target temperature during the boost
+	HeatingBoostKelvin float64 `json:"heatingBoostKelvin"` // Raise of the normal heating setpoint, 0 = off
+	HeatingCircuit     int     `json:"heatingCircuit"`     // Heating circuit of the setpoint
+}
+
+// PVSurplusDecision is one evaluation of the automation
+type PVSurplusDecision struct {
+	ID              int64    `json:"id"`
+	Timestamp       string   `json:"timestamp"`
+	SurplusW        *float64 `json:"surplusW"`
+	PVProductionW   *float64 `json:"pvProductionW"`
+	GridW           *float64 `json:"gridW"`    // Positive = feed-in
+	BatteryW        *float64 `json:"batteryW"` // Negative = charging
+	BatterySOC      *float64 `json:"batterySoc"`
+	Active          bool     `json:"active"` // Boost active after the decision
+	Action          string   `json:"action"` // start, stop, hold, wait, blocked, idle, error
+	Reason          string   `json:"reason"`
+	DHWOriginal     *float64 `json:"dhwOriginal,omitempty"`
+	HeatingOriginal *float64 `json:"heatingOriginal,omitempty"`
+}
+
+// PVSurplusStatus is the current state of the automation
+type PVSurplusStatus struct {
+	Enabled          bool               `json:"enabled"`
+	Running          bool               `json:"running"`
+	Active           bool               `json:"active"`
+	ActiveSince      *time.Time         `json:"activeSince,omitempty"`
+	AboveSince       *time.Time         `json:"aboveSince,omitempty"`
+	BelowSince       *time.Time         `json:"belowSince,omitempty"`
+	LastStop         *time.Time         `json:"lastStop,omitempty"`
+	ActivationsToday int                `json:"activationsToday"`
+	LastDecision     *PVSurplusDecision `json:"lastDecision,omitempty"`
+}
+
+const (
+	pvSurplusDHWFeature    = "heating.dhw.temperature.main"
+	pvSurplusChargeFeature = "heating.dhw.oneTimeCharge"
+
+	// DHW actions
+	pvSurplusDHWTemperature   = "temperature"
+	pvSurplusDHWOneTimeCharge = "oneTimeCharge"
+
+	// pvSurplusDecisionRetention is how long decisions are kept
+	pvSurplusDecisionRetention = 30 * 24 * time.Hour
+)
+
+// pvSurplusState is the in-memory state of the automation, restored from the decision log at start
+type pvSurplusState struct {
+	active          bool
+	activeSince     time.Time
+	lastStop        time.Time
+	aboveSince      time.Time
+	belowSince      time.Time
+	dhwOriginal     *float64
+	heatingOriginal *float64
+	lastDecision    *PVSurplusDecision
+}
+
+var (
+	pvSurplusSchedulerRunning bool
+	pvSurplusSchedulerMutex   sync.Mutex
+	pvSurplusSchedulerStop    chan bool
+
+	pvSurplusMutex sync.Mutex // Guards pvSurplus and serializes evaluations
+	pvSurplus      pvSurplusState
+)
+
+// GetPVSurplusSettings returns the PV surplus settings with defaults applied
+func GetPVSurplusSettings() (*PVSurplusSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.PVSurplusSettings
+	if settings == nil {
+		settings = &PVSurplusSettings{}
+	}
+	applyPVSurplusDefaults(settings)
+	return settings, nil
+}
+
+// SetPVSurplusSettings updates the PV surplus settings
+func SetPVSurplusSettings(settings *PVSurplusSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	store.PVSurplusSettings = settings
+	return SaveAccounts(store)
+}
+
+func applyPVSurplusDefaults(settings *PVSurplusSettings) {
+	if settings.IntervalMinutes < 1 {
+		settings.IntervalMinutes = 5
+	}
+	if settings.StartThresholdW <= 0 {
+		settings.StartThresholdW = 1500
+	}
+	if settings.StartDelayMinutes < 0 {
+		settings.StartDelayMinutes = 0
+	}
+	if settings.StopDelayMinutes < 0 {
+		settings.StopDelayMinutes = 0
+	}
+	if settings.MinRunMinutes < 0 {
+		settings.MinRunMinutes = 0
+	}
+	if settings.MinPauseMinutes < 0 {
+		settings.MinPauseMinutes = 0
+	}
+	if settings.MaxActivationsPerDay < 1 {
+		settings.MaxActivationsPerDay = 3
+	}
+	if settings.DHWAction == pvSurplusDHWTemperature && settings.DHWBoostTarget <= 0 {
+		settings.DHWBoostTarget = 60
+	}
+}
+
+// validatePVSurplusSettings checks an enabled configuration
+func validatePVSurplusSettings(settings *PVSurplusSettings) error {
+	if !settings.Enabled {
+		return nil
+	}
+	if settings.SourceAccountID == "" || settings.SourceInstallationID == "" || settings.SourceGatewaySerial == "" || settings.SourceDeviceID == "" {
+		return fmt.Errorf("Vitocharge device is required")
+	}
+	if settings.AccountID == "" || settings.InstallationID == "" || settings.GatewaySerial == "" || settings.DeviceID == "" {
+		return fmt.Errorf("heat pump device is required")
+	}
+	if settings.StopThresholdW >= settings.StartThresholdW {
+		return fmt.Errorf("stop threshold must be below the start threshold")
+	}
+	switch settings.DHWAction {
+	case "", pvSurplusDHWTemperature, pvSurplusDHWOneTimeCharge:
+	default:
+		return fmt.Errorf("invalid dhwAction: %s", settings.DHWAction)
+	}
+	if settings.DHWAction == "" && settings.HeatingBoostKelvin <= 0 {
+		return fmt.Errorf("at least one action (DHW or heating setpoint) is required")
+	}
+	if settings.HeatingBoostKelvin < 0 || settings.HeatingBoostKelvin > 5 {
+		return fmt.Errorf("heating boost must be between 0 and 5 K")
+	}
+	return nil
+}
+
+func (s *PVSurplusSettings) heatingFeature() string {
+	return fmt.Sprintf("heating.circuits.%d.operating.programs.normal", s.HeatingCircuit)
+}
+
+// createPVSurplusTables creates the decision log of the PV surplus automation, dbMutex must be held
+func createPVSurplusTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS pv_surplus_decisions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp TEXT NOT NULL,
+		surplus_w REAL,
+		pv_production_w REAL,
+		grid_w REAL,
+		battery_w REAL,
+		battery_soc REAL,
+		active INTEGER NOT NULL DEFAULT 0,
+		action TEXT NOT NULL,
+		reason TEXT,
+		dhw_original REAL,
+		heating_original REAL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_pv_surplus_decisions_timestamp ON pv_surplus_decisions(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_pv_surplus_decisions_action ON pv_surplus_decisions(action, timestamp);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create PV surplus tables: %v", err)
+	}
+	return nil
+}
+
+// savePVSurplusDecision logs a decision and removes decisions older than the retention
+func savePVSurplusDecision(d *PVSurplusDecision) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	err := eventDB.QueryRow(`
+		INSERT INTO pv_surplus_decisions (timestamp, surplus_w, pv_production_w, grid_w, battery_w, battery_soc,
+			active, action, reason, dhw_original, heating_original)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, d.Timestamp, d.SurplusW, d.PVProductionW, d.GridW, d.BatteryW, d.BatterySOC,
+		d.Active, d.Action, d.Reason, d.DHWOriginal, d.HeatingOriginal).Scan(&d.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save PV surplus decision: %v", err)
+	}
+
+	cutoff := time.Now().Add(-pvSurplusDecisionRetention).UTC().Format(time.RFC3339)
+	if _, err := eventDB.Exec(`DELETE FROM pv_surplus_decisions WHERE timestamp < ?`, cutoff); err != nil {
+		return fmt.Errorf("failed to clean up PV surplus decisions: %v", err)
+	}
+	return nil
+}
+
+const pvSurplusDecisionColumns = `id, timestamp, surplus_w, pv_production_w, grid_w, battery_w, battery_soc,
+	active, action, COALESCE(reason, ''), dhw_original, heating_original`
+
+// GetPVSurplusDecisions returns the newest decisions. With changesOnly, evaluations without
+// change (hold, wait, idle) are left out.
+func GetPVSurplusDecisions(limit int, changesOnly bool) ([]PVSurplusDecision, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	query := `SELECT ` + pvSurplusDecisionColumns + ` FROM pv_surplus_decisions`
+	if changesOnly {
+		query += ` WHERE action IN ('start', 'stop', 'blocked', 'error')`
+	}
+	query += ` ORDER BY timestamp DESC, id DESC LIMIT ?`
+
+	rows, err := eventDB.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PV surplus decisions: %v", err)
+	}
+	defer rows.Close()
+
+	decisions := []PVSurplusDecision{}
+	for rows.Next() {
+		var d PVSurplusDecision
+		if err := rows.Scan(&d.ID, &d.Timestamp, &d.SurplusW, &d.PVProductionW, &d.GridW, &d.BatteryW, &d.BatterySOC,
+			&d.Active, &d.Action, &d.Reason, &d.DHWOriginal, &d.HeatingOriginal); err != nil {
+			return nil, fmt.Errorf("failed to scan PV surplus decision: %v", err)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, rows.Err()
+}
+
+// countPVSurplusActivationsSince returns the number of boosts started since t
+func countPVSurplusActivationsSince(t time.Time) (int, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	var count int
+	err := eventDB.QueryRow(`SELECT COUNT(*) FROM pv_surplus_decisions WHERE action = 'start' AND timestamp >= ?`,
+		t.UTC().Format(time.RFC3339)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count PV surplus activations: %v", err)
+	}
+	return count, nil
+}
+
+// restorePVSurplusState restores an active boost and the last stop from the decision log,
+// so a restart during a boost still reverts the original setpoints. pvSurplusMutex must be held.
+func restorePVSurplusState() {
+	pvSurplus = pvSurplusState{}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return
+	}
+
+	rows, err := eventDB.Query(`SELECT ` + pvSurplusDecisionColumns + ` FROM pv_surplus_decisions
+		WHERE action IN ('start', 'stop') ORDER BY timestamp DESC, id DESC LIMIT 2`)
+	if err != nil {
+		log.Printf("Warning: failed to restore PV surplus state: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for i := 0; rows.Next(); i++ {
+		var d PVSurplusDecision
+		if err := rows.Scan(&d.ID, &d.Timestamp, &d.SurplusW, &d.PVProductionW, &d.GridW, &d.BatteryW, &d.BatterySOC,
+			&d.Active, &d.Action, &d.Reason, &d.DHWOriginal, &d.HeatingOriginal); err != nil {
+			log.Printf("Warning: failed to restore PV surplus state: %v", err)
+			return
+		}
+		ts, _ := time.Parse(time.RFC3339, d.Timestamp)
+		switch {
+		case i == 0 && d.Action == "start":
+			pvSurplus.active = true
+			pvSurplus.activeSince = ts
+			pvSurplus.dhwOriginal = d.DHWOriginal
+			pvSurplus.heatingOriginal = d.HeatingOriginal
+		case d.Action == "stop" && pvSurplus.lastStop.IsZero():
+			pvSurplus.lastStop = ts
+		}
+	}
+	if pvSurplus.active {
+		log.Printf("PV surplus: restored active boost since %s", pvSurplus.activeSince.Format(time.RFC3339))
+	}
+}
+
+// featureNumber returns a numeric property of a feature
+func featureNumber(features *DeviceFeatures, feature, property string) (float64, bool) {
+	for _, f := range features.RawFeatures {
+		if f.Feature != feature {
+			continue
+		}
+		if prop, ok := f.Properties[property].(map[string]interface{}); ok {
+			return toFloat64(prop["value"])
+		}
+	}
+	return 0, false
+}
+
+// fetchPVSurplusFeatures reads the features of a device for the automation with background priority
+func fetchPVSurplusFeatures(accountID, installationID, gatewaySerial, deviceID string, cacheDuration time.Duration) (*DeviceFeatures, error) {
+	account, err := GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+	token, err := ensureAccountAuthenticated(account)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFeaturesWithCustomCache(installationID, gatewaySerial, deviceID, token.AccessToken, cacheDuration, PriorityBackground)
+}
+
+// latestHeatPumpPowerW returns the newest logged compressor power of the heat pump. Snapshots older
+// than two log intervals are ignored, a stopped logger must not keep a stale consumption alive.
+func latestHeatPumpPowerW(settings *PVSurplusSettings, now time.Time) (float64, bool) {
+	maxAge := 10 * time.Minute // Two default log intervals
+	if logSettings, err := GetTemperatureLogSettings(); err == nil && logSettings.SampleInterval > 0 {
+		maxAge = 2 * time.Duration(logSettings.SampleInterval) * time.Minute
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, false
+	}
+
+	var power float64
+	err := eventDB.QueryRow(`
+		SELECT compressor_power FROM temperature_snapshots
+		WHERE installation_id = ? AND gateway_id = ? AND device_id = ? AND timestamp >= ? AND compressor_power IS NOT NULL
+		ORDER BY timestamp DESC LIMIT 1
+	`, settings.InstallationID, settings.GatewaySerial, settings.DeviceID, now.Add(-maxAge).UTC().Format(time.RFC3339)).Scan(&power)
+	if err != nil {
+		return 0, false
+	}
+	return power, true
+}
+
+// readPVSurplus reads the current energy flows of the Vitocharge into the decision
+func readPVSurplus(settings *PVSurplusSettings, d *PVSurplusDecision) error {
+	cacheDuration := time.Duration(settings.IntervalMinutes) * time.Minute / 2
+	features, err := fetchPVSurplusFeatures(settings.SourceAccountID, settings.SourceInstallationID,
+		settings.SourceGatewaySerial, settings.SourceDeviceID, cacheDuration)
+	if err != nil {
+		return err
+	}
+
+	grid, ok := featureNumber(features, "pcc.transfer.power.exchange", "value")
+	if !ok {
+		return fmt.Errorf("Vitocharge reports no grid exchange (pcc.transfer.power.exchange)")
+	}
+	if settings.InvertGridSign {
+		grid = -grid
+	}
+	d.GridW = &grid
+	surplus := grid
+
+	if pv, ok := featureNumber(features, "photovoltaic.production.current", "value"); ok {
+		pv *= 1000 // kW
+		d.PVProductionW = &pv
+	}
+	if battery, ok := featureNumber(features, "ess.power", "value"); ok {
+		d.BatteryW = &battery
+		if settings.IncludeBatteryCharging && battery < 0 {
+			surplus -= battery
+		}
+	}
+	if soc, ok := featureNumber(features, "ess.stateOfCharge", "value"); ok {
+		d.BatterySOC = &soc
+	}
+	d.SurplusW = &surplus
+	return nil
+}
+
+// startPVSurplusBoost remembers the current setpoints and raises them
+func startPVSurplusBoost(settings *PVSurplusSettings) (dhwOriginal, heatingOriginal *float64, err error) {
+	features, err := fetchPVSurplusFeatures(settings.AccountID, settings.InstallationID, settings.GatewaySerial, settings.DeviceID, time.Minute)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := FeatureCommandRequest{
+		AccountID:      settings.AccountID,
+		InstallationID: settings.InstallationID,
+		GatewaySerial:  settings.GatewaySerial,
+		DeviceID:       settings.DeviceID,
+	}
+
+	if settings.HeatingBoostKelvin > 0 {
+		current, ok := featureNumber(features, settings.heatingFeature(), "temperature")
+		if !ok {
+			return nil, nil, fmt.Errorf("no heating setpoint found (%s)", settings.heatingFeature())
+		}
+		req := target
+		req.Feature, req.Command = settings.heatingFeature(), "setTemperature"
+		req.Params = map[string]interface{}{"targetTemperature": current + settings.HeatingBoostKelvin}
+		if err := executeFeatureCommand(req, PriorityBackground); err != nil {
+			return nil, nil, fmt.Errorf("heating setpoint: %w", err)
+		}
+		heatingOriginal = &current
+	}
+
+	switch settings.DHWAction {
+	case pvSurplusDHWTemperature:
+		current, ok := featureNumber(features, pvSurplusDHWFeature, "value")
+		if !ok {
+			err = fmt.Errorf("no DHW target temperature found (%s)", pvSurplusDHWFeature)
+			break
+		}
+		if current >= settings.DHWBoostTarget {
+			break // Already at or above the boost target, nothing to raise and nothing to revert
+		}
+		req := target
+		req.Feature, req.Command = pvSurplusDHWFeature, "setTargetTemperature"
+		req.Params = map[string]interface{}{"temperature": settings.DHWBoostTarget}
+		if err = executeFeatureCommand(req, PriorityBackground); err == nil {
+			dhwOriginal = &current
+		}
+	case pvSurplusDHWOneTimeCharge:
+		req := target
+		req.Feature, req.Command = pvSurplusChargeFeature, "activate"
+		err = executeFeatureCommand(req, PriorityBackground)
+	}
+
+	if err != nil {
+		// Leave nothing half-raised behind
+		if heatingOriginal != nil {
+			if revertErr := revertPVSurplusBoost(settings, nil, heatingOriginal, false); revertErr != nil {
+				log.Printf("PV surplus: %v", revertErr)
+			}
+		}
+		return nil, nil, fmt.Errorf("DHW: %w", err)
+	}
+	return dhwOriginal, heatingOriginal, nil
+}
+
+// revertPVSurplusBoost restores the remembered setpoints and ends a started one-time charge
+func revertPVSurplusBoost(settings *PVSurplusSettings, dhwOriginal, heatingOriginal *float64, endCharge bool) error {
+	target := FeatureCommandRequest{
+		AccountID:      settings.AccountID,
+		InstallationID: settings.InstallationID,
+		GatewaySerial:  settings.GatewaySerial,
+		DeviceID:       settings.DeviceID,
+	}
+
+	var firstErr error
+	if endCharge {
+		// The one-time charge also ends by itself once the tank is heated, so a failing deactivate is no reason to retry
+		req := target
+		req.Feature, req.Command = pvSurplusChargeFeature, "deactivate"
+		if err := executeFeatureCommand(req, PriorityBackground); err != nil {
+			log.Printf("PV surplus: one-time charge not deactivated: %v", err)
+		}
+	} else if dhwOriginal != nil {
+		req := target
+		req.Feature, req.Command = pvSurplusDHWFeature, "setTargetTemperature"
+		req.Params = map[string]interface{}{"temperature": *dhwOriginal}
+		if err := executeFeatureCommand(req, PriorityBackground); err != nil {
+			firstErr = fmt.Errorf("DHW: %w", err)
+		}
+	}
+	if heatingOriginal != nil {
+		req := target
+		req.Feature, req.Command = settings.heatingFeature(), "setTemperature"
+		req.Params = map[string]interface{}{"targetTemperature": *heatingOriginal}
+		if err := executeFeatureCommand(req, PriorityBackground); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("heating setpoint: %w", err)
+		}
+	}
+	return firstErr
+}
+
+// evaluatePVSurplus runs one evaluation of the automation and logs the decision
+func evaluatePVSurplus(settings *PVSurplusSettings, now time.Time) *PVSurplusDecision {
+	pvSurplusMutex.Lock()
+	defer pvSurplusMutex.Unlock()
+
+	s := &pvSurplus
+	d := &PVSurplusDecision{Timestamp: now.UTC().Format(time.RFC3339)}
+	defer func() {
+		d.Active = s.active
+		s.lastDecision = d
+		if err := savePVSurplusDecision(d); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		if d.Action != "idle" && d.Action != "hold" {
+			log.Printf("PV surplus: %s - %s", d.Action, d.Reason)
+		}
+	}()
+
+	if err := readPVSurplus(settings, d); err != nil {
+		d.Action, d.Reason = "error", err.Error()
+		return d
+	}
+	surplus := *d.SurplusW
+	minutes := func(t time.Time) int { return int(now.Sub(t).Minutes()) }
+
+	if !s.active {
+		s.belowSince = time.Time{}
+		if surplus < settings.StartThresholdW {
+			s.aboveSince = time.Time{}
+			d.Action, d.Reason = "idle", fmt.Sprintf("surplus %.0f W below start threshold %.0f W", surplus, settings.StartThresholdW)
+			return d
+		}
+		if s.aboveSince.IsZero() {
+			s.aboveSince = now
+		}
+		if above := now.Sub(s.aboveSince); above < time.Duration(settings.StartDelayMinutes)*time.Minute {
+			d.Action, d.Reason = "wait", fmt.Sprintf("surplus %.0f W for %d of %d min", surplus, int(above.Minutes()), settings.StartDelayMinutes)
+			return d
+		}
+		if !s.lastStop.IsZero() && now.Sub(s.lastStop) < time.Duration(settings.MinPauseMinutes)*time.Minute {
+			d.Action, d.Reason = "wait", fmt.Sprintf("pause %d of %d min", minutes(s.lastStop), settings.MinPauseMinutes)
+			return d
+		}
+		if settings.MinBatterySOC > 0 && d.BatterySOC != nil && *d.BatterySOC < settings.MinBatterySOC {
+			d.Action, d.Reason = "wait", fmt.Sprintf("battery %.0f %% below %.0f %%", *d.BatterySOC, settings.MinBatterySOC)
+			return d
+		}
+		y, m, day := now.Date()
+		count, err := countPVSurplusActivationsSince(time.Date(y, m, day, 0, 0, 0, 0, now.Location()))
+		if err != nil {
+			d.Action, d.Reason = "error", err.Error()
+			return d
+		}
+		if count >= settings.MaxActivationsPerDay {
+			d.Action, d.Reason = "blocked", fmt.Sprintf("daily cap of %d activations reached", settings.MaxActivationsPerDay)
+			return d
+		}
+
+		dhwOriginal, heatingOriginal, err := startPVSurplusBoost(settings)
+		if err != nil {
+			d.Action, d.Reason = "error", "start failed: "+err.Error()
+			return d
+		}
+		s.active, s.activeSince = true, now
+		s.dhwOriginal, s.heatingOriginal = dhwOriginal, heatingOriginal
+		d.DHWOriginal, d.HeatingOriginal = dhwOriginal, heatingOriginal
+		d.Action, d.Reason = "start", fmt.Sprintf("surplus %.0f W for %d min (activation %d of %d today)",
+			surplus, minutes(s.aboveSince), count+1, settings.MaxActivationsPerDay)
+		s.aboveSince = time.Time{}
+		return d
+	}
+
+	s.aboveSince = time.Time{}
+	// The boost uses the surplus itself: the grid only shows what is left after the heat pump. Adding its
+	// consumption back keeps the boost from ending as soon as the compressor starts.
+	heatPump := ""
+	if power, ok := latestHeatPumpPowerW(settings, now); ok {
+		surplus += power
+		d.SurplusW = &surplus
+		heatPump = fmt.Sprintf(" (incl. heat pump %.0f W)", power)
+	}
+	if surplus >= settings.StopThresholdW {
+		s.belowSince = time.Time{}
+		d.Action, d.Reason = "hold", fmt.Sprintf("surplus %.0f W%s, active for %d min", surplus, heatPump, minutes(s.activeSince))
+		return d
+	}
+	if s.belowSince.IsZero() {
+		s.belowSince = now
+	}
+	if below := now.Sub(s.belowSince); below < time.Duration(settings.StopDelayMinutes)*time.Minute {
+		d.Action, d.Reason = "hold", fmt.Sprintf("surplus %.0f W%s below stop threshold for %d of %d min", surplus, heatPump, int(below.Minutes()), settings.StopDelayMinutes)
+		return d
+	}
+	if run := now.Sub(s.activeSince); run < time.Duration(settings.MinRunMinutes)*time.Minute {
+		d.Action, d.Reason = "hold", fmt.Sprintf("minimum run time %d of %d min", int(run.Minutes()), settings.MinRunMinutes)
+		return d
+	}
+	if err := stopPVSurplusBoostLocked(settings, now); err != nil {
+		d.Action, d.Reason = "error", "revert failed: "+err.Error()
+		return d
+	}
+	d.Action, d.Reason = "stop", fmt.Sprintf("surplus %.0f W%s below stop threshold %.0f W", surplus, heatPump, settings.StopThresholdW)
+	return d
+}
+
+// stopPVSurplusBoostLocked reverts the setpoints and resets the state, pvSurplusMutex must be held
+func stopPVSurplusBoostLocked(settings *PVSurplusSettings, now time.Time) error {
+	endCharge := settings.DHWAction == pvSurplusDHWOneTimeCharge
+	if err := revertPVSurplusBoost(settings, pvSurplus.dhwOriginal, pvSurplus.heatingOriginal, endCharge); err != nil {
+		return err
+	}
+	pvSurplus.active = false
+	pvSurplus.lastStop = now
+	pvSurplus.belowSince = time.Time{}
+	pvSurplus.dhwOriginal, pvSurplus.heatingOriginal = nil, nil
+	return nil
+}
+
+// StopPVSurplusBoost ends an active boost immediately (automation disabled or manual stop)
+func StopPVSurplusBoost(settings *PVSurplusSettings, reason string) error {
+	pvSurplusMutex.Lock()
+	defer pvSurplusMutex.Unlock()
+
+	if !pvSurplus.active {
+		return nil
+	}
+	now := time.Now()
+	d := &PVSurplusDecision{Timestamp: now.UTC().Format(time.RFC3339), Action: "stop", Reason: reason}
+	if err := stopPVSurplusBoostLocked(settings, now); err != nil {
+		d.Action, d.Reason, d.Active = "error", "revert failed: "+err.Error(), true
+	}
+	pvSurplus.lastDecision = d
+	if err := savePVSurplusDecision(d); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	log.Printf("PV surplus: %s - %s", d.Action, d.Reason)
+	if d.Action == "error" {
+		return fmt.Errorf("%s", d.Reason)
+	}
+	return nil
+}
+
+// GetPVSurplusStatus returns the current state of the automation
+func GetPVSurplusStatus() (*PVSurplusStatus, error) {
+	settings, err := GetPVSurplusSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	pvSurplusMutex.Lock()
+	defer pvSurplusMutex.Unlock()
+
+	optionalTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+	status := &PVSurplusStatus{
+		Enabled:      settings.Enabled,
+		Running:      IsPVSurplusSchedulerRunning(),
+		Active:       pvSurplus.active,
+		AboveSince:   optionalTime(pvSurplus.aboveSince),
+		BelowSince:   optionalTime(pvSurplus.belowSince),
+		LastStop:     optionalTime(pvSurplus.lastStop),
+		LastDecision: pvSurplus.lastDecision,
+	}
+	if pvSurplus.active {
+		status.ActiveSince = &pvSurplus.activeSince
+	}
+	if dbInitialized {
+		y, m, day := time.Now().Date()
+		status.ActivationsToday, _ = countPVSurplusActivationsSince(time.Date(y, m, day, 0, 0, 0, 0, time.Local))
+	}
+	return status, nil
+}
+
+// pvSurplusJob evaluates the automation once
+func pvSurplusJob() {
+	settings, err := GetPVSurplusSettings()
+	if err != nil {
+		log.Printf("Error getting PV surplus settings: %v", err)
+		return
+	}
+	if !settings.Enabled {
+		return
+	}
+	if !dbInitialized {
+		log.Println("Database not initialized, skipping PV surplus evaluation")
+		return
+	}
+
+	start := time.Now()
+	d := evaluatePVSurplus(settings, start)
+	var jobErr error
+	if d.Action == "error" {
+		jobErr = fmt.Errorf("%s", d.Reason)
+	}
+	recordJobRun("pv_surplus", start, jobErr)
+}
+
+// StartPVSurplusScheduler starts the periodic evaluation if enabled
+func StartPVSurplusScheduler() error {
+	pvSurplusSchedulerMutex.Lock()
+	defer pvSurplusSchedulerMutex.Unlock()
+
+	if pvSurplusSchedulerRunning {
+		return nil
+	}
+
+	settings, err := GetPVSurplusSettings()
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		log.Println("PV surplus automation is disabled, scheduler not started")
+		return nil
+	}
+
+	pvSurplusMutex.Lock()
+	restorePVSurplusState()
+	pvSurplusMutex.Unlock()
+
+	interval := time.Duration(settings.IntervalMinutes) * time.Minute
+	pvSurplusSchedulerStop = make(chan bool)
+	pvSurplusSchedulerRunning = true
+	stop := pvSurplusSchedulerStop
+
+	log.Printf("PV surplus scheduler started: every %d minute(s), start above %.0f W, stop below %.0f W",
+		settings.IntervalMinutes, settings.StartThresholdW, settings.StopThresholdW)
+
+	go func() {
+		timer := time.NewTimer(30 * time.Second)
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				pvSurplusJob()
+				timer.Reset(interval)
+			case <-stop:
+				log.Println("PV surplus scheduler stopped")
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// StopPVSurplusScheduler stops the evaluation, an active boost stays until the next start
+func StopPVSurplusScheduler() {
+	pvSurplusSchedulerMutex.Lock()
+	defer pvSurplusSchedulerMutex.Unlock()
+
+	if !pvSurplusSchedulerRunning {
+		return
+	}
+	close(pvSurplusSchedulerStop)
+	pvSurplusSchedulerRunning = false
+}
+
+// RestartPVSurplusScheduler restarts the scheduler with new settings
+func RestartPVSurplusScheduler() error {
+	StopPVSurplusScheduler()
+	return StartPVSurplusScheduler()
+}
+
+// IsPVSurplusSchedulerRunning returns whether the PV surplus scheduler is running
+func IsPVSurplusSchedulerRunning() bool {
+	pvSurplusSchedulerMutex.Lock()
+	defer pvSurplusSchedulerMutex.Unlock()
+	return pvSurplusSchedulerRunning
+}
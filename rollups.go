@@ -0,0 +1,722 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Rollup levels. Hourly rollups are built from the raw snapshots, daily rollups from the hourly ones
+// (days in DefaultLocation, so the daily values match the dashboard's local days).
+const (
+	RollupHourly = "hourly"
+	RollupDaily  = "daily"
+)
+
+const (
+	// Time ranges of at least this length are calculated from the rollups
+	rollupMinRange = 48 * time.Hour
+	// An hour is rolled up once it is over, plus this grace period for a logging job still saving it
+	rollupGracePeriod = 5 * time.Minute
+	// Raw snapshots are rolled up in chunks, so the write lock is never held for long
+	rollupChunk = 7 * 24 * time.Hour
+	// Snapshots before a chunk that are read to continue the compressor start counter
+	rollupCounterLookback = 2 * time.Hour
+)
+
+// rollupSensorColumns are the snapshot columns kept as min/avg/max per hour and day
+var rollupSensorColumns = []string{
+	"outside_temp", "calculated_outside_temp", "return_temp", "supply_temp",
+	"hp_primary_circuit_supply_temp", "hp_secondary_circuit_supply_temp",
+	"heating_circuit_0_supply_temp", "heating_circuit_1_supply_temp", "heating_circuit_2_supply_temp", "heating_circuit_3_supply_temp",
+	"heating_circuit_0_delta_t", "heating_circuit_1_delta_t", "heating_circuit_2_delta_t", "heating_circuit_3_delta_t",
+	"dhw_temp", "dhw_cylinder_middle_temp", "boiler_temp", "buffer_temp", "buffer_temp_top",
+	"compressor_speed", "compressor_current", "compressor_pressure", "compressor_power",
+	"compressor_oil_temp", "compressor_motor_temp", "compressor_inlet_temp", "compressor_outlet_temp",
+	"volumetric_flow", "thermal_power", "cop", "pressure_supply", "burner_modulation",
+	"room_temp", "room_humidity",
+}
+
+// Same rules as GetConsumptionStats: no thermal power without compressor power, NULL counts as 0
+const rollupThermalPowerSQL = `CASE WHEN compressor_power > 0 THEN thermal_power
+		ELSE CASE WHEN compressor_power = 0 AND IFNULL(thermal_power, 0) > 0 THEN 0
+			ELSE CASE WHEN IFNULL(thermal_power, 0) = 0 THEN 0 ELSE NULL END
+		END END`
+
+func rollupTable(level string) string {
+	return "temperature_rollups_" + level
+}
+
+// createRollupTables creates the rollup tables and adds columns for sensors added later, dbMutex must be held
+func createRollupTables() error {
+	for _, level := range []string{RollupHourly, RollupDaily} {
+		table := rollupTable(level)
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, `
+		CREATE TABLE IF NOT EXISTS %s (
+			bucket_start TEXT NOT NULL,
+			installation_id TEXT NOT NULL,
+			gateway_id TEXT NOT NULL,
+			device_id TEXT NOT NULL,
+			account_id TEXT,
+			account_name TEXT,
+			samples INTEGER NOT NULL,
+			sample_minutes REAL NOT NULL,
+			electricity_wh REAL NOT NULL,
+			thermal_wh REAL NOT NULL,
+			runtime_minutes REAL NOT NULL,
+			compressor_starts REAL NOT NULL,
+			cop_sum REAL NOT NULL,
+			cop_count INTEGER NOT NULL,`, table)
+		for _, c := range rollupSensorColumns {
+			fmt.Fprintf(&sb, "\n\t\t\t%s_min REAL, %s_avg REAL, %s_max REAL,", c, c, c)
+		}
+		fmt.Fprintf(&sb, `
+			PRIMARY KEY (installation_id, gateway_id, device_id, bucket_start)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_%s_bucket ON %s(bucket_start);
+		`, table, table)
+
+		if _, err := eventDB.Exec(sb.String()); err != nil {
+			return fmt.Errorf("failed to create %s table: %v", table, err)
+		}
+
+		for _, c := range rollupSensorColumns {
+			for _, suffix := range []string{"_min", "_avg", "_max"} {
+				if columnExists(table, c+suffix) {
+					continue
+				}
+				if _, err := eventDB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s%s REAL", table, c, suffix)); err != nil {
+					return fmt.Errorf("failed to add %s%s to %s: %v", c, suffix, table, err)
+				}
+			}
+		}
+	}
+
+	// Up to where each level is complete
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS temperature_rollup_state (
+		level TEXT PRIMARY KEY,
+		processed_until TEXT NOT NULL
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create temperature_rollup_state table: %v", err)
+	}
+	return nil
+}
+
+// rollupColumnList returns the columns written by the rollup statements
+func rollupColumnList() string {
+	columns := []string{
+		"bucket_start", "installation_id", "gateway_id", "device_id", "account_id", "account_name",
+		"samples", "sample_minutes", "electricity_wh", "thermal_wh", "runtime_minutes", "compressor_starts", "cop_sum", "cop_count",
+	}
+	for _, c := range rollupSensorColumns {
+		columns = append(columns, c+"_min", c+"_avg", c+"_max")
+	}
+	return strings.Join(columns, ", ")
+}
+
+// rollupUpsertClause updates existing rollups, unless the new one has fewer samples. Snapshots are
+// only ever deleted by the retention, so recalculating a period after an import must not shrink its
+// rollups to what is left of the raw data.
+func rollupUpsertClause(level string) string {
+	table := rollupTable(level)
+	var set []string
+	for _, c := range strings.Split(rollupColumnList(), ", ") {
+		set = append(set, c+" = excluded."+c)
+	}
+	return "ON CONFLICT(installation_id, gateway_id, device_id, bucket_start) DO UPDATE SET " +
+		strings.Join(set, ", ") + " WHERE excluded.samples >= " + table + ".samples"
+}
+
+// rollupStateLocked returns up to where a level is complete, dbMutex must be held
+func rollupStateLocked(level string) (time.Time, bool) {
+	var until string
+	if err := eventDB.QueryRow("SELECT processed_until FROM temperature_rollup_state WHERE level = ?", level).Scan(&until); err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+type sqlExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func setRollupState(db sqlExecer, level string, until time.Time) error {
+	_, err := db.Exec(`INSERT INTO temperature_rollup_state (level, processed_until) VALUES (?, ?)
+		ON CONFLICT(level) DO UPDATE SET processed_until = excluded.processed_until`,
+		level, until.UTC().Format(time.RFC3339))
+	return err
+}
+
+// rollupCoverageLocked returns the time range a level has data for, dbMutex must be held.
+// The start is the oldest bucket still kept, the end is where the level is complete.
+func rollupCoverageLocked(level string) (time.Time, time.Time, bool) {
+	until, ok := rollupStateLocked(level)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	var oldest sql.NullString
+	if err := eventDB.QueryRow("SELECT MIN(bucket_start) FROM " + rollupTable(level)).Scan(&oldest); err != nil || !oldest.Valid {
+		return time.Time{}, time.Time{}, false
+	}
+	from, err := time.Parse(time.RFC3339, oldest.String)
+	if err != nil || !from.Before(until) {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, until, true
+}
+
+// localDayStart returns midnight of the local day (DefaultLocation) containing t
+func localDayStart(t time.Time) time.Time {
+	local := t.In(DefaultLocation)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, DefaultLocation)
+}
+
+// bucketBounds returns the first bucket boundary at or after start and the last one at or before end
+func bucketBounds(level string, start, end time.Time) (time.Time, time.Time) {
+	if level == RollupDaily {
+		first := localDayStart(start)
+		if first.Before(start) {
+			first = first.AddDate(0, 0, 1)
+		}
+		return first, localDayStart(end)
+	}
+	first := start.Truncate(time.Hour)
+	if first.Before(start) {
+		first = first.Add(time.Hour)
+	}
+	return first, end.Truncate(time.Hour)
+}
+
+// UpdateTemperatureRollups rolls up all complete hours and days that are not processed yet.
+// The first run processes the whole history, later runs only the new hours.
+func UpdateTemperatureRollups(fallbackInterval int) error {
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	start := time.Now()
+	hours, err := updateHourlyRollups(fallbackInterval)
+	if err != nil {
+		return err
+	}
+	days, err := updateDailyRollups()
+	if err != nil {
+		return err
+	}
+	if hours > 0 || days > 0 {
+		log.Printf("Temperature rollups updated: %d hour(s), %d day(s) in %v", hours, days, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+func updateHourlyRollups(fallbackInterval int) (int, error) {
+	dbMutex.RLock()
+	from, ok := rollupStateLocked(RollupHourly)
+	if !ok {
+		var oldest sql.NullString
+		eventDB.QueryRow("SELECT MIN(timestamp) FROM temperature_snapshots").Scan(&oldest)
+		if oldest.Valid {
+			if t, err := time.Parse(time.RFC3339, oldest.String); err == nil {
+				from, ok = t.UTC().Truncate(time.Hour), true
+			}
+		}
+	}
+	dbMutex.RUnlock()
+	if !ok {
+		return 0, nil
+	}
+
+	until := time.Now().UTC().Add(-rollupGracePeriod).Truncate(time.Hour)
+	hours := 0
+	for from.Before(until) {
+		to := from.Add(rollupChunk)
+		if to.After(until) {
+			to = until
+		}
+		if err := rollupHourlyChunk(from, to, fallbackInterval); err != nil {
+			return hours, err
+		}
+		hours += int(to.Sub(from) / time.Hour)
+		from = to
+	}
+	return hours, nil
+}
+
+// rollupHourlyChunk aggregates the snapshots of [from, to) per device and hour
+func rollupHourlyChunk(from, to time.Time, fallbackInterval int) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `
+		INSERT INTO %s (%s)
+		SELECT
+			strftime('%%Y-%%m-%%dT%%H:00:00Z', timestamp) AS bucket,
+			installation_id, IFNULL(gateway_id, '') AS gw, IFNULL(device_id, '') AS dev,
+			MAX(account_id), MAX(account_name),
+			COUNT(*),
+			SUM(COALESCE(sample_interval, ?1)),
+			SUM(COALESCE(compressor_power, 0) * COALESCE(sample_interval, ?1) / 60.0),
+			SUM(COALESCE(%s, 0) * 1000.0 * COALESCE(sample_interval, ?1) / 60.0),
+			SUM(CASE WHEN compressor_active = 1 THEN COALESCE(sample_interval, ?1) ELSE 0 END),
+			SUM(CASE WHEN compressor_starts > prev_starts THEN compressor_starts - prev_starts ELSE 0 END),
+			IFNULL(SUM(CASE WHEN cop > 0 THEN cop END), 0),
+			COUNT(CASE WHEN cop > 0 THEN 1 END)`, rollupTable(RollupHourly), rollupColumnList(), rollupThermalPowerSQL)
+	for _, c := range rollupSensorColumns {
+		fmt.Fprintf(&sb, ",\n\t\t\tMIN(%s), AVG(%s), MAX(%s)", c, c, c)
+	}
+	// compressor_starts is a counter, the starts of an hour are the increase since the previous snapshot
+	sb.WriteString(`
+		FROM (
+			SELECT *, LAG(compressor_starts) OVER (
+				PARTITION BY installation_id, gateway_id, device_id ORDER BY timestamp
+			) AS prev_starts
+			FROM temperature_snapshots
+			WHERE timestamp >= ?2 AND timestamp < ?4
+		)
+		WHERE timestamp >= ?3
+		GROUP BY bucket, installation_id, gw, dev
+	`)
+	sb.WriteString(rollupUpsertClause(RollupHourly))
+
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback() // Will be no-op if committed
+
+	_, err = tx.Exec(sb.String(), fallbackInterval,
+		from.Add(-rollupCounterLookback).Format(time.RFC3339), from.Format(time.RFC3339), to.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to roll up hours: %v", err)
+	}
+	if err := setRollupState(tx, RollupHourly, to); err != nil {
+		return fmt.Errorf("failed to save rollup state: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
+
+func updateDailyRollups() (int, error) {
+	dbMutex.RLock()
+	hourlyUntil, ok := rollupStateLocked(RollupHourly)
+	from, hasState := rollupStateLocked(RollupDaily)
+	if ok && !hasState {
+		var oldest sql.NullString
+		eventDB.QueryRow("SELECT MIN(bucket_start) FROM " + rollupTable(RollupHourly)).Scan(&oldest)
+		if t, err := time.Parse(time.RFC3339, oldest.String); oldest.Valid && err == nil {
+			from, hasState = localDayStart(t), true
+		}
+	}
+	dbMutex.RUnlock()
+	if !ok || !hasState {
+		return 0, nil
+	}
+
+	// Only days whose hours are all rolled up
+	until := localDayStart(hourlyUntil)
+	days := 0
+	for from.Before(until) {
+		to := from.AddDate(0, 1, 0)
+		if to.After(until) {
+			to = until
+		}
+		n, err := rollupDailyChunk(from, to)
+		if err != nil {
+			return days, err
+		}
+		days += n
+		from = to
+	}
+	return days, nil
+}
+
+// rollupDailyChunk aggregates the hourly rollups of the local days in [from, to)
+func rollupDailyChunk(from, to time.Time) (int, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `
+		INSERT INTO %s (%s)
+		SELECT
+			?1, installation_id, gateway_id, device_id, MAX(account_id), MAX(account_name),
+			SUM(samples), SUM(sample_minutes), SUM(electricity_wh), SUM(thermal_wh), SUM(runtime_minutes),
+			SUM(compressor_starts), SUM(cop_sum), SUM(cop_count)`, rollupTable(RollupDaily), rollupColumnList())
+	// Hourly averages are weighted by their number of samples
+	for _, c := range rollupSensorColumns {
+		fmt.Fprintf(&sb, ",\n\t\t\tMIN(%s_min), SUM(%s_avg * samples) / SUM(CASE WHEN %s_avg IS NOT NULL THEN samples END), MAX(%s_max)", c, c, c, c)
+	}
+	fmt.Fprintf(&sb, `
+		FROM %s
+		WHERE bucket_start >= ?1 AND bucket_start < ?2
+		GROUP BY installation_id, gateway_id, device_id
+	`, rollupTable(RollupHourly))
+	sb.WriteString(rollupUpsertClause(RollupDaily))
+	query := sb.String()
+
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback() // Will be no-op if committed
+
+	days := 0
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		if _, err := tx.Exec(query, day.UTC().Format(time.RFC3339), next.UTC().Format(time.RFC3339)); err != nil {
+			return 0, fmt.Errorf("failed to roll up day %s: %v", day.Format("2006-01-02"), err)
+		}
+		days++
+	}
+	if err := setRollupState(tx, RollupDaily, to); err != nil {
+		return 0, fmt.Errorf("failed to save rollup state: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return days, nil
+}
+
+// invalidateTemperatureRollupsLocked makes the next update roll up everything from since again,
+// e.g. after snapshots were imported into the past. dbMutex must be held.
+func invalidateTemperatureRollupsLocked(since time.Time) error {
+	for level, start := range map[string]time.Time{
+		RollupHourly: since.UTC().Truncate(time.Hour),
+		RollupDaily:  localDayStart(since),
+	} {
+		if until, ok := rollupStateLocked(level); ok && start.Before(until) {
+			if err := setRollupState(eventDB, level, start); err != nil {
+				return fmt.Errorf("failed to reset rollup state: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// CleanupOldTemperatureRollups removes rollups older than their retention period (0 = keep forever)
+func CleanupOldTemperatureRollups(hourlyRetentionDays, dailyRetentionDays int) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	for level, days := range map[string]int{RollupHourly: hourlyRetentionDays, RollupDaily: dailyRetentionDays} {
+		if days <= 0 {
+			continue
+		}
+		cutoff := time.Now().UTC().AddDate(0, 0, -days)
+		result, err := eventDB.Exec("DELETE FROM "+rollupTable(level)+" WHERE bucket_start < ?", cutoff.Format(time.RFC3339))
+		if err != nil {
+			return fmt.Errorf("failed to cleanup old %s rollups: %v", level, err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			log.Printf("Cleaned up %d old %s rollups (retention: %d days)", n, level, days)
+		}
+	}
+	return nil
+}
+
+// GetTemperatureRollupCount returns the number of rollups of a level
+func GetTemperatureRollupCount(level string) (int64, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+
+	var count int64
+	if err := eventDB.QueryRow("SELECT COUNT(*) FROM " + rollupTable(level)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count %s rollups: %v", level, err)
+	}
+	return count, nil
+}
+
+// useRollups decides whether a time range is read from the rollups: long ranges, and ranges
+// reaching back beyond the retention of the raw snapshots
+func useRollups(start, end time.Time, rawRetentionDays int) bool {
+	return end.Sub(start) >= rollupMinRange || start.Before(time.Now().AddDate(0, 0, -rawRetentionDays))
+}
+
+// consumptionTotals are the sums behind ConsumptionStats
+type consumptionTotals struct {
+	electricityWh  float64
+	thermalWh      float64
+	copSum         float64
+	copCount       int
+	runtimeMinutes float64
+	samples        int
+}
+
+func (t *consumptionTotals) add(o consumptionTotals) {
+	t.electricityWh += o.electricityWh
+	t.thermalWh += o.thermalWh
+	t.copSum += o.copSum
+	t.copCount += o.copCount
+	t.runtimeMinutes += o.runtimeMinutes
+	t.samples += o.samples
+}
+
+// sumConsumptionLocked adds up the consumption of [start, end) from the rollups of a level where they
+// cover the range, and from the next finer level (down to the raw snapshots) for the rest.
+// An empty level reads the raw snapshots. dbMutex must be held.
+func sumConsumptionLocked(level, installationID, gatewayID, deviceID string, start, end time.Time, fallbackInterval int) (consumptionTotals, error) {
+	var totals consumptionTotals
+	if !start.Before(end) {
+		return totals, nil
+	}
+	if level == "" {
+		return rawConsumptionLocked(installationID, gatewayID, deviceID, start, end, fallbackInterval)
+	}
+
+	finer := ""
+	if level == RollupDaily {
+		finer = RollupHourly
+	}
+
+	innerStart, innerEnd := bucketBounds(level, start, end)
+	if from, until, ok := rollupCoverageLocked(level); ok {
+		if innerStart.Before(from) {
+			innerStart = from
+		}
+		if innerEnd.After(until) {
+			innerEnd = until
+		}
+	} else {
+		innerEnd = innerStart
+	}
+	if !innerStart.Before(innerEnd) {
+		return sumConsumptionLocked(finer, installationID, gatewayID, deviceID, start, end, fallbackInterval)
+	}
+
+	err := eventDB.QueryRow(`
+		SELECT
+			IFNULL(SUM(electricity_wh), 0), IFNULL(SUM(thermal_wh), 0),
+			IFNULL(SUM(cop_sum), 0), IFNULL(SUM(cop_count), 0),
+			IFNULL(SUM(runtime_minutes), 0), IFNULL(SUM(samples), 0)
+		FROM `+rollupTable(level)+`
+		WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+			AND bucket_start >= ? AND bucket_start < ?
+	`, installationID, gatewayID, deviceID, innerStart.UTC().Format(time.RFC3339), innerEnd.UTC().Format(time.RFC3339)).Scan(
+		&totals.electricityWh, &totals.thermalWh, &totals.copSum, &totals.copCount, &totals.runtimeMinutes, &totals.samples)
+	if err != nil {
+		return totals, fmt.Errorf("failed to query %s rollups: %v", level, err)
+	}
+
+	before, err := sumConsumptionLocked(finer, installationID, gatewayID, deviceID, start, innerStart, fallbackInterval)
+	if err != nil {
+		return totals, err
+	}
+	after, err := sumConsumptionLocked(finer, installationID, gatewayID, deviceID, innerEnd, end, fallbackInterval)
+	if err != nil {
+		return totals, err
+	}
+	totals.add(before)
+	totals.add(after)
+	return totals, nil
+}
+
+// rollupBreakdownLocked returns one ConsumptionDataPoint per bucket of a level in [start, end).
+// Daily points are stamped with the local date like GetDailyConsumptionBreakdown. dbMutex must be held.
+func rollupBreakdownLocked(level, installationID, gatewayID, deviceID string, start, end time.Time) ([]ConsumptionDataPoint, error) {
+	rows, err := eventDB.Query(`
+		SELECT bucket_start, electricity_wh, thermal_wh, cop_avg, runtime_minutes, samples
+		FROM `+rollupTable(level)+`
+		WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+			AND bucket_start >= ? AND bucket_start < ?
+		ORDER BY bucket_start ASC
+	`, installationID, gatewayID, deviceID, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s rollups: %v", level, err)
+	}
+	defer rows.Close()
+
+	var dataPoints []ConsumptionDataPoint
+	for rows.Next() {
+		var bucket string
+		var electricityWh, thermalWh, runtimeMinutes float64
+		var avgCOP *float64
+		var samples int
+		if err := rows.Scan(&bucket, &electricityWh, &thermalWh, &avgCOP, &runtimeMinutes, &samples); err != nil {
+			log.Printf("Warning: failed to scan %s rollup row: %v", level, err)
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, bucket)
+		if err != nil {
+			continue
+		}
+		local := ts.In(DefaultLocation)
+		if level == RollupDaily {
+			ts = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		} else {
+			ts = local
+		}
+
+		dataPoint := ConsumptionDataPoint{
+			Timestamp:      ts,
+			ElectricityKWh: electricityWh / 1000.0, // Wh -> kWh
+			ThermalKWh:     thermalWh / 1000.0,     // Wh -> kWh
+			RuntimeHours:   runtimeMinutes / 60.0,
+			Samples:        samples,
+		}
+		if avgCOP != nil {
+			dataPoint.AvgCOP = *avgCOP
+		}
+		dataPoints = append(dataPoints, dataPoint)
+	}
+	return dataPoints, rows.Err()
+}
+
+// GetTemperatureRollups returns the rollups of a level, gateway and devices are optional
+func GetTemperatureRollups(level, installationID, gatewayID string, deviceIDs []string, startTime, endTime time.Time, limit int) ([]TemperatureRollup, error) {
+	if level != RollupHourly && level != RollupDaily {
+		return nil, fmt.Errorf("invalid rollup level %q", level)
+	}
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	columns := []string{
+		"bucket_start", "installation_id", "gateway_id", "device_id", "IFNULL(account_id, '')", "IFNULL(account_name, '')",
+		"samples", "sample_minutes", "electricity_wh", "thermal_wh", "runtime_minutes", "compressor_starts", "cop_sum", "cop_count",
+	}
+	for _, c := range rollupSensorColumns {
+		columns = append(columns, c+"_min", c+"_avg", c+"_max")
+	}
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM " + rollupTable(level) +
+		" WHERE installation_id = ? AND bucket_start >= ? AND bucket_start <= ?"
+	args := []interface{}{installationID, startTime.UTC().Format(time.RFC3339), endTime.UTC().Format(time.RFC3339)}
+	if gatewayID != "" {
+		query += " AND gateway_id = ?"
+		args = append(args, gatewayID)
+	}
+	query, args = appendDeviceFilter(query, args, deviceIDs)
+	query += " ORDER BY bucket_start ASC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s rollups: %v", level, err)
+	}
+	defer rows.Close()
+
+	var rollups []TemperatureRollup
+	sensorValues := make([]*float64, len(rollupSensorColumns)*3)
+	for rows.Next() {
+		var r TemperatureRollup
+		var bucket string
+		var electricityWh, thermalWh, runtimeMinutes, copSum float64
+		var copCount int
+		dest := []interface{}{
+			&bucket, &r.InstallationID, &r.GatewayID, &r.DeviceID, &r.AccountID, &r.AccountName,
+			&r.Samples, &r.SampleMinutes, &electricityWh, &thermalWh, &runtimeMinutes, &r.CompressorStarts, &copSum, &copCount,
+		}
+		for i := range sensorValues {
+			sensorValues[i] = nil
+			dest = append(dest, &sensorValues[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			log.Printf("Warning: failed to scan %s rollup row: %v", level, err)
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, bucket)
+		if err != nil {
+			continue
+		}
+
+		r.BucketStart = ts
+		r.Resolution = level
+		r.ElectricityKWh = electricityWh / 1000.0
+		r.ThermalKWh = thermalWh / 1000.0
+		r.RuntimeHours = runtimeMinutes / 60.0
+		if copCount > 0 {
+			r.AvgCOP = copSum / float64(copCount)
+		}
+		r.Sensors = make(map[string]RollupValue)
+		for i, c := range rollupSensorColumns {
+			if sensorValues[i*3+1] == nil {
+				continue
+			}
+			r.Sensors[c] = RollupValue{Min: sensorValues[i*3], Avg: sensorValues[i*3+1], Max: sensorValues[i*3+2]}
+		}
+		rollups = append(rollups, r)
+	}
+	return rollups, rows.Err()
+}
+
+// snapshotFieldsByColumn maps the JSON names of TemperatureSnapshot to its *float64 fields
+var snapshotFieldsByColumn = func() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(TemperatureSnapshot{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if t.Field(i).Type == reflect.TypeOf((*float64)(nil)) {
+			fields[name] = i
+		}
+	}
+	return fields
+}()
+
+// Snapshot converts a rollup into a snapshot with the average values, so charts can show it like raw data
+func (r *TemperatureRollup) Snapshot() TemperatureSnapshot {
+	snapshot := TemperatureSnapshot{
+		Timestamp:      r.BucketStart,
+		InstallationID: r.InstallationID,
+		GatewayID:      r.GatewayID,
+		DeviceID:       r.DeviceID,
+		AccountID:      r.AccountID,
+		AccountName:    r.AccountName,
+		SampleInterval: 60,
+	}
+	if r.Resolution == RollupDaily {
+		snapshot.SampleInterval = 24 * 60
+	}
+
+	v := reflect.ValueOf(&snapshot).Elem()
+	for column, value := range r.Sensors {
+		if i, ok := snapshotFieldsByColumn[column]; ok && value.Avg != nil {
+			avg := *value.Avg
+			v.Field(i).Set(reflect.ValueOf(&avg))
+		}
+	}
+	return snapshot
+}
+
+// temperatureChartResolution picks the resolution of chart data for a time range: raw snapshots up
+// to a month, hourly rollups up to a year, daily rollups beyond. A finer level that is already
+// deleted for the start of the range is skipped.
+func temperatureChartResolution(start, end time.Time, settings *TemperatureLogSettings) string {
+	span := end.Sub(start)
+	hourlyDeleted := settings.HourlyRollupRetentionDays > 0 && start.Before(time.Now().AddDate(0, 0, -settings.HourlyRollupRetentionDays))
+	switch {
+	case span > 400*24*time.Hour || hourlyDeleted:
+		return RollupDaily
+	case span > 31*24*time.Hour || start.Before(time.Now().AddDate(0, 0, -settings.RetentionDays)):
+		return RollupHourly
+	}
+	return "raw"
+}
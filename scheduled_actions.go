@@ -0,0 +1,586 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduledAction is a persistent control action (cron or one-shot) executed through executeFeatureCommand.
+// Cron expressions and one-shot times without zone use the local time of the server.
+type ScheduledAction struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Schedule string `json:"schedule,omitempty"` // Cron expression, e.g. "0 13 * * *"; empty for one-shot actions
+	RunAt    string `json:"runAt,omitempty"`    // One-shot time (RFC3339 or 2006-01-02T15:04 local)
+	FeatureCommandRequest
+	NextRun     *string `json:"nextRun"`
+	LastRun     *string `json:"lastRun"`
+	LastStatus  string  `json:"lastStatus,omitempty"`
+	LastMessage string  `json:"lastMessage,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+// ScheduledActionRun is one execution (or skip) of a scheduled action
+type ScheduledActionRun struct {
+	ID           int64  `json:"id"`
+	ActionID     int64  `json:"actionId"`
+	ActionName   string `json:"actionName"`
+	ScheduledFor string `json:"scheduledFor"`
+	ExecutedAt   string `json:"executedAt"`
+	Status       string `json:"status"` // success, failed, skipped
+	Message      string `json:"message,omitempty"`
+	Manual       bool   `json:"manual"`
+}
+
+const (
+	scheduledRunSuccess = "success"
+	scheduledRunFailed  = "failed"
+	scheduledRunSkipped = "skipped"
+
+	// scheduledActionMissedAfter: runs that are overdue by more (e.g. after a restart) are skipped instead of executed late
+	scheduledActionMissedAfter = 15 * time.Minute
+	// scheduledActionRunsKept is the number of run records kept per action
+	scheduledActionRunsKept = 200
+)
+
+var (
+	actionSchedulerRunning bool
+	actionSchedulerMutex   sync.Mutex
+	actionSchedulerStop    chan bool
+	actionRunMutex         sync.Mutex // Serializes scheduled and manual runs
+)
+
+// createScheduledActionTables creates the tables of the action scheduler, dbMutex must be held
+func createScheduledActionTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS scheduled_actions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		enabled INTEGER NOT NULL DEFAULT 1,
+		schedule TEXT,
+		run_at TEXT,
+		account_id TEXT NOT NULL,
+		installation_id TEXT NOT NULL,
+		gateway_serial TEXT NOT NULL,
+		device_id TEXT NOT NULL,
+		feature TEXT NOT NULL,
+		command TEXT NOT NULL,
+		params TEXT,
+		next_run TEXT,
+		last_run TEXT,
+		last_status TEXT,
+		last_message TEXT,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_scheduled_actions_next_run ON scheduled_actions(enabled, next_run);
+
+	CREATE TABLE IF NOT EXISTS scheduled_action_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		action_id INTEGER NOT NULL,
+		action_name TEXT,
+		scheduled_for TEXT,
+		executed_at TEXT NOT NULL,
+		status TEXT NOT NULL,
+		message TEXT,
+		manual INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_scheduled_action_runs_action ON scheduled_action_runs(action_id, executed_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled action tables: %v", err)
+	}
+	return nil
+}
+
+// parseScheduledRunAt parses the time of a one-shot action, times without zone are local
+func parseScheduledRunAt(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid runAt %q (expected RFC3339 or 2006-01-02T15:04)", value)
+}
+
+// validateScheduledAction checks the fields and normalizes name and params
+func validateScheduledAction(a *ScheduledAction) error {
+	a.Schedule = strings.TrimSpace(a.Schedule)
+	a.RunAt = strings.TrimSpace(a.RunAt)
+
+	r := a.FeatureCommandRequest
+	if r.AccountID == "" || r.InstallationID == "" || r.GatewaySerial == "" || r.DeviceID == "" || r.Feature == "" || r.Command == "" {
+		return fmt.Errorf("accountId, installationId, gatewaySerial, deviceId, feature and command are required")
+	}
+	if a.Params == nil {
+		a.Params = make(map[string]interface{})
+	}
+
+	switch {
+	case a.Schedule != "" && a.RunAt != "":
+		return fmt.Errorf("either schedule or runAt must be set, not both")
+	case a.Schedule != "":
+		s, err := parseCronSchedule(a.Schedule)
+		if err != nil {
+			return fmt.Errorf("invalid schedule: %v", err)
+		}
+		if s.Next(time.Now()).IsZero() {
+			return fmt.Errorf("schedule %q never matches", a.Schedule)
+		}
+	case a.RunAt != "":
+		if _, err := parseScheduledRunAt(a.RunAt); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("schedule (cron) or runAt (one-shot) is required")
+	}
+
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		a.Name = a.Feature + "." + a.Command
+	}
+	return nil
+}
+
+// upcomingRuns returns the run times of an action after from (at most max, none after until)
+func (a *ScheduledAction) upcomingRuns(from, until time.Time, max int) []time.Time {
+	var runs []time.Time
+	if a.RunAt != "" {
+		if t, err := parseScheduledRunAt(a.RunAt); err == nil && !t.Before(from) && !t.After(until) {
+			runs = append(runs, t)
+		}
+		return runs
+	}
+
+	s, err := parseCronSchedule(a.Schedule)
+	if err != nil {
+		return nil
+	}
+	t := from.In(time.Local).Add(-time.Minute)
+	for len(runs) < max {
+		t = s.Next(t)
+		if t.IsZero() || t.After(until) {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// nextRunAfter returns the next run of an enabled action after t, nil if there is none
+func (a *ScheduledAction) nextRunAfter(t time.Time) *string {
+	if !a.Enabled {
+		return nil
+	}
+	var next time.Time
+	if a.RunAt != "" {
+		runAt, err := parseScheduledRunAt(a.RunAt)
+		if err != nil || a.LastRun != nil {
+			return nil
+		}
+		// An overdue one-shot action is reported as missed by the scheduler
+		next = runAt
+	} else {
+		s, err := parseCronSchedule(a.Schedule)
+		if err != nil {
+			return nil
+		}
+		if next = s.Next(t.In(time.Local)); next.IsZero() {
+			return nil
+		}
+	}
+	formatted := next.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+const scheduledActionColumns = `id, name, enabled, COALESCE(schedule, ''), COALESCE(run_at, ''), account_id, installation_id,
+	gateway_serial, device_id, feature, command, COALESCE(params, '{}'), next_run, last_run,
+	COALESCE(last_status, ''), COALESCE(last_message, ''), created_at, updated_at`
+
+func scanScheduledAction(row interface{ Scan(...interface{}) error }) (*ScheduledAction, error) {
+	a := &ScheduledAction{}
+	var params string
+	err := row.Scan(&a.ID, &a.Name, &a.Enabled, &a.Schedule, &a.RunAt, &a.AccountID, &a.InstallationID,
+		&a.GatewaySerial, &a.DeviceID, &a.Feature, &a.Command, &params, &a.NextRun, &a.LastRun,
+		&a.LastStatus, &a.LastMessage, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(params), &a.Params); err != nil {
+		return nil, fmt.Errorf("invalid params of scheduled action %d: %v", a.ID, err)
+	}
+	return a, nil
+}
+
+// GetScheduledActions returns all actions, the next due first
+func GetScheduledActions() ([]ScheduledAction, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(`SELECT ` + scheduledActionColumns + ` FROM scheduled_actions
+		ORDER BY next_run IS NULL, next_run, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled actions: %v", err)
+	}
+	defer rows.Close()
+
+	actions := []ScheduledAction{}
+	for rows.Next() {
+		a, err := scanScheduledAction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled action: %v", err)
+		}
+		actions = append(actions, *a)
+	}
+	return actions, rows.Err()
+}
+
+// GetScheduledAction returns one action
+func GetScheduledAction(id int64) (*ScheduledAction, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	a, err := scanScheduledAction(eventDB.QueryRow(`SELECT `+scheduledActionColumns+` FROM scheduled_actions WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("scheduled action %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled action: %v", err)
+	}
+	return a, nil
+}
+
+// SaveScheduledAction creates (ID 0) or updates an action and computes its next run
+func SaveScheduledAction(a *ScheduledAction) (*ScheduledAction, error) {
+	if err := validateScheduledAction(a); err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(a.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid params: %v", err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	dbMutex.Lock()
+	if !dbInitialized || eventDB == nil {
+		dbMutex.Unlock()
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if a.ID == 0 {
+		a.NextRun = a.nextRunAfter(time.Now())
+		err = eventDB.QueryRow(`
+			INSERT INTO scheduled_actions (name, enabled, schedule, run_at, account_id, installation_id, gateway_serial,
+				device_id, feature, command, params, next_run, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`, a.Name, a.Enabled, a.Schedule, a.RunAt, a.AccountID, a.InstallationID, a.GatewaySerial,
+			a.DeviceID, a.Feature, a.Command, string(params), a.NextRun, now, now).Scan(&a.ID)
+	} else {
+		// A changed schedule starts over, also for one-shot actions that already ran
+		a.LastRun = nil
+		a.NextRun = a.nextRunAfter(time.Now())
+		var result sql.Result
+		result, err = eventDB.Exec(`
+			UPDATE scheduled_actions SET name = ?, enabled = ?, schedule = ?, run_at = ?, account_id = ?,
+				installation_id = ?, gateway_serial = ?, device_id = ?, feature = ?, command = ?, params = ?,
+				next_run = ?, updated_at = ?
+			WHERE id = ?
+		`, a.Name, a.Enabled, a.Schedule, a.RunAt, a.AccountID, a.InstallationID, a.GatewaySerial,
+			a.DeviceID, a.Feature, a.Command, string(params), a.NextRun, now, a.ID)
+		if err == nil {
+			if n, _ := result.RowsAffected(); n == 0 {
+				err = fmt.Errorf("scheduled action %d not found", a.ID)
+			}
+		}
+	}
+	dbMutex.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save scheduled action: %v", err)
+	}
+	return GetScheduledAction(a.ID)
+}
+
+// DeleteScheduledAction removes an action and its run history
+func DeleteScheduledAction(id int64) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	result, err := eventDB.Exec(`DELETE FROM scheduled_actions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled action: %v", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("scheduled action %d not found", id)
+	}
+	if _, err := eventDB.Exec(`DELETE FROM scheduled_action_runs WHERE action_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete scheduled action runs: %v", err)
+	}
+	return nil
+}
+
+// GetScheduledActionRuns returns the newest runs, of one action or of all actions (actionID 0)
+func GetScheduledActionRuns(actionID int64, limit int) ([]ScheduledActionRun, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	query := `SELECT id, action_id, COALESCE(action_name, ''), COALESCE(scheduled_for, ''), executed_at, status,
+		COALESCE(message, ''), manual FROM scheduled_action_runs`
+	args := []interface{}{}
+	if actionID > 0 {
+		query += ` WHERE action_id = ?`
+		args = append(args, actionID)
+	}
+	query += ` ORDER BY executed_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := eventDB.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled action runs: %v", err)
+	}
+	defer rows.Close()
+
+	runs := []ScheduledActionRun{}
+	for rows.Next() {
+		var run ScheduledActionRun
+		if err := rows.Scan(&run.ID, &run.ActionID, &run.ActionName, &run.ScheduledFor, &run.ExecutedAt,
+			&run.Status, &run.Message, &run.Manual); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled action run: %v", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// recordScheduledActionRun stores the result of a run and keeps the newest runs per action
+func recordScheduledActionRun(a *ScheduledAction, scheduledFor time.Time, status, message string, manual bool) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	executedAt := time.Now().UTC().Format(time.RFC3339)
+	var scheduled interface{}
+	if !scheduledFor.IsZero() {
+		scheduled = scheduledFor.UTC().Format(time.RFC3339)
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO scheduled_action_runs (action_id, action_name, scheduled_for, executed_at, status, message, manual)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, a.ID, a.Name, scheduled, executedAt, status, message, manual); err != nil {
+		return fmt.Errorf("failed to record scheduled action run: %v", err)
+	}
+	if _, err := tx.Exec(`UPDATE scheduled_actions SET last_run = ?, last_status = ?, last_message = ? WHERE id = ?`,
+		executedAt, status, message, a.ID); err != nil {
+		return fmt.Errorf("failed to update scheduled action: %v", err)
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM scheduled_action_runs WHERE action_id = ? AND id NOT IN (
+			SELECT id FROM scheduled_action_runs WHERE action_id = ? ORDER BY id DESC LIMIT ?)
+	`, a.ID, a.ID, scheduledActionRunsKept); err != nil {
+		return fmt.Errorf("failed to clean up scheduled action runs: %v", err)
+	}
+	return tx.Commit()
+}
+
+// claimDueScheduledActions returns the actions due at now and moves their next run forward,
+// so a slow or crashing run is not repeated. One-shot actions are disabled.
+func claimDueScheduledActions(now time.Time) ([]ScheduledAction, []time.Time, error) {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, nil, nil
+	}
+
+	rows, err := eventDB.Query(`SELECT `+scheduledActionColumns+` FROM scheduled_actions
+		WHERE enabled = 1 AND next_run IS NOT NULL AND next_run <= ? ORDER BY next_run`, now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query due scheduled actions: %v", err)
+	}
+	var due []ScheduledAction
+	for rows.Next() {
+		a, err := scanScheduledAction(rows)
+		if err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("failed to scan scheduled action: %v", err)
+		}
+		due = append(due, *a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	scheduledFor := make([]time.Time, len(due))
+	for i := range due {
+		a := &due[i]
+		scheduledFor[i], _ = time.Parse(time.RFC3339, *a.NextRun)
+
+		var next *string
+		enabled := a.Enabled
+		if a.RunAt != "" {
+			enabled = false
+		} else {
+			next = a.nextRunAfter(now)
+		}
+		if _, err := eventDB.Exec(`UPDATE scheduled_actions SET next_run = ?, enabled = ? WHERE id = ?`, next, enabled, a.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to update scheduled action: %v", err)
+		}
+	}
+	return due, scheduledFor, nil
+}
+
+// lastGatewayStatus returns whether the newest archived gateway-online/-offline event reports the gateway offline
+func lastGatewayStatus(gatewaySerial string) (offline bool, since string) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return false, ""
+	}
+
+	var eventType string
+	err := eventDB.QueryRow(`
+		SELECT event_type, event_timestamp FROM events
+		WHERE gateway_serial = ? AND event_type IN ('gateway-online', 'gateway-offline')
+		ORDER BY event_timestamp DESC LIMIT 1
+	`, gatewaySerial).Scan(&eventType, &since)
+	if err != nil {
+		return false, ""
+	}
+	return eventType == "gateway-offline", since
+}
+
+// runScheduledAction executes an action through the common feature command path.
+// Offline gateways are skipped, according to the archived events or the API response.
+func runScheduledAction(a *ScheduledAction, scheduledFor time.Time, manual bool) (status, message string) {
+	actionRunMutex.Lock()
+	defer actionRunMutex.Unlock()
+
+	// Runs started from the UI are user commands, cron and one-shot runs are background jobs
+	priority := PriorityBackground
+	if manual {
+		priority = PriorityInteractive
+	}
+
+	if offline, since := lastGatewayStatus(a.GatewaySerial); offline {
+		status, message = scheduledRunSkipped, fmt.Sprintf("gateway %s offline since %s", a.GatewaySerial, since)
+	} else if err := executeFeatureCommand(a.FeatureCommandRequest, priority); err != nil {
+		status, message = scheduledRunFailed, err.Error()
+		if apiErr := asAPIError(err); apiErr != nil {
+			message = apiErr.LocalizedMessage(defaultLanguage)
+			if apiErr.Kind == APIErrorGatewayOffline {
+				status = scheduledRunSkipped
+			}
+		}
+	} else {
+		status = scheduledRunSuccess
+	}
+
+	log.Printf("Scheduled action %d (%s): %s %s", a.ID, a.Name, status, message)
+	if err := recordScheduledActionRun(a, scheduledFor, status, message, manual); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return status, message
+}
+
+// scheduledActionsJob runs the due actions, runs overdue by more than scheduledActionMissedAfter are skipped
+func scheduledActionsJob() {
+	now := time.Now()
+	due, scheduledFor, err := claimDueScheduledActions(now)
+	if err != nil {
+		log.Printf("Scheduled actions: %v", err)
+		return
+	}
+
+	for i := range due {
+		if now.Sub(scheduledFor[i]) > scheduledActionMissedAfter {
+			message := fmt.Sprintf("missed, due at %s", scheduledFor[i].In(time.Local).Format("2006-01-02 15:04"))
+			log.Printf("Scheduled action %d (%s): %s", due[i].ID, due[i].Name, message)
+			if err := recordScheduledActionRun(&due[i], scheduledFor[i], scheduledRunSkipped, message, false); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+			continue
+		}
+		runScheduledAction(&due[i], scheduledFor[i], false)
+	}
+}
+
+// StartActionScheduler starts the background job for the scheduled actions.
+// The actions are stored in the event database, without it the job idles.
+func StartActionScheduler() {
+	actionSchedulerMutex.Lock()
+	defer actionSchedulerMutex.Unlock()
+
+	if actionSchedulerRunning {
+		return
+	}
+	actionSchedulerStop = make(chan bool)
+	actionSchedulerRunning = true
+	stop := actionSchedulerStop
+
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				scheduledActionsJob()
+			case <-stop:
+				log.Println("Action scheduler stopped")
+				return
+			}
+		}
+	}()
+	log.Println("Action scheduler started")
+}
+
+// StopActionScheduler stops the background job
+func StopActionScheduler() {
+	actionSchedulerMutex.Lock()
+	defer actionSchedulerMutex.Unlock()
+
+	if !actionSchedulerRunning {
+		return
+	}
+	close(actionSchedulerStop)
+	actionSchedulerRunning = false
+}
@@ -5,7 +5,7 @@
 
                 const response = await fetch('/api/debug/devices?' + params.toString());
                 if (!response.ok) {
-                    throw new Error('API Fehler: ' + response.status);
+                    throw new Error(t('dashboard.error_api', response.status));
                 }
 
                 const data = await response.json();
@@ -13,7 +13,7 @@
                 renderDebugModal(data);
 
             } catch (error) {
-                showError('Fehler beim Laden der Debug-Daten: ' + error.message);
+                showError(t('dashboard.debug_error_load', error.message));
             }
         }
 
@@ -34,7 +34,7 @@
                     if (hasFeatures.error) {
                         featuresHtml = `
             <div style="margin-top: 10px; padding: 10px; background: rgba(220, 38, 38, 0.1); border: 1px solid rgba(220, 38, 38, 0.3); border-radius: 4px; color: #fca5a5;">
-                ❌ Fehler beim Laden der Features: ${hasFeatures.error}
+                ❌ ${t('dashboard.error_load_features', hasFeatures.error)}
             </div>
                         `;
                     } else if (hasFeatures.features && hasFeatures.features.length > 0) {
@@ -58,7 +58,7 @@
                     } else {
                         featuresHtml = `
             <div style="margin-top: 10px; padding: 10px; background: rgba(0,0,0,0.3); border-radius: 4px; color: #a0a0b0; font-style: italic;">
-                Keine Features gefunden
+                ${t('dashboard.debug_no_features')}
             </div>
                         `;
                     }
@@ -94,13 +94,13 @@
                         <div style="margin-top: 12px; display: flex; gap: 8px; flex-wrap: wrap;">
             <button class="toggle-all" style="flex: 1; min-width: 140px; ${hasFeatures ? 'background: linear-gradient(135deg, #10b981 0%, #059669 100%);' : ''}"
                     onclick="loadDeviceFeatures('${deviceKey}', ${i})">
-                ${hasFeatures ? '✅ Features geladen' : '📊 Features laden'}
+                ${hasFeatures ? '✅ ' + t('dashboard.debug_features_loaded') : '📊 ' + t('dashboard.debug_load_features')}
             </button>
             <button class="toggle-all" style="flex: 1; min-width: 140px;" onclick="copyDeviceJson(${i})">
-                📋 JSON kopieren
+                📋 ${t('dashboard.debug_copy_json')}
             </button>
             <button class="toggle-all" style="flex: 1; min-width: 140px;" onclick="downloadDeviceJson(${i})">
-                💾 JSON herunterladen
+                💾 ${t('dashboard.debug_download_json')}
             </button>
                         </div>
                     </div>
@@ -112,32 +112,32 @@
             modal.innerHTML = `
                 <div class="debug-content">
                     <div class="debug-header">
-                        <h2>🐛 Debug: Geräte-Übersicht</h2>
-                        <button class="close-btn" onclick="closeDebugModal()">✕ Schließen</button>
+                        <h2>🐛 ${t('dashboard.debug_title')}</h2>
+                        <button class="close-btn" onclick="closeDebugModal()">✕ ${t('dashboard.close')}</button>
                     </div>
                     <div class="debug-summary">
                         <div class="debug-stat">
-            <div class="debug-stat-label">Angezeigte Geräte</div>
+            <div class="debug-stat-label">${t('dashboard.debug_shown_devices')}</div>
             <div class="debug-stat-value">${data.totalDevices}</div>
                         </div>
                         <div class="debug-stat">
-            <div class="debug-stat-label">Unbekannte Geräte</div>
+            <div class="debug-stat-label">${t('dashboard.debug_unknown_devices')}</div>
             <div class="debug-stat-value">${data.unknownDevices}</div>
                         </div>
                     </div>
                     <div class="debug-actions">
                         <button class="toggle-all" onclick="toggleAllDevices()">
-            ${showAllDevices ? '🔽 Nur unbekannte Geräte' : '🔼 Alle Geräte anzeigen'}
+            ${showAllDevices ? '🔽 ' + t('dashboard.debug_only_unknown') : '🔼 ' + t('dashboard.debug_show_all')}
                         </button>
                         <button class="toggle-all" onclick="toggleJsonView()">
-            ${showJsonView ? '👁️ Liste anzeigen' : '📄 JSON anzeigen'}
+            ${showJsonView ? '👁️ ' + t('dashboard.debug_show_list') : '📄 ' + t('dashboard.debug_show_json')}
                         </button>
                     </div>
                     <div style="padding: 12px; background: rgba(102, 126, 234, 0.1); border: 1px solid rgba(102, 126, 234, 0.3); border-radius: 6px; margin-bottom: 15px; color: #667eea; font-size: 13px;">
-                        ℹ️ Verwende die Buttons unter jedem Gerät um Features zu laden oder das Gerät zu exportieren.
+                        ℹ️ ${t('dashboard.debug_hint')}
                     </div>
                     <div id="debugDeviceList" style="max-height: 500px; overflow-y: auto; display: ${showJsonView ? 'none' : 'block'};">
-                        ${devicesHtml || '<p style="color: #a0a0b0; text-align: center; padding: 20px;">Keine Geräte gefunden</p>'}
+                        ${devicesHtml || '<p style="color: #a0a0b0; text-align: center; padding: 20px;">' + t('dashboard.debug_no_devices') + '</p>'}
                     </div>
                     <div id="debugJsonView" class="json-view" style="display: ${showJsonView ? 'block' : 'none'};">${jsonString}</div>
                 </div>
@@ -178,13 +178,13 @@
             // Show loading indicator
             const btn = event.target;
             const originalText = btn.textContent;
-            btn.textContent = '⏳ Laden...';
+            btn.textContent = '⏳ ' + t('dashboard.loading_short');
             btn.disabled = true;
 
             try {
                 const response = await fetch(`/api/features?installationId=${device.installationId}&gatewaySerial=${device.gatewaySerial}&deviceId=${device.deviceId}`);
                 if (!response.ok) {
-                    throw new Error('API Fehler: ' + response.status);
+                    throw new Error(t('dashboard.error_api', response.status));
                 }
 
                 const features = await response.json();
@@ -229,14 +229,14 @@
                 // Show success feedback
                 const btn = event.target;
                 const originalText = btn.textContent;
-                btn.textContent = '✅ Kopiert!';
+                btn.textContent = '✅ ' + t('dashboard.debug_copied');
                 btn.style.background = 'linear-gradient(135deg, #10b981 0%, #059669 100%)';
                 setTimeout(() => {
                     btn.textContent = originalText;
                     btn.style.background = '';
                 }, 2000);
             } catch (err) {
-                showError('Fehler beim Kopieren: ' + err.message);
+                showError(t('dashboard.debug_error_copy', err.message));
             }
         }
 
@@ -273,7 +273,7 @@
             // Show success feedback
             const btn = event.target;
             const originalText = btn.textContent;
-            btn.textContent = '✅ Heruntergeladen!';
+            btn.textContent = '✅ ' + t('dashboard.debug_downloaded');
             btn.style.background = 'linear-gradient(135deg, #10b981 0%, #059669 100%)';
             setTimeout(() => {
                 btn.textContent = originalText;
@@ -321,7 +321,7 @@
             // Get account from current device
             const accountId = window.currentDeviceInfo?.accountId;
             if (!accountId) {
-                alert('Kein Account für dieses Gerät verfügbar');
+                alert(t('dashboard.error_no_account'));
                 return;
             }
 
@@ -387,12 +387,12 @@
 
             modal.innerHTML = `
                 <div style="background: #1a1a2e; padding: 30px; border-radius: 12px; max-width: 500px; width: 95%; max-height: 90vh; overflow-y: auto; box-shadow: 0 20px 60px rgba(0,0,0,0.5);">
-                    <h2 style="margin-top: 0; color: #fff;">⚙️ Geräteeinstellungen</h2>
+                    <h2 style="margin-top: 0; color: #fff;">⚙️ ${t('dashboard.device_settings')}</h2>
                     <p style="color: #a0a0b0; margin-bottom: 20px;">Device: ${deviceId}</p>
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 8px; font-weight: 600;">
-                            Kompressor U/min Minimum
+                            ${t('dashboard.settings_rpm_min')}
                         </label>
                         <input type="number" id="rpmMin" value="${currentMin}"
                                style="width: 100%; padding: 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 14px;">
@@ -400,7 +400,7 @@
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 8px; font-weight: 600;">
-                            Kompressor U/min Maximum
+                            ${t('dashboard.settings_rpm_max')}
                         </label>
                         <input type="number" id="rpmMax" value="${currentMax}"
                                style="width: 100%; padding: 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 14px;">
@@ -408,46 +408,46 @@
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 8px; font-weight: 600;">
-                            Leistungskorrekturfaktor (Standard: 1.00)
+                            ${t('dashboard.settings_correction_factor')}
                         </label>
                         <input type="number" id="powerCorrectionFactor" value="${correctionFactor.toFixed(2)}" step="0.01" min="0.01" max="10.00"
                                style="width: 100%; padding: 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 14px;">
-                        <p style="color: #a0a0b0; font-size: 12px; margin-top: 5px;">Korrigiert die Leistungsanzeige und COP-Berechnung (Leistung × Faktor)</p>
+                        <p style="color: #a0a0b0; font-size: 12px; margin-top: 5px;">${t('dashboard.settings_correction_factor_hint')}</p>
                     </div>
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 8px; font-weight: 600;">
-                            ⚡ Strompreis (Standard: 0.30 €/kWh)
+                            ⚡ ${t('dashboard.settings_electricity_price')}
                         </label>
                         <input type="number" id="electricityPrice" value="${electricityPrice.toFixed(2)}" step="0.01" min="0.01" max="1.00"
                                style="width: 100%; padding: 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 14px;">
-                        <p style="color: #a0a0b0; font-size: 12px; margin-top: 5px;">Preis pro kWh für Verbrauchskosten-Berechnung in den Statistiken</p>
+                        <p style="color: #a0a0b0; font-size: 12px; margin-top: 5px;">${t('dashboard.settings_electricity_price_hint')}</p>
                     </div>
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 12px; font-weight: 600;">
-                            Temperaturbezeichnung für Primärkreis
+                            ${t('dashboard.settings_primary_label')}
                         </label>
                         <div style="background: rgba(255,255,255,0.05); padding: 12px; border-radius: 6px;">
                             <div style="margin-bottom: 8px;">
                                 <input type="radio" id="labelAuto" name="tempLabel" value="auto" ${radioState === 'auto' ? 'checked' : ''}
                                        style="cursor: pointer;">
                                 <label for="labelAuto" style="color: #a0a0b0; cursor: pointer; display: inline;">
-                                    Automatisch erkennen (Standard)
+                                    ${t('dashboard.settings_label_auto')}
                                 </label>
                             </div>
                             <div style="margin-bottom: 8px;">
                                 <input type="radio" id="labelAir" name="tempLabel" value="air" ${radioState === 'air' ? 'checked' : ''}
                                        style="cursor: pointer;">
                                 <label for="labelAir" style="color: #a0a0b0; cursor: pointer; display: inline;">
-                                    Lufteintrittstemperatur
+                                    ${t('dashboard.refrigerant_air_intake_temp')}
                                 </label>
                             </div>
                             <div>
                                 <input type="radio" id="labelPrimary" name="tempLabel" value="primary" ${radioState === 'primary' ? 'checked' : ''}
                                        style="cursor: pointer;">
                                 <label for="labelPrimary" style="color: #a0a0b0; cursor: pointer; display: inline;">
-                                    Primärkreisvorlauf
+                                    ${t('dashboard.settings_label_primary')}
                                 </label>
                             </div>
                         </div>
@@ -455,21 +455,21 @@
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 12px; font-weight: 600;">
-                            Spreizungs- / COP- berechnung
+                            ${t('dashboard.settings_spread')}
                         </label>
                         <div style="background: rgba(255,255,255,0.05); padding: 12px; border-radius: 6px;">
                             <div style="margin-bottom: 8px;">
                                 <input type="radio" id="spreizungODU" name="spreizung" value="ODU" ${spreizungState === 'ODU' ? 'checked' : ''}
                                        style="cursor: pointer;">
                                 <label for="spreizungWith" style="color: #a0a0b0; cursor: pointer; display: inline;">
-                                    ODU Sekundärkreis
+                                    ${t('dashboard.settings_spread_odu')}
                                 </label>
                             </div>
                             <div>
                                 <input type="radio" id="spreizungIDU" name="spreizung" value="IDU" ${spreizungState === 'IDU' ? 'checked' : ''}
                                        style="cursor: pointer;">
                                 <label for="spreizungWithout" style="color: #a0a0b0; cursor: pointer; display: inline;">
-                                    IDU Heizkreis / Wärmeerzeuger
+                                    ${t('dashboard.settings_spread_idu')}
                                 </label>
                             </div>
                         </div>
@@ -477,10 +477,10 @@
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 12px; font-weight: 600;">
-                            Durchschnittl. Takte
+                            ${t('dashboard.average_cycles')}
                         </label>
                         <div style="display: flex; align-items: center; gap: 15px; margin-bottom: 10px;">
-                            <label style="color: #a0a0b0; font-size: 16px;">Anzeigen:</label>
+                            <label style="color: #a0a0b0; font-size: 16px;">${t('dashboard.settings_show')}</label>
                             <label style="position: relative; display: inline-block; width: 50px; height: 24px; cursor: pointer;">
                                 <input type="checkbox" id="showCyclesPerDayToggle" ${toggleChecked ? 'checked' : ''}
                                        onchange="document.getElementById('showCyclesPerDayToggle').nextElementSibling.style.backgroundColor = this.checked ? '#667eea' : 'rgba(255,255,255,0.2)'; document.getElementById('showCyclesPerDayToggle').nextElementSibling.nextElementSibling.style.transform = this.checked ? 'translateX(26px)' : 'translateX(0)';"
@@ -490,17 +490,17 @@
                             </label>
                         </div>
                         <div style="display: inline-flex; align-items: center; gap: 8px; margin-left: 10px;">
-                            <label for="cyclesperDayCustomDatePicker" style="color: #a0a0b0; font-size: 16px; white-space: nowrap;">📅 Inbetriebnahmedatum:</label>
+                            <label for="cyclesperDayCustomDatePicker" style="color: #a0a0b0; font-size: 16px; white-space: nowrap;">📅 ${t('dashboard.settings_commissioning_date')}</label>
                             <input type="date" id="cyclesperDayCustomDatePicker" class="custom-date-input" value="${cyclestart}" style="padding: 6px 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 16px; cursor: pointer;">
                         </div>
                     </div>
 
                     <div style="margin-bottom: 20px;">
                         <label style="display: block; color: #fff; margin-bottom: 12px; font-weight: 600;">
-                            Kältekreislauf-Visualisierung
+                            ${t('dashboard.group_refrigerant_visual')}
                         </label>
                         <div style="display: flex; align-items: center; gap: 15px;">
-                            <label style="color: #a0a0b0; font-size: 16px;">Anzeigen:</label>
+                            <label style="color: #a0a0b0; font-size: 16px;">${t('dashboard.settings_show')}</label>
                             <label style="position: relative; display: inline-block; width: 50px; height: 24px; cursor: pointer;">
                                 <input type="checkbox" id="showRefrigerantVisualToggle" ${refrigerantToggleChecked ? 'checked' : ''}
                                        onchange="document.getElementById('showRefrigerantVisualToggle').nextElementSibling.style.backgroundColor = this.checked ? '#667eea' : 'rgba(255,255,255,0.2)'; document.getElementById('showRefrigerantVisualToggle').nextElementSibling.nextElementSibling.style.transform = this.checked ? 'translateX(26px)' : 'translateX(0)';"
@@ -508,7 +508,7 @@
                                 <span style="position: absolute; cursor: pointer; top: 0; left: 0; right: 0; bottom: 0; background-color: ${refrigerantToggleChecked ? '#667eea' : 'rgba(255,255,255,0.2)'}; transition: .4s; border-radius: 24px; border: 1px solid rgba(255,255,255,0.1);"></span>
                                 <span style="position: absolute; height: 18px; width: 18px; left: 3px; bottom: 3px; background-color: white; transition: .4s; border-radius: 50%; transform: ${refrigerantToggleChecked ? 'translateX(26px)' : 'translateX(0)'};"></span>
                             </label>
-                            <label style="color: #a0a0b0; font-size: 16px;">alternatives Bild:</label>
+                            <label style="color: #a0a0b0; font-size: 16px;">${t('dashboard.settings_alternative_picture')}</label>
                             <label style="position: relative; display: inline-block; width: 50px; height: 24px; cursor: pointer;">
                                 <input type="checkbox" id="useOtherRefrigerantPictureToggle" ${otherRefrigerantPicToggleChecked ? 'checked' : ''}
                                        onchange="document.getElementById('useOtherRefrigerantPictureToggle').nextElementSibling.style.backgroundColor = this.checked ? '#667eea' : 'rgba(255,255,255,0.2)'; document.getElementById('useOtherRefrigerantPictureToggle').nextElementSibling.nextElementSibling.style.transform = this.checked ? 'translateX(26px)' : 'translateX(0)';"
@@ -522,15 +522,15 @@
                     <div style="display: flex; gap: 10px; margin-top: 30px;">
                         <button onclick="saveDeviceSettings('${installationId}', '${deviceId}')"
                                 style="flex: 1; padding: 12px; background: linear-gradient(135deg, #667eea 0%, #764ba2 100%); color: white; border: none; border-radius: 6px; cursor: pointer; font-weight: 600;">
-                            💾 Speichern
+                            💾 ${t('common.save')}
                         </button>
                         <button onclick="deleteDeviceSettings('${installationId}', '${deviceId}')"
                                 style="flex: 1; padding: 12px; background: linear-gradient(135deg, #f093fb 0%, #f5576c 100%); color: white; border: none; border-radius: 6px; cursor: pointer; font-weight: 600;">
-                            🗑️ Löschen
+                            🗑️ ${t('dashboard.delete')}
                         </button>
                         <button onclick="closeDeviceSettingsModal()"
                                 style="padding: 12px 20px; background: rgba(255,255,255,0.05); color: white; border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; cursor: pointer; font-weight: 600;">
-                            Abbrechen
+                            ${t('dashboard.cancel')}
                         </button>
                     </div>
                 </div>
@@ -557,7 +557,7 @@
         async function saveDeviceSettings(installationId, deviceId) {
             const accountId = window.currentDeviceInfo?.accountId;
             if (!accountId) {
-                alert('Kein Account für dieses Gerät verfügbar');
+                alert(t('dashboard.error_no_account'));
                 return;
             }
 
@@ -567,17 +567,17 @@
             const electricityPrice = parseFloat(document.getElementById('electricityPrice').value) || 0.30;
 
             if (rpmMin >= rpmMax && rpmMax !== 0) {
-                alert('Minimum muss kleiner als Maximum sein');
+                alert(t('dashboard.settings_error_min_max'));
                 return;
             }
 
             if (powerCorrectionFactor <= 0 || powerCorrectionFactor > 10) {
-                alert('Korrekturfaktor muss zwischen 0.01 und 10.00 liegen');
+                alert(t('dashboard.settings_error_correction_factor'));
                 return;
             }
 
             if (electricityPrice <= 0 || electricityPrice > 1) {
-                alert('Strompreis muss zwischen 0.01 und 1.00 €/kWh liegen');
+                alert(t('dashboard.settings_error_electricity_price'));
                 return;
             }
 
@@ -663,27 +663,27 @@
                     window.deviceSettingsCache[deviceKey].showRefrigerantVisual = showRefrigerantVisual;
                     window.deviceSettingsCache[deviceKey].useOtherRefrigerantPic = useOtherRefrigerantPic;
 
-                    alert('Einstellungen gespeichert!');
+                    alert(t('dashboard.settings_saved'));
                     closeDeviceSettingsModal();
 
                     // Reload dashboard to show updated labels and percentages
                     loadDashboard();
                 } else {
-                    alert('Fehler beim Speichern: ' + data.error);
+                    alert(t('dashboard.error_save', data.error));
                 }
             } catch (error) {
-                alert('Fehler beim Speichern: ' + error.message);
+                alert(t('dashboard.error_save', error.message));
             }
         }
 
         async function deleteDeviceSettings(installationId, deviceId) {
-            if (!confirm('Einstellungen wirklich löschen?')) {
+            if (!confirm(t('dashboard.settings_confirm_delete'))) {
                 return;
             }
 
             const accountId = window.currentDeviceInfo?.accountId;
             if (!accountId) {
-                alert('Kein Account für dieses Gerät verfügbar');
+                alert(t('dashboard.error_no_account'));
                 return;
             }
 
@@ -705,16 +705,16 @@
                     const deviceKey = `${installationId}_${deviceId}`;
                     delete window.deviceSettingsCache[deviceKey];
 
-                    alert('Einstellungen gelöscht!');
+                    alert(t('dashboard.settings_deleted'));
                     closeDeviceSettingsModal();
 
                     // Reload dashboard
                     loadDashboard();
                 } else {
-                    alert('Fehler beim Löschen: ' + data.error);
+                    alert(t('dashboard.error_delete', data.error));
                 }
             } catch (error) {
-                alert('Fehler beim Löschen: ' + error.message);
+                alert(t('dashboard.error_delete', error.message));
             }
         }
 
@@ -727,7 +727,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -735,7 +735,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing
@@ -764,12 +764,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Betriebsart: ' + data.error);
+                    alert(t('dashboard.error_dhw_mode', data.error));
                     select.value = originalValue;
                     select.disabled = false;
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Betriebsart: ' + error.message);
+                alert(t('dashboard.error_dhw_mode', error.message));
                 select.value = originalValue;
                 select.disabled = false;
             }
@@ -787,7 +787,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -795,7 +795,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing
@@ -824,12 +824,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Temperatur: ' + data.error);
+                    alert(t('dashboard.error_temperature', data.error));
                     select.value = originalValue;
                     select.disabled = false;
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Temperatur: ' + error.message);
+                alert(t('dashboard.error_temperature', error.message));
                 select.value = originalValue;
                 select.disabled = false;
             }
@@ -845,7 +845,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -853,7 +853,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing
@@ -883,12 +883,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Hysterese: ' + data.error);
+                    alert(t('dashboard.error_hysteresis', data.error));
                     select.value = originalValue;
                     select.disabled = false;
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Hysterese: ' + error.message);
+                alert(t('dashboard.error_hysteresis', error.message));
                 select.value = originalValue;
                 select.disabled = false;
             }
@@ -903,7 +903,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -911,7 +911,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing
@@ -940,12 +940,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Temperatur 2: ' + data.error);
+                    alert(t('dashboard.error_temperature2', data.error));
                     select.value = originalValue;
                     select.disabled = false;
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Temperatur 2: ' + error.message);
+                alert(t('dashboard.error_temperature2', error.message));
                 select.value = originalValue;
                 select.disabled = false;
             }
@@ -958,7 +958,7 @@
 
         // DHW One Time Charge Function
         async function startOneTimeCharge() {
-            if (!confirm('Möchten Sie die einmalige Warmwassererwärmung wirklich starten?')) {
+            if (!confirm(t('dashboard.dhw_one_time_charge_confirm'))) {
                 return;
             }
 
@@ -966,7 +966,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -974,7 +974,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 const response = await fetch('/api/dhw/oneTimeCharge/activate', {
@@ -993,16 +993,16 @@
                 const data = await response.json();
 
                 if (data.success) {
-                    alert('Einmalige Warmwassererwärmung wurde gestartet! ✓');
+                    alert(t('dashboard.dhw_one_time_charge_started'));
                     // Wait a bit then reload to show new status
                     setTimeout(() => {
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Starten: ' + data.error);
+                    alert(t('dashboard.error_start', data.error));
                 }
             } catch (error) {
-                alert('Fehler beim Starten: ' + error.message);
+                alert(t('dashboard.error_start', error.message));
             }
         }
 
@@ -1029,7 +1029,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -1037,7 +1037,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Get current values from window.heatingCurveData for this circuit
@@ -1086,12 +1086,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Heizkurve: ' + data.error);
+                    alert(t('dashboard.error_heating_curve', data.error));
                     if (shiftSelect) shiftSelect.disabled = false;
                     if (slopeSelect) slopeSelect.disabled = false;
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Heizkurve: ' + error.message);
+                alert(t('dashboard.error_heating_curve', error.message));
                 const shiftSelect = document.getElementById('heatingCurveShiftSelect');
                 const slopeSelect = document.getElementById('heatingCurveSlopeSelect');
                 if (shiftSelect) shiftSelect.disabled = false;
@@ -1099,6 +1099,159 @@
             }
         }
 
+        // Heating curve analysis: fits the curve the system ran and suggests slope/shift
+        // The notes of the analysis are translated as dashboard.curve_note_<note>, unknown notes are shown as they are
+        function heatingCurveNoteText(note) {
+            const key = 'dashboard.curve_note_' + note;
+            const text = t(key);
+            return text === key ? note : text;
+        }
+
+        async function analyzeHeatingCurve(circuitId) {
+            const container = document.getElementById('heatingCurveAnalysis_' + circuitId);
+            if (!container) return;
+
+            const currentInstall = installations.find(i => i.installationId === currentInstallationId);
+            const currentDevice = currentInstall && currentInstall.devices
+                ? currentInstall.devices.find(d => d.deviceId === currentDeviceId && d.gatewaySerial === currentGatewaySerial)
+                : null;
+            if (!currentDevice) {
+                container.textContent = t('dashboard.error_device_missing');
+                return;
+            }
+
+            const daysSelect = document.getElementById('heatingCurveAnalysisDays_' + circuitId);
+            const days = daysSelect ? parseInt(daysSelect.value) : 14;
+            const toDate = new Date();
+            const fromDate = new Date();
+            fromDate.setDate(toDate.getDate() - days + 1);
+            const ymd = d => `${d.getFullYear()}-${String(d.getMonth() + 1).padStart(2, '0')}-${String(d.getDate()).padStart(2, '0')}`;
+
+            const params = new URLSearchParams({
+                accountId: currentDevice.accountId,
+                installationId: currentInstallationId,
+                gatewaySerial: currentGatewaySerial,
+                deviceId: currentDeviceId,
+                circuit: circuitId,
+                from: ymd(fromDate),
+                to: ymd(toDate)
+            });
+
+            container.textContent = t('dashboard.curve_analyzing');
+            try {
+                const response = await fetch('/api/heating-curve/analysis?' + params.toString());
+                const data = await response.json();
+                if (!data.success) {
+                    container.textContent = t('dashboard.curve_error', data.error);
+                    return;
+                }
+                renderHeatingCurveAnalysis(container, data.analysis, currentDevice.accountId);
+            } catch (error) {
+                container.textContent = t('dashboard.curve_error', error.message);
+            }
+        }
+
+        function renderHeatingCurveAnalysis(container, a, accountId) {
+            const fmtTime = iso => new Date(iso).toLocaleString(uiLocale(), { day: '2-digit', month: '2-digit', hour: '2-digit', minute: '2-digit' });
+            const signed = v => (v > 0 ? '+' : '') + v.toFixed(1);
+
+            let html = '<div style="background: rgba(0,0,0,0.2); border-radius: 8px; padding: 12px; font-size: 13px; line-height: 1.6;">';
+            html += `<div><strong>${t('dashboard.curve_evaluation')}</strong> ${t('dashboard.curve_evaluation_text', a.hours, a.avgOutsideTemp.toFixed(1), a.avgSupplyTemp.toFixed(1))}`;
+            if (a.avgRoomTemp !== undefined) {
+                html += ', ' + t('dashboard.curve_evaluation_room', a.avgRoomTemp.toFixed(1), a.targetRoomTemp.toFixed(1));
+            }
+            if (a.avgCop !== undefined) {
+                html += ', ' + t('dashboard.curve_evaluation_cop', a.avgCop.toFixed(2));
+            }
+            html += '</div>';
+
+            if (a.current) {
+                html += `<div>${t('dashboard.curve_current_setting', a.current.slope.toFixed(1), a.current.shift)}</div>`;
+            }
+            if (a.fitted) {
+                html += `<div>${t('dashboard.curve_fitted', a.fitted.slope.toFixed(2), a.fitted.shift.toFixed(1), a.fitted.rmse.toFixed(1))}</div>`;
+            }
+
+            if (a.periods.length > 0) {
+                const over = a.periods.filter(p => p.type === 'oversupply');
+                const under = a.periods.filter(p => p.type === 'undersupply');
+                html += '<div style="margin-top: 6px;">';
+                if (over.length > 0) {
+                    html += `<div style="color: #f97316;">🔥 ${t('dashboard.curve_oversupply', over.length, over.reduce((s, p) => s + p.hours, 0))}</div>`;
+                }
+                if (under.length > 0) {
+                    html += `<div style="color: #60a5fa;">❄️ ${t('dashboard.curve_undersupply', under.length, under.reduce((s, p) => s + p.hours, 0))}</div>`;
+                }
+                html += '<ul style="margin: 4px 0 0 18px; padding: 0;">';
+                a.periods.slice(-5).forEach(p => {
+                    html += `<li>${fmtTime(p.start)} - ${fmtTime(p.end)}: ${t('dashboard.curve_period', signed(p.avgRoomDelta), p.avgOutsideTemp.toFixed(1))}</li>`;
+                });
+                html += '</ul></div>';
+            }
+
+            const rec = a.recommendation;
+            if (rec) {
+                html += '<div style="margin-top: 8px;">';
+                if (!rec.change) {
+                    html += '<div style="color: #10b981;">✅ ' + t('dashboard.curve_fits') + '</div>';
+                } else {
+                    html += `<div><strong>${t('dashboard.curve_recommendation')}</strong> ${t('dashboard.curve_slope_shift', rec.slope.toFixed(1), rec.shift)}`;
+                    if (rec.target.slope !== rec.slope || rec.target.shift !== rec.shift) {
+                        html += ' ' + t('dashboard.curve_target', rec.target.slope.toFixed(1), rec.target.shift);
+                    }
+                    html += '</div>';
+                    html += `<div>${t('dashboard.curve_supply_change', signed(rec.avgSupplyChange))}`;
+                    if (rec.copMethod) {
+                        html += `, COP ${signed(rec.copChange)} (${signed(rec.copChangePercent)} %, ${rec.copMethod === 'data' ? t('dashboard.curve_cop_measured') : t('dashboard.curve_cop_estimated')})`;
+                    }
+                    html += '</div>';
+                    html += '<div style="opacity: 0.8;">' + rec.effects.map(e =>
+                        `${e.outsideTemp}°C: ${e.currentSupply.toFixed(1)} → ${e.newSupply.toFixed(1)}°C`).join(' · ') + '</div>';
+                    html += `<button class="time-btn" style="margin-top: 6px;" onclick="applyHeatingCurveRecommendation(${a.circuit}, ${rec.slope}, ${rec.shift}, '${accountId.replace(/'/g, "\\'")}')">✔️ ${t('dashboard.curve_apply')}</button>`;
+                }
+                html += '</div>';
+            }
+
+            a.notes.forEach(note => {
+                html += `<div style="opacity: 0.7; margin-top: 4px;">ℹ️ ${heatingCurveNoteText(note)}</div>`;
+            });
+            html += '</div>';
+            container.innerHTML = html;
+        }
+
+        async function applyHeatingCurveRecommendation(circuitId, slope, shift, accountId) {
+            if (!confirm(t('dashboard.curve_apply_confirm', slope.toFixed(1), shift))) {
+                return;
+            }
+            try {
+                const response = await fetch('/api/heating-curve/apply', {
+                    method: 'POST',
+                    headers: {
+                        'Content-Type': 'application/json'
+                    },
+                    body: JSON.stringify({
+                        accountId: accountId,
+                        installationId: currentInstallationId,
+                        gatewaySerial: currentGatewaySerial,
+                        deviceId: currentDeviceId,
+                        circuit: circuitId,
+                        slope: slope,
+                        shift: shift
+                    })
+                });
+                const data = await response.json();
+                if (data.success) {
+                    setTimeout(() => {
+                        loadDashboard(true); // Force refresh
+                    }, 2000);
+                } else {
+                    alert(t('dashboard.error_heating_curve', data.error));
+                }
+            } catch (error) {
+                alert(t('dashboard.error_heating_curve', error.message));
+            }
+        }
+
         // Supply Temperature Max Change Function
         async function changeSupplyTempMax(circuitIdOrValue, tempValue = null) {
             // Support both old signature changeSupplyTempMax(value) and new changeSupplyTempMax(circuitId, value)
@@ -1119,7 +1272,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -1127,7 +1280,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing (if it exists)
@@ -1159,13 +1312,13 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Vorlauftemperaturbegrenzung: ' + data.error);
+                    alert(t('dashboard.error_supply_temperature_max', data.error));
                     if (select) {
                         select.disabled = false;
                     }
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Vorlauftemperaturbegrenzung: ' + error.message);
+                alert(t('dashboard.error_supply_temperature_max', error.message));
                 if (select) {
                     select.disabled = false;
                 }
@@ -1178,7 +1331,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -1186,7 +1339,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 const response = await fetch('/api/heating/roomTemp/set', {
@@ -1214,10 +1367,10 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern der Raumtemperatur: ' + data.error);
+                    alert(t('dashboard.error_room_temperature', data.error));
                 }
             } catch (error) {
-                alert('Fehler beim Ändern der Raumtemperatur: ' + error.message);
+                alert(t('dashboard.error_room_temperature', error.message));
             }
         }
 
@@ -1308,7 +1461,7 @@
                 // Get current device info
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 const currentDevice = currentInstall.devices.find(d =>
@@ -1316,7 +1469,7 @@
                 );
 
                 if (!currentDevice) {
-                    throw new Error('Gerät nicht gefunden');
+                    throw new Error(t('dashboard.error_device_missing'));
                 }
 
                 // Disable select while changing (if it exists)
@@ -1348,13 +1501,13 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Ändern des Betriebsmodus: ' + data.error);
+                    alert(t('dashboard.error_operating_mode', data.error));
                     if (select) {
                         select.disabled = false;
                     }
                 }
             } catch (error) {
-                alert('Fehler beim Ändern des Betriebsmodus: ' + error.message);
+                alert(t('dashboard.error_operating_mode', error.message));
                 if (select) {
                     select.disabled = false;
                 }
@@ -1370,7 +1523,7 @@
 
             try {
                 if (!currentDeviceInfo) {
-                    throw new Error('Device-Information nicht verfügbar');
+                    throw new Error(t('dashboard.error_device_info_missing'));
                 }
 
                 const button = event.target;
@@ -1380,7 +1533,7 @@
                 // Disable button while changing
                 button.disabled = true;
                 const originalText = button.textContent;
-                button.textContent = '⏳ Wird geändert...';
+                button.textContent = '⏳ ' + t('dashboard.changing');
 
                 const response = await fetch('/api/fan-ring/toggle', {
                     method: 'POST',
@@ -1405,12 +1558,12 @@
                         loadDashboard(true); // Force refresh
                     }, 2000);
                 } else {
-                    alert('Fehler beim Umschalten der Ventilatorringheizung: ' + data.error);
+                    alert(t('dashboard.error_fan_ring', data.error));
                     button.disabled = false;
                     button.textContent = originalText;
                 }
             } catch (error) {
-                alert('Fehler beim Umschalten der Ventilatorringheizung: ' + error.message);
+                alert(t('dashboard.error_fan_ring', error.message));
                 button.disabled = false;
                 const originalText = event.target.dataset.originalText;
                 if (originalText) {
@@ -1427,7 +1580,7 @@
             // Get account from current device
             const accountId = window.currentDeviceInfo?.accountId;
             if (!accountId) {
-                alert('Kein Account für dieses Gerät verfügbar');
+                alert(t('dashboard.error_no_account'));
                 return;
             }
 
@@ -1475,34 +1628,34 @@
                 container.innerHTML = `
                     <div style="margin-bottom: 20px; border-bottom: 2px solid #ddd; padding-bottom: 10px;">
                         <h2 style="margin: 0 0 5px 0; color: #333;">☀️ Hybrid Pro Control</h2>
-                        <p style="margin: 0; color: #666; font-size: 14px;">Einstellungen für Hybrid-Wärmepumpensysteme</p>
+                        <p style="margin: 0; color: #666; font-size: 14px;">${t('dashboard.hybrid_subtitle')}</p>
                     </div>
 
                     <div id="hybridTabs" style="display: flex; gap: 10px; margin-bottom: 20px; border-bottom: 1px solid #ddd; flex-wrap: wrap;">
-                        <button class="hybrid-tab-btn active" onclick="switchHybridTab(event, 'electricity')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">💡 Stromtarife</button>
-                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'strategy')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">⚙️ Regelstrategie</button>
-                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'energyFactors')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">📊 Primärenergiefaktoren</button>
-                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'fossil')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">🔥 Fossile Brennstoffe</button>
+                        <button class="hybrid-tab-btn active" onclick="switchHybridTab(event, 'electricity')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">💡 ${t('dashboard.hybrid_tab_electricity')}</button>
+                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'strategy')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">⚙️ ${t('dashboard.hybrid_strategy')}</button>
+                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'energyFactors')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">📊 ${t('dashboard.hybrid_tab_factors')}</button>
+                        <button class="hybrid-tab-btn" onclick="switchHybridTab(event, 'fossil')" style="padding: 10px 15px; border: none; background: #f5f5f5; cursor: pointer; border-radius: 4px 4px 0 0; color: #666; font-weight: 500; transition: all 0.3s ease;">🔥 ${t('dashboard.hybrid_tab_fossil')}</button>
                     </div>
 
                     <form id="hybridProControlForm" style="margin-bottom: 20px;">
                         <!-- Electricity Prices Tab -->
                         <div id="electricity-tab" style="display: block; background: #f9f9f9; padding: 15px; border-radius: 4px; margin-bottom: 15px;">
-                            <h3 style="margin: 0 0 10px 0; color: #333;">Elektrizitätstarife</h3>
-                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">Einheit: EUR/kWh</p>
+                            <h3 style="margin: 0 0 10px 0; color: #333;">${t('dashboard.hybrid_electricity_title')}</h3>
+                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">${t('dashboard.hybrid_unit', 'EUR/kWh')}</p>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Niedertarif (Low) <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_price_low')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="electricityPriceLow" name="electricityPriceLow" step="0.001" min="0" placeholder="z.B. 0.15" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <input type="number" id="electricityPriceLow" name="electricityPriceLow" step="0.001" min="0" placeholder="${t('dashboard.example')} 0.15" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
                                     <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">EUR/kWh</span>
                                 </div>
                             </div>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Normaltarif (Normal) <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_price_normal')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="electricityPriceNormal" name="electricityPriceNormal" step="0.001" min="0" placeholder="z.B. 0.28" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <input type="number" id="electricityPriceNormal" name="electricityPriceNormal" step="0.001" min="0" placeholder="${t('dashboard.example')} 0.28" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
                                     <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">EUR/kWh</span>
                                 </div>
                             </div>
@@ -1510,72 +1663,72 @@
 
                         <!-- Control Strategy Tab -->
                         <div id="strategy-tab" style="display: none; background: #f9f9f9; padding: 15px; border-radius: 4px; margin-bottom: 15px;">
-                            <h3 style="margin: 0 0 10px 0; color: #333;">Regelstrategie</h3>
-                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">Auswahl der Betriebsstrategie für das Hybrid-System</p>
+                            <h3 style="margin: 0 0 10px 0; color: #333;">${t('dashboard.hybrid_strategy')}</h3>
+                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">${t('dashboard.hybrid_strategy_hint')}</p>
 
                             <div style="margin: 0;">
                                 <div style="display: flex; align-items: flex-start; gap: 10px; margin-bottom: 15px; padding: 12px; background: white; border: 1px solid #ddd; border-radius: 4px; cursor: pointer; transition: all 0.2s ease;">
                                     <input type="radio" id="strategy-constant" name="controlStrategy" value="constant" style="cursor: pointer; accent-color: #4CAF50; margin-top: 2px;">
-                                    <label for="strategy-constant" style="margin: 0; cursor: pointer; flex: 1;"><strong>Konstanttemperatur</strong><br><span style="color: #666; font-size: 13px;">Konstante Rücklauftemperatur unabhängig von Außentemperatur</span></label>
+                                    <label for="strategy-constant" style="margin: 0; cursor: pointer; flex: 1;"><strong>${t('dashboard.hybrid_strategy_constant')}</strong><br><span style="color: #666; font-size: 13px;">${t('dashboard.hybrid_strategy_constant_hint')}</span></label>
                                 </div>
 
                                 <div style="display: flex; align-items: flex-start; gap: 10px; margin-bottom: 15px; padding: 12px; background: white; border: 1px solid #ddd; border-radius: 4px; cursor: pointer; transition: all 0.2s ease;">
                                     <input type="radio" id="strategy-ecological" name="controlStrategy" value="ecological" style="cursor: pointer; accent-color: #4CAF50; margin-top: 2px;">
-                                    <label for="strategy-ecological" style="margin: 0; cursor: pointer; flex: 1;"><strong>Ökologisch</strong><br><span style="color: #666; font-size: 13px;">Maximaler Einsatz der Wärmepumpe für geringere CO₂-Emissionen</span></label>
+                                    <label for="strategy-ecological" style="margin: 0; cursor: pointer; flex: 1;"><strong>${t('dashboard.hybrid_strategy_ecological')}</strong><br><span style="color: #666; font-size: 13px;">${t('dashboard.hybrid_strategy_ecological_hint')}</span></label>
                                 </div>
 
                                 <div style="display: flex; align-items: flex-start; gap: 10px; padding: 12px; background: white; border: 1px solid #ddd; border-radius: 4px; cursor: pointer; transition: all 0.2s ease;">
                                     <input type="radio" id="strategy-economic" name="controlStrategy" value="economic" style="cursor: pointer; accent-color: #4CAF50; margin-top: 2px;">
-                                    <label for="strategy-economic" style="margin: 0; cursor: pointer; flex: 1;"><strong>Ökonomisch</strong><br><span style="color: #666; font-size: 13px;">Optimierung der Betriebskosten unter Berücksichtigung der Strompreise</span></label>
+                                    <label for="strategy-economic" style="margin: 0; cursor: pointer; flex: 1;"><strong>${t('dashboard.hybrid_strategy_economic')}</strong><br><span style="color: #666; font-size: 13px;">${t('dashboard.hybrid_strategy_economic_hint')}</span></label>
                                 </div>
                             </div>
                         </div>
 
                         <!-- Energy Factors Tab -->
                         <div id="energyFactors-tab" style="display: none; background: #f9f9f9; padding: 15px; border-radius: 4px; margin-bottom: 15px;">
-                            <h3 style="margin: 0 0 10px 0; color: #333;">Primärenergiefaktoren</h3>
-                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">Wertangaben für die Effizienzberechnung</p>
+                            <h3 style="margin: 0 0 10px 0; color: #333;">${t('dashboard.hybrid_tab_factors')}</h3>
+                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">${t('dashboard.hybrid_factors_hint')}</p>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Primärenergiefaktor Wärmepumpe <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_factor_heat_pump_long')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="heatPumpEnergyFactor" name="heatPumpEnergyFactor" step="0.01" min="0" placeholder="z.B. 2.4" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
-                                    <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">(dimensionslos)</span>
+                                    <input type="number" id="heatPumpEnergyFactor" name="heatPumpEnergyFactor" step="0.01" min="0" placeholder="${t('dashboard.example')} 2.4" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">${t('dashboard.hybrid_dimensionless')}</span>
                                 </div>
-                                <small style="display: block; margin-top: 4px; color: #999; font-size: 12px;">Typisch: 2.0 - 3.5 für moderne Wärmepumpen</small>
+                                <small style="display: block; margin-top: 4px; color: #999; font-size: 12px;">${t('dashboard.hybrid_factor_heat_pump_hint')}</small>
                             </div>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Primärenergiefaktor Fossil (Kessel) <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_factor_fossil_long')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="fossilEnergyFactor" name="fossilEnergyFactor" step="0.01" min="0" placeholder="z.B. 1.1" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
-                                    <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">(dimensionslos)</span>
+                                    <input type="number" id="fossilEnergyFactor" name="fossilEnergyFactor" step="0.01" min="0" placeholder="${t('dashboard.example')} 1.1" required style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">${t('dashboard.hybrid_dimensionless')}</span>
                                 </div>
-                                <small style="display: block; margin-top: 4px; color: #999; font-size: 12px;">Typisch: 1.0 - 1.3 für Gasheizungen</small>
+                                <small style="display: block; margin-top: 4px; color: #999; font-size: 12px;">${t('dashboard.hybrid_factor_fossil_hint')}</small>
                             </div>
                         </div>
 
                         <!-- Fossil Fuels Tab -->
                         <div id="fossil-tab" style="display: none; background: #f9f9f9; padding: 15px; border-radius: 4px; margin-bottom: 15px;">
-                            <h3 style="margin: 0 0 10px 0; color: #333;">Energiekosten externer Wärmeerzeuger (Fossil)</h3>
-                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">Einheit: EUR/kWh (für Gas) oder EUR/Liter (für Öl)</p>
+                            <h3 style="margin: 0 0 10px 0; color: #333;">${t('dashboard.hybrid_fossil_title')}</h3>
+                            <p style="margin: 0 0 15px 0; color: #888; font-size: 13px;">${t('dashboard.hybrid_fossil_unit')}</p>
 
                             <div style="background: #e3f2fd; border-left: 4px solid #2196F3; padding: 12px; margin-bottom: 15px; border-radius: 4px; color: #1976d2; font-size: 13px;">
-                                💡 <strong>Hinweis:</strong> Diese Werte sind optional und werden für erweiterte Kostenberechnungen verwendet.
+                                💡 <strong>${t('dashboard.hint')}</strong> ${t('dashboard.hybrid_fossil_hint')}
                             </div>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Niedertarif (Low) <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_price_low')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="fossilPriceLow" name="fossilPriceLow" step="0.001" min="0" placeholder="z.B. 0.08" style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <input type="number" id="fossilPriceLow" name="fossilPriceLow" step="0.001" min="0" placeholder="${t('dashboard.example')} 0.08" style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
                                     <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">EUR/kWh</span>
                                 </div>
                             </div>
 
                             <div style="margin-bottom: 15px;">
-                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">Normaltarif (Normal) <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
+                                <label style="display: block; margin-bottom: 5px; font-weight: 500; color: #333;">${t('dashboard.hybrid_price_normal')} <span style="color: #2196F3; font-size: 12px;">ℹ️</span></label>
                                 <div style="display: flex; align-items: center; gap: 10px;">
-                                    <input type="number" id="fossilPriceNormal" name="fossilPriceNormal" step="0.001" min="0" placeholder="z.B. 0.10" style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
+                                    <input type="number" id="fossilPriceNormal" name="fossilPriceNormal" step="0.001" min="0" placeholder="${t('dashboard.example')} 0.10" style="flex: 1; padding: 8px 10px; border: 1px solid #ddd; border-radius: 4px; font-size: 14px;">
                                     <span style="white-space: nowrap; color: #666; font-size: 13px; padding: 8px 10px; background: #f0f0f0; border-radius: 4px;">EUR/kWh</span>
                                 </div>
                             </div>
@@ -1583,8 +1736,8 @@
                     </form>
 
                     <div style="display: flex; gap: 10px; align-items: center; margin-top: 20px; flex-wrap: wrap;">
-                        <button onclick="saveHybridProControlFromModal()" style="padding: 10px 20px; background: #4CAF50; color: white; border: none; border-radius: 4px; font-weight: 500; cursor: pointer;">💾 Einstellungen speichern</button>
-                        <button onclick="resetHybridProControl()" style="padding: 10px 20px; background: #f0f0f0; color: #333; border: 1px solid #ddd; border-radius: 4px; font-weight: 500; cursor: pointer;">🔄 Zurücksetzen</button>
+                        <button onclick="saveHybridProControlFromModal()" style="padding: 10px 20px; background: #4CAF50; color: white; border: none; border-radius: 4px; font-weight: 500; cursor: pointer;">💾 ${t('dashboard.save_settings')}</button>
+                        <button onclick="resetHybridProControl()" style="padding: 10px 20px; background: #f0f0f0; color: #333; border: 1px solid #ddd; border-radius: 4px; font-weight: 500; cursor: pointer;">🔄 ${t('dashboard.reset')}</button>
                         <div id="hybridProControlStatus" style="flex: 1; min-height: 20px; font-size: 13px; display: flex; align-items: center;"></div>
                     </div>
                 `;
@@ -1755,7 +1908,7 @@
             if (hybridModalParams.installationId && hybridModalParams.deviceId && hybridModalParams.accountId) {
                 saveHybridProControl(hybridModalParams.installationId, hybridModalParams.deviceId, hybridModalParams.accountId);
             } else {
-                showHybridStatus('❌ Fehler: Keine Geräte-Informationen verfügbar', 'error');
+                showHybridStatus('❌ ' + t('dashboard.error_device_info_missing'), 'error');
             }
         }
 
@@ -1772,7 +1925,7 @@
 
             // Check if at least ONE field has been filled in
             if (!electricityLow && !electricityNormal && !strategy && !heatPumpFactor && !fossilFactor && !fossilPriceLow && !fossilPriceNorm) {
-                showHybridStatus('❌ Bitte füllen Sie mindestens ein Feld aus', 'error');
+                showHybridStatus('❌ ' + t('dashboard.hybrid_fill_one_field'), 'error');
                 return;
             }
 
@@ -1822,7 +1975,7 @@
                 settings: settings
             };
 
-            showHybridStatus('Speichern...', 'loading');
+            showHybridStatus(t('dashboard.saving'), 'loading');
 
             try {
                 const response = await fetch('/api/hybrid-pro-control/set', {
@@ -1836,12 +1989,12 @@
                 const data = await response.json();
 
                 if (data.success) {
-                    showHybridStatus('✅ Einstellungen erfolgreich gespeichert', 'success');
+                    showHybridStatus('✅ ' + t('dashboard.settings_saved'), 'success');
                 } else {
-                    showHybridStatus('❌ Fehler: ' + (data.error || 'Unbekannter Fehler'), 'error');
+                    showHybridStatus('❌ ' + t('dashboard.error', data.error || t('dashboard.unknown_error')), 'error');
                 }
             } catch (error) {
-                showHybridStatus('❌ Fehler beim Speichern: ' + error.message, 'error');
+                showHybridStatus('❌ ' + t('dashboard.error_save', error.message), 'error');
             }
         }
 
@@ -85,7 +85,7 @@
                 }
 
             } catch (error) {
-                showError('Fehler beim Laden der Geräte: ' + error.message);
+                showError(t('dashboard.error_load_devices', error.message));
             }
         }
 
@@ -141,7 +141,7 @@
                 // Fallback to Device 0
                 const option = document.createElement('option');
                 option.value = '0';
-                option.textContent = 'Device 0 (Standard)';
+                option.textContent = t('dashboard.device_default');
                 deviceSelect.appendChild(option);
                 currentDeviceId = '0';
             }
@@ -152,13 +152,13 @@
         async function loadDashboard(forceRefresh = false) {
             const contentDiv = document.getElementById('dashboardContent');
             contentDiv.className = 'loading';
-            contentDiv.innerHTML = '<div class="spinner"></div><p>Lade Dashboard-Daten...</p>';
+            contentDiv.innerHTML = '<div class="spinner"></div><p>' + t('dashboard.loading') + '</p>';
 
             try {
                 // Get current device to extract gateway serial
                 const currentInstall = installations.find(i => i.installationId === currentInstallationId);
                 if (!currentInstall || !currentInstall.devices) {
-                    throw new Error('Installation nicht gefunden');
+                    throw new Error(t('dashboard.error_installation_not_found'));
                 }
 
                 // Find device by BOTH gatewaySerial AND deviceId (because multiple devices can have the same deviceId!)
@@ -172,7 +172,7 @@
                         currentGatewaySerial,
                         availableDevices: currentInstall.devices
                     });
-                    throw new Error(`Gerät nicht gefunden: ${currentDeviceId} @ ${currentGatewaySerial}`);
+                    throw new Error(t('dashboard.error_device_not_found', currentDeviceId, currentGatewaySerial));
                 }
 
                 const gatewaySerial = currentDevice.gatewaySerial || '';
@@ -189,7 +189,7 @@
 
                 const response = await fetch(`/api/features?installationId=${currentInstallationId}&gatewaySerial=${gatewaySerial}&deviceId=${currentDeviceId}${refreshParam}`);
                 if (!response.ok) {
-                    throw new Error('API Fehler: ' + response.status);
+                    throw new Error(t('dashboard.error_api', response.status));
                 }
 
                 const features = await response.json();
@@ -215,8 +215,8 @@
                 }
 
             } catch (error) {
-                showError('Fehler beim Laden der Features: ' + error.message);
-                contentDiv.innerHTML = '<div class="error">Fehler beim Laden der Daten: ' + error.message + '</div>';
+                showError(t('dashboard.error_load_features', error.message));
+                contentDiv.innerHTML = '<div class="error">' + t('dashboard.error_load_data', error.message) + '</div>';
             }
         }
 
@@ -224,14 +224,14 @@
         function translateMode(mode) {
             const modes = {
                 'standby': 'Standby',
-                'heating': 'Heizen',
-                'cooling': 'Kühlen',
-                'heatingCooling': 'Heizen/Kühlen',
-                'dhw': 'Warmwasser',
-                'dhwAndHeating': 'Heizen + Warmwasser',
-                'forcedReduced': 'Reduziert',
-                'forcedNormal': 'Normal',
-                'normal': 'Normal'
+                'heating': t('dashboard.mode_heating'),
+                'cooling': t('dashboard.mode_cooling'),
+                'heatingCooling': t('dashboard.mode_heating_cooling'),
+                'dhw': t('dashboard.mode_dhw'),
+                'dhwAndHeating': t('dashboard.mode_dhw_and_heating'),
+                'forcedReduced': t('dashboard.mode_reduced'),
+                'forcedNormal': t('dashboard.mode_normal'),
+                'normal': t('dashboard.mode_normal')
             };
             return modes[mode] || mode;
         }
@@ -332,13 +332,13 @@
                 'ampere': 'A',
                 'watt': 'W',
                 'kilowatt': 'kW',
-                'hour': 'Std.',
-                'hours': 'Std.',
+                'hour': t('dashboard.unit_hours'),
+                'hours': t('dashboard.unit_hours'),
                 'kilowattHour': 'kWh',
                 'percent': '%',
                 'bar': 'bar',
-                'revolutionsPerSecond': 'U/s',
-                'revolutionsPerMinute': 'U/min',
+                'revolutionsPerSecond': t('dashboard.unit_rps'),
+                'revolutionsPerMinute': t('dashboard.unit_rpm'),
                 'cubicMeter': 'm³',
                 'cubicMeterPerHour': 'm³/h',
                 'litersPerHour': 'l/h',
@@ -371,7 +371,7 @@
             let supplyTemp = null;
             let returnTemp = null;
             let usedBoilerFallback = false;
-            let label = 'Spreizung';
+            let label = t('dashboard.spread');
 
 //  the values as set up in dashboard-render-engine.js 
 //      kf.supplyTemp: find(['heating.circuits.0.sensors.temperature.supply']),                  // Vorlauf zum Heizkreis IDU
@@ -387,7 +387,7 @@
                     spreizung = supplyVal - returnVal;
                     supplyTemp = supplyVal;
                     returnTemp = returnVal;
-                    label = 'Spreizung Sekundärkreis';
+                    label = t('dashboard.spread_secondary');
                 }
             }
             // kf.secondarySupplyTemp not set: use alternativ kf.supplyTemp
@@ -400,7 +400,7 @@
                         spreizung = supplyVal - returnVal;
                         supplyTemp = supplyVal;
                         returnTemp = returnVal;
-                        label = 'Spreizung Heizkreis';
+                        label = t('dashboard.spread_circuit');
                     }
                 }
             }
@@ -413,7 +413,7 @@
                     spreizung = boilerVal - returnTemp;
                     supplyTemp = boilerVal;
                     usedBoilerFallback = true;
-                    label = 'Spreizung W.-Erzeuger';
+                    label = t('dashboard.spread_generator');
                 }
             }
 
@@ -471,7 +471,7 @@
 
         function updateLastUpdate() {
             const now = new Date();
-            document.getElementById('lastUpdate').textContent = now.toLocaleTimeString('de-DE');
+            document.getElementById('lastUpdate').textContent = now.toLocaleTimeString(uiLocale());
         }
 
         function startAutoRefresh() {
@@ -219,63 +219,63 @@ function renderRefrigerantCircuitVisual(keyFeatures) {
     return `
             <div class="refrigerant-visual-container">
                 <div class="refrigerant-diagram">
-                    <img src="${baseImage}" alt="Kältekreislauf" class="base-diagram">
+                    <img src="${baseImage}" alt="${t('dashboard.refrigerant_circuit')}" class="base-diagram">
 
                     <!-- Component overlays with status images -->
                     <!-- DHW Storage -->
                     ${dhw_exists ? `
                     <img src="${dhw_image}"
-                         alt="Warmwasserspeicher" class="component-overlay dhw-storage-overlay">
+                         alt="${t('dashboard.refrigerant_dhw_storage')}" class="component-overlay dhw-storage-overlay">
                     ` : ''}
 
                     <!-- Heating Storage -->
                     <img src="/static/img/vitocal/Heizwasserspeicher%20${heatingActive ? 'ein' : 'aus'}.png"
-                         alt="Heizwasserspeicher" class="component-overlay heating-storage-overlay">
+                         alt="${t('dashboard.refrigerant_heating_storage')}" class="component-overlay heating-storage-overlay">
 
                     <!-- Electric Heater (Heizstab) -->
                     ${heaterActive ? `
-                    <img src="/static/img/vitocal/Heizstab%20ein.png" alt="Heizstab aktiv" class="component-overlay heater-overlay">
+                    <img src="/static/img/vitocal/Heizstab%20ein.png" alt="${t('dashboard.refrigerant_heater_on')}" class="component-overlay heater-overlay">
                     ` : `
-                    <img src="/static/img/vitocal/Heizstab%20aus.png" alt="Heizstab aus" class="component-overlay heater-overlay">
+                    <img src="/static/img/vitocal/Heizstab%20aus.png" alt="${t('dashboard.refrigerant_heater_off')}" class="component-overlay heater-overlay">
                     `}
 
                     <!-- Individual value overlays with tooltips -->
                     <!-- Alle Positionen basierend auf View-Größe 847x363px -->
-                    ${values.fan1 !== null ? `<div class="value-label" style="top: 51.52%; left: 8.03%;" title="Lüfter 1">${formatValue(values.fan1, '%', 0)}</div>` : ''}
-                    ${values.fan2 !== null ? `<div class="value-label" style="top: 22.87%; left: 8.03%;" title="Lüfter 2">${formatValue(values.fan2, '%', 0)}</div>` : ''}
+                    ${values.fan1 !== null ? `<div class="value-label" style="top: 51.52%; left: 8.03%;" title="${t('dashboard.refrigerant_fan1')}">${formatValue(values.fan1, '%', 0)}</div>` : ''}
+                    ${values.fan2 !== null ? `<div class="value-label" style="top: 22.87%; left: 8.03%;" title="${t('dashboard.refrigerant_fan2')}">${formatValue(values.fan2, '%', 0)}</div>` : ''}
 
-                    ${values.evaporatorTemp !== null ? `<div class="value-label" style="top: 37.19%; left: 21.72%;" title="Verdampfer Temperatur">${formatValue(values.evaporatorTemp, '°C')}</div>` : ''}
-                    ${values.evaporatorOverheat !== null ? `<div class="value-label" style="top: 37.19%; left: 56.79%;" title="Verdampfer Überhitzung">${formatValue(values.evaporatorOverheat, '°C')}</div>` : ''}
+                    ${values.evaporatorTemp !== null ? `<div class="value-label" style="top: 37.19%; left: 21.72%;" title="${t('dashboard.refrigerant_evaporator_temp')}">${formatValue(values.evaporatorTemp, '°C')}</div>` : ''}
+                    ${values.evaporatorOverheat !== null ? `<div class="value-label" style="top: 37.19%; left: 56.79%;" title="${t('dashboard.refrigerant_evaporator_overheat')}">${formatValue(values.evaporatorOverheat, '°C')}</div>` : ''}
 
-                    ${values.economizer !== null ? `<div class="value-label" style="top: 17.36%; left: 38.72%;" title="Economizer">${formatValue(values.economizer, '°C')}</div>` : ''}
+                    ${values.economizer !== null ? `<div class="value-label" style="top: 17.36%; left: 38.72%;" title="${t('dashboard.refrigerant_economizer')}">${formatValue(values.economizer, '°C')}</div>` : ''}
 
-                    ${values.compressorSpeed !== null ? `<div class="value-label" style="top: 71.35%; left: 38.61%;" title="Kompressor Drehzahl">${formatValue(values.compressorSpeed, values.compressorSpeedUnit, 0)}</div>` : ''}
-                    ${values.compressorInletTemp !== null ? `<div class="value-label" style="top: 69.70%; left: 47.93%;" title="Kompressor Einlasstemperatur">${formatValue(values.compressorInletTemp, '°C')}</div>` : ''}
-                    ${values.compressorOutletTemp !== null ? `<div class="value-label" style="top: 93.94%; left: 38.84%;" title="Kompressor Auslasstemperatur">${formatValue(values.compressorOutletTemp, '°C')}</div>` : ''}
-                    ${values.compressorOilTemp !== null ? `<div class="value-label" style="top: 52.62%; left: 32.35%;" title="Kompressor Öltemperatur">${formatValue(values.compressorOilTemp, '°C')}</div>` : ''}
-                    ${values.compressorPressure !== null ? `<div class="value-label" style="top: 69.70%; left: 56.43%;" title="Kompressor Einlassdruck">${formatValue(values.compressorPressure, 'bar')}</div>` : ''}
-                    ${values.expansionValve1 !== null ? `<div class="value-label" style="top: 17.36%; left: 58%;" title="Ventil">${formatValue(values.expansionValve1, '%')}</div>` : ''}
-                    ${values.expansionValve2 !== null ? `<div class="value-label" style="top: 37.20%; left: 33%;" title="Ventil">${formatValue(values.expansionValve2, '%')}</div>` : ''}
+                    ${values.compressorSpeed !== null ? `<div class="value-label" style="top: 71.35%; left: 38.61%;" title="${t('dashboard.refrigerant_compressor_speed')}">${formatValue(values.compressorSpeed, values.compressorSpeedUnit, 0)}</div>` : ''}
+                    ${values.compressorInletTemp !== null ? `<div class="value-label" style="top: 69.70%; left: 47.93%;" title="${t('dashboard.refrigerant_compressor_inlet_temp')}">${formatValue(values.compressorInletTemp, '°C')}</div>` : ''}
+                    ${values.compressorOutletTemp !== null ? `<div class="value-label" style="top: 93.94%; left: 38.84%;" title="${t('dashboard.refrigerant_compressor_outlet_temp')}">${formatValue(values.compressorOutletTemp, '°C')}</div>` : ''}
+                    ${values.compressorOilTemp !== null ? `<div class="value-label" style="top: 52.62%; left: 32.35%;" title="${t('dashboard.refrigerant_compressor_oil_temp')}">${formatValue(values.compressorOilTemp, '°C')}</div>` : ''}
+                    ${values.compressorPressure !== null ? `<div class="value-label" style="top: 69.70%; left: 56.43%;" title="${t('dashboard.refrigerant_compressor_inlet_pressure')}">${formatValue(values.compressorPressure, 'bar')}</div>` : ''}
+                    ${values.expansionValve1 !== null ? `<div class="value-label" style="top: 17.36%; left: 58%;" title="${t('dashboard.refrigerant_valve')}">${formatValue(values.expansionValve1, '%')}</div>` : ''}
+                    ${values.expansionValve2 !== null ? `<div class="value-label" style="top: 37.20%; left: 33%;" title="${t('dashboard.refrigerant_valve')}">${formatValue(values.expansionValve2, '%')}</div>` : ''}
 
-                    ${values.condensorTemp !== null  ? `<div class="value-label" style="top: 17.36%; left: 66.47%;" title="Verflüssiger">${formatValue(values.condensorTemp, '°C')}</div>` : ''}
+                    ${values.condensorTemp !== null  ? `<div class="value-label" style="top: 17.36%; left: 66.47%;" title="${t('dashboard.refrigerant_condenser')}">${formatValue(values.condensorTemp, '°C')}</div>` : ''}
 
-                    ${values.returnTemp !== null ? `<div class="value-label" style="top: 17.36%; left: 83.83%;" title="Rücklauftemperatur">${formatValue(values.returnTemp, '°C')}</div>` : ''}
-                    ${values.pressure !== null ? `<div class="value-label" style="top: 22.87%; left: 83.83%;" title="Druck">${formatValue(values.pressure, 'bar')}</div>` : ''}
-                    ${values.supplyTempSec !== null ? `<div class="value-label" style="top: 83.75%; left: 82.0%;" title="ODU sekundär Vorlauftemperatur">${formatValue(values.supplyTempSec, '°C')}</div>` : ''}
-                    ${values.supplyTemp !== null ? `<div class="value-label" style="top: 83.75%; left: 88.0%;" title="IDU Vorlauftemperatur">${formatValue(values.supplyTemp, '°C')}</div>` : ''}
-                    ${values.pumpInternal !== null ? `<div class="value-label" style="top: 22.87%; left: 95.0%;" title="Interne Pumpe">${formatValue(values.pumpInternal, '%', 0)}</div>` : ''}
+                    ${values.returnTemp !== null ? `<div class="value-label" style="top: 17.36%; left: 83.83%;" title="${t('dashboard.return_temperature')}">${formatValue(values.returnTemp, '°C')}</div>` : ''}
+                    ${values.pressure !== null ? `<div class="value-label" style="top: 22.87%; left: 83.83%;" title="${t('dashboard.refrigerant_pressure')}">${formatValue(values.pressure, 'bar')}</div>` : ''}
+                    ${values.supplyTempSec !== null ? `<div class="value-label" style="top: 83.75%; left: 82.0%;" title="${t('dashboard.refrigerant_odu_supply_secondary')}">${formatValue(values.supplyTempSec, '°C')}</div>` : ''}
+                    ${values.supplyTemp !== null ? `<div class="value-label" style="top: 83.75%; left: 88.0%;" title="${t('dashboard.refrigerant_idu_supply')}">${formatValue(values.supplyTemp, '°C')}</div>` : ''}
+                    ${values.pumpInternal !== null ? `<div class="value-label" style="top: 22.87%; left: 95.0%;" title="${t('dashboard.refrigerant_internal_pump')}">${formatValue(values.pumpInternal, '%', 0)}</div>` : ''}
 
-                    ${values.airIntakeTemp !== null ? `<div class="value-label" style="top: 44.35%; left: 0.71%;" title="Lufteintrittstemperatur">${formatValue(values.airIntakeTemp, '°C')}</div>` : ''}
-                    ${values.outsideTemp !== null ? `<div class="value-label" style="top: 48.35%; left: 0.71%;" title="Außentemperatur">${formatValue(values.outsideTemp, '°C')}</div>` : ''}
-                    ${values.volumetricFlow !== null ? `<div class="value-label" style="top: 17.36%; left: 93.0%;" title="Volumenstrom">${formatValue(values.volumetricFlow, 'l/h', 0)}</div>` : ''}
+                    ${values.airIntakeTemp !== null ? `<div class="value-label" style="top: 44.35%; left: 0.71%;" title="${t('dashboard.refrigerant_air_intake_temp')}">${formatValue(values.airIntakeTemp, '°C')}</div>` : ''}
+                    ${values.outsideTemp !== null ? `<div class="value-label" style="top: 48.35%; left: 0.71%;" title="${t('dashboard.outside_temperature')}">${formatValue(values.outsideTemp, '°C')}</div>` : ''}
+                    ${values.volumetricFlow !== null ? `<div class="value-label" style="top: 17.36%; left: 93.0%;" title="${t('dashboard.volumetric_flow')}">${formatValue(values.volumetricFlow, 'l/h', 0)}</div>` : ''}
 
                     <!-- Speichertemperaturen (unter den Speicher-Bildern) -->
-                    ${bufferTempVal !== null ? `<div class="value-label" style="top: 64.74%; left: 84.65%;" title="Heizpuffer Temperatur">${formatValue(bufferTempVal, '°C')}</div>` : ''}
-                    ${values.dhwTemp !== null ? `<div class="value-label" style="top: 64.74%; left: 91.26%;" title="Warmwasser Temperatur">${formatValue(values.dhwTemp, '°C')}</div>` : ''}
+                    ${bufferTempVal !== null ? `<div class="value-label" style="top: 64.74%; left: 84.65%;" title="${t('dashboard.refrigerant_buffer_temp')}">${formatValue(bufferTempVal, '°C')}</div>` : ''}
+                    ${values.dhwTemp !== null ? `<div class="value-label" style="top: 64.74%; left: 91.26%;" title="${t('dashboard.dhw_temperature')}">${formatValue(values.dhwTemp, '°C')}</div>` : ''}
 
                     <!-- Leistungsanzeigen -->
-                    ${values.compressorPower !== null  ? `<div class="value-label" style="top: 55.37%; left: 43.68%;" title="Elektrische Leistung Kompressor">${formatValue(values.compressorPower, 'W', 0)}</div>` : ''}
-                    ${thermalPowerW !== null ? `<div class="value-label" style="top: 37.19%; left: 82.64%;" title="Thermische Leistung (berechnet)">${formatValue(thermalPowerW, 'W', 0)}</div>` : ''}
+                    ${values.compressorPower !== null  ? `<div class="value-label" style="top: 55.37%; left: 43.68%;" title="${t('dashboard.refrigerant_compressor_power')}">${formatValue(values.compressorPower, 'W', 0)}</div>` : ''}
+                    ${thermalPowerW !== null ? `<div class="value-label" style="top: 37.19%; left: 82.64%;" title="${t('dashboard.refrigerant_thermal_power')}">${formatValue(thermalPowerW, 'W', 0)}</div>` : ''}
                 </div>
             </div>
     `;
@@ -414,41 +414,41 @@ function renderOtherRefrigerantCircuitPic(keyFeatures) {
     return `
             <div class="refrigerant-visual-container">
                 <div class="refrigerant-diagram">
-                    <img src="${baseImage}" alt="Kältekreislauf" class="base-diagram">
+                    <img src="${baseImage}" alt="${t('dashboard.refrigerant_circuit')}" class="base-diagram">
 
                     <!-- Component overlays with status images -->
                     <!-- DHW Storage -->
                     ${dhw_exists ? `
                     <img src="${dhw_image}"
-                         alt="Warmwasserspeicher" class="component-overlay dhw-storage-overlay-left">
+                         alt="${t('dashboard.refrigerant_dhw_storage')}" class="component-overlay dhw-storage-overlay-left">
                     ` : ''}
 
                     <!-- Individual value overlays with tooltips -->
                     <!-- Alle Positionen basierend auf WMPrefrigerant.jpg 1299x547px -->
 
-                    ${values.compressorInletTemp !== null ? `<div class="value-label" style="top: 18.00%; left: 55.00%;" title="Kompressor Einlasstemperatur">${formatValue(values.compressorInletTemp, '°C')}</div>` : ''}
-                    ${values.compressorPressure !== null ? `<div class="value-label" style="top: 23.00%; left: 55.00%;" title="Kompressor Einlassdruck">${formatValue(values.compressorPressure, 'bar')}</div>` : ''}
-                    ${values.compressorOutletTemp !== null ? `<div class="value-label" style="top: 18.00%; left: 39.00%;" title="Kompressor Auslasstemperatur">${formatValue(values.compressorOutletTemp, '°C')}</div>` : ''}
+                    ${values.compressorInletTemp !== null ? `<div class="value-label" style="top: 18.00%; left: 55.00%;" title="${t('dashboard.refrigerant_compressor_inlet_temp')}">${formatValue(values.compressorInletTemp, '°C')}</div>` : ''}
+                    ${values.compressorPressure !== null ? `<div class="value-label" style="top: 23.00%; left: 55.00%;" title="${t('dashboard.refrigerant_compressor_inlet_pressure')}">${formatValue(values.compressorPressure, 'bar')}</div>` : ''}
+                    ${values.compressorOutletTemp !== null ? `<div class="value-label" style="top: 18.00%; left: 39.00%;" title="${t('dashboard.refrigerant_compressor_outlet_temp')}">${formatValue(values.compressorOutletTemp, '°C')}</div>` : ''}
 
-                    ${values.returnTemp !== null ? `<div class="value-label" style="top: 84.00%; left: 15.00%;" title="Rücklauftemperatur sekundär">${formatValue(values.returnTemp, '°C')}</div>` : ''}
-                    ${values.supplyTempSec !== null ? `<div class="value-label" style="top: 16.00%; left: 15.00%;" title="Vorlauftemperatur sekundär">${formatValue(values.supplyTempSec, '°C')}</div>` : ''}
+                    ${values.returnTemp !== null ? `<div class="value-label" style="top: 84.00%; left: 15.00%;" title="${t('dashboard.refrigerant_return_secondary')}">${formatValue(values.returnTemp, '°C')}</div>` : ''}
+                    ${values.supplyTempSec !== null ? `<div class="value-label" style="top: 16.00%; left: 15.00%;" title="${t('dashboard.refrigerant_supply_secondary')}">${formatValue(values.supplyTempSec, '°C')}</div>` : ''}
 
-                    ${values.evaporatorTemp !== null ? `<div class="value-label" style="top: 59.09%; left: 85.39%;" title="Verdampfer Flüssigtemperatur (tO)">tO:${formatValue(values.evaporatorTemp, '°C')}</div>` : ''}
-                    ${values.evaporatorOverheat !== null ? `<div class="value-label" style="top: 50.00%; left: 85.00%;" title="Verdampfer Überhitzung">${formatValue(values.evaporatorOverheat, '°C')}</div>` : ''}
-                    ${values.primarySupply !== null ? `<div class="value-label" style="top: 16.00%; left: 80.00%;" title="Eintrittstemperatur Primärkreis (Sole)">${formatValue(values.primarySupply, '°C')}</div>` : ''}
-                    ${values.primaryReturn !== null ? `<div class="value-label" style="top: 84.00%; left: 80.00%;" title="Austrittstemperatur Primärkreis (Sole)">${formatValue(values.primaryReturn, '°C')}</div>` : ''}
-                    ${values.outsideTemp !== null ? `<div class="value-label" style="top: 49.00%; left: 92.00%;" title="Außentemperatur">${formatValue(values.outsideTemp, '°C')}</div>` : ''}
+                    ${values.evaporatorTemp !== null ? `<div class="value-label" style="top: 59.09%; left: 85.39%;" title="${t('dashboard.refrigerant_evaporator_liquid_temp')}">tO:${formatValue(values.evaporatorTemp, '°C')}</div>` : ''}
+                    ${values.evaporatorOverheat !== null ? `<div class="value-label" style="top: 50.00%; left: 85.00%;" title="${t('dashboard.refrigerant_evaporator_overheat')}">${formatValue(values.evaporatorOverheat, '°C')}</div>` : ''}
+                    ${values.primarySupply !== null ? `<div class="value-label" style="top: 16.00%; left: 80.00%;" title="${t('dashboard.refrigerant_primary_inlet')}">${formatValue(values.primarySupply, '°C')}</div>` : ''}
+                    ${values.primaryReturn !== null ? `<div class="value-label" style="top: 84.00%; left: 80.00%;" title="${t('dashboard.refrigerant_primary_outlet')}">${formatValue(values.primaryReturn, '°C')}</div>` : ''}
+                    ${values.outsideTemp !== null ? `<div class="value-label" style="top: 49.00%; left: 92.00%;" title="${t('dashboard.outside_temperature')}">${formatValue(values.outsideTemp, '°C')}</div>` : ''}
 
                     <!-- Sole-spezifische Sensoren -->
-                    ${values.hotGasPressure !== null ? `<div class="value-label" style="top: 52.49%; left: 55.23%;" title="Heißgasdruck">${formatValue(values.hotGasPressure, 'bar')}</div>` : ''}
-                    ${values.suctionGasPressure !== null ? `<div class="value-label" style="top: 44.14%; left: 55.31%;" title="Sauggasdruck">${formatValue(values.suctionGasPressure, 'bar')}</div>` : ''}
-                    ${values.hotGasTemp !== null ? `<div class="value-label" style="top: 25.16%; left: 39.08%;" title="Heißgastemperatur">${formatValue(values.hotGasTemp, '°C')}</div>` : ''}
-                    ${values.suctionGasTemp !== null ? `<div class="value-label" style="top: 12.00%; left: 55.00%;" title="Sauggastemperatur">${formatValue(values.suctionGasTemp, '°C')}</div>` : ''}
-                    ${values.liquidGasTemp !== null ? `<div class="value-label" style="top: 81.86%; left: 39.44%;" title="Flüssiggastemperatur">${formatValue(values.liquidGasTemp, '°C')}</div>` : ''}
-                    ${values.primaryRotation !== null ? `<div class="value-label" style="top: 38.67%; left: 93.48%;" title="Primärkreis Pumpendrehzahl">${formatValue(values.primaryRotation, '%', 0)}</div>` : ''}
+                    ${values.hotGasPressure !== null ? `<div class="value-label" style="top: 52.49%; left: 55.23%;" title="${t('dashboard.refrigerant_hot_gas_pressure')}">${formatValue(values.hotGasPressure, 'bar')}</div>` : ''}
+                    ${values.suctionGasPressure !== null ? `<div class="value-label" style="top: 44.14%; left: 55.31%;" title="${t('dashboard.refrigerant_suction_gas_pressure')}">${formatValue(values.suctionGasPressure, 'bar')}</div>` : ''}
+                    ${values.hotGasTemp !== null ? `<div class="value-label" style="top: 25.16%; left: 39.08%;" title="${t('dashboard.refrigerant_hot_gas_temp')}">${formatValue(values.hotGasTemp, '°C')}</div>` : ''}
+                    ${values.suctionGasTemp !== null ? `<div class="value-label" style="top: 12.00%; left: 55.00%;" title="${t('dashboard.refrigerant_suction_gas_temp')}">${formatValue(values.suctionGasTemp, '°C')}</div>` : ''}
+                    ${values.liquidGasTemp !== null ? `<div class="value-label" style="top: 81.86%; left: 39.44%;" title="${t('dashboard.refrigerant_liquid_gas_temp')}">${formatValue(values.liquidGasTemp, '°C')}</div>` : ''}
+                    ${values.primaryRotation !== null ? `<div class="value-label" style="top: 38.67%; left: 93.48%;" title="${t('dashboard.refrigerant_primary_pump_speed')}">${formatValue(values.primaryRotation, '%', 0)}</div>` : ''}
 
                     <!-- Speichertemperaturen (unter den Speicher-Bildern) -->
-                    ${values.dhwTemp !== null ? `<div class="value-label" style="top: 65.00%; left: 4.50%;" title="Warmwasser Temperatur">${formatValue(values.dhwTemp, '°C')}</div>` : ''}
+                    ${values.dhwTemp !== null ? `<div class="value-label" style="top: 65.00%; left: 4.50%;" title="${t('dashboard.dhw_temperature')}">${formatValue(values.dhwTemp, '°C')}</div>` : ''}
 
                 </div>
             </div>
@@ -62,22 +62,22 @@ async function renderConsumptionTile(deviceInfo, features) {
     consumptionSection.className = 'temperature-chart-container'; // Use same class as temperature chart
     consumptionSection.innerHTML = `
         <div class="chart-header">
-            <h2>⚡ Verbrauchsstatistiken
-                <span style="font-size: 11px; color: #666; margin-left: 10px; font-family: monospace;">  (berechnet)</span>
+            <h2>⚡ ${t('dashboard.consumption_title')}
+                <span style="font-size: 11px; color: #666; margin-left: 10px; font-family: monospace;">  ${t('dashboard.calculated')}</span>
             </h2>
             <div class="chart-controls">
                 <div class="time-range-selector">
-                    <button class="time-btn active" data-period="today">Heute</button>
-                    <button class="time-btn" data-period="yesterday">Gestern</button>
-                    <button class="time-btn" data-period="week">7 Tage</button>
-                    <button class="time-btn" data-period="month">Monat</button>
-                    <button class="time-btn" data-period="last30days">30 Tage</button>
-                    <button class="time-btn" data-period="year">Jahr</button>
+                    <button class="time-btn active" data-period="today">${t('dashboard.period_today')}</button>
+                    <button class="time-btn" data-period="yesterday">${t('dashboard.period_yesterday')}</button>
+                    <button class="time-btn" data-period="week">${t('dashboard.days', 7)}</button>
+                    <button class="time-btn" data-period="month">${t('dashboard.period_month')}</button>
+                    <button class="time-btn" data-period="last30days">${t('dashboard.days', 30)}</button>
+                    <button class="time-btn" data-period="year">${t('dashboard.period_year')}</button>
                     <div style="display: inline-flex; align-items: center; gap: 8px; margin-left: 10px;">
-                        <label for="customDateFrom" style="color: #a0a0b0; font-size: 13px; white-space: nowrap;">📅 Zeitraum:</label>
+                        <label for="customDateFrom" style="color: #a0a0b0; font-size: 13px; white-space: nowrap;">📅 ${t('dashboard.time_range')}</label>
                         <input type="date" id="customDateFrom" class="custom-date-input"
                                style="padding: 6px 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 13px; cursor: pointer;">
-                        <span style="color:#a0a0b0;font-size:13px;">bis</span>
+                        <span style="color:#a0a0b0;font-size:13px;">${t('dashboard.until')}</span>
                         <input type="date" id="customDateTo" class="custom-date-input"
                                style="padding: 6px 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 13px; cursor: pointer;">
                     </div>
@@ -88,20 +88,20 @@ async function renderConsumptionTile(deviceInfo, features) {
         <!-- Main Statistics Cards -->
         <div class="consumption-stats-grid" id="consumptionStatsGrid">
             <div class="spinner"></div>
-            <p style="color: #a0a0b0; text-align: center; margin-top: 10px;">Lade Verbrauchsdaten...</p>
+            <p style="color: #a0a0b0; text-align: center; margin-top: 10px;">${t('dashboard.consumption_loading')}</p>
         </div>
 
         <!-- Charts Container -->
         <div class="consumption-charts">
             <!-- Period Overview Chart -->
             <div class="consumption-chart-wrapper">
-                <h3 id="consumptionChartTitle" style="color: #e0e0e0; font-size: 16px; margin-bottom: 15px;">Tagesverlauf</h3>
+                <h3 id="consumptionChartTitle" style="color: #e0e0e0; font-size: 16px; margin-bottom: 15px;">${t('dashboard.chart_day')}</h3>
                 <div id="consumptionChart" style="width: 100%; height: 400px;"></div>
             </div>
 
             <!-- Comparison Chart -->
             <div class="consumption-chart-wrapper">
-                <h3 id="consumptionPeriodChartTitle" style="color: #e0e0e0; font-size: 16px; margin-bottom: 15px;">Energieverteilung</h3>
+                <h3 id="consumptionPeriodChartTitle" style="color: #e0e0e0; font-size: 16px; margin-bottom: 15px;">${t('dashboard.chart_distribution')}</h3>
                 <div id="consumptionPeriodChart" style="width: 100%; height: 400px;"></div>
             </div>
         </div>
@@ -276,7 +276,7 @@ async function loadConsumptionData(deviceInfo, period, customDate = null, fromDa
         // Show loading state
         const statsGrid = document.getElementById('consumptionStatsGrid');
         if (statsGrid) {
-            statsGrid.innerHTML = '<div class="spinner"></div><p style="color: #a0a0b0; text-align: center; margin-top: 10px;">Lade Verbrauchsdaten...</p>';
+            statsGrid.innerHTML = '<div class="spinner"></div><p style="color: #a0a0b0; text-align: center; margin-top: 10px;">' + t('dashboard.consumption_loading') + '</p>';
         }
 
         // Build API URL
@@ -328,7 +328,7 @@ async function loadConsumptionData(deviceInfo, period, customDate = null, fromDa
         if (statsGrid) {
             statsGrid.innerHTML = `
                 <div style="grid-column: 1/-1; background: rgba(220, 53, 69, 0.1); color: #f8d7da; padding: 15px; border-radius: 8px; border: 1px solid rgba(220, 53, 69, 0.3);">
-                    <strong>Fehler beim Laden der Verbrauchsdaten:</strong><br>
+                    <strong>${t('dashboard.consumption_error')}</strong><br>
                     ${err.message}
                 </div>
             `;
@@ -364,7 +364,15 @@ function renderConsumptionStats(stats, period, deviceInfo, customDate = null) {
         }
     }
 
-    const costs = stats.electricity_kwh * electricityPrice;
+    // The server prices every hour with the tariff of the device (incl. correction factor)
+    let costs = stats.electricity_kwh * electricityPrice;
+    let priceLabel = t('dashboard.price_at', electricityPrice.toFixed(2));
+    if (stats.cost_eur != null) {
+        costs = stats.cost_eur;
+        if (stats.electricity_kwh > 0) {
+            priceLabel = t('dashboard.price_tariff', (costs / stats.electricity_kwh).toFixed(3));
+        }
+    }
 
     // Calculate efficiency
     const efficiency = stats.thermal_kwh > 0 ? (stats.thermal_kwh / stats.electricity_kwh) : 0;
@@ -379,54 +387,54 @@ function renderConsumptionStats(stats, period, deviceInfo, customDate = null) {
         <div class="consumption-stat-card">
             <div class="stat-icon">⚡</div>
             <div class="stat-content">
-                <div class="stat-label">Stromverbrauch</div>
+                <div class="stat-label">${t('dashboard.power_consumption')}</div>
                 <div class="stat-value">${formatKWh(stats.electricity_kwh)}</div>
-                <div class="stat-sublabel">~${costs.toFixed(2)} € (bei ${electricityPrice.toFixed(2)} €/kWh)</div>
+                <div class="stat-sublabel">~${costs.toFixed(2)} € (${priceLabel})</div>
             </div>
         </div>
 
         <div class="consumption-stat-card">
             <div class="stat-icon">🔥</div>
             <div class="stat-content">
-                <div class="stat-label">Wärmeerzeugung</div>
+                <div class="stat-label">${t('dashboard.heat_generation')}</div>
                 <div class="stat-value">${formatKWh(stats.thermal_kwh)}</div>
-                <div class="stat-sublabel">Thermische Energie</div>
+                <div class="stat-sublabel">${t('dashboard.thermal_energy')}</div>
             </div>
         </div>
 
         <div class="consumption-stat-card ${copColorClass}">
             <div class="stat-icon">📊</div>
             <div class="stat-content">
-                <div class="stat-label">Ø ArbeitsZahl</div>
+                <div class="stat-label">Ø ${t('dashboard.cop_label')}</div>
                 <div class="stat-value">${stats.avg_cop.toFixed(2)}</div>
-                <div class="stat-sublabel">aus moment. ArbeitsZahl</div>
+                <div class="stat-sublabel">${t('dashboard.cop_from_current')}</div>
             </div>
         </div>
 
         <div class="consumption-stat-card">
             <div class="stat-icon">⏱️</div>
             <div class="stat-content">
-                <div class="stat-label">Betriebsstunden</div>
+                <div class="stat-label">${t('dashboard.operating_hours')}</div>
                 <div class="stat-value">${formatHours(stats.runtime_hours)}</div>
-                <div class="stat-sublabel">Kompressor aktiv</div>
+                <div class="stat-sublabel">${t('dashboard.compressor_active')}</div>
             </div>
         </div>
 
         <div class="consumption-stat-card">
             <div class="stat-icon">📈</div>
             <div class="stat-content">
-                <div class="stat-label">Effizienz</div>
+                <div class="stat-label">${t('dashboard.efficiency')}</div>
                 <div class="stat-value">${efficiency.toFixed(2)}x</div>
-                <div class="stat-sublabel">${stats.thermal_kwh.toFixed(1)} kWh aus ${stats.electricity_kwh.toFixed(1)} kWh</div>
+                <div class="stat-sublabel">${t('dashboard.kwh_from', stats.thermal_kwh.toFixed(1), stats.electricity_kwh.toFixed(1))}</div>
             </div>
         </div>
 
         <div class="consumption-stat-card">
             <div class="stat-icon">📉</div>
             <div class="stat-content">
-                <div class="stat-label">Datenpunkte</div>
+                <div class="stat-label">${t('dashboard.data_points')}</div>
                 <div class="stat-value">${stats.samples}</div>
-                <div class="stat-sublabel">Snapshots aufgezeichnet</div>
+                <div class="stat-sublabel">${t('dashboard.snapshots_recorded')}</div>
             </div>
         </div>
     `;
@@ -437,29 +445,28 @@ function renderConsumptionStats(stats, period, deviceInfo, customDate = null) {
 // ------------------------------
 function getConsumptionChartTitle(period, customDate = null, fromDate = null, toDate = null) {
     const formatDate = (str) => {
-        const d = new Date(str);
-        return `${d.getDate().toString().padStart(2, '0')}.${(d.getMonth() + 1).toString().padStart(2, '0')}.${d.getFullYear()}`;
+        return new Date(str).toLocaleDateString(uiLocale(), { day: '2-digit', month: '2-digit', year: 'numeric' });
     };
 
-    if (period === 'today') return 'Heutiger Tagesverlauf';
-    if (period === 'yesterday') return 'Gestriger Tagesverlauf';
-    if (period === 'week') return 'Letzte 7 Tage';
-    if (period === 'month') return 'Aktueller Monat';
-    if (period === 'last30days') return 'Letzte 30 Tage';
-    if (period === 'year') return 'Aktuelles Jahr';
+    if (period === 'today') return t('dashboard.chart_today');
+    if (period === 'yesterday') return t('dashboard.chart_yesterday');
+    if (period === 'week') return t('dashboard.last_days', 7);
+    if (period === 'month') return t('dashboard.current_month');
+    if (period === 'last30days') return t('dashboard.last_days', 30);
+    if (period === 'year') return t('dashboard.current_year');
 
     if (customDate || (fromDate && toDate && fromDate === toDate)) {
         const dateStr = formatDate(customDate || fromDate);
-        return `Tagesverlauf ${dateStr}`;
+        return t('dashboard.chart_day_of', dateStr);
     }
 
     if (fromDate && toDate && fromDate !== toDate) {
         const fromStr = formatDate(fromDate);
         const toStr   = formatDate(toDate);
-        return `Zeitraum ${fromStr} bis ${toStr}`;
+        return t('dashboard.chart_range', fromStr, toStr);
     }
 
-    return 'Verbrauchsverlauf';
+    return t('dashboard.chart_consumption');
 }
 
 function renderConsumptionCharts(stats, period, customDate = null, fromDate = null, toDate = null) {
@@ -525,7 +532,7 @@ function renderHourlyChart(stats, period, customDate = null) {
                 params.forEach(param => {
                     const value = typeof param.value === 'number' ? param.value.toFixed(2) : param.value;
                     result += param.marker + ' ' + param.seriesName + ': ' + value;
-                    if (param.seriesName !== 'ArbeitsZahl') {
+                    if (param.seriesName !== t('dashboard.cop_label')) {
                         result += ' kWh';
                     }
                     result += '<br/>';
@@ -534,7 +541,7 @@ function renderHourlyChart(stats, period, customDate = null) {
             }
         },
         legend: {
-            data: ['Stromverbrauch', 'Wärmeerzeugung', 'ArbeitsZahl'],
+            data: [t('dashboard.power_consumption'), t('dashboard.heat_generation'), t('dashboard.cop_label')],
             bottom: 0,
             textStyle: { color: '#a0a0b0' }
         },
@@ -554,7 +561,7 @@ function renderHourlyChart(stats, period, customDate = null) {
         yAxis: [
             {
                 type: 'value',
-                name: 'Energie (kWh)',
+                name: t('dashboard.energy_kwh'),
                 position: 'left',
                 axisLine: { lineStyle: { color: 'rgba(255,255,255,0.1)' } },
                 axisLabel: {
@@ -565,7 +572,7 @@ function renderHourlyChart(stats, period, customDate = null) {
             },
             {
                 type: 'value',
-                name: 'ArbeitsZahl',
+                name: t('dashboard.cop_label'),
                 position: 'right',
                 min: 0,
                 max: copAxisMax,
@@ -579,21 +586,21 @@ function renderHourlyChart(stats, period, customDate = null) {
         ],
         series: [
             {
-                name: 'Stromverbrauch',
+                name: t('dashboard.power_consumption'),
                 type: 'bar',
                 data: electricityData,
                 itemStyle: { color: '#ff6b6b' },
                 yAxisIndex: 0
             },
             {
-                name: 'Wärmeerzeugung',
+                name: t('dashboard.heat_generation'),
                 type: 'bar',
                 data: thermalData,
                 itemStyle: { color: '#4ecdc4' },
                 yAxisIndex: 0
             },
             {
-                name: 'ArbeitsZahl',
+                name: t('dashboard.cop_label'),
                 type: 'line',
                 data: copData,
                 smooth: true,
@@ -651,7 +658,7 @@ function renderDailyChart(stats, period, customDate = null, fromDate = null, toD
                 params.forEach(param => {
                     const value = typeof param.value === 'number' ? param.value.toFixed(2) : param.value;
                     result += param.marker + ' ' + param.seriesName + ': ' + value;
-                    if (param.seriesName !== 'ArbeitsZahl') {
+                    if (param.seriesName !== t('dashboard.cop_label')) {
                         result += ' kWh';
                     }
                     result += '<br/>';
@@ -660,7 +667,7 @@ function renderDailyChart(stats, period, customDate = null, fromDate = null, toD
             }
         },
         legend: {
-            data: ['Stromverbrauch', 'Wärmeerzeugung', 'ArbeitsZahl'],
+            data: [t('dashboard.power_consumption'), t('dashboard.heat_generation'), t('dashboard.cop_label')],
             bottom: 0,
             textStyle: { color: '#a0a0b0' }
         },
@@ -682,7 +689,7 @@ function renderDailyChart(stats, period, customDate = null, fromDate = null, toD
         yAxis: [
             {
                 type: 'value',
-                name: 'Energie (kWh)',
+                name: t('dashboard.energy_kwh'),
                 position: 'left',
                 axisLine: { lineStyle: { color: 'rgba(255,255,255,0.1)' } },
                 axisLabel: {
@@ -693,7 +700,7 @@ function renderDailyChart(stats, period, customDate = null, fromDate = null, toD
             },
             {
                 type: 'value',
-                name: 'ArbeitsZahl',
+                name: t('dashboard.cop_label'),
                 position: 'right',
                 min: 0,
                 max: copAxisMax,
@@ -707,21 +714,21 @@ function renderDailyChart(stats, period, customDate = null, fromDate = null, toD
         ],
         series: [
             {
-                name: 'Stromverbrauch',
+                name: t('dashboard.power_consumption'),
                 type: 'bar',
                 data: electricityData,
                 itemStyle: { color: '#ff6b6b' },
                 yAxisIndex: 0
             },
             {
-                name: 'Wärmeerzeugung',
+                name: t('dashboard.heat_generation'),
                 type: 'bar',
                 data: thermalData,
                 itemStyle: { color: '#4ecdc4' },
                 yAxisIndex: 0
             },
             {
-                name: 'ArbeitsZahl',
+                name: t('dashboard.cop_label'),
                 type: 'line',
                 data: copData,
                 smooth: true,
@@ -744,7 +751,7 @@ function renderPeriodComparisonChart(stats, period, customDate = null) {
 
     const chartTitle = document.getElementById('consumptionPeriodChartTitle');
     if (chartTitle) {
-        chartTitle.textContent = 'Energieverteilung';
+        chartTitle.textContent = t('dashboard.chart_distribution');
     }
 
     // Dispose old chart
@@ -774,7 +781,7 @@ function renderPeriodComparisonChart(stats, period, customDate = null) {
         },
         series: [
             {
-                name: 'Energie',
+                name: t('dashboard.energy'),
                 type: 'pie',
                 radius: ['40%', '70%'],
                 avoidLabelOverlap: false,
@@ -801,12 +808,12 @@ function renderPeriodComparisonChart(stats, period, customDate = null) {
                 data: [
                     {
                         value: stats.electricity_kwh,
-                        name: 'Stromverbrauch',
+                        name: t('dashboard.power_consumption'),
                         itemStyle: { color: '#ff6b6b' }
                     },
                     {
                         value: stats.thermal_kwh,
-                        name: 'Wärmeerzeugung',
+                        name: t('dashboard.heat_generation'),
                         itemStyle: { color: '#4ecdc4' }
                     }
                 ]
@@ -856,21 +863,21 @@ function renderConsumptionBreakdown(stats, period, customDate = null, fromDate =
     }
 
     if (!breakdown || breakdown.length === 0) {
-        breakdownContainer.innerHTML = `<p>Keine Daten verfügbar.</p>`;
+        breakdownContainer.innerHTML = `<p>${t('dashboard.no_data')}</p>`;
         return;
     }
 
     let html = `
-        <h3 style="color: #e0e0e0; font-size: 16px; margin: 20px 0 15px 0;">Detaillierte Aufschlüsselung</h3>
+        <h3 style="color: #e0e0e0; font-size: 16px; margin: 20px 0 15px 0;">${t('dashboard.breakdown_title')}</h3>
         <div class="breakdown-table-container">
             <table class="breakdown-table">
                 <thead>
                     <tr>
-                        <th>${isHourly ? 'Uhrzeit' : 'Datum'}</th>
-                        <th>Strom (kWh)</th>
-                        <th>Wärme (kWh)</th>
-                        <th>ArbeitsZahl</th>
-                        <th>Laufzeit</th>
+                        <th>${isHourly ? t('dashboard.time') : t('dashboard.date')}</th>
+                        <th>${t('dashboard.electricity_kwh')}</th>
+                        <th>${t('dashboard.heat_kwh')}</th>
+                        <th>${t('dashboard.cop_label')}</th>
+                        <th>${t('dashboard.runtime')}</th>
                         <th>Samples</th>
                     </tr>
                 </thead>
@@ -886,7 +893,7 @@ function renderConsumptionBreakdown(stats, period, customDate = null, fromDate =
             const endHour = (startHour + 1) % 24; // Wrap 24 to 0
             timeLabel = `${startHour.toString().padStart(2,'0')}:00 - ${endHour.toString().padStart(2,'0')}:00`;
         } else {
-            timeLabel = `${date.getDate()}.${date.getMonth() + 1}.${date.getFullYear()}`;
+            timeLabel = date.toLocaleDateString(uiLocale());
         }
 
         const hours = Math.floor(item.runtime_hours);
@@ -1022,26 +1029,26 @@ window.addEventListener('resize', () => {
 
             const getMonthName = (index) => {
                 const d = new Date(now.getFullYear(), now.getMonth() - index, 1);
-                return d.toLocaleDateString('de-DE', { month: 'long', year: 'numeric' });
+                return d.toLocaleDateString(uiLocale(), { month: 'long', year: 'numeric' });
             };
         
             const getWeekLabel = (index) => {
                 const d = new Date(now.getTime() - (index * 7 * 24 * 60 * 60 * 1000));
                 const onejan = new Date(d.getFullYear(), 0, 1);
                 const week = Math.ceil((((d - onejan) / 86400000) + onejan.getDay() + 1) / 7);
-                return `KW ${week}`;
+                return t('dashboard.calendar_week', week);
             };
 
             const getDayLabel = (index) => {
                 const d = new Date(now.getTime() - (index * 24 * 60 * 60 * 1000));
-                return d.toLocaleDateString('de-DE', { weekday: 'short', day: '2-digit', month: '2-digit' });
+                return d.toLocaleDateString(uiLocale(), { weekday: 'short', day: '2-digit', month: '2-digit' });
             };
         
             let mainTabsHtml = `
-                <button class="stat-tab active" onclick="switchStatPeriod(event, 'power-period-day')">Tag</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-week')">Woche</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-month')">Monat</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-year')">Jahr</button>
+                <button class="stat-tab active" onclick="switchStatPeriod(event, 'power-period-day')">${t('dashboard.period_day')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-week')">${t('dashboard.period_week')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-month')">${t('dashboard.period_month')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'power-period-year')">${t('dashboard.period_year')}</button>
             `;
         
             // Build days
@@ -1059,9 +1066,9 @@ window.addEventListener('resize', () => {
                 dayContentHtml += `
                     <div id="power-day-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
-                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
-                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
+                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
+                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
+                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1082,9 +1089,9 @@ window.addEventListener('resize', () => {
                 weekContentHtml += `
                     <div id="power-week-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
-                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
-                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
+                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
+                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
+                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1105,9 +1112,9 @@ window.addEventListener('resize', () => {
                 monthContentHtml += `
                     <div id="power-month-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
-                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
-                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
+                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
+                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
+                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1130,9 +1137,9 @@ window.addEventListener('resize', () => {
                 yearContentHtml += `
                     <div id="power-year-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
-                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
-                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
+                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
+                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
+                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1142,7 +1149,7 @@ window.addEventListener('resize', () => {
         
             return `
                 <div class="card">
-                    <div class="card-header"><h2>⚡ Stromverbrauch</h2></div>
+                    <div class="card-header"><h2>⚡ ${t('dashboard.power_consumption')}</h2></div>
                     <div class="stat-tabs stat-tabs-main">${mainTabsHtml}</div>
                     <div id="power-period-day" class="stat-period-content" style="display: block;">
                         <div class="stat-tabs stat-tabs-scrollable">${dayTabsHtml}</div>
@@ -1180,26 +1187,26 @@ window.addEventListener('resize', () => {
 
             const getMonthName = (index) => {
                 const d = new Date(now.getFullYear(), now.getMonth() - index, 1);
-                return d.toLocaleDateString('de-DE', { month: 'long', year: 'numeric' });
+                return d.toLocaleDateString(uiLocale(), { month: 'long', year: 'numeric' });
             };
 
             const getWeekLabel = (index) => {
                 const d = new Date(now.getTime() - (index * 7 * 24 * 60 * 60 * 1000));
                 const onejan = new Date(d.getFullYear(), 0, 1);
                 const week = Math.ceil((((d - onejan) / 86400000) + onejan.getDay() + 1) / 7);
-                return `KW ${week}`;
+                return t('dashboard.calendar_week', week);
             };
 
             const getDayLabel = (index) => {
                 const d = new Date(now.getTime() - (index * 24 * 60 * 60 * 1000));
-                return d.toLocaleDateString('de-DE', { weekday: 'short', day: '2-digit', month: '2-digit' });
+                return d.toLocaleDateString(uiLocale(), { weekday: 'short', day: '2-digit', month: '2-digit' });
             };
 
             let mainTabsHtml = `
-                <button class="stat-tab active" onclick="switchStatPeriod(event, 'gas-period-day')">Tag</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-week')">Woche</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-month')">Monat</button>
-                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-year')">Jahr</button>
+                <button class="stat-tab active" onclick="switchStatPeriod(event, 'gas-period-day')">${t('dashboard.period_day')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-week')">${t('dashboard.period_week')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-month')">${t('dashboard.period_month')}</button>
+                <button class="stat-tab" onclick="switchStatPeriod(event, 'gas-period-year')">${t('dashboard.period_year')}</button>
             `;
 
             // Build days
@@ -1218,9 +1225,9 @@ window.addEventListener('resize', () => {
                 dayContentHtml += `
                     <div id="gas-day-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
-                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
-                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
+                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
+                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
+                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1241,9 +1248,9 @@ window.addEventListener('resize', () => {
                 weekContentHtml += `
                     <div id="gas-week-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
-                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
-                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
+                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
+                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
+                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1264,9 +1271,9 @@ window.addEventListener('resize', () => {
                 monthContentHtml += `
                     <div id="gas-month-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
-                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
-                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
+                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
+                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
+                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1289,9 +1296,9 @@ window.addEventListener('resize', () => {
                 yearContentHtml += `
                     <div id="gas-year-${i}" class="stat-tab-content" style="${i === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
-                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
-                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
+                            ${gasDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(gasDhw)} m³</span></div>` : ''}
+                            ${gasHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(gasHeating)} m³</span></div>` : ''}
+                            ${totalGas > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalGas)} m³</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1301,7 +1308,7 @@ window.addEventListener('resize', () => {
 
             return `
                 <div class="card">
-                    <div class="card-header"><h2>🔥 Gasverbrauch</h2></div>
+                    <div class="card-header"><h2>🔥 ${t('dashboard.gas_consumption')}</h2></div>
                     <div class="stat-tabs stat-tabs-main">${mainTabsHtml}</div>
                     <div id="gas-period-day" class="stat-period-content" style="display: block;">
                         <div class="stat-tabs stat-tabs-scrollable">${dayTabsHtml}</div>
@@ -1327,12 +1334,12 @@ window.addEventListener('resize', () => {
         // Heat Production Summary Card (Erzeugte Wärmeenergie) - separate Kachel mit Summary-Daten
         function renderHeatProductionSummaryCard(kf, getSummaryValue) {
             const periods = [
-                {key: 'currentDay', label: 'Heute'},
-                {key: 'lastSevenDays', label: 'Letzte 7 Tage'},
-                {key: 'currentMonth', label: 'Aktueller Monat'},
-                {key: 'lastMonth', label: 'Letzter Monat'},
-                {key: 'currentYear', label: 'Aktuelles Jahr'},
-                {key: 'lastYear', label: 'Letztes Jahr'}
+                {key: 'currentDay', label: t('dashboard.period_today')},
+                {key: 'lastSevenDays', label: t('dashboard.last_days', 7)},
+                {key: 'currentMonth', label: t('dashboard.current_month')},
+                {key: 'lastMonth', label: t('dashboard.last_month')},
+                {key: 'currentYear', label: t('dashboard.current_year')},
+                {key: 'lastYear', label: t('dashboard.last_year')}
             ];
         
             let tabsHtml = '';
@@ -1350,9 +1357,9 @@ window.addEventListener('resize', () => {
                 contentHtml += `
                     <div id="heat-${period.key}" class="stat-tab-content" style="${index === 0 ? 'display: block;' : 'display: none;'}">
                         <div class="stat-grid">
-                            ${heatDhw !== null ? `<div class="stat-item stat-heat"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(heatDhw)} kWh</span></div>` : ''}
-                            ${heatHeating !== null ? `<div class="stat-item stat-heat"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(heatHeating)} kWh</span></div>` : ''}
-                            ${totalHeat > 0 ? `<div class="stat-item stat-heat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalHeat)} kWh</span></div>` : ''}
+                            ${heatDhw !== null ? `<div class="stat-item stat-heat"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(heatDhw)} kWh</span></div>` : ''}
+                            ${heatHeating !== null ? `<div class="stat-item stat-heat"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(heatHeating)} kWh</span></div>` : ''}
+                            ${totalHeat > 0 ? `<div class="stat-item stat-heat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalHeat)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1362,7 +1369,7 @@ window.addEventListener('resize', () => {
         
             return `
                 <div class="card">
-                    <div class="card-header"><h2>🌡️ Erzeugte Wärmeenergie</h2></div>
+                    <div class="card-header"><h2>🌡️ ${t('dashboard.heat_produced')}</h2></div>
                     <div class="stat-tabs stat-tabs-scrollable">${tabsHtml}</div>
                     <div class="stat-content">${contentHtml}</div>
                 </div>
@@ -1395,11 +1402,11 @@ window.addEventListener('resize', () => {
                 const totalPower = (powerDhw || 0) + (powerHeating || 0);
                 html += `
                     <div class="card">
-                        <div class="card-header"><h2>⚡ Verdichter Stromverbrauch (Wöchentlich)</h2></div>
+                        <div class="card-header"><h2>⚡ ${t('dashboard.compressor_power_weekly')}</h2></div>
                         <div class="stat-grid">
-                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
-                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
-                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
+                            ${powerDhw !== null ? `<div class="stat-item stat-power"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(powerDhw)} kWh</span></div>` : ''}
+                            ${powerHeating !== null ? `<div class="stat-item stat-power"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(powerHeating)} kWh</span></div>` : ''}
+                            ${totalPower > 0 ? `<div class="stat-item stat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalPower)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1414,12 +1421,12 @@ window.addEventListener('resize', () => {
                 const totalHeat = (heatDhw || 0) + (heatHeating || 0) + (heatCooling || 0);
                 html += `
                     <div class="card">
-                        <div class="card-header"><h2>🌡️ Verdichter Wärmeproduktion (Wöchentlich)</h2></div>
+                        <div class="card-header"><h2>🌡️ ${t('dashboard.compressor_heat_weekly')}</h2></div>
                         <div class="stat-grid">
-                            ${heatDhw !== null ? `<div class="stat-item stat-heat"><span class="stat-label">💧 Warmwasser</span><span class="stat-value">${formatNum(heatDhw)} kWh</span></div>` : ''}
-                            ${heatHeating !== null ? `<div class="stat-item stat-heat"><span class="stat-label">🔥 Heizen</span><span class="stat-value">${formatNum(heatHeating)} kWh</span></div>` : ''}
-                            ${heatCooling !== null ? `<div class="stat-item stat-cool"><span class="stat-label">❄️ Kühlung</span><span class="stat-value">${formatNum(heatCooling)} kWh</span></div>` : ''}
-                            ${totalHeat > 0 ? `<div class="stat-item stat-heat-total"><span class="stat-label">Gesamt</span><span class="stat-value">${formatNum(totalHeat)} kWh</span></div>` : ''}
+                            ${heatDhw !== null ? `<div class="stat-item stat-heat"><span class="stat-label">💧 ${t('dashboard.mode_dhw')}</span><span class="stat-value">${formatNum(heatDhw)} kWh</span></div>` : ''}
+                            ${heatHeating !== null ? `<div class="stat-item stat-heat"><span class="stat-label">🔥 ${t('dashboard.mode_heating')}</span><span class="stat-value">${formatNum(heatHeating)} kWh</span></div>` : ''}
+                            ${heatCooling !== null ? `<div class="stat-item stat-cool"><span class="stat-label">❄️ ${t('dashboard.cooling')}</span><span class="stat-value">${formatNum(heatCooling)} kWh</span></div>` : ''}
+                            ${totalHeat > 0 ? `<div class="stat-item stat-heat-total"><span class="stat-label">${t('dashboard.total')}</span><span class="stat-value">${formatNum(totalHeat)} kWh</span></div>` : ''}
                         </div>
                     </div>
                 `;
@@ -1444,10 +1451,10 @@ window.addEventListener('resize', () => {
 
             // Collect data for each time period
             const periods = [
-                {key: 'currentDay', label: 'Heute', divider: 1},
-                {key: 'lastSevenDays', label: '7 Tage', divider: 7},
-                {key: 'currentMonth', label: 'Monat', divider: 30},
-                {key: 'currentYear', label: 'Jahr', divider: 365}
+                {key: 'currentDay', label: t('dashboard.period_today'), divider: 1},
+                {key: 'lastSevenDays', label: t('dashboard.days', 7), divider: 7},
+                {key: 'currentMonth', label: t('dashboard.period_month'), divider: 30},
+                {key: 'currentYear', label: t('dashboard.period_year'), divider: 365}
             ];
 
             let tabsHtml = '';
@@ -1490,38 +1497,38 @@ window.addEventListener('resize', () => {
                         <div class="stat-grid">
                             ${powerDhw !== null ? `
                                 <div class="stat-item stat-power">
-                                    <span class="stat-label">💧 Warmwasser/Verdichter</span>
+                                    <span class="stat-label">💧 ${t('dashboard.dhw_compressor')}</span>
                                     <span class="stat-value">${formatNum(powerDhw)} kWh</span>
-                                    ${avgPerWeek && period.key !== 'currentDay' ? `<span class="stat-avg">≈ ${avgPerWeek} kWh/Woche</span>` : ''}
+                                    ${avgPerWeek && period.key !== 'currentDay' ? `<span class="stat-avg">≈ ${t('dashboard.kwh_per_week', avgPerWeek)}</span>` : ''}
                                 </div>
                             ` : ''}
                             ${powerHeating !== null ? `
                                 <div class="stat-item stat-power">
-                                    <span class="stat-label">🔥 Heizen/Verdichter</span>
+                                    <span class="stat-label">🔥 ${t('dashboard.heating_compressor')}</span>
                                     <span class="stat-value">${formatNum(powerHeating)} kWh</span>
                                 </div>
                             ` : ''}
                             ${totalPower > 0 ? `
                                 <div class="stat-item stat-total">
-                                    <span class="stat-label">⚡ Strom Gesamt</span>
+                                    <span class="stat-label">⚡ ${t('dashboard.electricity_total')}</span>
                                     <span class="stat-value">${formatNum(totalPower)} kWh</span>
                                 </div>
                             ` : ''}
                             ${heatDhw !== null ? `
                                 <div class="stat-item stat-heat">
-                                    <span class="stat-label">🌡️ Wärme Warmwasser</span>
+                                    <span class="stat-label">🌡️ ${t('dashboard.heat_dhw')}</span>
                                     <span class="stat-value">${formatNum(heatDhw)} kWh</span>
                                 </div>
                             ` : ''}
                             ${heatHeating !== null ? `
                                 <div class="stat-item stat-heat">
-                                    <span class="stat-label">🏠 Wärme Heizen</span>
+                                    <span class="stat-label">🏠 ${t('dashboard.heat_heating')}</span>
                                     <span class="stat-value">${formatNum(heatHeating)} kWh</span>
                                 </div>
                             ` : ''}
                             ${totalHeat > 0 && totalPower > 0 ? `
                                 <div class="stat-item stat-cop">
-                                    <span class="stat-label">📊 momentane AZ (${period.label})</span>
+                                    <span class="stat-label">📊 ${t('dashboard.current_cop', period.label)}</span>
                                     <span class="stat-value">${cop}</span>
                                 </div>
                             ` : ''}
@@ -1535,7 +1542,7 @@ window.addEventListener('resize', () => {
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>📈 Verbrauchsstatistik</h2>
+                        <h2>📈 ${t('dashboard.consumption_statistics')}</h2>
                     </div>
                     <div class="stat-tabs">
                         ${tabsHtml}
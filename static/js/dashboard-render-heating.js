@@ -34,7 +34,7 @@
             const settingsButton = hasCompressor ? `
                 <button onclick="openDeviceSettingsModal('${deviceInfo.installationId}', '${deviceInfo.deviceId}')"
                         style="margin-left: 10px; padding: 5px 10px; cursor: pointer;">
-                    ⚙️ Einstellungen
+                    ⚙️ ${t('dashboard.settings')}
                 </button>
             ` : '';
 
@@ -66,7 +66,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup1 += `
                     <div class="temp-item">
-                        <span class="temp-label">Außentemperatur</span>
+                        <span class="temp-label">${t('dashboard.outside_temperature')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -81,7 +81,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup1 += `
                     <div class="temp-item">
-                        <span class="temp-label">Außentemp. (ged.)</span>
+                        <span class="temp-label">${t('dashboard.outside_temperature_damped')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -95,18 +95,18 @@
                 const [value, ...unitParts] = formatted.split(' ');
                 const unit = unitParts.join(' ');
                 // Determine label: check device settings first, then fall back to auto-detection
-                let label = 'Primärkreis-Vorlauf'; // Default
+                let label = t('dashboard.primary_supply'); // Default
 
                 if (deviceSettings && deviceSettings.useAirIntakeTemperatureLabel !== null && deviceSettings.useAirIntakeTemperatureLabel !== undefined) {
                     // Use the explicit setting from device settings
-                    label = deviceSettings.useAirIntakeTemperatureLabel ? 'Lufteintritts-temperatur' : 'Primärkreis-Vorlauf';
+                    label = deviceSettings.useAirIntakeTemperatureLabel ? t('dashboard.air_intake_temperature') : t('dashboard.primary_supply');
                 } else {
                     // Fall back to auto-detection based on compressor sensors
                     const isVitocal = kf.compressorActive || kf.compressorSpeed || kf.compressorInletTemp ||
                                      kf.compressorOutletTemp || kf.compressorOilTemp || kf.compressorMotorTemp ||
                                      kf.compressorPressure;
                     if (isVitocal) {
-                        label = 'Lufteintritts-temperatur';
+                        label = t('dashboard.air_intake_temperature');
                     }
                 }
 
@@ -127,7 +127,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup1 += `
                     <div class="temp-item">
-                        <span class="temp-label">Primärkreis-Rücklauf</span>
+                        <span class="temp-label">${t('dashboard.primary_return')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -142,7 +142,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup1 += `
                     <div class="temp-item">
-                        <span class="temp-label">Sekundärkreis-Vorlauf</span>
+                        <span class="temp-label">${t('dashboard.secondary_supply')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -178,7 +178,7 @@
 
                 tempsGroup2 += `
                     <div class="temp-item">
-                        <span class="temp-label">Solltemperatur (Heizkurve)</span>
+                        <span class="temp-label">${t('dashboard.target_temperature_curve')}</span>
                         <div>
                             <span class="temp-value">${formatNum(targetTemp)}</span>
                             <span class="temp-unit">°C</span>
@@ -194,7 +194,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup2 += `
                     <div class="temp-item">
-                        <span class="temp-label">Gemeinsame Vorlauftemperatur</span>
+                        <span class="temp-label">${t('dashboard.common_supply_temperature')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -209,7 +209,7 @@
                 const unit = unitParts.join(' ');
                 tempsGroup2 += `
                     <div class="temp-item">
-                        <span class="temp-label">Rücklauftemperatur</span>
+                        <span class="temp-label">${t('dashboard.return_temperature')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -225,7 +225,7 @@
                     const spreizung = supplyValue - returnValue;
                     tempsGroup2 += `
                         <div class="temp-item">
-                            <span class="temp-label">Spreizung Primärkreis</span>
+                            <span class="temp-label">${t('dashboard.spread_primary')}</span>
                             <div>
                                 <span class="temp-value">${formatNum(spreizung)}</span>
                                 <span class="temp-unit">K</span>
@@ -264,7 +264,7 @@
                 const dhwClass = isDhwActive ? 'with-bg-fire' : '';
                 tempsGroup3 += `
                     <div class="temp-item ${dhwClass}">
-                        <span class="temp-label">Warmwasser</span>
+                        <span class="temp-label">${t('dashboard.mode_dhw')}</span>
                         <div>
                             <span class="temp-value">${value}</span>
                             <span class="temp-unit">${unit}</span>
@@ -283,7 +283,7 @@
                     const compressorClass = isCompressorRunning(kf) ? 'with-bg-fan' : '';
             	    tempsGroup3 += `
                 	    <div class="temp-item ${compressorClass}">
-                    	    <span class="temp-label">Wärmeerzeuger-Vorlauf</span>
+                    	    <span class="temp-label">${t('dashboard.generator_supply')}</span>
                         	<div>
                             	<span class="temp-value">${value}</span>
 	                            <span class="temp-unit">${unit}</span>
@@ -301,7 +301,7 @@
         	        const unit = unitParts.join(' ');
 	                tempsGroup3 += `
     	                <div class="temp-item">
-        	                <span class="temp-label">Puffertemperatur</span>
+        	                <span class="temp-label">${t('dashboard.buffer_temperature')}</span>
             	            <div>
                 	            <span class="temp-value">${value}</span>
                     	        <span class="temp-unit">${unit}</span>
@@ -319,7 +319,7 @@
         	        const unit = unitParts.join(' ');
     	            tempsGroup3 += `
         	            <div class="temp-item">
-            	            <span class="temp-label">Puffertemperatur Oben</span>
+            	            <span class="temp-label">${t('dashboard.buffer_temperature_top')}</span>
                 	        <div>
                     	        <span class="temp-value">${value}</span>
                         	    <span class="temp-unit">${unit}</span>
@@ -388,8 +388,8 @@
                 // Add electrical power consumption tile (in W or kW)
 				let elPow = 0.0;
 				let elPowUnit = " ";
-				let elPowlabel = "Stromverbrauch";
-				if (correctionFactor != 1.0) elPowlabel = elPowlabel + "<br>(korrigiert)";
+				let elPowlabel = t('dashboard.power_consumption');
+				if (correctionFactor != 1.0) elPowlabel = elPowlabel + '<br>' + t('dashboard.corrected');
 				if (electricalPowerW < 1000.0){elPow = formatNum(electricalPowerW, 0); elPowUnit = elPowUnit + "W";}
 				else{elPow = formatNum(electricalPowerW / 1000, 2);elPowUnit = elPowUnit + "kW";}
                 tempsGroup4 += `
@@ -433,7 +433,7 @@
                 if (thermalPowerW !== null) {
 					tempsGroup4 += `
                         <div class="temp-item">
-                            <span class="temp-label">Thermische Leistung<br>(berechnet)</span>
+                            <span class="temp-label">${t('dashboard.thermal_power_calculated')}</span>
                             <div>
                                 <span class="temp-value">${formatNum(thermalPowerW / 1000, 2)}</span>
                                 <span class="temp-unit">kW</span>
@@ -445,7 +445,7 @@
                     const cop = thermalPowerW / electricalPowerW;
                     tempsGroup4 += `
                         <div class="temp-item">
-                            <span class="temp-label">moment. ArbeitsZahl<br>(berechnet)</span>
+                            <span class="temp-label">${t('dashboard.current_cop_calculated')}</span>
                             <div>
                                 <span class="temp-value">${formatNum(cop, 2)}</span>
                                 <span class="temp-unit"></span>
@@ -480,31 +480,31 @@
                     </div>
                     ${tempsGroup1 ? `
                         <div class="temp-group">
-                            <h3 class="temp-group-title">Außentemperaturen & Systemkreise</h3>
+                            <h3 class="temp-group-title">${t('dashboard.group_outside_and_circuits')}</h3>
                             <div class="temp-grid">${tempsGroup1}</div>
                         </div>
                     ` : ''}
                     ${tempsGroup2 ? `
                         <div class="temp-group">
-                            <h3 class="temp-group-title">Heizkreis</h3>
+                            <h3 class="temp-group-title">${t('dashboard.group_heating_circuit')}</h3>
                             <div class="temp-grid">${tempsGroup2}</div>
                         </div>
                     ` : ''}
                     ${tempsGroup3 ? `
                         <div class="temp-group">
-                            <h3 class="temp-group-title">Speicher & Warmwasser</h3>
+                            <h3 class="temp-group-title">${t('dashboard.group_storage_and_dhw')}</h3>
                             <div class="temp-grid">${tempsGroup3}</div>
                         </div>
                     ` : ''}
                     ${tempsGroup4 ? `
                         <div class="temp-group">
-                            <h3 class="temp-group-title">Energiecockpit</h3>
+                            <h3 class="temp-group-title">${t('dashboard.group_energy_cockpit')}</h3>
                             <div class="temp-grid">${tempsGroup4}</div>
                         </div>
                     ` : ''}
                     ${visualDiagram ? `
                         <div class="temp-group">
-                            <h3 class="temp-group-title">Kältekreislauf-Visualisierung</h3>
+                            <h3 class="temp-group-title">${t('dashboard.group_refrigerant_visual')}</h3>
                             ${visualDiagram}
                         </div>
                     ` : ''}
@@ -532,7 +532,7 @@
             let title = '';
 
             if (hasCompressor) {
-                title = '⚙️ Verdichter';
+                title = '⚙️ ' + t('dashboard.compressor');
                 const isRunning = isCompressorRunning(kf);
 
                 // Convert speed to RPM if unit is revolutionsPerSecond
@@ -541,7 +541,7 @@
 
                 if (speedUnit === 'revolutionsPerSecond') {
                     speedValue = speedValue * 60;
-                    speedUnit = 'U/min';
+                    speedUnit = t('dashboard.unit_rpm');
                 }
 
                 // Extract compressor statistics (use compressorStats0 since it now holds the primary compressor data)
@@ -595,12 +595,12 @@
 				
                 content = `
                     <div class="status-item">
-                        <span class="status-label">Status</span>
-                        <span class="status-value">${isRunning ? '🟢 An' : '⚪ Aus'}</span>
+                        <span class="status-label">${t('dashboard.status')}</span>
+                        <span class="status-value">${isRunning ? '🟢 ' + t('dashboard.on_short') : '⚪ ' + t('dashboard.off_short')}</span>
                     </div>
                     ${kf.compressorSpeed ? `
                         <div class="status-item">
-                            <span class="status-label">Drehzahl</span>
+                            <span class="status-label">${t('dashboard.speed')}</span>
                             <span class="status-value">
                                 ${speedValue !== 0 ? formatNum(speedValue, 0) + ' ' + speedUnit : '--'}
                                 ${rpmPercentage !== null ? `<span style="color: #10b981; margin-left: 8px;">(${rpmPercentage}%)</span>` : ''}
@@ -609,7 +609,7 @@
                     ` : ''}
                     ${kf.compressorPower ? `
                         <div class="status-item">
-                            <span class="status-label">Leistung</span>
+                            <span class="status-label">${t('dashboard.power')}</span>
                             <span class="status-value">${(() => {
                                 if (!isValidNumericValue(kf.compressorPower)) return '--';
                                 if (!kf.compressorPower.value) return '--';
@@ -621,7 +621,7 @@
 
 					${kf.compressorHeatPower ? `
                         <div class="status-item">
-                            <span class="status-label">therm. Leistung</span>
+                            <span class="status-label">${t('dashboard.thermal_power')}</span>
                             <span class="status-value">${(() => {
                                 if (!isValidNumericValue(kf.compressorHeatPower)) return '--';
                                 if (!kf.compressorHeatPower.value) return '--';
@@ -633,7 +633,7 @@
 
                     ${kf.compressorHeatPercentPower ? `
                         <div class="status-item">
-                            <span class="status-label">aktuelle th. Leistung</span>
+                            <span class="status-label">${t('dashboard.current_thermal_power')}</span>
                             <span class="status-value">${(() => {
                                 if (compressorHeatingNow == -1) return '--';
                                 return formatNum(compressorHeatingNow, 1) + ' kW';
@@ -646,7 +646,7 @@
 					
 					${kf.compressorCurrent ? `
                         <div class="status-item">
-                            <span class="status-label">Stromaufnahme</span>
+                            <span class="status-label">${t('dashboard.current_draw')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorCurrent) ? formatValue(kf.compressorCurrent) : '--'}</span>
                         </div>
                     ` : ''}
@@ -655,93 +655,93 @@
 
                     ${kf.compressorPressure ? `
                         <div class="status-item">
-                            <span class="status-label">Einlassdruck</span>
+                            <span class="status-label">${t('dashboard.inlet_pressure')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorPressure) ? formatValue(kf.compressorPressure) : '--'}</span>
                         </div>
                     ` : ''}
                     ${kf.compressorOilTemp ? `
                         <div class="status-item">
-                            <span class="status-label">Öltemperatur</span>
+                            <span class="status-label">${t('dashboard.oil_temperature')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorOilTemp) ? formatValue(kf.compressorOilTemp) : '--'}</span>
                         </div>
                     ` : ''}
                     ${kf.compressorMotorTemp ? `
                         <div class="status-item">
-                            <span class="status-label">Motorraumtemperatur</span>
+                            <span class="status-label">${t('dashboard.motor_temperature')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorMotorTemp) ? formatValue(kf.compressorMotorTemp) : '--'}</span>
                         </div>
                     ` : ''}
                     ${kf.compressorInletTemp ? `
                         <div class="status-item">
-                            <span class="status-label">Einlasstemperatur</span>
+                            <span class="status-label">${t('dashboard.inlet_temperature')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorInletTemp) ? formatValue(kf.compressorInletTemp) : '--'}</span>
                         </div>
                     ` : ''}
                     ${kf.compressorOutletTemp ? `
                         <div class="status-item">
-                            <span class="status-label">Auslasstemperatur</span>
+                            <span class="status-label">${t('dashboard.outlet_temperature')}</span>
                             <span class="status-value">${isValidNumericValue(kf.compressorOutletTemp) ? formatValue(kf.compressorOutletTemp) : '--'}</span>
                         </div>
                     ` : ''}
                     ${compressorHours > 0 ? `
                         <div class="status-item">
-                            <span class="status-label">Betriebsstunden Verdichter</span>
-                            <span class="status-value">${formatNum(compressorHours, 0)} Std.</span>
+                            <span class="status-label">${t('dashboard.compressor_hours')}</span>
+                            <span class="status-value">${formatNum(compressorHours, 0)} ${t('dashboard.unit_hours')}</span>
                         </div>
                     ` : ''}
                     ${compressorStarts > 0 ? `
                         <div class="status-item">
-                            <span class="status-label">Anzahl Verdichterstarts</span>
+                            <span class="status-label">${t('dashboard.compressor_starts')}</span>
                             <span class="status-value">${compressorStarts}</span>
                         </div>
                     ` : ''}
                     ${avgRuntime > 0 ? `
                         <div class="status-item">
-                            <span class="status-label">Durchschnittl. mittlere Laufzeit</span>
-                            <span class="status-value">${formatNum(avgRuntime)} Std.</span>
+                            <span class="status-label">${t('dashboard.average_runtime')}</span>
+                            <span class="status-value">${formatNum(avgRuntime)} ${t('dashboard.unit_hours')}</span>
                         </div>
                     ` : ''}
                     ${cyclesPerDay !== null ? `
                         <div class="status-item">
-                            <span class="status-label">Durchschnittl. Takte</span>
-                            <span class="status-value">${formatNum(cyclesPerDay, 1)} T/Tag</span>
+                            <span class="status-label">${t('dashboard.average_cycles')}</span>
+                            <span class="status-value">${formatNum(cyclesPerDay, 1)} ${t('dashboard.cycles_per_day')}</span>
                         </div>
                     ` : ''}
                     ${kf.fanRing ? `
                         <div class="status-item">
-                            <span class="status-label">Ventilatorringheizung</span>
+                            <span class="status-label">${t('dashboard.fan_ring_heating')}</span>
                             <span class="status-value">
                                 <button id="fanRingToggle" class="toggle-btn ${kf.fanRing.value ? 'active' : ''}"
                                     data-current="${kf.fanRing.value ? 'true' : 'false'}">
-                                    ${kf.fanRing.value ? '🟢 An' : '⚪ Aus'}
+                                    ${kf.fanRing.value ? '🟢 ' + t('dashboard.on_short') : '⚪ ' + t('dashboard.off_short')}
                                 </button>
                             </span>
                         </div>
                     ` : ''}
                     ${kf.condensatePan ? `
                         <div class="status-item">
-                            <span class="status-label">Wannenheizung</span>
+                            <span class="status-label">${t('dashboard.condensate_pan_heating')}</span>
                             <span class="status-value">
                                 <button id="condensatePanToggle" class="toggle-btn ${kf.condensatePan.value ? 'active' : ''}"
                                     data-current="${kf.condensatePan.value ? 'true' : 'false'}">
-                                    ${kf.condensatePan.value ? '🟢 An' : '⚪ Aus'}
+                                    ${kf.condensatePan.value ? '🟢 ' + t('dashboard.on_short') : '⚪ ' + t('dashboard.off_short')}
                                 </button>
                             </span>
                         </div>
                     ` : ''}
                 `;
             } else if (hasBurner) {
-                title = '🔥 Brenner';
+                title = '🔥 ' + t('dashboard.burner');
                 const modulation = kf.burnerModulation ? kf.burnerModulation.value : 0;
                 const isRunning = modulation > 0;
 
                 content = `
                     <div class="status-item">
-                        <span class="status-label">Status</span>
-                        <span class="status-value">${isRunning ? '🟢 An' : '⚪ Aus'}</span>
+                        <span class="status-label">${t('dashboard.status')}</span>
+                        <span class="status-value">${isRunning ? '🟢 ' + t('dashboard.on_short') : '⚪ ' + t('dashboard.off_short')}</span>
                     </div>
                     <div class="status-item">
-                        <span class="status-label">Modulation</span>
+                        <span class="status-label">${t('dashboard.modulation')}</span>
                         <span class="status-value">${formatValue(kf.burnerModulation)}</span>
                     </div>
                 `;
@@ -874,7 +874,7 @@
 
             // Get circuit name (handle nested structure)
             // Display circuit number starting from 1 (circuitId 0 = Heizkreis 1)
-            let displayName = `Heizkreis ${circuitId + 1}`;
+            let displayName = t('dashboard.circuit_name', circuitId + 1);
             if (circuitName && circuitName.value) {
                 let nameValue = circuitName.value;
                 if (nameValue.name && typeof nameValue.name === 'object') {
@@ -889,38 +889,38 @@
 
             // Program name translations
             const programNames = {
-                'normal': 'Normal',
-                'normalHeating': 'Normal (Heizen)',
-                'normalCooling': 'Normal (Kühlen)',
-                'normalEnergySaving': 'Normal (Energiesparen)',
-                'normalCoolingEnergySaving': 'Normal (Kühlen, Energiesparen)',
-                'comfort': 'Komfort',
-                'comfortHeating': 'Komfort (Heizen)',
-                'comfortCooling': 'Komfort (Kühlen)',
-                'comfortEnergySaving': 'Komfort (Energiesparen)',
-                'comfortCoolingEnergySaving': 'Komfort (Kühlen, Energiesparen)',
-                'reduced': 'Reduziert',
-                'reducedHeating': 'Reduziert (Heizen)',
-                'reducedCooling': 'Reduziert (Kühlen)',
-                'reducedEnergySaving': 'Reduziert (Energiesparen)',
-                'reducedCoolingEnergySaving': 'Reduziert (Kühlen, Energiesparen)',
+                'normal': t('dashboard.program_normal'),
+                'normalHeating': t('dashboard.program_normalHeating'),
+                'normalCooling': t('dashboard.program_normalCooling'),
+                'normalEnergySaving': t('dashboard.program_normalEnergySaving'),
+                'normalCoolingEnergySaving': t('dashboard.program_normalCoolingEnergySaving'),
+                'comfort': t('dashboard.program_comfort'),
+                'comfortHeating': t('dashboard.program_comfortHeating'),
+                'comfortCooling': t('dashboard.program_comfortCooling'),
+                'comfortEnergySaving': t('dashboard.program_comfortEnergySaving'),
+                'comfortCoolingEnergySaving': t('dashboard.program_comfortCoolingEnergySaving'),
+                'reduced': t('dashboard.program_reduced'),
+                'reducedHeating': t('dashboard.program_reducedHeating'),
+                'reducedCooling': t('dashboard.program_reducedCooling'),
+                'reducedEnergySaving': t('dashboard.program_reducedEnergySaving'),
+                'reducedCoolingEnergySaving': t('dashboard.program_reducedCoolingEnergySaving'),
                 'eco': 'Eco',
-                'fixed': 'Fest',
+                'fixed': t('dashboard.program_fixed'),
                 'standby': 'Standby',
-                'frostprotection': 'Frostschutz',
-                'forcedLastFromSchedule': 'Zeitprogramm',
+                'frostprotection': t('dashboard.program_frostprotection'),
+                'forcedLastFromSchedule': t('dashboard.program_forcedLastFromSchedule'),
             };
 
             // Mode translations
             const modeNames = {
-                'heating': 'Heizen',
-                'standby': 'Standby',
-                'cooling': 'Kühlen',
-                'heatingCooling': 'Heizen/Kühlen',
-                'dhw': 'Warmwasser',
-                'dhwAndHeating': 'Warmwasser und Heizen',
-                'forcedReduced': 'Reduziert (Erzwungen)',
-                'forcedNormal': 'Normal (Erzwungen)',
+                'heating': translateMode('heating'),
+                'standby': translateMode('standby'),
+                'cooling': translateMode('cooling'),
+                'heatingCooling': translateMode('heatingCooling'),
+                'dhw': translateMode('dhw'),
+                'dhwAndHeating': t('dashboard.mode_dhw_and_heating_circuit'),
+                'forcedReduced': t('dashboard.mode_forced_reduced'),
+                'forcedNormal': t('dashboard.mode_forced_normal'),
             };
 
             const currentMode = operatingMode ? operatingMode.value : '';
@@ -952,7 +952,7 @@
 
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Betriebsart</span>
+                        <span class="status-label">${t('dashboard.operating_mode_short')}</span>
                         <span class="status-value">
                             <select onchange="changeHeatingMode(${circuitId}, this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                 `;
@@ -971,7 +971,7 @@
             if (operatingProgram) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Aktives Programm</span>
+                        <span class="status-label">${t('dashboard.active_program')}</span>
                         <span class="status-value">${programDisplay}</span>
                     </div>
                 `;
@@ -998,7 +998,7 @@
             if (reducedProg) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Raumtemperatur Reduziert</span>
+                        <span class="status-label">${t('dashboard.room_temperature_reduced')}</span>
                         <span class="status-value">
                             <select onchange="changeRoomTemp(${circuitId}, '${reducedApiName}', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                 `;
@@ -1016,7 +1016,7 @@
             if (normalProg) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Raumtemperatur Normal</span>
+                        <span class="status-label">${t('dashboard.room_temperature_normal')}</span>
                         <span class="status-value">
                             <select onchange="changeRoomTemp(${circuitId}, '${normalApiName}', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                 `;
@@ -1034,7 +1034,7 @@
             if (comfortProg) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Raumtemperatur Komfort</span>
+                        <span class="status-label">${t('dashboard.room_temperature_comfort')}</span>
                         <span class="status-value">
                             <select onchange="changeRoomTemp(${circuitId}, '${comfortApiName}', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                 `;
@@ -1053,7 +1053,7 @@
             if (circuitTemp) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Vorlauftemperatur</span>
+                        <span class="status-label">${t('dashboard.supply_temperature')}</span>
                         <span class="status-value">${formatValue(circuitTemp)}</span>
                     </div>
                 `;
@@ -1063,7 +1063,7 @@
             if (burnerDemandTemp) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Solltemperatur</span>
+                        <span class="status-label">${t('dashboard.target_temperature_short')}</span>
                         <span class="status-value">${formatValue(burnerDemandTemp)}</span>
                     </div>
                 `;
@@ -1073,7 +1073,7 @@
             if (roomTemp) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Raumtemperatur (Ist)</span>
+                        <span class="status-label">${t('dashboard.room_temperature_actual')}</span>
                         <span class="status-value">${formatValue(roomTemp)}</span>
                     </div>
                 `;
@@ -1082,10 +1082,10 @@
             // Circulation pump status (only show if feature has status property)
             if (circuitPump) {
                 const pumpStatus = circuitPump.value;
-                const statusDisplay = pumpStatus === 'on' ? '🔄 Ein' : (pumpStatus === 'off' ? '⚪ Aus' : pumpStatus);
+                const statusDisplay = pumpStatus === 'on' ? '🔄 ' + t('dashboard.on_short') : (pumpStatus === 'off' ? '⚪ ' + t('dashboard.off_short') : pumpStatus);
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Umwälzpumpe</span>
+                        <span class="status-label">${t('dashboard.circulation_pump')}</span>
                         <span class="status-value">${statusDisplay}</span>
                     </div>
                 `;
@@ -1103,12 +1103,12 @@
                     const valueProp = humidityDewpoint.value.value;
 
                     if (statusProp && statusProp.value) {
-                        statusText = statusProp.value === 'connected' ? 'Verbunden' : 'Nicht verbunden';
+                        statusText = statusProp.value === 'connected' ? t('dashboard.connected') : t('dashboard.not_connected');
                         statusClass = statusProp.value === 'connected' ? 'sensor-connected' : 'sensor-disconnected';
                     }
 
                     if (valueProp && valueProp.value) {
-                        const valueText = valueProp.value === 'on' ? 'EIN' : 'AUS';
+                        const valueText = valueProp.value === 'on' ? t('dashboard.on') : t('dashboard.off');
                         statusText += ` (${valueText})`;
                         if (valueProp.value === 'on') {
                             statusClass = 'sensor-active';
@@ -1116,7 +1116,7 @@
                     }
                 } else if (humidityDewpoint.value !== null && humidityDewpoint.value !== undefined) {
                     // Direct value (e.g., "on" or "off")
-                    const valueText = humidityDewpoint.value === 'on' ? 'EIN' : 'AUS';
+                    const valueText = humidityDewpoint.value === 'on' ? t('dashboard.on') : t('dashboard.off');
                     statusText = valueText;
                     statusClass = humidityDewpoint.value === 'on' ? 'sensor-active' : 'sensor-disconnected';
                 }
@@ -1124,7 +1124,7 @@
                 if (statusText) {
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Feuchteanbauschalter</span>
+                            <span class="status-label">${t('dashboard.humidity_switch')}</span>
                             <span class="status-value ${statusClass}">${statusText}</span>
                         </div>
                     `;
@@ -1142,7 +1142,7 @@
                 }
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Heizkurve Neigung</span>
+                        <span class="status-label">${t('dashboard.curve_slope')}</span>
                         <span class="status-value">
                             <select onchange="changeHeatingCurve(${circuitId}, 'slope', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                                 ${slopeOptions.join('')}
@@ -1161,7 +1161,7 @@
                 }
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Heizkurve Niveau</span>
+                        <span class="status-label">${t('dashboard.curve_shift')}</span>
                         <span class="status-value">
                             <select onchange="changeHeatingCurve(${circuitId}, 'shift', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                                 ${shiftOptions.join('')}
@@ -1175,7 +1175,7 @@
             if (supplyTempMax) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Vorlauftemperaturbegrenzung (max)</span>
+                        <span class="status-label">${t('dashboard.supply_temperature_max')}</span>
                         <span class="status-value">
                             <select onchange="changeSupplyTempMax(${circuitId}, this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                 `;
@@ -1198,6 +1198,15 @@
             if (heatingCurveSlope || heatingCurveShift) {
                 html += `
                     <div id="heatingCurveChart_${circuitId}" style="width: 100%; height: 400px; margin-top: 15px;"></div>
+                    <div style="margin-top: 10px; display: flex; gap: 8px; align-items: center; flex-wrap: wrap;">
+                        <select id="heatingCurveAnalysisDays_${circuitId}" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px;">
+                            <option value="7">${t('dashboard.days', 7)}</option>
+                            <option value="14" selected>${t('dashboard.days', 14)}</option>
+                            <option value="30">${t('dashboard.days', 30)}</option>
+                        </select>
+                        <button class="time-btn" onclick="analyzeHeatingCurve(${circuitId})">🔍 ${t('dashboard.curve_analyze')}</button>
+                    </div>
+                    <div id="heatingCurveAnalysis_${circuitId}" style="margin-top: 10px;"></div>
                 `;
             }
 
@@ -1215,10 +1224,10 @@
             const modeMapping = {
                 'eco': 'Eco',
                 'efficient': 'Eco',
-                'efficientWithMinComfort': 'Komfort',
+                'efficientWithMinComfort': t('dashboard.program_comfort'),
                 'balanced': 'Balanced',
-                'comfort': 'Komfort',
-                'off': 'Aus'
+                'comfort': t('dashboard.program_comfort'),
+                'off': t('dashboard.off_short')
             };
 
             // Get current mode and convert to display mode
@@ -1228,37 +1237,37 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>💧 Warmwasser</h2>
+                        <h2>💧 ${t('dashboard.mode_dhw')}</h2>
                     </div>
                     <div class="status-list">
                         ${kf.dhwStatus ? `
             <div class="status-item">
-                <span class="status-label">Betriebsart</span>
+                <span class="status-label">${t('dashboard.operating_mode_short')}</span>
                 <span class="status-value">
                     <select id="dhwModeSelect" onchange="changeDhwMode(this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                         <option value="efficient" ${currentApiMode === 'efficient' ? 'selected' : ''}>Eco</option>
-                        <option value="efficientWithMinComfort" ${currentApiMode === 'efficientWithMinComfort' ? 'selected' : ''}>Komfort</option>
+                        <option value="efficientWithMinComfort" ${currentApiMode === 'efficientWithMinComfort' ? 'selected' : ''}>${t('dashboard.program_comfort')}</option>
                         <option value="balanced" ${currentApiMode === 'balanced' ? 'selected' : ''}>Balanced</option>
-                        <option value="off" ${currentApiMode === 'off' ? 'selected' : ''}>Aus</option>
+                        <option value="off" ${currentApiMode === 'off' ? 'selected' : ''}>${t('dashboard.off_short')}</option>
                     </select>
                 </span>
             </div>
                         ` : ''}
                         ${kf.dhwTemp ? `
             <div class="status-item">
-                <span class="status-label">Ist-Temperatur</span>
+                <span class="status-label">${t('dashboard.actual_temperature')}</span>
                 <span class="status-value">${formatValue(kf.dhwTemp)}</span>
             </div>
                         ` : ''}
                         ${kf.dhwCylinderMiddleTemp ? `
             <div class="status-item">
-                <span class="status-label">Mittlere Speichertemperatur</span>
+                <span class="status-label">${t('dashboard.cylinder_middle_temperature')}</span>
                 <span class="status-value">${formatValue(kf.dhwCylinderMiddleTemp)}</span>
             </div>
                         ` : ''}
                         ${kf.dhwTarget ? `
             <div class="status-item">
-                <span class="status-label">Soll-Temperatur</span>
+                <span class="status-label">${t('dashboard.target_temperature')}</span>
                 <span class="status-value">
                     <select id="dhwTargetSelect" onchange="changeDhwTemperature(this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                         ${Array.from({length: 51}, (_, i) => i + 10).map(temp => `
@@ -1270,7 +1279,7 @@
                         ` : ''}
                         ${kf.dhwTarget2 ? `
             <div class="status-item">
-                <span class="status-label">Soll-Temperatur 2</span>
+                <span class="status-label">${t('dashboard.target_temperature2')}</span>
                 <span class="status-value">
                     <select id="dhwTarget2Select" onchange="changeDhwTemperature2(this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                         ${Array.from({length: 51}, (_, i) => i + 10).map(temp => `
@@ -1282,7 +1291,7 @@
                         ` : ''}
                         ${kf.dhwHysteresisSwitchOn ? `
             <div class="status-item">
-                <span class="status-label">Hysterese Ein</span>
+                <span class="status-label">${t('dashboard.hysteresis_on')}</span>
                 <span class="status-value">
                     <select id="dhwHysteresisOnSelect" onchange="changeDhwHysteresis('on', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                         ${Array.from({length: 19}, (_, i) => 1 + (i * 0.5)).map(val => `
@@ -1294,7 +1303,7 @@
                         ` : ''}
                         ${kf.dhwHysteresisSwitchOff ? `
             <div class="status-item">
-                <span class="status-label">Hysterese Aus</span>
+                <span class="status-label">${t('dashboard.hysteresis_off')}</span>
                 <span class="status-value">
                     <select id="dhwHysteresisOffSelect" onchange="changeDhwHysteresis('off', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                         <option value="0" ${kf.dhwHysteresisSwitchOff.value === 0 ? 'selected' : ''}>0K</option>
@@ -1310,7 +1319,7 @@
                     </div>
                     <div style="margin-top: 15px; padding: 0 15px 15px 15px;">
                         <button onclick="startOneTimeCharge()" style="width: 100%; padding: 10px; background: rgba(59, 130, 246, 0.8); color: white; border: none; border-radius: 4px; cursor: pointer; font-weight: 600; font-size: 14px;">
-                            🔥 Einmalige Warmwassererwärmung starten
+                            🔥 ${t('dashboard.dhw_one_time_charge')}
                         </button>
                     </div>
                 </div>
@@ -1346,7 +1355,7 @@
                 }
                 settings += `
                     <div class="status-item">
-                        <span class="status-label">Neigung</span>
+                        <span class="status-label">${t('dashboard.slope')}</span>
                         <span class="status-value">
                             <select id="heatingCurveSlopeSelect" onchange="changeHeatingCurve('slope', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                                 ${slopeOptions.join('')}
@@ -1363,7 +1372,7 @@
                 }
                 settings += `
                     <div class="status-item">
-                        <span class="status-label">Niveau (Verschiebung)</span>
+                        <span class="status-label">${t('dashboard.shift')}</span>
                         <span class="status-value">
                             <select id="heatingCurveShiftSelect" onchange="changeHeatingCurve('shift', this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                                 ${shiftOptions.join('')}
@@ -1380,7 +1389,7 @@
                 }
                 settings += `
                     <div class="status-item">
-                        <span class="status-label">Vorlauftemperaturbegrenzung (max)</span>
+                        <span class="status-label">${t('dashboard.supply_temperature_max')}</span>
                         <span class="status-value">
                             <select id="supplyTempMaxSelect" onchange="changeSupplyTempMax(this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
                                 ${maxTempOptions.join('')}
@@ -1392,7 +1401,7 @@
             if (kf.supplyTempMin) {
                 settings += `
                     <div class="status-item">
-                        <span class="status-label">Vorlauftemperatur (min)</span>
+                        <span class="status-label">${t('dashboard.supply_temperature_min')}</span>
                         <span class="status-value">${formatValue(kf.supplyTempMin)}</span>
                     </div>
                 `;
@@ -1401,7 +1410,7 @@
             return `
                 <div class="card wide">
                     <div class="card-header">
-                        <h2>📐 Heizkurve</h2>
+                        <h2>📐 ${t('dashboard.heating_curve')}</h2>
                     </div>
                     <div class="status-list" style="margin-bottom: 15px;">
                         ${settings}
@@ -1433,7 +1442,7 @@
             // Check if D3 is loaded
             if (typeof d3 === 'undefined') {
                 console.error('❌ D3.js is not loaded');
-                chartElement.innerHTML = '<div style="color: #ef4444; padding: 20px; text-align: center;">D3.js konnte nicht geladen werden.</div>';
+                chartElement.innerHTML = '<div style="color: #ef4444; padding: 20px; text-align: center;">' + t('dashboard.d3_missing') + '</div>';
                 return;
             }
             console.log('✅ D3.js is loaded, version:', d3.version);
@@ -1569,7 +1578,7 @@
                     .attr('fill', '#ef4444')
                     .attr('font-size', '11px')
                     .attr('font-weight', 'bold')
-                    .text('Max: ' + maxSupply + '°C');
+                    .text(t('dashboard.curve_max', maxSupply));
             }
 
             // Draw min supply temp reference line
@@ -1591,7 +1600,7 @@
                     .attr('fill', '#3b82f6')
                     .attr('font-size', '11px')
                     .attr('font-weight', 'bold')
-                    .text('Min: ' + minSupply + '°C');
+                    .text(t('dashboard.curve_min', minSupply));
             }
 
             // Draw current point if available
@@ -1610,7 +1619,7 @@
                     .attr('fill', '#f59e0b')
                     .attr('font-size', '12px')
                     .attr('font-weight', 'bold')
-                    .text('Aktuell: ' + currentOutside.toFixed(1) + '°C / ' + currentSupply.toFixed(1) + '°C');
+                    .text(t('dashboard.curve_current', currentOutside.toFixed(1), currentSupply.toFixed(1)));
             }
 
             // X-Axis
@@ -1625,7 +1634,7 @@
                 .attr('text-anchor', 'middle')
                 .attr('fill', '#e0e0e0')
                 .attr('font-size', '14px')
-                .text('Außentemperatur (°C)');
+                .text(t('dashboard.outside_temperature') + ' (°C)');
 
             // Y-Axis
             svg.append('g')
@@ -1639,7 +1648,7 @@
                 .attr('text-anchor', 'middle')
                 .attr('fill', '#e0e0e0')
                 .attr('font-size', '14px')
-                .text('Vorlauftemperatur (°C)');
+                .text(t('dashboard.supply_temperature') + ' (°C)');
 
             // Formula text - Viessmann official formula (simplified display)
             const RTSoll = roomTempSetpoint || 20;
@@ -1650,7 +1659,7 @@
                 .attr('fill', '#667eea')
                 .attr('font-size', '11px')
                 .attr('font-family', 'monospace')
-                .text('VL = ' + RTSoll + ' ' + shiftText + ' - ' + slope.toFixed(1) + ' × DAR × (1.4347 + 0.021×DAR + 247.9×10⁻⁶×DAR²)   mit DAR = AT - ' + RTSoll);
+                .text(t('dashboard.curve_formula', RTSoll, shiftText, slope.toFixed(1)));
 
             // Add hover functionality
             // Create tooltip
@@ -1708,8 +1717,8 @@
                     tooltip
                         .style('opacity', 1)
                         .html(`
-                            <strong>Außentemperatur:</strong> ${outsideTemp.toFixed(1)}°C<br>
-                            <strong>Vorlauftemperatur:</strong> ${supplyTemp.toFixed(1)}°C
+                            <strong>${t('dashboard.outside_temperature')}:</strong> ${outsideTemp.toFixed(1)}°C<br>
+                            <strong>${t('dashboard.supply_temperature')}:</strong> ${supplyTemp.toFixed(1)}°C
                         `)
                         .style('left', (event.pageX + 15) + 'px')
                         .style('top', (event.pageY - 15) + 'px');
@@ -1730,7 +1739,7 @@
             if (kf.powerConsumptionToday) {
                 consumption += `
                     <div class="status-item">
-                        <span class="status-label">Stromverbrauch heute</span>
+                        <span class="status-label">${t('dashboard.power_consumption_today')}</span>
                         <span class="status-value">${formatValue(kf.powerConsumptionToday)}</span>
                     </div>
                 `;
@@ -1738,7 +1747,7 @@
             if (kf.powerConsumptionHeatingToday) {
                 consumption += `
                     <div class="status-item">
-                        <span class="status-label">Heizung-Stromverbrauch heute</span>
+                        <span class="status-label">${t('dashboard.power_consumption_heating_today')}</span>
                         <span class="status-value">${formatValue(kf.powerConsumptionHeatingToday)}</span>
                     </div>
                 `;
@@ -1746,7 +1755,7 @@
             if (kf.powerConsumptionDhwToday) {
                 consumption += `
                     <div class="status-item">
-                        <span class="status-label">WW-Stromverbrauch heute</span>
+                        <span class="status-label">${t('dashboard.power_consumption_dhw_today')}</span>
                         <span class="status-value">${formatValue(kf.powerConsumptionDhwToday)}</span>
                     </div>
                 `;
@@ -1757,7 +1766,7 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>📊 Verbrauch heute</h2>
+                        <h2>📊 ${t('dashboard.consumption_today')}</h2>
                     </div>
                     <div class="status-list">
                         ${consumption}
@@ -1774,7 +1783,7 @@
             if (kf.volumetricFlow) {
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Volumenstrom</span>
+                        <span class="status-label">${t('dashboard.volumetric_flow')}</span>
                         <span class="status-value">${formatNum(kf.volumetricFlow.value, 0)} ${kf.volumetricFlow.unit || 'l/h'}</span>
                     </div>
                 `;
@@ -1783,7 +1792,7 @@
             if (kf.pressure) {
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Druck</span>
+                        <span class="status-label">${t('dashboard.refrigerant_pressure')}</span>
                         <span class="status-value">${formatNum(kf.pressure.value)} ${kf.pressure.unit || 'bar'}</span>
                     </div>
                 `;
@@ -1792,7 +1801,7 @@
             if (kf.pumpInternal) {
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Interne Pumpe</span>
+                        <span class="status-label">${t('dashboard.refrigerant_internal_pump')}</span>
                         <span class="status-value">${formatNum(kf.pumpInternal.value, 0)} ${kf.pumpInternal.unit || '%'}</span>
                     </div>
                 `;
@@ -1801,7 +1810,7 @@
             if (kf.fan0) {
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Lüfter 1</span>
+                        <span class="status-label">${t('dashboard.refrigerant_fan1')}</span>
                         <span class="status-value">${formatNum(kf.fan0.value, 0)} ${kf.fan0.unit || '%'}</span>
                     </div>
                 `;
@@ -1810,7 +1819,7 @@
             if (kf.fan1) {
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Lüfter 2</span>
+                        <span class="status-label">${t('dashboard.refrigerant_fan2')}</span>
                         <span class="status-value">${formatNum(kf.fan1.value, 0)} ${kf.fan1.unit || '%'}</span>
                     </div>
                 `;
@@ -1819,21 +1828,21 @@
             // 4/3-Way Valve Position
             if (kf.fourWayValve) {
                 const valveLabels = {
-					'climateCircuitTwoDefrostDomesticHotWater': 'Warmwasser',
-                    'domesticHotWater': 'Warmwasser',
-                    'heating': 'Heizen',
-                    'cooling': 'Kühlen',
-                    'defrost': 'Abtauen',
+					'climateCircuitTwoDefrostDomesticHotWater': translateMode('dhw'),
+                    'domesticHotWater': translateMode('dhw'),
+                    'heating': translateMode('heating'),
+                    'cooling': translateMode('cooling'),
+                    'defrost': t('dashboard.valve_defrost'),
                     'standby': 'Standby',
-                    'off': 'Aus',
-                    'climateCircuitOne': 'Heiz-/Kühlkreis 1',
-                    'climatCircuitTwoDefrost': 'Integrierter Pufferspeicher'
+                    'off': t('dashboard.off_short'),
+                    'climateCircuitOne': t('dashboard.valve_climate_circuit_one'),
+                    'climatCircuitTwoDefrost': t('dashboard.valve_integrated_buffer')
                 };
                 const valveValue = kf.fourWayValve.value;
                 const valveDisplay = valveLabels[valveValue] || valveValue;
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">4/3-Wege-Ventil</span>
+                        <span class="status-label">${t('dashboard.four_way_valve')}</span>
                         <span class="status-value">${valveDisplay}</span>
                     </div>
                 `;
@@ -1845,7 +1854,7 @@
                 const isActive = heaterStatus !== 'off' && heaterStatus !== 'standby';
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Zusatzheizung</span>
+                        <span class="status-label">${t('dashboard.secondary_heater')}</span>
                         <span class="status-value">${isActive ? '🟢' : '⚪'} ${heaterStatus}</span>
                     </div>
                 `;
@@ -1855,14 +1864,14 @@
             if (kf.noiseReductionExists) {
                 const currentApiMode = kf.noiseReductionMode ? kf.noiseReductionMode.value : 'notReduced';
                 const modeLabels = {
-                    'notReduced': 'Aus',
-                    'slightlyReduced': 'Leicht reduziert',
-                    'maxReduced': 'Maximal reduziert'
+                    'notReduced': t('dashboard.off_short'),
+                    'slightlyReduced': t('dashboard.noise_slightly_reduced'),
+                    'maxReduced': t('dashboard.noise_max_reduced')
                 };
                 const modeLabel = modeLabels[currentApiMode] || currentApiMode;
                 sensors += `
                     <div class="status-item">
-                        <span class="status-label">Geräuschreduzierung</span>
+                        <span class="status-label">${t('dashboard.noise_reduction')}</span>
                         <span class="status-value">${modeLabel}</span>
                     </div>
                 `;
@@ -1873,7 +1882,7 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>🔧 Weitere Komponenten</h2>
+                        <h2>🔧 ${t('dashboard.additional_components')}</h2>
                     </div>
                     <div class="status-list">
                         ${sensors}
@@ -1964,7 +1973,7 @@
             if (elLow) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Stromtarif Niedrig</span>
+                        <span class="status-label">${t('dashboard.hybrid_electricity_low')}</span>
                         <span class="status-value">${elLow} EUR/kWh</span>
                     </div>
                 `;
@@ -1975,7 +1984,7 @@
             if (elNorm) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Stromtarif Normal</span>
+                        <span class="status-label">${t('dashboard.hybrid_electricity_normal')}</span>
                         <span class="status-value">${elNorm} EUR/kWh</span>
                     </div>
                 `;
@@ -1986,7 +1995,7 @@
             if (hpFactor) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Primärenergiefaktor WP</span>
+                        <span class="status-label">${t('dashboard.hybrid_factor_heat_pump')}</span>
                         <span class="status-value">${hpFactor}</span>
                     </div>
                 `;
@@ -1997,7 +2006,7 @@
             if (fosFactor) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Primärenergiefaktor Fossil</span>
+                        <span class="status-label">${t('dashboard.hybrid_factor_fossil')}</span>
                         <span class="status-value">${fosFactor}</span>
                     </div>
                 `;
@@ -2008,7 +2017,7 @@
             if (fosPriceLow) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Fossil Tarif Niedrig</span>
+                        <span class="status-label">${t('dashboard.hybrid_fossil_low')}</span>
                         <span class="status-value">${fosPriceLow} EUR/kWh</span>
                     </div>
                 `;
@@ -2019,7 +2028,7 @@
             if (fosPriceNorm) {
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Fossil Tarif Normal</span>
+                        <span class="status-label">${t('dashboard.hybrid_fossil_normal')}</span>
                         <span class="status-value">${fosPriceNorm} EUR/kWh</span>
                     </div>
                 `;
@@ -2027,16 +2036,16 @@
 
             // Regelstrategie (nur aus gespeicherten Einstellungen, nicht aus API)
             const strategyMap = {
-                'constant': 'Konstanttemperatur',
-                'ecological': 'Ökologisch',
-                'economic': 'Ökonomisch'
+                'constant': t('dashboard.hybrid_strategy_constant'),
+                'ecological': t('dashboard.hybrid_strategy_ecological'),
+                'economic': t('dashboard.hybrid_strategy_economic')
             };
 
             if (saved.controlStrategy) {
                 const strategy = strategyMap[saved.controlStrategy] || saved.controlStrategy;
                 hybrid += `
                     <div class="status-item">
-                        <span class="status-label">Regelstrategie</span>
+                        <span class="status-label">${t('dashboard.hybrid_strategy')}</span>
                         <span class="status-value">${strategy}</span>
                     </div>
                 `;
@@ -2065,7 +2074,7 @@
             if (kf.evaporatorTemp && isValidNumericValue(kf.evaporatorTemp)) {
                 circuit += `
                     <div class="status-item">
-                        <span class="status-label">Verdampfer</span>
+                        <span class="status-label">${t('dashboard.evaporator')}</span>
                         <span class="status-value">${formatValue(kf.evaporatorTemp)}</span>
                     </div>
                 `;
@@ -2074,7 +2083,7 @@
             if (kf.evaporatorOverheat && isValidNumericValue(kf.evaporatorOverheat)) {
                 circuit += `
                     <div class="status-item">
-                        <span class="status-label">Verdampfer Überhitzung</span>
+                        <span class="status-label">${t('dashboard.refrigerant_evaporator_overheat')}</span>
                         <span class="status-value">${formatValue(kf.evaporatorOverheat)}</span>
                     </div>
                 `;
@@ -2083,7 +2092,7 @@
             if (kf.condensorTemp && isValidNumericValue(kf.condensorTemp)) {
                 circuit += `
                     <div class="status-item">
-                        <span class="status-label">Verflüssiger</span>
+                        <span class="status-label">${t('dashboard.refrigerant_condenser')}</span>
                         <span class="status-value">${formatValue(kf.condensorTemp)}</span>
                     </div>
                 `;
@@ -2092,7 +2101,7 @@
             if (kf.economizerTemp && isValidNumericValue(kf.economizerTemp)) {
                 circuit += `
                     <div class="status-item">
-                        <span class="status-label">Economizer</span>
+                        <span class="status-label">${t('dashboard.refrigerant_economizer')}</span>
                         <span class="status-value">${formatValue(kf.economizerTemp)}</span>
                     </div>
                 `;
@@ -2101,7 +2110,7 @@
             if (kf.inverterTemp && isValidNumericValue(kf.inverterTemp)) {
                 circuit += `
                     <div class="status-item">
-                        <span class="status-label">Wechselrichter</span>
+                        <span class="status-label">${t('dashboard.inverter')}</span>
                         <span class="status-value">${formatValue(kf.inverterTemp)}</span>
                     </div>
                 `;
@@ -2110,7 +2119,7 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>🔧 Kältekreislauf</h2>
+                        <h2>🔧 ${t('dashboard.refrigerant_circuit')}</h2>
                     </div>
                     <div class="status-list">
                         ${circuit}
@@ -2125,22 +2134,22 @@
             if (kf.operatingMode) {
                 // Map API modes to German labels
                 const modeLabels = {
-                    'heating': 'Heizen',
-                    'standby': 'Standby',
-                    'cooling': 'Kühlen',
-                    'heatingCooling': 'Heizen/Kühlen'
+                    'heating': translateMode('heating'),
+                    'standby': translateMode('standby'),
+                    'cooling': translateMode('cooling'),
+                    'heatingCooling': translateMode('heatingCooling')
                 };
                 const currentMode = kf.operatingMode.value;
 
                 status += `
                     <div class="status-item">
-                        <span class="status-label">Betriebsmodus</span>
+                        <span class="status-label">${t('dashboard.operating_mode')}</span>
                         <span class="status-value">
                             <select id="heatingModeSelect" onchange="changeHeatingMode(this.value)" style="background: rgba(255,255,255,0.1); color: #fff; border: 1px solid rgba(255,255,255,0.2); border-radius: 4px; padding: 4px 8px; cursor: pointer;">
-                                <option value="heating" ${currentMode === 'heating' ? 'selected' : ''}>Heizen</option>
+                                <option value="heating" ${currentMode === 'heating' ? 'selected' : ''}>${translateMode('heating')}</option>
                                 <option value="standby" ${currentMode === 'standby' ? 'selected' : ''}>Standby</option>
-                                <option value="cooling" ${currentMode === 'cooling' ? 'selected' : ''}>Kühlen</option>
-                                <option value="heatingCooling" ${currentMode === 'heatingCooling' ? 'selected' : ''}>Heizen/Kühlen</option>
+                                <option value="cooling" ${currentMode === 'cooling' ? 'selected' : ''}>${translateMode('cooling')}</option>
+                                <option value="heatingCooling" ${currentMode === 'heatingCooling' ? 'selected' : ''}>${translateMode('heatingCooling')}</option>
                             </select>
                         </span>
                     </div>
@@ -2152,8 +2161,8 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>⚙️ Systemstatus</h2>
-                        <span class="badge badge-success">Normal</span>
+                        <h2>⚙️ ${t('dashboard.system_status')}</h2>
+                        <span class="badge badge-success">${t('dashboard.mode_normal')}</span>
                     </div>
                     <div class="status-list">
                         ${status}
@@ -2173,7 +2182,7 @@
                 devVariant = kf.deviceVariant.value;
                 info += `
                     <div class="status-item">
-                        <span class="status-label">Modell</span>
+                        <span class="status-label">${t('dashboard.model')}</span>
                         <span class="status-value">${devVariant}</span>
                     </div>
                 `;
@@ -2182,7 +2191,7 @@
             if (kf.deviceType) {
                 info += `
                     <div class="status-item">
-                        <span class="status-label">Typ</span>
+                        <span class="status-label">${t('dashboard.type')}</span>
                         <span class="status-value">${kf.deviceType.value}</span>
                     </div>
                 `;
@@ -2191,7 +2200,7 @@
             if (kf.deviceSerial) {
                 info += `
                     <div class="status-item">
-                        <span class="status-label">Seriennummer</span>
+                        <span class="status-label">${t('dashboard.serial_number')}</span>
                         <span class="status-value" style="font-family: monospace;">${kf.deviceSerial.value}</span>
                     </div>
                 `;
@@ -2207,7 +2216,7 @@
                 if (wifiStrength > -115) {
                     info += `
                         <div class="status-item">
-                            <span class="status-label">WiFi Pegel</span>
+                            <span class="status-label">${t('dashboard.wifi_level')}</span>
                             <span class="status-value" style="font-family: monospace;">${wifiStrength} dBm</span>
                         </div>
                     `;
@@ -2218,7 +2227,7 @@
             if (kf.copTotal || kf.copHeating || kf.copDhw || kf.copCooling || kf.scop || kf.scopHeating || kf.scopDhw || kf.seerCooling) {
                 info += `
                     <div class="status-item" style="grid-column: 1 / -1; border-top: 1px solid rgba(255,255,255,0.1); margin-top: 10px; padding-top: 10px;">
-                        <span class="status-label" style="font-weight: 600; color: #667eea;">interne Geräte-JAZ</span>
+                        <span class="status-label" style="font-weight: 600; color: #667eea;">${t('dashboard.internal_spf')}</span>
                     </div>
                 `;
 
@@ -2227,7 +2236,7 @@
                     const value = kf.copTotal || kf.scop;
                     info += `
                         <div class="status-item">
-            <span class="status-label">JAZ Gesamt</span>
+            <span class="status-label">${t('dashboard.spf_total')}</span>
             <span class="status-value">${formatNum(value.value)}</span>
                         </div>
                     `;
@@ -2238,7 +2247,7 @@
                     const value = kf.copHeating || kf.scopHeating;
                     info += `
                         <div class="status-item">
-            <span class="status-label">JAZ Heizen</span>
+            <span class="status-label">${t('dashboard.spf_heating')}</span>
             <span class="status-value">${formatNum(value.value)}</span>
                         </div>
                     `;
@@ -2249,7 +2258,7 @@
                     const value = kf.copDhw || kf.scopDhw;
                     info += `
                         <div class="status-item">
-            <span class="status-label">JAZ Warmwasser</span>
+            <span class="status-label">${t('dashboard.spf_dhw')}</span>
             <span class="status-value">${formatNum(value.value)}</span>
                         </div>
                     `;
@@ -2260,7 +2269,7 @@
                     const value = kf.copCooling || kf.seerCooling;
                     info += `
                         <div class="status-item">
-            <span class="status-label">JAZ Kühlen</span>
+            <span class="status-label">${t('dashboard.spf_cooling')}</span>
             <span class="status-value">${formatNum(value.value)}</span>
                         </div>
                     `;
@@ -2282,7 +2291,7 @@
                         // This is the load class data (heating.compressors.X.statistics with loadClassOne, etc.)
                         html += `
             <div class="status-item" style="grid-column: 1 / -1; border-top: 1px solid rgba(255,255,255,0.1); margin-top: 10px; padding-top: 10px;">
-                <span class="status-label" style="font-weight: 600; color: #667eea;">Aufteilung Kompressor-Lastklassen${compressorIndex > 0 ? ' - Kompressor ' + (compressorIndex + 1) : ''}</span>
+                <span class="status-label" style="font-weight: 600; color: #667eea;">${t('dashboard.load_classes')}${compressorIndex > 0 ? ' - ' + t('dashboard.compressor_number', compressorIndex + 1) : ''}</span>
             </div>
                         `;
 
@@ -2323,7 +2332,7 @@
                 if (value !== null) {
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Stunden Lastklasse ${['eins', 'zwei', 'drei', 'vier', 'fünf'][index]}</span>
+                            <span class="status-label">${t('dashboard.load_class_hours', index + 1)}</span>
                             <span class="status-value">${value} h</span>
                         </div>
                     `;
@@ -2356,7 +2365,7 @@
             return `
                 <div class="card">
                     <div class="card-header">
-                        <h2>ℹ️ Geräteinformationen</h2>
+                        <h2>ℹ️ ${t('dashboard.device_info')}</h2>
                     </div>
                     <div class="status-list">
                         ${info}
@@ -14,7 +14,7 @@
             html += `
                 <div class="card">
                     <div class="card-header">
-                        <h2>🌡️ Raumtemperatur</h2>
+                        <h2>🌡️ ${t('dashboard.room_temperature')}</h2>
                     </div>
                     <div class="status-list">
             `;
@@ -22,7 +22,7 @@
             if (kf.deviceTemperature && isValidNumericValue(kf.deviceTemperature)) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Ist-Temperatur</span>
+                        <span class="status-label">${t('dashboard.actual_temperature')}</span>
                         <span class="status-value">${formatNum(kf.deviceTemperature.value)} ${kf.deviceTemperature.unit || '°C'}</span>
                     </div>
                 `;
@@ -31,7 +31,7 @@
             if (kf.trvTemperature && isValidNumericValue(kf.trvTemperature)) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Soll-Temperatur</span>
+                        <span class="status-label">${t('dashboard.target_temperature')}</span>
                         <span class="status-value">${formatNum(kf.trvTemperature.value)} ${kf.trvTemperature.unit || '°C'}</span>
                     </div>
                 `;
@@ -43,10 +43,10 @@
                 if (valveData && valveData.position && typeof valveData.position.value === 'number') {
                     const valvePos = valveData.position.value;
                     const valveUnit = valveData.position.unit || '%';
-                    const valveStatus = valvePos > 0 ? '🟢 Offen' : '⚪ Geschlossen';
+                    const valveStatus = valvePos > 0 ? '🟢 ' + t('dashboard.open') : '⚪ ' + t('dashboard.closed');
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Ventilstellung</span>
+                            <span class="status-label">${t('dashboard.valve_position')}</span>
                             <span class="status-value">${valveStatus} (${formatNum(valvePos)} ${valveUnit})</span>
                         </div>
                     `;
@@ -62,7 +62,7 @@
             html += `
                 <div class="card">
                     <div class="card-header">
-                        <h2>⚙️ Einstellungen & Status</h2>
+                        <h2>⚙️ ${t('dashboard.settings_and_status')}</h2>
                     </div>
                     <div class="status-list">
             `;
@@ -72,10 +72,10 @@
                 const lockData = kf.trvChildLock.value;
                 if (lockData && lockData.status) {
                     const lockStatus = lockData.status.value;
-                    const lockText = lockStatus === 'active' ? '🔒 AN' : '🔓 AUS';
+                    const lockText = lockStatus === 'active' ? '🔒 ' + t('dashboard.on') : '🔓 ' + t('dashboard.off');
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Kindersicherung</span>
+                            <span class="status-label">${t('dashboard.child_lock')}</span>
                             <span class="status-value">${lockText}</span>
                         </div>
                     `;
@@ -89,8 +89,8 @@
                     const mountingActive = mountingData.active.value;
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Montagemodus</span>
-                            <span class="status-value">${mountingActive ? 'Aktiv' : 'Inaktiv'}</span>
+                            <span class="status-label">${t('dashboard.mounting_mode')}</span>
+                            <span class="status-value">${mountingActive ? t('dashboard.active') : t('dashboard.inactive')}</span>
                         </div>
                     `;
                 }
@@ -117,7 +117,7 @@
             html += `
                 <div class="card wide">
                     <div class="card-header">
-                        <h2>📊 Sensordaten</h2>
+                        <h2>📊 ${t('dashboard.sensor_data')}</h2>
                     </div>
                     <div class="temp-grid">
             `;
@@ -125,7 +125,7 @@
             if (kf.deviceTemperature && isValidNumericValue(kf.deviceTemperature)) {
                 html += `
                     <div class="temp-item">
-                        <span class="temp-label">Temperatur</span>
+                        <span class="temp-label">${t('dashboard.temperature')}</span>
                         <div>
                             <span class="temp-value">${formatNum(kf.deviceTemperature.value)}</span>
                             <span class="temp-unit">${kf.deviceTemperature.unit || '°C'}</span>
@@ -137,7 +137,7 @@
             if (kf.deviceHumidity && isValidNumericValue(kf.deviceHumidity)) {
                 html += `
                     <div class="temp-item">
-                        <span class="temp-label">Luftfeuchtigkeit</span>
+                        <span class="temp-label">${t('dashboard.humidity')}</span>
                         <div>
                             <span class="temp-value">${formatNum(kf.deviceHumidity.value)}</span>
                             <span class="temp-unit">${kf.deviceHumidity.unit || '%'}</span>
@@ -167,15 +167,12 @@
             html += `
                 <div class="card">
                     <div class="card-header">
-                        <h2>🏠 Betriebsmodus</h2>
+                        <h2>🏠 ${t('dashboard.operating_mode')}</h2>
             `;
 
             if (kf.fhtOperatingMode && kf.fhtOperatingMode.value) {
                 const mode = kf.fhtOperatingMode.value;
-                const modeText = mode === 'heating' ? 'Heizen' :
-                                 mode === 'cooling' ? 'Kühlen' :
-                                 mode === 'heatingCooling' ? 'Heizen/Kühlen' :
-                                 'Standby';
+                const modeText = translateMode(mode === 'heating' || mode === 'cooling' || mode === 'heatingCooling' ? mode : 'standby');
                 const badgeClass = mode === 'heating' ? 'badge-info' :
                                    mode === 'cooling' ? 'badge-success' :
                                    mode === 'heatingCooling' ? 'badge-primary' :
@@ -191,7 +188,7 @@
             if (kf.fhtSupplyTemp && isValidNumericValue(kf.fhtSupplyTemp)) {
                 html += `
                     <div class="status-item">
-                        <span class="status-label">Vorlauftemperatur</span>
+                        <span class="status-label">${t('dashboard.supply_temperature')}</span>
                         <span class="status-value">${formatNum(kf.fhtSupplyTemp.value)} ${kf.fhtSupplyTemp.unit || '°C'}</span>
                     </div>
                 `;
@@ -203,8 +200,8 @@
                 if (heatingData && heatingData.active && heatingData.active.value !== undefined) {
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Heizen</span>
-                            <span class="status-value">${heatingData.active.value ? '🟢 Aktiv' : '⚪ Inaktiv'}</span>
+                            <span class="status-label">${t('dashboard.mode_heating')}</span>
+                            <span class="status-value">${heatingData.active.value ? '🟢 ' + t('dashboard.active') : '⚪ ' + t('dashboard.inactive')}</span>
                         </div>
                     `;
                 }
@@ -216,8 +213,8 @@
                 if (coolingData && coolingData.active && coolingData.active.value !== undefined) {
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Kühlen</span>
-                            <span class="status-value">${coolingData.active.value ? '🟢 Aktiv' : '⚪ Inaktiv'}</span>
+                            <span class="status-label">${t('dashboard.mode_cooling')}</span>
+                            <span class="status-value">${coolingData.active.value ? '🟢 ' + t('dashboard.active') : '⚪ ' + t('dashboard.inactive')}</span>
                         </div>
                     `;
                 }
@@ -244,7 +241,7 @@
             html += `
                 <div class="card wide">
                     <div class="card-header">
-                        <h2>📡 Netzwerk-Informationen</h2>
+                        <h2>📡 ${t('dashboard.network_info')}</h2>
                     </div>
                     <div class="status-list">
             `;
@@ -269,7 +266,7 @@
 
             html += `
                         <div class="status-item">
-                            <span class="status-label">Gerätetyp</span>
+                            <span class="status-label">${t('dashboard.device_type')}</span>
                             <span class="status-value">Zigbee Repeater</span>
                         </div>
                     </div>
@@ -285,7 +282,7 @@
             let html = `
                 <div class="card">
                     <div class="card-header">
-                        <h2>🔋 Geräteinformationen</h2>
+                        <h2>🔋 ${t('dashboard.device_info')}</h2>
                     </div>
                     <div class="status-list">
             `;
@@ -300,7 +297,7 @@
                     const batteryIcon = battery >= 70 ? '🔋' : battery >= 30 ? '🪫' : '🔴';
                     html += `
                         <div class="status-item">
-                            <span class="status-label">Batteriestand</span>
+                            <span class="status-label">${t('dashboard.battery_level')}</span>
                             <span class="status-value" style="color: ${batteryColor}; font-weight: bold;">
                                 ${batteryIcon} ${formatNum(battery)} ${batteryUnit}
                             </span>
@@ -9,6 +9,8 @@ let availableDataFields = new Set();
 let selectedFields = new Set();
 let cachedTemperatureData = null;  // Store current data for re-rendering
 let savedFieldsLoaded = false;     // Flag: persisted fields loaded once from localStorage
+let overlayDeviceIds = new Set();  // Further logged devices of the installation drawn into the chart
+let overlayDeviceKey = null;       // Device the overlay selection belongs to
 
 // User preferences for chart display (previously auto-determined based on time range)
 let nullconnect = false;  // Connect points across null values
@@ -59,7 +61,7 @@ async function initTemperatureChart() {
         chartSection.className = 'temperature-chart-container';
         chartSection.innerHTML = `
             <div class="chart-header">
-                <h2>📊 Temperatur- und Sensor-Verlauf</h2>
+                <h2>📊 ${t('dashboard.temperature_chart_title')}</h2>
                 <div class="chart-controls">
                     <div class="time-range-selector">
                         <button class='time-btn ${ (currentTimeRange == "1h") ? 'active' : ''}' data-range='1h'>1h</button>
@@ -72,14 +74,15 @@ async function initTemperatureChart() {
                         <button class='time-btn ${ (currentTimeRange == "30d") ? 'active' : ''}' data-range='30d'>30d</button>
                         <button class='time-btn ${ (currentTimeRange == "90d") ? 'active' : ''}' data-range='90d'>90d</button>
                         <div style="display: inline-flex; align-items: center; gap: 8px; margin-left: 10px;">
-                            <label for="temperatureCustomDatePicker" style="color: #a0a0b0; font-size: 13px; white-space: nowrap;">📅 Bestimmter Tag:</label>
+                            <label for="temperatureCustomDatePicker" style="color: #a0a0b0; font-size: 13px; white-space: nowrap;">📅 ${t('dashboard.specific_day')}</label>
                             <input type="date" id="temperatureCustomDatePicker" class="custom-date-input" style="padding: 6px 10px; background: rgba(255,255,255,0.05); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px; color: #fff; font-size: 13px; cursor: pointer;">
                         </div>
                     </div>
                 </div>
             </div>
+            <div class="chart-device-overlay" id="temperature-chart-devices" style="display: none;"></div>
             <div class="chart-filters" id="temperature-chart-filters">
-                <div class="filters-loading">Lade verfügbare Sensoren...</div>
+                <div class="filters-loading">${t('dashboard.sensors_loading')}</div>
             </div>
             <div id="temperature-chart" style="width: 100%; height: 600px; margin-top: 20px;"></div>
         `;
@@ -89,44 +92,44 @@ async function initTemperatureChart() {
             <div class="chart-display-options" style="margin-top: 15px; padding: 10px; background: rgba(0,0,0,0.2); border: 1px solid rgba(255,255,255,0.1); border-radius: 6px;">
                 <label class="display-option">
                     <input type="checkbox" id="symbolToggle" ${symbolshow ? 'checked' : ''}>
-                    <span>Punkte anzeigen</span>
+                    <span>${t('dashboard.chart_show_points')}</span>
                 </label>
                 <label class="display-option">
                     <input type="checkbox" id="connectToggle" ${nullconnect ? 'checked' : ''}>
-                    <span>Lücken verbinden</span>
+                    <span>${t('dashboard.chart_connect_gaps')}</span>
                 </label>
                 <label class="display-option">
                     <input type="checkbox" id="smoothToggle" ${smoothdata ? 'checked' : ''}>
-                    <span>Glättung aktivieren</span>
+                    <span>${t('dashboard.chart_smoothing')}</span>
                 </label>
 
 				<label class="display-option">
-                    <button type="button" title="Großer Sprung links" id="jumpleft">
+                    <button type="button" title="${t('dashboard.chart_jump_left')}" id="jumpleft">
                     <span> << </span></button>
                 </label>
 
    				<label class="display-option">
-                    <button type="button" title="Pan links" id="panleft">
+                    <button type="button" title="${t('dashboard.chart_pan_left')}" id="panleft">
                     <span> < </span> </button>
                 </label>
 
 				<label class="display-option">
-                    <button type="button" title="Zentrieren" id="center" >
+                    <button type="button" title="${t('dashboard.chart_center')}" id="center" >
                     <span> >< </span></button>
                 </label>
 
 				<label class="display-option">
-                    <button type="button" title="Pan rechts" id="panright">
+                    <button type="button" title="${t('dashboard.chart_pan_right')}" id="panright">
                     <span> > </span></button>
                 </label>
 
 				<label class="display-option">
-                    <button type="button" title="Großer Sprung rechts" id="jumpright" >
+                    <button type="button" title="${t('dashboard.chart_jump_right')}" id="jumpright" >
                     <span> >> </span></button>
                 </label>
 
 				<label class="display-option">
-                    <button type="button" title="Alles anzeigen" id="full">
+                    <button type="button" title="${t('dashboard.chart_show_all')}" id="full">
                     <span> < * > </span></button>
                 </label>
             </div>
@@ -273,6 +276,7 @@ async function initTemperatureChart() {
         temperatureChart = echarts.init(chartContainer);
 
         // Load initial data
+        loadOverlayDevices();
         loadTemperatureData();
 
 		if(temperatureChart !== null ) {chart_datazoom_event();}
@@ -321,13 +325,67 @@ async function chart_datazoom_event(){
     })
 }
 
+// Offer the other logged devices of the installation (cascades, room controllers, climate sensors) for overlay
+async function loadOverlayDevices() {
+    const container = document.getElementById('temperature-chart-devices');
+    if (!container || !currentInstallationId) return;
+
+    // Keep the selection across dashboard refreshes, reset it when another device is shown
+    const deviceKey = `${currentInstallationId}/${currentGatewaySerial}/${currentDeviceId}`;
+    if (overlayDeviceKey !== deviceKey) {
+        overlayDeviceIds.clear();
+        overlayDeviceKey = deviceKey;
+    }
+    container.style.display = 'none';
+    container.innerHTML = '';
+
+    try {
+        const response = await fetch('/api/temperature-log/devices');
+        if (!response.ok) return;
+
+        const result = await response.json();
+        const devices = (result.devices || []).filter(d =>
+            d.installation_id === String(currentInstallationId) &&
+            d.gateway_id === String(currentGatewaySerial) &&
+            d.device_id !== String(currentDeviceId) &&
+            d.snapshots > 0
+        );
+        if (devices.length === 0) return;
+
+        let html = '<span class="overlay-title">' + t('dashboard.overlay_devices') + '</span>';
+        devices.forEach(d => {
+            html += `
+                <label class="filter-checkbox">
+                    <input type="checkbox" value="${d.device_id}" ${overlayDeviceIds.has(d.device_id) ? 'checked' : ''} onchange="toggleOverlayDevice(this.value)">
+                    <span>${t('dashboard.device_n', d.device_id)} (${d.model_id})</span>
+                </label>
+            `;
+        });
+        container.innerHTML = html;
+        container.style.display = 'flex';
+    } catch (error) {
+        console.error('Error loading temperature log devices:', error);
+    }
+}
+
+// Toggle an overlay device and reload the data
+function toggleOverlayDevice(deviceId) {
+    if (overlayDeviceIds.has(deviceId)) {
+        overlayDeviceIds.delete(deviceId);
+    } else {
+        overlayDeviceIds.add(deviceId);
+    }
+    loadTemperatureData();
+}
+
 // Load temperature data from API
 async function loadTemperatureData(silent = false) {
     if (!currentInstallationId) return;
 
     try {
-        // Build API URL
-        let apiUrl = `/api/temperature-log/data?installationId=${currentInstallationId}&gatewayId=${currentGatewaySerial}&deviceId=${currentDeviceId}&limit=50000`;
+        // Build API URL, overlay devices are requested together with the current device
+        const deviceIds = [String(currentDeviceId), ...overlayDeviceIds].map(encodeURIComponent).join(',');
+        let apiUrl = `/api/temperature-log/data?installationId=${currentInstallationId}&gatewayId=${currentGatewaySerial}&deviceId=${deviceIds}&limit=50000`;
 
         if (customTemperatureDate) {
             // Use specific date range (from midnight to midnight next day)
@@ -365,7 +423,7 @@ async function loadTemperatureData(silent = false) {
 
         if (!result.data || result.data.length === 0) {
             if (!silent) {
-                showChartMessage('Keine Temperaturdaten verfügbar. Temperatur-Logging muss aktiviert sein.');
+                showChartMessage(t('dashboard.temperature_no_data'));
             }
             return;
         }
@@ -383,7 +441,7 @@ async function loadTemperatureData(silent = false) {
     } catch (error) {
         console.error('Error loading temperature data:', error);
         if (!silent) {
-            showChartMessage('Fehler beim Laden der Temperaturdaten: ' + error.message);
+            showChartMessage(t('dashboard.temperature_error', error.message));
         }
     }
 }
@@ -407,7 +465,7 @@ function saveSelectedFields() {
 		const btn = document.getElementById('saveSelectedFieldsBtn');
 		if (btn) {
 			const originalText = btn.textContent;
-			btn.textContent = '✓ gespeichert';
+			btn.textContent = '✓ ' + t('dashboard.saved');
 			btn.style.background = 'linear-gradient(135deg, #10b981 0%, #059669 100%)';
 			setTimeout(() => {
 				btn.textContent = originalText;
@@ -416,7 +474,7 @@ function saveSelectedFields() {
 		}
 	} catch (e) {
 		console.error('Failed to save fields:', e);
-		alert('Fehler beim Speichern der Einstellungen');
+		alert(t('dashboard.error_save_settings'));
 	}
 }
 
@@ -441,7 +499,7 @@ function loadSelectedFields() {
 
 // Reset fields to default
 function presetSelectedFields() {
-	if (confirm('Möchtest du die Selektion auf die Standardwerte zurücksetzen?')) {
+	if (confirm(t('dashboard.chart_reset_confirm'))) {
 		selectedFields.clear();
 		// Clear from localStorage
 		localStorage.removeItem('vieventlog_graphic_fields');
@@ -535,89 +593,94 @@ function renderFilters() {
 
     const fieldLabels = {
         // Basic temperatures
-        'outside_temp': '🌡️ Außentemperatur',
-        'calculated_outside_temp': '🌡️ Außentemp. (berechnet)',
+        'outside_temp': '🌡️ ' + t('dashboard.field_outside_temp'),
+        'calculated_outside_temp': '🌡️ ' + t('dashboard.field_calculated_outside_temp'),
 
         // Heat pump circuits (ODU) - only supply temps exist
-        'hp_primary_circuit_supply_temp': '🔄 WP Primärkreis-Vorlauf (Lufteintritt)',
-        'hp_secondary_circuit_supply_temp': '🔄 WP Sekundärkreis-Vorlauf',
+        'hp_primary_circuit_supply_temp': '🔄 ' + t('dashboard.field_hp_primary_circuit_supply_temp'),
+        'hp_secondary_circuit_supply_temp': '🔄 ' + t('dashboard.field_hp_secondary_circuit_supply_temp'),
 
         // Heating circuits (IDU) - only supply temps exist
-        'heating_circuit_0_supply_temp': '🏠 Heizkreis 0 Vorlauf',
-        'heating_circuit_1_supply_temp': '🏠 Heizkreis 1 Vorlauf',
-        'heating_circuit_2_supply_temp': '🏠 Heizkreis 2 Vorlauf',
-        'heating_circuit_3_supply_temp': '🏠 Heizkreis 3 Vorlauf',
+        'heating_circuit_0_supply_temp': '🏠 ' + t('dashboard.field_heating_circuit_0_supply_temp'),
+        'heating_circuit_1_supply_temp': '🏠 ' + t('dashboard.field_heating_circuit_1_supply_temp'),
+        'heating_circuit_2_supply_temp': '🏠 ' + t('dashboard.field_heating_circuit_2_supply_temp'),
+        'heating_circuit_3_supply_temp': '🏠 ' + t('dashboard.field_heating_circuit_3_supply_temp'),
 
         // Temperature spreads (deltaT) per circuit
-        'heating_circuit_0_delta_t': '📊 Spreizung (ΔT)',
-        'heating_circuit_1_delta_t': '📊 Heizkreis 1 Spreizung (ΔT)',
-        'heating_circuit_2_delta_t': '📊 Heizkreis 2 Spreizung (ΔT)',
-        'heating_circuit_3_delta_t': '📊 Heizkreis 3 Spreizung (ΔT)',
+        'heating_circuit_0_delta_t': '📊 ' + t('dashboard.field_heating_circuit_0_delta_t'),
+        'heating_circuit_1_delta_t': '📊 ' + t('dashboard.field_heating_circuit_1_delta_t'),
+        'heating_circuit_2_delta_t': '📊 ' + t('dashboard.field_heating_circuit_2_delta_t'),
+        'heating_circuit_3_delta_t': '📊 ' + t('dashboard.field_heating_circuit_3_delta_t'),
 
         // Common return temperature (system-wide, shared by all circuits)
-        'return_temp': '↩️ Gemeinsamer Rücklauf',
+        'return_temp': '↩️ ' + t('dashboard.field_return_temp'),
 
         // DHW and storage
-        'dhw_temp': '🚿 Warmwasser',
-        'dhw_cylinder_middle_temp': '🚿 WW Mitte',
-        'boiler_temp': '🔥 Kessel',
-        'buffer_temp': '📦 Puffer',
-        'buffer_temp_top': '📦 Puffer (oben)',
+        'dhw_temp': '🚿 ' + t('dashboard.field_dhw_temp'),
+        'dhw_cylinder_middle_temp': '🚿 ' + t('dashboard.field_dhw_cylinder_middle_temp'),
+        'boiler_temp': '🔥 ' + t('dashboard.field_boiler_temp'),
+        'buffer_temp': '📦 ' + t('dashboard.field_buffer_temp'),
+        'buffer_temp_top': '📦 ' + t('dashboard.field_buffer_temp_top'),
 
         // DEPRECATED: Legacy fields (kept for backward compatibility)
-        'supply_temp': '↗️ Vorlauftemperatur (Legacy)',
-        'primary_supply_temp': '↗️ Vorlauf IDU (Legacy)',
-        'secondary_supply_temp': '↗️ Sekundär-Vorlauf ODU (Legacy)',
-        'primary_return_temp': '↩️ Primär-Rücklauf (Legacy)',
-        'secondary_return_temp': '↩️ Sekundär-Rücklauf (Legacy)',
+        'supply_temp': '↗️ ' + t('dashboard.field_supply_temp'),
+        'primary_supply_temp': '↗️ ' + t('dashboard.field_primary_supply_temp'),
+        'secondary_supply_temp': '↗️ ' + t('dashboard.field_secondary_supply_temp'),
+        'primary_return_temp': '↩️ ' + t('dashboard.field_primary_return_temp'),
+        'secondary_return_temp': '↩️ ' + t('dashboard.field_secondary_return_temp'),
 
         // Compressor
-        'compressor_active': '⚙️ Kompressor aktiv',
-        'compressor_speed': '🔧 Kompressor Drehzahl',
-        'compressor_current': '⚡ Kompressor Strom',
-        'compressor_pressure': '💨 Kompressor Druck',
-        'compressor_oil_temp': '🛢️ Öl-Temp.',
-        'compressor_motor_temp': '🔧 Motor-Temp.',
-        'compressor_inlet_temp': '❄️ Eintritt-Temp.',
-        'compressor_outlet_temp': '♨️ Austritt-Temp.',
-        'compressor_hours': '⏱️ Betriebsstunden',
-        'compressor_starts': '️🔧 Anzahl Starts',
-        'compressor_power': '⚡ Leistungsaufnahme',
+        'compressor_active': '⚙️ ' + t('dashboard.field_compressor_active'),
+        'compressor_speed': '🔧 ' + t('dashboard.field_compressor_speed'),
+        'compressor_current': '⚡ ' + t('dashboard.field_compressor_current'),
+        'compressor_pressure': '💨 ' + t('dashboard.field_compressor_pressure'),
+        'compressor_oil_temp': '🛢️ ' + t('dashboard.field_compressor_oil_temp'),
+        'compressor_motor_temp': '🔧 ' + t('dashboard.field_compressor_motor_temp'),
+        'compressor_inlet_temp': '❄️ ' + t('dashboard.field_compressor_inlet_temp'),
+        'compressor_outlet_temp': '♨️ ' + t('dashboard.field_compressor_outlet_temp'),
+        'compressor_hours': '⏱️ ' + t('dashboard.field_compressor_hours'),
+        'compressor_starts': '️🔧 ' + t('dashboard.field_compressor_starts'),
+        'compressor_power': '⚡ ' + t('dashboard.field_compressor_power'),
 
         // Pumps
-        'circulation_pump_active': '🔄 Umwälzpumpe',
-        'dhw_pump_active': '🚿 WW-Pumpe',
-        'internal_pump_active': '🔄 Interne Pumpe',
+        'circulation_pump_active': '🔄 ' + t('dashboard.field_circulation_pump_active'),
+        'dhw_pump_active': '🚿 ' + t('dashboard.field_dhw_pump_active'),
+        'internal_pump_active': '🔄 ' + t('dashboard.field_internal_pump_active'),
 
         // Flow/Energy
-        'volumetric_flow': '💧 Volumenstrom',
-        'thermal_power': '🔥 Thermische Leistung',
-        'cop': '📊 moment. Arbeitszahl (AZ)',
+        'volumetric_flow': '💧 ' + t('dashboard.field_volumetric_flow'),
+        'thermal_power': '🔥 ' + t('dashboard.field_thermal_power'),
+        'cop': '📊 ' + t('dashboard.field_cop'),
 
         // Operating state
-        'four_way_valve': '🔀 4-Wege-Ventil',
-        'four_way_valve_target': '🔀 4-Wege-Ventil soll',
-        'four_way_valve_current': '🔀 4-Wege-Ventil ist',
-        'burner_modulation': '🔥 Brenner Modulation',
-        'secondary_heat_generator_status': '🔥 Zusatzheizung'
+        'four_way_valve': '🔀 ' + t('dashboard.field_four_way_valve'),
+        'four_way_valve_target': '🔀 ' + t('dashboard.field_four_way_valve_target'),
+        'four_way_valve_current': '🔀 ' + t('dashboard.field_four_way_valve_current'),
+        'burner_modulation': '🔥 ' + t('dashboard.field_burner_modulation'),
+        'secondary_heat_generator_status': '🔥 ' + t('dashboard.field_secondary_heat_generator_status'),
+
+        // Room climate (room controllers, climate sensors)
+        'room_temp': '🛋️ ' + t('dashboard.field_room_temp'),
+        'room_humidity': '💧 ' + t('dashboard.field_room_humidity')
     };
 
     const categories = {
-        'Temperaturen': ['outside_temp', 'calculated_outside_temp', 'dhw_temp', 'dhw_cylinder_middle_temp', 'boiler_temp',
+        [t('dashboard.category_temperatures')]: ['outside_temp', 'calculated_outside_temp', 'dhw_temp', 'dhw_cylinder_middle_temp', 'boiler_temp',
                         'buffer_temp', 'buffer_temp_top'],
-        'Kreise': ['supply_temp', 'return_temp',
+        [t('dashboard.category_circuits')]: ['supply_temp', 'return_temp',
                    'hp_primary_circuit_supply_temp', 'hp_secondary_circuit_supply_temp',
                    'heating_circuit_0_supply_temp', 'heating_circuit_1_supply_temp',
                    'heating_circuit_2_supply_temp', 'heating_circuit_3_supply_temp',
                    'heating_circuit_0_delta_t', 'heating_circuit_1_delta_t',
                    'heating_circuit_2_delta_t', 'heating_circuit_3_delta_t',
                    'primary_supply_temp', 'secondary_supply_temp', 'primary_return_temp', 'secondary_return_temp'],
-        'Kompressor': ['compressor_active', 'compressor_speed', 'compressor_current', 'compressor_pressure',
+        [t('dashboard.category_compressor')]: ['compressor_active', 'compressor_speed', 'compressor_current', 'compressor_pressure',
                       'compressor_oil_temp', 'compressor_motor_temp', 'compressor_inlet_temp', 'compressor_outlet_temp',
                       'compressor_hours', 'compressor_starts', 'compressor_power'],
-        'Info': ['circulation_pump_active', 'dhw_pump_active', 'internal_pump_active', 'four_way_valve_target', 'four_way_valve_current'],
-        'Energie': ['volumetric_flow', 'thermal_power', 'cop'],
-        'Betrieb': ['burner_modulation', 'secondary_heat_generator_status']
+        [t('dashboard.category_info')]: ['circulation_pump_active', 'dhw_pump_active', 'internal_pump_active', 'four_way_valve_target', 'four_way_valve_current'],
+        [t('dashboard.energy')]: ['volumetric_flow', 'thermal_power', 'cop'],
+        [t('dashboard.category_operation')]: ['burner_modulation', 'secondary_heat_generator_status'],
+        [t('dashboard.category_room_climate')]: ['room_temp', 'room_humidity']
     };
 
     let html = '<div class="filter-categories">';
@@ -654,7 +717,7 @@ function renderFilters() {
 		if ( categoriesCnt == btnPos) {
             html += `
 			<div class="field-persistence-buttons">
-                <button id="saveSelectedFieldsBtn" title="Selektion speichern" class="field-btn field-btn-save" onclick="saveSelectedFields()">💾 Speichern</button>
+                <button id="saveSelectedFieldsBtn" title="${t('dashboard.chart_save_selection')}" class="field-btn field-btn-save" onclick="saveSelectedFields()">💾 ${t('common.save')}</button>
                 <button id="presetSelectedFieldsBtn" class="field-btn field-btn-reset" onclick="presetSelectedFields()">↺ Reset</button>
             </div>
             `;
@@ -752,106 +815,133 @@ function renderTemperatureChart(data) {
         'volumetric_flow': { type: 'line', yAxisIndex: 2, color: '#2196f3', smooth: true },
         'thermal_power': { type: 'line', yAxisIndex: 2, color: '#ff5722', smooth: true },
         'cop': { type: 'line', yAxisIndex: 2, color: '#4caf50', smooth: true },
-        'burner_modulation': { type: 'line', yAxisIndex: 2, color: '#ff6f00', smooth: true }
+        'burner_modulation': { type: 'line', yAxisIndex: 2, color: '#ff6f00', smooth: true },
+
+        // Room climate
+        'room_temp': { type: 'line', yAxisIndex: 0, color: '#26a69a', smooth: true },
+        'room_humidity': { type: 'line', yAxisIndex: 2, color: '#4dd0e1', smooth: true }
     };
 
     const fieldNames = {
         // Basic temperatures
-        'outside_temp': 'Außentemp.',
-        'calculated_outside_temp': 'Außentemp. (ged.)',
+        'outside_temp': t('dashboard.series_outside_temp'),
+        'calculated_outside_temp': t('dashboard.series_calculated_outside_temp'),
 
         // Heat pump circuits
-        'hp_primary_circuit_supply_temp': 'WP Primär VL',
-        'hp_secondary_circuit_supply_temp': 'WP Sekundär VL',
+        'hp_primary_circuit_supply_temp': t('dashboard.series_hp_primary_circuit_supply_temp'),
+        'hp_secondary_circuit_supply_temp': t('dashboard.series_hp_secondary_circuit_supply_temp'),
 
         // Heating circuits
-        'heating_circuit_0_supply_temp': 'HK0 VL',
-        'heating_circuit_1_supply_temp': 'HK1 VL',
-        'heating_circuit_2_supply_temp': 'HK2 VL',
-        'heating_circuit_3_supply_temp': 'HK3 VL',
+        'heating_circuit_0_supply_temp': t('dashboard.series_heating_circuit_0_supply_temp'),
+        'heating_circuit_1_supply_temp': t('dashboard.series_heating_circuit_1_supply_temp'),
+        'heating_circuit_2_supply_temp': t('dashboard.series_heating_circuit_2_supply_temp'),
+        'heating_circuit_3_supply_temp': t('dashboard.series_heating_circuit_3_supply_temp'),
 
         // Temperature spreads (deltaT)
         'heating_circuit_0_delta_t': 'ΔT',
-        'heating_circuit_1_delta_t': 'HK1 ΔT',
-        'heating_circuit_2_delta_t': 'HK2 ΔT',
-        'heating_circuit_3_delta_t': 'HK3 ΔT',
+        'heating_circuit_1_delta_t': t('dashboard.series_heating_circuit_1_delta_t'),
+        'heating_circuit_2_delta_t': t('dashboard.series_heating_circuit_2_delta_t'),
+        'heating_circuit_3_delta_t': t('dashboard.series_heating_circuit_3_delta_t'),
 
         // Common return
-        'return_temp': 'Gemeins. RL',
+        'return_temp': t('dashboard.series_return_temp'),
 
         // DHW and storage
-        'dhw_temp': 'Warmwasser',
-        'dhw_cylinder_middle_temp': 'WW Mitte',
-        'boiler_temp': 'Kessel',
-        'buffer_temp': 'Puffer',
-        'buffer_temp_top': 'Puffer (oben)',
+        'dhw_temp': t('dashboard.series_dhw_temp'),
+        'dhw_cylinder_middle_temp': t('dashboard.series_dhw_cylinder_middle_temp'),
+        'boiler_temp': t('dashboard.series_boiler_temp'),
+        'buffer_temp': t('dashboard.series_buffer_temp'),
+        'buffer_temp_top': t('dashboard.series_buffer_temp_top'),
 
         // DEPRECATED: Legacy fields
-        'supply_temp': 'Vorlauf (L)',
-        'primary_supply_temp': 'Vorlauf IDU (L)',
-        'secondary_supply_temp': 'Sek.-Vorlauf ODU (L)',
-        'primary_return_temp': 'Primär-RL (L)',
-        'secondary_return_temp': 'Sek.-RL (L)',
+        'supply_temp': t('dashboard.series_supply_temp'),
+        'primary_supply_temp': t('dashboard.series_primary_supply_temp'),
+        'secondary_supply_temp': t('dashboard.series_secondary_supply_temp'),
+        'primary_return_temp': t('dashboard.series_primary_return_temp'),
+        'secondary_return_temp': t('dashboard.series_secondary_return_temp'),
 
 		// Compressor
-        'compressor_active': 'Kompressor',
-        'compressor_speed': 'Drehzahl',
-        'compressor_current': 'Strom',
-        'compressor_pressure': 'Druck',
-        'compressor_oil_temp': 'Öl-Temp.',
-        'compressor_motor_temp': 'Motor-Temp.',
-        'compressor_inlet_temp': 'Eintritt-Temp.',
-        'compressor_outlet_temp': 'Austritt-Temp.',
-        'compressor_hours': 'Betriebsstunden',
-        'compressor_starts': 'Starts',
-        'compressor_power': 'Leistung',
+        'compressor_active': t('dashboard.series_compressor_active'),
+        'compressor_speed': t('dashboard.series_compressor_speed'),
+        'compressor_current': t('dashboard.series_compressor_current'),
+        'compressor_pressure': t('dashboard.series_compressor_pressure'),
+        'compressor_oil_temp': t('dashboard.series_compressor_oil_temp'),
+        'compressor_motor_temp': t('dashboard.series_compressor_motor_temp'),
+        'compressor_inlet_temp': t('dashboard.series_compressor_inlet_temp'),
+        'compressor_outlet_temp': t('dashboard.series_compressor_outlet_temp'),
+        'compressor_hours': t('dashboard.series_compressor_hours'),
+        'compressor_starts': t('dashboard.series_compressor_starts'),
+        'compressor_power': t('dashboard.series_compressor_power'),
 		
 		// 4/3 Valve
-		'four_way_valve_current': 'V ist',
-		'four_way_valve_target': 'V soll',
+		'four_way_valve_current': t('dashboard.series_four_way_valve_current'),
+		'four_way_valve_target': t('dashboard.series_four_way_valve_target'),
 		// other info
-        'circulation_pump_active': 'Umwälzpumpe',
-        'dhw_pump_active': 'WW-Pumpe',
-        'internal_pump_active': 'Int. Pumpe',
+        'circulation_pump_active': t('dashboard.series_circulation_pump_active'),
+        'dhw_pump_active': t('dashboard.series_dhw_pump_active'),
+        'internal_pump_active': t('dashboard.series_internal_pump_active'),
 		// Power/COP/Flow
-        'volumetric_flow': 'Volumenstrom',
-        'thermal_power': 'Therm. Leistung',
-        'cop': 'AZ',
-        'burner_modulation': 'Brenner Mod.'
+        'volumetric_flow': t('dashboard.series_volumetric_flow'),
+        'thermal_power': t('dashboard.series_thermal_power'),
+        'cop': t('dashboard.series_cop'),
+        'burner_modulation': t('dashboard.series_burner_modulation'),
+        // Room climate
+        'room_temp': t('dashboard.series_room_temp'),
+        'room_humidity': t('dashboard.series_room_humidity')
     };
 
+    // Series on the status axis (on/off values), the tooltip shows them as on/off
+    const statusSeries = new Set();
+
+    // Group rows by device: the current device is drawn as before, overlay devices dashed
+    const rowsByDevice = new Map();
+    data.forEach((d, index) => {
+        const deviceId = d.device_id || String(currentDeviceId);
+        if (!rowsByDevice.has(deviceId)) rowsByDevice.set(deviceId, []);
+        rowsByDevice.get(deviceId).push(index);
+    });
+
     // Create series for selected fields
-    selectedFields.forEach(field => {
-        const config = fieldConfig[field];
-        if (!config) return;
-
-        const seriesData = data.map((d, index) => {
-            const value = d[field];
-            // Convert boolean to number for chart
-            let numValue;
-            if (typeof value === 'boolean') {
-                numValue = value ? 1 : 0;
-            } else {
-                numValue = value;
-            }
-            // Return [timestamp, value] pair for ECharts time axis
-            return [timestamps[index], numValue];
-        });
+    rowsByDevice.forEach((indices, deviceId) => {
+        const overlay = deviceId !== String(currentDeviceId);
+
+        selectedFields.forEach(field => {
+            const config = fieldConfig[field];
+            if (!config) return;
+
+            // Overlay devices only get series for the values they actually have
+            if (overlay && !indices.some(index => data[index][field] !== null && data[index][field] !== undefined)) return;
+
+            const seriesData = indices.map(index => {
+                const value = data[index][field];
+                // Convert boolean to number for chart
+                let numValue;
+                if (typeof value === 'boolean') {
+                    numValue = value ? 1 : 0;
+                } else {
+                    numValue = value;
+                }
+                // Return [timestamp, value] pair for ECharts time axis
+                return [timestamps[index], numValue];
+            });
 
-        series.push({
-            name: fieldNames[field] || field,
-            type: config.type,
-            data: seriesData,
-            smooth: smoothdata,
-            step: config.step || false,
-            yAxisIndex: config.yAxisIndex,
-            itemStyle: { color: config.color },
-            lineStyle: { color: config.color, width: 2 },
-            showSymbol: symbolshow,
-            connectNulls: nullconnect
-        });
+            const name = (fieldNames[field] || field) + (overlay ? ` (${t('dashboard.device_n', deviceId)})` : '');
+            series.push({
+                name: name,
+                type: config.type,
+                data: seriesData,
+                smooth: smoothdata,
+                step: config.step || false,
+                yAxisIndex: config.yAxisIndex,
+                itemStyle: { color: config.color },
+                lineStyle: { color: config.color, width: 2, type: overlay ? 'dashed' : 'solid' },
+                showSymbol: symbolshow,
+                connectNulls: nullconnect
+            });
 
-        legend.push(fieldNames[field] || field);
+            legend.push(name);
+            if (config.yAxisIndex === 1) statusSeries.add(name);
+        });
     });
 
     // Determine axis formatter based on time range
@@ -878,8 +968,8 @@ function renderTemperatureChart(data) {
 
                     if (value !== null && value !== undefined) {
                         // Format boolean values
-                        const displayValue = param.seriesName.includes('aktiv') || param.seriesName.includes('Pumpe')
-                            ? (value === 1 ? 'AN' : 'AUS')
+                        const displayValue = statusSeries.has(param.seriesName)
+                            ? (value === 1 ? t('dashboard.on') : t('dashboard.off'))
                             : (typeof value === 'number' ? value.toFixed(2) : value);
                         result += `${param.marker} ${param.seriesName}: <strong>${displayValue}</strong><br/>`;
                     }
@@ -913,7 +1003,7 @@ function renderTemperatureChart(data) {
         yAxis: [
             {
                 type: 'value',
-                name: 'Temperatur (°C)',
+                name: t('dashboard.temperature_axis'),
        			nameTextStyle: {color: "#ffffff"},
                 position: 'left',
                 // Minimum and maximum variations according to the value of incoming
@@ -940,13 +1030,13 @@ function renderTemperatureChart(data) {
                 axisLabel: {
        				color: "#0f9d58",
                     formatter: function(value) {
-                        return value === 1 ? 'AN' : 'AUS';
+                        return value === 1 ? t('dashboard.on') : t('dashboard.off');
                     }
                 }
             },
             {
                 type: 'value',
-                name: 'Leistung;\nFlow',
+                name: t('dashboard.power_flow_axis'),
 				nameTextStyle: {color: "#ffffff"},
 				axisLabel: {color: "#ffffff"},
                 position: 'right',
@@ -1133,6 +1223,23 @@ chartStyles.textContent = `
     user-select: none;
 }
 
+.chart-device-overlay {
+    margin-top: 15px;
+    padding: 10px 15px;
+    background: rgba(0,0,0,0.2);
+    border: 1px solid rgba(255,255,255,0.1);
+    border-radius: 6px;
+    gap: 15px;
+    flex-wrap: wrap;
+    align-items: center;
+}
+
+.overlay-title {
+    font-size: 0.9rem;
+    color: #e0e0e0;
+    font-weight: 600;
+}
+
 .filters-loading {
     text-align: center;
     color: #a0a0b0;
@@ -0,0 +1,12 @@
+// Translation helper for the pages. The server renders the message bundle of the
+// page language into window.I18N (see renderTemplate), missing keys show the key itself.
+// Placeholders {0}, {1}, ... are replaced by the arguments.
+function t(key, ...args) {
+    const text = (window.I18N && window.I18N[key]) || key;
+    return text.replace(/\{(\d+)\}/g, (match, index) => args[index] !== undefined ? args[index] : match);
+}
+
+// Locale for dates and numbers, follows the page language (<html lang>)
+function uiLocale() {
+    return document.documentElement.lang === 'en' ? 'en-GB' : 'de-DE';
+}
@@ -49,14 +49,14 @@ async function loadDevices() {
         }
 
     } catch (error) {
-        showError('Fehler beim Laden der Geräte: ' + error.message);
+        showError(t('device.devices_load_error', error.message));
     }
 }
 
 async function loadSmartClimateDevices(forceRefresh = false) {
     const contentDiv = document.getElementById('smartclimateContent');
     contentDiv.className = 'loading';
-    contentDiv.innerHTML = '<div class="spinner"></div><p>Lade SmartClimate-Geräte & Räume...</p>';
+    contentDiv.innerHTML = `<div class="spinner"></div><p>${t('smartclimate.loading_rooms')}</p>`;
 
     try {
         // Load devices and rooms in parallel
@@ -66,7 +66,7 @@ async function loadSmartClimateDevices(forceRefresh = false) {
         ]);
 
         if (!devicesResponse.ok) {
-            throw new Error('API Fehler (Devices): ' + devicesResponse.status);
+            throw new Error(t('smartclimate.api_error_devices', devicesResponse.status));
         }
 
         const devicesData = await devicesResponse.json();
@@ -82,8 +82,8 @@ async function loadSmartClimateDevices(forceRefresh = false) {
         updateLastUpdate();
 
     } catch (error) {
-        showError('Fehler beim Laden der Daten: ' + error.message);
-        contentDiv.innerHTML = '<div class="error">Fehler beim Laden der Daten: ' + error.message + '</div>';
+        showError(t('device.data_load_error', error.message));
+        contentDiv.innerHTML = `<div class="error">${t('device.data_load_error', error.message)}</div>`;
     }
 }
 
@@ -95,7 +95,7 @@ function renderSmartClimateDevices(devicesData, roomsData) {
     const hasRooms = roomsData && roomsData.rooms && roomsData.rooms.length > 0;
 
     if (!hasDevices && !hasRooms) {
-        contentDiv.innerHTML = '<div class="no-devices">Keine SmartClimate-Geräte oder Räume gefunden</div>';
+        contentDiv.innerHTML = `<div class="no-devices">${t('smartclimate.no_devices')}</div>`;
         return;
     }
 
@@ -107,7 +107,7 @@ function renderSmartClimateDevices(devicesData, roomsData) {
             <div class="category-section">
                 <h2 class="category-header">
                     <span class="category-icon">🚪</span>
-                    Räume
+                    ${t('smartclimate.rooms')}
                     <span class="device-count">${roomsData.rooms.length}</span>
                 </h2>
                 <div class="devices-grid">
@@ -179,7 +179,7 @@ function renderClimateSensorCard(device) {
                             data-gateway="${device.gatewaySerial}"
                             data-installation="${device.installationId}"
                             data-account="${device.accountId}"
-                            title="Namen bearbeiten">✏️</button>
+                            title="${t('smartclimate.edit_name')}">✏️</button>
                 </div>
             </div>
             <div class="device-body">
@@ -223,14 +223,14 @@ function renderRadiatorThermostatCard(device) {
                                 data-gateway="${device.gatewaySerial}"
                                 data-installation="${device.installationId}"
                                 data-account="${device.accountId}"
-                                title="Namen bearbeiten">✏️</button>
+                                title="${t('smartclimate.edit_name')}">✏️</button>
                         <button class="child-lock-btn ${childLock ? 'active' : ''}"
                                 data-device-id="${device.deviceId}"
                                 data-gateway="${device.gatewaySerial}"
                                 data-installation="${device.installationId}"
                                 data-account="${device.accountId}"
                                 data-active="${childLock}"
-                                title="Kindersicherung ${childLock ? 'deaktivieren' : 'aktivieren'}">${childLock ? '🔒' : '🔓'}</button>
+                                title="${childLock ? t('smartclimate.child_lock_disable') : t('smartclimate.child_lock_enable')}">${childLock ? '🔒' : '🔓'}</button>
                     </div>
                 </div>
             </div>
@@ -238,7 +238,7 @@ function renderRadiatorThermostatCard(device) {
                 <div class="sensor-reading main">
                     <span class="icon">🌡️</span>
                     <span class="value">${temp}°C</span>
-                    <span class="label">Ist</span>
+                    <span class="label">${t('smartclimate.actual')}</span>
                 </div>
                 <div class="sensor-reading editable">
                     <span class="icon">🎯</span>
@@ -257,20 +257,20 @@ function renderRadiatorThermostatCard(device) {
                                 data-account="${device.accountId}"
                                 data-current="${setpoint}">+</button>
                     </div>
-                    <span class="label">Soll</span>
+                    <span class="label">${t('smartclimate.setpoint')}</span>
                 </div>
                 <div class="valve-indicator">
                     <div class="valve-bar">
                         <div class="valve-fill" style="width: ${valvePos}%"></div>
                     </div>
-                    <span class="valve-label">Ventil: ${valvePos}%</span>
+                    <span class="valve-label">${t('smartclimate.valve', valvePos)}</span>
                 </div>
             </div>
             <div class="device-footer">
                 <span class="battery ${getBatteryClass(device.battery)}">🔋 ${battery}%</span>
                 <span class="signal ${getSignalClass(device.signalStrength)}">📶 ${signal}%</span>
                 ${device.features.heating_circuit_id !== undefined ?
-                    `<span class="circuit-id">HK${device.features.heating_circuit_id + 1}</span>` : ''}
+                    `<span class="circuit-id">${t('smartclimate.circuit', device.features.heating_circuit_id + 1)}</span>` : ''}
             </div>
         </div>
     `;
@@ -287,7 +287,7 @@ function renderFloorThermostatCard(device) {
     let lqiTimestampFormatted = '';
     if (device.lqiTimestamp) {
         const date = new Date(device.lqiTimestamp);
-        lqiTimestampFormatted = date.toLocaleString('de-DE', {
+        lqiTimestampFormatted = date.toLocaleString(uiLocale(), {
             day: '2-digit',
             month: '2-digit',
             year: 'numeric',
@@ -306,17 +306,17 @@ function renderFloorThermostatCard(device) {
                 <div class="sensor-reading main">
                     <span class="icon">🌡️</span>
                     <span class="value">${supplyTemp}°C</span>
-                    <span class="label">Vorlauf</span>
+                    <span class="label">${t('smartclimate.supply')}</span>
                 </div>
                 <div class="sensor-reading">
                     <span class="icon">🔥</span>
                     <span class="value">${maxTemp}°C</span>
-                    <span class="label">Max. Vorlauf</span>
+                    <span class="label">${t('smartclimate.supply_max')}</span>
                 </div>
                 <div class="sensor-reading">
                     <span class="icon">💧</span>
                     <span class="value">${condensation}%</span>
-                    <span class="label">Kondensation</span>
+                    <span class="label">${t('smartclimate.condensation')}</span>
                 </div>
                 <div class="mode-indicator">
                     <span class="mode-badge ${mode.toLowerCase()}">${translateMode(mode)}</span>
@@ -324,9 +324,9 @@ function renderFloorThermostatCard(device) {
             </div>
             <div class="device-footer">
                 <span class="signal ${getSignalClass(device.signalStrength)}">📶 ${signal}%</span>
-                ${lqiTimestampFormatted ? `<span class="timestamp" title="Zuletzt empfangene Daten">🕐 ${lqiTimestampFormatted}</span>` : ''}
+                ${lqiTimestampFormatted ? `<span class="timestamp" title="${t('smartclimate.last_received')}">🕐 ${lqiTimestampFormatted}</span>` : ''}
                 ${device.features.heating_circuit_id !== undefined ?
-                    `<span class="circuit-id">HK${device.features.heating_circuit_id + 1}</span>` : ''}
+                    `<span class="circuit-id">${t('smartclimate.circuit', device.features.heating_circuit_id + 1)}</span>` : ''}
             </div>
         </div>
     `;
@@ -365,7 +365,7 @@ function renderRoomControlCard(device) {
             <div class="device-body">
                 <div class="sensor-reading main">
                     <span class="icon">🎛️</span>
-                    <span class="label">Raumsteuerung</span>
+                    <span class="label">${t('smartclimate.room_control')}</span>
                 </div>
             </div>
         </div>
@@ -399,7 +399,7 @@ function renderRoomCard(room) {
 
     // Use systemName if available, otherwise fall back to roomName
     const displayName = room.systemName || room.roomName;
-    const roomTypeLabel = room.roomTypeDE || '';
+    const roomTypeLabel = room.roomTypeLabel || room.roomTypeDE || '';
 
     const hasTemp = temp !== '-' && tempStatus === 'connected';
     const hasHumidity = humidity !== '-' && humidityStatus === 'connected';
@@ -414,12 +414,12 @@ function renderRoomCard(room) {
                     <input type="text" class="device-name-edit room-name-edit" id="room-name-edit-${room.roomId}" value="${room.roomName}" style="display: none;" maxlength="40">
                 </div>
                 <div class="device-header-actions">
-                    <span class="device-id">${roomTypeLabel ? roomTypeLabel + ' · ' : ''}Raum ${room.roomId}</span>
+                    <span class="device-id">${roomTypeLabel ? roomTypeLabel + ' · ' : ''}${t('smartclimate.room', room.roomId)}</span>
                     <button class="edit-room-name-btn"
                             data-room-id="${room.roomId}"
                             data-installation="${room.installationId}"
                             data-account="${room.accountId}"
-                            title="Namen bearbeiten">✏️</button>
+                            title="${t('smartclimate.edit_name')}">✏️</button>
                 </div>
             </div>
             <div class="device-body">
@@ -427,7 +427,7 @@ function renderRoomCard(room) {
                 <div class="sensor-reading main">
                     <span class="icon">🌡️</span>
                     <span class="value">${temp}°C</span>
-                    <span class="label">Ist</span>
+                    <span class="label">${t('smartclimate.actual')}</span>
                 </div>
                 ` : ''}
                 ${heatingSetpoint !== '-' ? `
@@ -448,21 +448,21 @@ function renderRoomCard(room) {
                                 data-account="${room.accountId}"
                                 data-current="${heatingSetpoint}">+</button>
                     </div>
-                    <span class="label">Soll (Heizen)</span>
+                    <span class="label">${t('smartclimate.setpoint_heating')}</span>
                 </div>
                 ` : ''}
                 ${coolingSetpoint !== '-' ? `
                 <div class="sensor-reading">
                     <span class="icon">❄️</span>
                     <span class="value">${coolingSetpoint}°C</span>
-                    <span class="label">Soll (Kühlen)</span>
+                    <span class="label">${t('smartclimate.setpoint_cooling')}</span>
                 </div>
                 ` : ''}
                 ${hasHumidity ? `
                 <div class="sensor-reading">
                     <span class="icon">💧</span>
                     <span class="value ${room.condensationRisk ? 'condensation-risk' : ''}">${humidity}%</span>
-                    <span class="label">Luftfeuchte</span>
+                    <span class="label">${t('smartclimate.humidity')}</span>
                 </div>
                 ` : ''}
                 ${hasCO2 ? `
@@ -474,13 +474,13 @@ function renderRoomCard(room) {
                 ` : ''}
             </div>
             <div class="device-footer">
-                ${isHeating ? '<span class="status-badge heating">🔥 Heizt</span>' :
-                  operatingState === 'energySaving' ? '<span class="status-badge energy-saving">💤 Energiesparen</span>' :
-                  operatingState === 'cooling' ? '<span class="status-badge cooling">❄️ Kühlt</span>' : ''}
-                ${room.windowOpen ? '<span class="status-badge warning">🪟 Fenster offen</span>' : ''}
-                ${room.condensationRisk ? '<span class="status-badge alert">💧 Kondensationsgefahr</span>' : ''}
-                ${room.childLock === 'active' ? '<span class="status-badge">🔒 Kindersicherung</span>' : ''}
-                ${!hasTemp ? '<span class="status-badge inactive">Kein Sensor</span>' : ''}
+                ${isHeating ? `<span class="status-badge heating">${t('smartclimate.state_heating')}</span>` :
+                  operatingState === 'energySaving' ? `<span class="status-badge energy-saving">${t('smartclimate.state_energy_saving')}</span>` :
+                  operatingState === 'cooling' ? `<span class="status-badge cooling">${t('smartclimate.state_cooling')}</span>` : ''}
+                ${room.windowOpen ? `<span class="status-badge warning">${t('smartclimate.state_window_open')}</span>` : ''}
+                ${room.condensationRisk ? `<span class="status-badge alert">${t('smartclimate.state_condensation_risk')}</span>` : ''}
+                ${room.childLock === 'active' ? `<span class="status-badge">${t('smartclimate.state_child_lock')}</span>` : ''}
+                ${!hasTemp ? `<span class="status-badge inactive">${t('smartclimate.state_no_sensor')}</span>` : ''}
             </div>
         </div>
     `;
@@ -503,10 +503,10 @@ function getSignalClass(signal) {
 
 function translateMode(mode) {
     const translations = {
-        'heating': 'Heizen',
-        'cooling': 'Kühlen',
+        'heating': t('smartclimate.mode_heating'),
+        'cooling': t('smartclimate.mode_cooling'),
         'standby': 'Standby',
-        'off': 'Aus'
+        'off': t('smartclimate.mode_off')
     };
     return translations[mode] || mode;
 }
@@ -530,12 +530,12 @@ function attachEventListeners() {
                 editElem.focus();
                 editElem.select();
                 button.textContent = '💾';
-                button.title = 'Speichern';
+                button.title = t('common.save');
             } else {
                 // Save the name
                 const newName = editElem.value.trim();
                 if (newName === '' || newName.length > 40) {
-                    showError('Name muss zwischen 1 und 40 Zeichen lang sein');
+                    showError(t('smartclimate.name_length'));
                     return;
                 }
 
@@ -548,9 +548,9 @@ function attachEventListeners() {
                     displayElem.style.display = 'block';
                     editElem.style.display = 'none';
                     button.textContent = '✏️';
-                    button.title = 'Namen bearbeiten';
+                    button.title = t('smartclimate.edit_name');
 
-                    showSuccess(`Raumname geändert zu: ${newName}`);
+                    showSuccess(t('smartclimate.room_renamed', newName));
 
                     // Reload after 2 seconds
                     setTimeout(() => {
@@ -558,7 +558,7 @@ function attachEventListeners() {
                     }, 2000);
 
                 } catch (error) {
-                    showError('Fehler beim Ändern des Raumnamens: ' + error.message);
+                    showError(t('smartclimate.room_rename_error', error.message));
                 } finally {
                     button.disabled = false;
                 }
@@ -584,10 +584,10 @@ function attachEventListeners() {
                 // Update button
                 button.dataset.active = newState;
                 button.textContent = newState ? '🔒' : '🔓';
-                button.title = `Kindersicherung ${newState ? 'deaktivieren' : 'aktivieren'}`;
+                button.title = newState ? t('smartclimate.child_lock_disable') : t('smartclimate.child_lock_enable');
                 button.classList.toggle('active', newState);
 
-                showSuccess(`Kindersicherung ${newState ? 'aktiviert' : 'deaktiviert'}`);
+                showSuccess(newState ? t('smartclimate.child_lock_enabled') : t('smartclimate.child_lock_disabled'));
 
                 // Reload after 2 seconds
                 setTimeout(() => {
@@ -595,7 +595,7 @@ function attachEventListeners() {
                 }, 2000);
 
             } catch (error) {
-                showError('Fehler beim Umschalten der Kindersicherung: ' + error.message);
+                showError(t('smartclimate.child_lock_error', error.message));
             } finally {
                 button.disabled = false;
             }
@@ -621,12 +621,12 @@ function attachEventListeners() {
                 editElem.focus();
                 editElem.select();
                 button.textContent = '💾';
-                button.title = 'Speichern';
+                button.title = t('common.save');
             } else {
                 // Save the name
                 const newName = editElem.value.trim();
                 if (newName === '' || newName.length > 40) {
-                    showError('Name muss zwischen 1 und 40 Zeichen lang sein');
+                    showError(t('smartclimate.name_length'));
                     return;
                 }
 
@@ -639,9 +639,9 @@ function attachEventListeners() {
                     displayElem.style.display = 'block';
                     editElem.style.display = 'none';
                     button.textContent = '✏️';
-                    button.title = 'Namen bearbeiten';
+                    button.title = t('smartclimate.edit_name');
 
-                    showSuccess(`Name geändert zu: ${newName}`);
+                    showSuccess(t('smartclimate.renamed', newName));
 
                     // Reload after 2 seconds
                     setTimeout(() => {
@@ -649,7 +649,7 @@ function attachEventListeners() {
                     }, 2000);
 
                 } catch (error) {
-                    showError('Fehler beim Ändern des Namens: ' + error.message);
+                    showError(t('smartclimate.rename_error', error.message));
                 } finally {
                     button.disabled = false;
                 }
@@ -691,7 +691,7 @@ function attachEventListeners() {
                 });
 
                 // Show success
-                showSuccess(`Temperatur auf ${newTemp.toFixed(1)}°C gesetzt`);
+                showSuccess(t('smartclimate.temperature_set', newTemp.toFixed(1)));
 
                 // Reload after 2 seconds to get actual value
                 setTimeout(() => {
@@ -699,7 +699,7 @@ function attachEventListeners() {
                 }, 2000);
 
             } catch (error) {
-                showError('Fehler beim Setzen der Temperatur: ' + error.message);
+                showError(t('smartclimate.temperature_error', error.message));
             } finally {
                 // Re-enable buttons
                 card.querySelectorAll('.temp-btn').forEach(b => b.disabled = false);
@@ -741,7 +741,7 @@ function attachEventListeners() {
                 });
 
                 // Show success
-                showSuccess(`Raumtemperatur auf ${newTemp.toFixed(1)}°C gesetzt`);
+                showSuccess(t('smartclimate.room_temperature_set', newTemp.toFixed(1)));
 
                 // Reload after 2 seconds to get actual value
                 setTimeout(() => {
@@ -749,7 +749,7 @@ function attachEventListeners() {
                 }, 2000);
 
             } catch (error) {
-                showError('Fehler beim Setzen der Raumtemperatur: ' + error.message);
+                showError(t('smartclimate.room_temperature_error', error.message));
             } finally {
                 // Re-enable buttons
                 card.querySelectorAll('.room-temp-btn').forEach(b => b.disabled = false);
@@ -902,7 +902,7 @@ function showSuccess(message) {
 
 function updateLastUpdate() {
     const now = new Date();
-    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString('de-DE');
+    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString(uiLocale());
 }
 
 function showError(message) {
@@ -62,14 +62,14 @@ async function loadInstallations() {
         }
 
     } catch (error) {
-        showError('Fehler beim Laden der Installationen: ' + error.message);
+        showError(t('device.installations_load_error', error.message));
     }
 }
 
 async function loadVitochargeData(forceRefresh = false) {
     const contentDiv = document.getElementById('vitochargeContent');
     contentDiv.className = 'loading';
-    contentDiv.innerHTML = '<div class="spinner"></div><p>Lade Vitocharge-Daten...</p>';
+    contentDiv.innerHTML = `<div class="spinner"></div><p>${t('vitocharge.loading')}</p>`;
 
     try {
         // Debug mode: Load mock data from local JSON
@@ -111,7 +111,7 @@ async function loadVitochargeData(forceRefresh = false) {
         // Normal mode: Use real API
         const currentInstall = installations.find(i => i.installationId === currentInstallationId);
         if (!currentInstall || !currentInstall.devices) {
-            throw new Error('Installation nicht gefunden');
+            throw new Error(t('device.installation_not_found'));
         }
 
         // Find Vitocharge device (electricityStorage)
@@ -123,7 +123,7 @@ async function loadVitochargeData(forceRefresh = false) {
         console.log('Wallbox device found:', wallboxDevice);
 
         if (!vitochargeDevice) {
-            contentDiv.innerHTML = '<div class="no-devices">Kein Vitocharge-Gerät gefunden in dieser Installation.</div>';
+            contentDiv.innerHTML = `<div class="no-devices">${t('vitocharge.no_device')}</div>`;
             return;
         }
 
@@ -140,7 +140,7 @@ async function loadVitochargeData(forceRefresh = false) {
         const response = await fetch(`/api/features?installationId=${currentInstallationId}&gatewaySerial=${gatewaySerial}&deviceId=${deviceId}${refreshParam}`);
 
         if (!response.ok) {
-            throw new Error('API Fehler: ' + response.status);
+            throw new Error(t('device.api_error', response.status));
         }
 
         const features = await response.json();
@@ -162,8 +162,8 @@ async function loadVitochargeData(forceRefresh = false) {
         updateLastUpdate();
 
     } catch (error) {
-        showError('Fehler beim Laden der Vitocharge-Daten: ' + error.message);
-        contentDiv.innerHTML = '<div class="error">Fehler beim Laden der Daten: ' + error.message + '</div>';
+        showError(t('vitocharge.load_error', error.message));
+        contentDiv.innerHTML = `<div class="error">${t('device.data_load_error', error.message)}</div>`;
     }
 }
 
@@ -372,7 +372,7 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     let pvStatusText = pvStatus;
     if (pvStatus === 'production') {
         pvStatusEmoji = '🟢';
-        pvStatusText = 'Produktion';
+        pvStatusText = t('vitocharge.pv_production_state');
     }
 
     // Determine battery status emoji and text
@@ -380,10 +380,10 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     let batteryStatusText = batteryStatus;
     if (batteryStatus === 'charge') {
         batteryStatusEmoji = '🔵';
-        batteryStatusText = 'Laden';
+        batteryStatusText = t('vitocharge.battery_charge');
     } else if (batteryStatus === 'discharge') {
         batteryStatusEmoji = '🔴';
-        batteryStatusText = 'Entladen';
+        batteryStatusText = t('vitocharge.battery_discharge');
     } else if (batteryStatus === 'standby') {
         batteryStatusEmoji = '⚪';
         batteryStatusText = 'Standby';
@@ -394,29 +394,29 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Power Flow Diagram
     html += `
         <div class="power-flow">
-            <div class="section-header">🔋 Energiefluss</div>
+            <div class="section-header">${t('vitocharge.section_flow')}</div>
             <div class="flow-diagram">
                 <div class="flow-node">
                     <div class="flow-node-icon">☀️</div>
-                    <div class="flow-node-label">PV-Produktion</div>
+                    <div class="flow-node-label">${t('vitocharge.flow_pv')}</div>
                     <div class="flow-node-value">${formatNum(pvProductionCurrent)} kW</div>
                 </div>
                 <div class="flow-arrow">→</div>
                 <div class="flow-node">
                     <div class="flow-node-icon">🔌</div>
-                    <div class="flow-node-label">Wechselrichter</div>
+                    <div class="flow-node-label">${t('vitocharge.flow_inverter')}</div>
                     <div class="flow-node-value">${formatNum(inverterPowerActive / 1000)} kW</div>
                 </div>
                 <div class="flow-arrow ${batteryPower > 0 ? 'reverse' : ''}">→</div>
                 <div class="flow-node">
                     <div class="flow-node-icon">🔋</div>
-                    <div class="flow-node-label">Batterie</div>
+                    <div class="flow-node-label">${t('vitocharge.flow_battery')}</div>
                     <div class="flow-node-value">${formatNum(Math.abs(batteryPower) / 1000)} kW</div>
                 </div>
                 <div class="flow-arrow ${gridPower < 0 ? 'reverse' : ''}">→</div>
                 <div class="flow-node">
                     <div class="flow-node-icon">🏠</div>
-                    <div class="flow-node-label">Netzbezug</div>
+                    <div class="flow-node-label">${t('vitocharge.flow_grid')}</div>
                     <div class="flow-node-value">${formatNum(Math.abs(gridPower) / 1000)} kW</div>
                 </div>
                 ${wallboxFeatures ? `
@@ -437,14 +437,14 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Photovoltaik Card
     html += `
         <div class="section-card">
-            <div class="section-header">☀️ Photovoltaik</div>
+            <div class="section-header">${t('vitocharge.section_pv')}</div>
             <div class="status-box pv-status">
-                <div class="status-label">PV Status</div>
+                <div class="status-label">${t('vitocharge.pv_status')}</div>
                 <div class="status-value">${pvStatusEmoji} ${pvStatusText}</div>
             </div>
             <div class="metrics-grid">
                 <div class="metric-item">
-                    <div class="metric-label">PV Produktion</div>
+                    <div class="metric-label">${t('vitocharge.pv_production')}</div>
                     <div class="metric-value">${formatNum(pvProductionCurrent)} <span class="metric-unit">kW</span></div>
                 </div>
                 <div class="metric-item">
@@ -452,11 +452,11 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
                     <div class="metric-value">${formatNum(pvInstalledPeakPower)} <span class="metric-unit">kWp</span></div>
                 </div>
                 <div class="metric-item">
-                    <div class="metric-label">Heute</div>
+                    <div class="metric-label">${t('vitocharge.today')}</div>
                     <div class="metric-value">${formatNum(pvProductionCurrentDay / 1000)} <span class="metric-unit">kWh</span></div>
                 </div>
                 <div class="metric-item">
-                    <div class="metric-label">Gesamt</div>
+                    <div class="metric-label">${t('vitocharge.total')}</div>
                     <div class="metric-value">${formatNum(pvProductionLifeCycle / 1000000)} <span class="metric-unit">MWh</span></div>
                 </div>
             </div>
@@ -472,9 +472,9 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
         `;
 
         const strings = [
-            { name: 'String 1', voltage: pvStringVoltages.one, current: pvStringCurrents.one },
-            { name: 'String 2', voltage: pvStringVoltages.two, current: pvStringCurrents.two },
-            { name: 'String 3', voltage: pvStringVoltages.three, current: pvStringCurrents.three }
+            { name: t('vitocharge.pv_string', 1), voltage: pvStringVoltages.one, current: pvStringCurrents.one },
+            { name: t('vitocharge.pv_string', 2), voltage: pvStringVoltages.two, current: pvStringCurrents.two },
+            { name: t('vitocharge.pv_string', 3), voltage: pvStringVoltages.three, current: pvStringCurrents.three }
         ];
 
         strings.forEach(str => {
@@ -502,26 +502,26 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Batterie Card
     html += `
         <div class="section-card">
-            <div class="section-header">🔋 Batterie</div>
+            <div class="section-header">${t('vitocharge.section_battery')}</div>
             <div class="status-box battery-status ${batteryStatusClass}">
-                <div class="status-label">Batteriestatus</div>
+                <div class="status-label">${t('vitocharge.battery_status')}</div>
                 <div class="status-value ${batteryStatusClass}">${batteryStatusEmoji} ${batteryStatusText}</div>
             </div>
             <div class="metrics-grid">
                 <div class="metric-item">
-                    <div class="metric-label">Batterieleistung</div>
+                    <div class="metric-label">${t('vitocharge.battery_power')}</div>
                     <div class="metric-value">${formatNum(batteryPower / 1000)} <span class="metric-unit">kW</span></div>
                 </div>
                 <div class="metric-item">
-                    <div class="metric-label">Batterieladezustand</div>
+                    <div class="metric-label">${t('vitocharge.battery_soc')}</div>
                     <div class="metric-value">${batterySOC} <span class="metric-unit">%</span></div>
                 </div>
                 <div class="metric-item">
-                    <div class="metric-label">Gesamtkapazität</div>
+                    <div class="metric-label">${t('vitocharge.battery_capacity')}</div>
                     <div class="metric-value">${Math.round(batteryCapacity)} <span class="metric-unit">Wh</span> <span style="font-size: 0.8em; color: #a0a0b0;">(${(batteryCapacity / 1000).toFixed(2)} kWh)</span></div>
                 </div>
                 <div class="metric-item">
-                    <div class="metric-label">Notstromreserve</div>
+                    <div class="metric-label">${t('vitocharge.backup_reserve')}</div>
                     <div class="metric-value">${backupReserve} <span class="metric-unit">%</span></div>
                 </div>
             </div>
@@ -531,7 +531,7 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Wallbox Card (if available)
     if (wallboxFeatures) {
         const statusEmoji = wallboxStatus === 'charging' ? '🔌' : wallboxStatus === 'connected' ? '🔗' : '⚫';
-        const statusText = wallboxStatus === 'charging' ? 'Lädt' : wallboxStatus === 'connected' ? 'Verbunden' : 'Nicht verbunden';
+        const statusText = wallboxStatus === 'charging' ? t('vitocharge.wallbox_charging') : wallboxStatus === 'connected' ? t('vitocharge.wallbox_connected') : t('vitocharge.wallbox_disconnected');
         const statusClass = wallboxStatus === 'charging' ? 'charging' : '';
 
         // Format session time
@@ -548,19 +548,19 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
                 </div>
                 <div class="metrics-grid">
                     <div class="metric-item">
-                        <div class="metric-label">Ladeleistung</div>
+                        <div class="metric-label">${t('vitocharge.wallbox_power')}</div>
                         <div class="metric-value">${formatNum(wallboxPower / 1000)} <span class="metric-unit">kW</span></div>
                     </div>
                     <div class="metric-item">
-                        <div class="metric-label">Session-Energie</div>
+                        <div class="metric-label">${t('vitocharge.wallbox_session_energy')}</div>
                         <div class="metric-value">${(wallboxSessionEnergy || 0).toFixed(2)} <span class="metric-unit">kWh</span></div>
                     </div>
                     <div class="metric-item">
-                        <div class="metric-label">Ladezeit (Session)</div>
+                        <div class="metric-label">${t('vitocharge.wallbox_session_time')}</div>
                         <div class="metric-value">${sessionTimeFormatted}</div>
                     </div>
                     <div class="metric-item">
-                        <div class="metric-label">Modell</div>
+                        <div class="metric-label">${t('vitocharge.wallbox_model')}</div>
                         <div class="metric-value" style="font-size: 0.85em;">${wallboxManufacturer} ${wallboxModel}</div>
                     </div>
                 </div>
@@ -574,19 +574,19 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     if (modules.length > 0) {
         html += `
             <div class="battery-modules">
-                <div class="section-header">🔋 Batteriemodule</div>
+                <div class="section-header">${t('vitocharge.section_modules')}</div>
                 <div class="modules-grid">
         `;
         modules.forEach(module => {
             html += `
                 <div class="module-card">
-                    <div class="module-header">Batteriemodul ${module.id}</div>
+                    <div class="module-header">${t('vitocharge.module', module.id)}</div>
                     <div class="module-info">
-                        <span class="module-info-label">Seriennummer:</span>
+                        <span class="module-info-label">${t('vitocharge.module_serial')}</span>
                         <span class="module-info-value">${module.serial}</span>
                     </div>
                     <div class="module-info">
-                        <span class="module-info-label">Kapazität:</span>
+                        <span class="module-info-label">${t('vitocharge.module_capacity')}</span>
                         <span class="module-info-value">${Math.round(module.capacityWh)} Wh (${module.capacityKWh} kWh)</span>
                     </div>
                 </div>
@@ -601,22 +601,22 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Statistics Section
     html += `
         <div class="statistics-section">
-            <div class="section-header">📊 Statistiken</div>
+            <div class="section-header">${t('vitocharge.section_stats')}</div>
             <div class="stats-grid">
                 <div class="stat-card">
-                    <div class="stat-card-label">Laden Gesamt</div>
+                    <div class="stat-card-label">${t('vitocharge.stats_charge')}</div>
                     <div class="stat-card-value">${formatEnergy(batteryChargeLifeCycle)}</div>
                 </div>
                 <div class="stat-card">
-                    <div class="stat-card-label">Entladen Gesamt</div>
+                    <div class="stat-card-label">${t('vitocharge.stats_discharge')}</div>
                     <div class="stat-card-value">${formatEnergy(batteryDischargeLifeCycle)}</div>
                 </div>
                 <div class="stat-card">
-                    <div class="stat-card-label">Netzbezug Gesamt</div>
+                    <div class="stat-card-label">${t('vitocharge.stats_grid_consumption')}</div>
                     <div class="stat-card-value">${formatEnergy(gridConsumptionTotal)}</div>
                 </div>
                 <div class="stat-card">
-                    <div class="stat-card-label">Netzeinspeisung Gesamt</div>
+                    <div class="stat-card-label">${t('vitocharge.stats_grid_feed_in')}</div>
                     <div class="stat-card-value">${formatEnergy(gridFeedInTotal)}</div>
                 </div>
             </div>
@@ -627,7 +627,7 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     if (hasPhaseCurrent) {
         html += `
             <div style="margin-top: 20px; padding-top: 20px; border-top: 1px solid rgba(255,255,255,0.1);">
-                <div style="font-size: 16px; color: #fff; margin-bottom: 15px; font-weight: 600;">⚡ Netzphasen</div>
+                <div style="font-size: 16px; color: #fff; margin-bottom: 15px; font-weight: 600;">${t('vitocharge.grid_phases')}</div>
                 <div style="display: grid; grid-template-columns: repeat(3, 1fr); gap: 15px;">
         `;
 
@@ -650,17 +650,17 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
 
             html += `
                 <div style="background: rgba(255,255,255,0.03); padding: 15px; border-radius: 8px; border: 1px solid rgba(255,255,255,0.1);">
-                    <div style="font-size: 14px; color: #667eea; font-weight: 600; margin-bottom: 8px;">Phase ${phase.name}</div>
+                    <div style="font-size: 14px; color: #667eea; font-weight: 600; margin-bottom: 8px;">${t('vitocharge.phase', phase.name)}</div>
                     <div style="display: flex; justify-content: space-between; margin-bottom: 4px;">
-                        <span style="font-size: 12px; color: #a0a0b0;">Strom:</span>
+                        <span style="font-size: 12px; color: #a0a0b0;">${t('vitocharge.phase_current')}</span>
                         <span style="font-size: 13px; color: #e0e0e0; font-weight: 600;">${calculatedCurrent} A</span>
                     </div>
                     <div style="display: flex; justify-content: space-between; margin-bottom: 4px;">
-                        <span style="font-size: 12px; color: #a0a0b0;">Wirkleistung:</span>
+                        <span style="font-size: 12px; color: #a0a0b0;">${t('vitocharge.phase_active_power')}</span>
                         <span style="font-size: 13px; color: #e0e0e0;">${phase.active} W</span>
                     </div>
                     <div style="display: flex; justify-content: space-between;">
-                        <span style="font-size: 12px; color: #a0a0b0;">Blindleistung:</span>
+                        <span style="font-size: 12px; color: #a0a0b0;">${t('vitocharge.phase_reactive_power')}</span>
                         <span style="font-size: 13px; color: ${phase.reactive < 0 ? '#ef4444' : '#10b981'};">${Math.round(phase.reactive)} W</span>
                     </div>
                 </div>
@@ -678,14 +678,14 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
     // Device Info
     html += `
         <div class="device-info-section">
-            <div class="section-header">ℹ️ Geräteinformationen</div>
+            <div class="section-header">${t('vitocharge.section_device')}</div>
             <div class="info-grid">
                 <div class="info-item">
-                    <span class="info-item-label">Produktname:</span>
-                    <span class="info-item-value">Wechselrichter ${deviceInfo.modelId}</span>
+                    <span class="info-item-label">${t('vitocharge.product_name')}</span>
+                    <span class="info-item-value">${t('vitocharge.inverter_model', deviceInfo.modelId)}</span>
                 </div>
                 <div class="info-item">
-                    <span class="info-item-label">Systemtyp:</span>
+                    <span class="info-item-label">${t('vitocharge.system_type')}</span>
                     <span class="info-item-value">${systemType === 'hybrid' ? 'Hybrid' : systemType}</span>
                 </div>
                 <div class="info-item">
@@ -698,7 +698,7 @@ function renderVitocharge(features, deviceInfo, wallboxFeatures = null, wallboxD
                 </div>
                 ${ambientTemp !== null ? `
                 <div class="info-item">
-                    <span class="info-item-label">Umgebungstemperatur:</span>
+                    <span class="info-item-label">${t('vitocharge.ambient_temperature')}</span>
                     <span class="info-item-value">${formatNum(ambientTemp)} °C</span>
                 </div>
                 ` : ''}
@@ -716,7 +716,7 @@ function formatNum(value) {
 
 function updateLastUpdate() {
     const now = new Date();
-    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString('de-DE');
+    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString(uiLocale());
 }
 
 function showError(message) {
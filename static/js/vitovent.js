@@ -60,21 +60,21 @@ async function loadDevices() {
         });
 
     } catch (error) {
-        showError('Fehler beim Laden der Geräte: ' + error.message);
+        showError(t('device.devices_load_error', error.message));
     }
 }
 
 async function loadVitoventData(forceRefresh = false) {
     const contentDiv = document.getElementById('vitoventContent');
     contentDiv.className = 'loading';
-    contentDiv.innerHTML = '<div class="spinner"></div><p>Lade Vitovent-Daten...</p>';
+    contentDiv.innerHTML = `<div class="spinner"></div><p>${t('vitovent.loading_data')}</p>`;
 
     try {
         const refreshParam = forceRefresh ? '&refresh=true' : '';
         const response = await fetch(`/api/vitovent/devices?installationId=${currentInstallationId}${refreshParam}`);
 
         if (!response.ok) {
-            throw new Error('API Fehler: ' + response.status);
+            throw new Error(t('device.api_error', response.status));
         }
 
         const data = await response.json();
@@ -83,7 +83,7 @@ async function loadVitoventData(forceRefresh = false) {
 
         if (!data.device) {
             contentDiv.className = 'no-device';
-            contentDiv.innerHTML = '<div class="no-device">Kein Vitovent-System in dieser Installation gefunden</div>';
+            contentDiv.innerHTML = `<div class="no-device">${t('vitovent.no_device')}</div>`;
             console.warn('No Vitovent device found in installation:', currentInstallationId);
             return;
         }
@@ -100,8 +100,8 @@ async function loadVitoventData(forceRefresh = false) {
         updateLastUpdate();
 
     } catch (error) {
-        showError('Fehler beim Laden der Daten: ' + error.message);
-        contentDiv.innerHTML = '<div class="error">Fehler beim Laden der Daten: ' + error.message + '</div>';
+        showError(t('device.data_load_error', error.message));
+        contentDiv.innerHTML = `<div class="error">${t('device.data_load_error', error.message)}</div>`;
     }
 }
 
@@ -127,7 +127,7 @@ function renderVitoventDevice(data) {
 
     html += `
         <div class="section operating-mode-section">
-            <div class="section-title">⚙️ Betriebsmodus</div>
+            <div class="section-title">${t('vitovent.section_mode')}</div>
             <div class="status-badge ${getOperatingModeBadgeClass(features.operating_mode)}">
                 ${formatOperatingMode(features.operating_mode)}
             </div>
@@ -137,16 +137,16 @@ function renderVitoventDevice(data) {
         // VitoAir: Complex mode selection
         html += `
             <div class="control-group">
-                <label class="control-label">Modus ändern</label>
+                <label class="control-label">${t('vitovent.change_mode')}</label>
                 <div class="mode-selector">
                     <button class="mode-btn ${features.operating_mode === 'permanent' ? 'active' : ''}"
-                            onclick="setOperatingMode('permanent')">Konstant</button>
+                            onclick="setOperatingMode('permanent')">${t('vitovent.mode_btn_permanent')}</button>
                     <button class="mode-btn ${features.operating_mode === 'ventilation' ? 'active' : ''}"
-                            onclick="setOperatingMode('ventilation')">Programm</button>
+                            onclick="setOperatingMode('ventilation')">${t('vitovent.mode_btn_ventilation')}</button>
                     <button class="mode-btn ${features.operating_mode === 'sensorOverride' ? 'active' : ''}"
-                            onclick="setOperatingMode('sensorOverride')">Sensor+Prog</button>
+                            onclick="setOperatingMode('sensorOverride')">${t('vitovent.mode_btn_sensorOverride')}</button>
                     <button class="mode-btn ${features.operating_mode === 'sensorDriven' ? 'active' : ''}"
-                            onclick="setOperatingMode('sensorDriven')">Auto-Sensor</button>
+                            onclick="setOperatingMode('sensorDriven')">${t('vitovent.mode_btn_sensorDriven')}</button>
                 </div>
             </div>
         `;
@@ -154,14 +154,14 @@ function renderVitoventDevice(data) {
         // Vitovent 300F: Simple mode selection
         html += `
             <div class="control-group">
-                <label class="control-label">Modus ändern</label>
+                <label class="control-label">${t('vitovent.change_mode')}</label>
                 <div class="mode-selector">
                     <button class="mode-btn ${features.operating_mode === 'standby' ? 'active' : ''}"
                             onclick="setOperatingMode('standby')">Standby</button>
                     <button class="mode-btn ${features.operating_mode === 'standard' ? 'active' : ''}"
                             onclick="setOperatingMode('standard')">Standard</button>
                     <button class="mode-btn ${features.operating_mode === 'ventilation' ? 'active' : ''}"
-                            onclick="setOperatingMode('ventilation')">Lüftung</button>
+                            onclick="setOperatingMode('ventilation')">${t('vitovent.mode_btn_ventilation_300f')}</button>
                 </div>
             </div>
         `;
@@ -175,12 +175,12 @@ function renderVitoventDevice(data) {
                     <span class="state-value">${formatLevel(features.operating_state.level)}</span>
                 </div>
                 <div class="state-item">
-                    <span class="state-label">Grund:</span>
+                    <span class="state-label">${t('vitovent.reason')}</span>
                     <span class="state-value">${features.operating_state.reason || '-'}</span>
                 </div>
                 ${features.operating_state.demand ? `
                 <div class="state-item">
-                    <span class="state-label">Nachfrage:</span>
+                    <span class="state-label">${t('vitovent.demand')}</span>
                     <span class="state-value">${features.operating_state.demand}</span>
                 </div>
                 ` : ''}
@@ -193,7 +193,7 @@ function renderVitoventDevice(data) {
     // Quick Modes Section - device-specific
     html += `
         <div class="section quickmodes-section">
-            <div class="section-title">⚡ Schnellwahl-Modi</div>
+            <div class="section-title">${t('vitovent.section_quickmodes')}</div>
     `;
 
     if (isVitoair) {
@@ -202,8 +202,8 @@ function renderVitoventDevice(data) {
             const active = features.quickmode_intensive.active;
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('forcedLevelFour', ${!active})">
-                    <span>💨 Intensivlüftung ${active ? '(aktiv)' : ''}</span>
-                    <span class="quickmode-info">${features.quickmode_intensive.runtime || 30} Min</span>
+                    <span>${t('vitovent.quickmode_intensive')} ${active ? t('vitovent.quickmode_active') : ''}</span>
+                    <span class="quickmode-info">${features.quickmode_intensive.runtime || 30} ${t('vitovent.minutes')}</span>
                 </button>
             `;
         }
@@ -212,8 +212,8 @@ function renderVitoventDevice(data) {
             const active = features.quickmode_silent.active;
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('silent', ${!active})">
-                    <span>🔇 Geräuschreduziert ${active ? '(aktiv)' : ''}</span>
-                    <span class="quickmode-info">${features.quickmode_silent.runtime || 30} Min</span>
+                    <span>${t('vitovent.quickmode_silent')} ${active ? t('vitovent.quickmode_active') : ''}</span>
+                    <span class="quickmode-info">${features.quickmode_silent.runtime || 30} ${t('vitovent.minutes')}</span>
                 </button>
             `;
         }
@@ -222,8 +222,8 @@ function renderVitoventDevice(data) {
             const active = features.quickmode_shutdown.active;
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('temporaryShutdown', ${!active})">
-                    <span>⏸️ Temp. Abschaltung ${active ? '(aktiv)' : ''}</span>
-                    <span class="quickmode-info">${features.quickmode_shutdown.runtime || 360} Min</span>
+                    <span>${t('vitovent.quickmode_shutdown')} ${active ? t('vitovent.quickmode_active') : ''}</span>
+                    <span class="quickmode-info">${features.quickmode_shutdown.runtime || 360} ${t('vitovent.minutes')}</span>
                 </button>
             `;
         }
@@ -233,7 +233,7 @@ function renderVitoventDevice(data) {
             const active = features.quickmode_comfort.active;
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('comfort', ${!active})">
-                    <span>🛋️ Komfort ${active ? '(aktiv)' : ''}</span>
+                    <span>${t('vitovent.quickmode_comfort')} ${active ? t('vitovent.quickmode_active') : ''}</span>
                 </button>
             `;
         }
@@ -242,7 +242,7 @@ function renderVitoventDevice(data) {
             const active = features.quickmode_eco.active;
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('eco', ${!active})">
-                    <span>♻️ Eco ${active ? '(aktiv)' : ''}</span>
+                    <span>${t('vitovent.quickmode_eco')} ${active ? t('vitovent.quickmode_active') : ''}</span>
                 </button>
             `;
         }
@@ -250,11 +250,11 @@ function renderVitoventDevice(data) {
         if (features.quickmode_holiday) {
             const active = features.quickmode_holiday.active;
             const dateInfo = (features.quickmode_holiday.start || features.quickmode_holiday.end)
-                ? `${features.quickmode_holiday.start} bis ${features.quickmode_holiday.end}`
-                : 'nicht aktiv';
+                ? t('vitovent.holiday_range', features.quickmode_holiday.start, features.quickmode_holiday.end)
+                : t('vitovent.not_active');
             html += `
                 <button class="quickmode-button ${active ? 'active' : ''}" onclick="toggleQuickMode('holiday', ${!active})">
-                    <span>🏖️ Urlaubsmodus ${active ? '(aktiv)' : ''}</span>
+                    <span>${t('vitovent.quickmode_holiday')} ${active ? t('vitovent.quickmode_active') : ''}</span>
                     <span class="quickmode-info" style="font-size: 0.85em;">${dateInfo}</span>
                 </button>
             `;
@@ -268,14 +268,14 @@ function renderVitoventDevice(data) {
                    features.level_three_volumeflow !== undefined || features.level_four_volumeflow !== undefined)) {
         html += `
             <div class="section levels-section">
-                <div class="section-title">📊 Lüftungsstufen</div>
+                <div class="section-title">${t('vitovent.section_levels')}</div>
                 <div style="display: grid; grid-template-columns: repeat(2, 1fr); gap: 10px;">
         `;
 
         if (features.level_one_volumeflow !== undefined) {
             html += `
                 <div class="sensor-row">
-                    <span class="sensor-label">Stufe 1:</span>
+                    <span class="sensor-label">${t('vitovent.level_label', 1)}</span>
                     <span class="sensor-value">${features.level_one_volumeflow} m³/h</span>
                 </div>
             `;
@@ -283,7 +283,7 @@ function renderVitoventDevice(data) {
         if (features.level_two_volumeflow !== undefined) {
             html += `
                 <div class="sensor-row">
-                    <span class="sensor-label">Stufe 2:</span>
+                    <span class="sensor-label">${t('vitovent.level_label', 2)}</span>
                     <span class="sensor-value">${features.level_two_volumeflow} m³/h</span>
                 </div>
             `;
@@ -291,7 +291,7 @@ function renderVitoventDevice(data) {
         if (features.level_three_volumeflow !== undefined) {
             html += `
                 <div class="sensor-row">
-                    <span class="sensor-label">Stufe 3:</span>
+                    <span class="sensor-label">${t('vitovent.level_label', 3)}</span>
                     <span class="sensor-value">${features.level_three_volumeflow} m³/h</span>
                 </div>
             `;
@@ -299,7 +299,7 @@ function renderVitoventDevice(data) {
         if (features.level_four_volumeflow !== undefined) {
             html += `
                 <div class="sensor-row">
-                    <span class="sensor-label">Stufe 4:</span>
+                    <span class="sensor-label">${t('vitovent.level_label', 4)}</span>
                     <span class="sensor-value">${features.level_four_volumeflow} m³/h</span>
                 </div>
             `;
@@ -315,20 +315,20 @@ function renderVitoventDevice(data) {
     if (isVitoair) {
         html += `
             <div class="section temperature-section">
-                <div class="section-title">🌡️ Temperaturen</div>
+                <div class="section-title">${t('vitovent.section_temperatures')}</div>
         `;
     } else {
         // For 300F, only show volume flow section (no temperature sensors)
         html += `
             <div class="section temperature-section" style="display: none;">
-                <div class="section-title">🌡️ Temperaturen</div>
+                <div class="section-title">${t('vitovent.section_temperatures')}</div>
         `;
     }
 
     if (features.temp_supply !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Zuluft:</span>
+                <span class="sensor-label">${t('vitovent.supply_air')}</span>
                 <span class="sensor-value">${features.temp_supply.toFixed(1)}°C</span>
             </div>
         `;
@@ -336,7 +336,7 @@ function renderVitoventDevice(data) {
     if (features.temp_extract !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Abluft (Raum):</span>
+                <span class="sensor-label">${t('vitovent.extract_air')}</span>
                 <span class="sensor-value">${features.temp_extract.toFixed(1)}°C</span>
             </div>
         `;
@@ -344,7 +344,7 @@ function renderVitoventDevice(data) {
     if (features.temp_exhaust !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Fortluft:</span>
+                <span class="sensor-label">${t('vitovent.exhaust_air')}</span>
                 <span class="sensor-value">${features.temp_exhaust.toFixed(1)}°C</span>
             </div>
         `;
@@ -352,7 +352,7 @@ function renderVitoventDevice(data) {
     if (features.temp_outside !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Außentemperatur:</span>
+                <span class="sensor-label">${t('vitovent.outside_temperature')}</span>
                 <span class="sensor-value">${features.temp_outside.toFixed(1)}°C</span>
             </div>
         `;
@@ -360,7 +360,7 @@ function renderVitoventDevice(data) {
     if (features.heat_recovery !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Wärmerückgewinnung:</span>
+                <span class="sensor-label">${t('vitovent.heat_recovery')}</span>
                 <span class="sensor-value">${features.heat_recovery.toFixed(0)}%</span>
             </div>
         `;
@@ -371,13 +371,13 @@ function renderVitoventDevice(data) {
     // Humidity Section
     html += `
         <div class="section humidity-section">
-            <div class="section-title">💧 Luftfeuchte</div>
+            <div class="section-title">${t('vitovent.section_humidity')}</div>
     `;
 
     if (features.humidity_supply !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Zuluft:</span>
+                <span class="sensor-label">${t('vitovent.supply_air')}</span>
                 <span class="sensor-value">${features.humidity_supply.toFixed(0)}%</span>
             </div>
         `;
@@ -385,7 +385,7 @@ function renderVitoventDevice(data) {
     if (features.humidity_extract !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Abluft (Raum):</span>
+                <span class="sensor-label">${t('vitovent.extract_air')}</span>
                 <span class="sensor-value">${features.humidity_extract.toFixed(0)}%</span>
             </div>
         `;
@@ -393,7 +393,7 @@ function renderVitoventDevice(data) {
     if (features.humidity_exhaust !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Fortluft:</span>
+                <span class="sensor-label">${t('vitovent.exhaust_air')}</span>
                 <span class="sensor-value">${features.humidity_exhaust.toFixed(0)}%</span>
             </div>
         `;
@@ -401,7 +401,7 @@ function renderVitoventDevice(data) {
     if (features.humidity_outdoor !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Außenluft:</span>
+                <span class="sensor-label">${t('vitovent.outdoor_air')}</span>
                 <span class="sensor-value">${features.humidity_outdoor.toFixed(0)}%</span>
             </div>
         `;
@@ -412,13 +412,13 @@ function renderVitoventDevice(data) {
     // Volume Flow Section
     html += `
         <div class="section volumeflow-section">
-            <div class="section-title">💨 Luftmenge</div>
+            <div class="section-title">${t('vitovent.section_volumeflow')}</div>
     `;
 
     if (features.volumeflow_input !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Zu:</span>
+                <span class="sensor-label">${t('vitovent.flow_in')}</span>
                 <span class="sensor-value">${features.volumeflow_input.toFixed(0)} m³/h</span>
             </div>
         `;
@@ -426,7 +426,7 @@ function renderVitoventDevice(data) {
     if (features.volumeflow_output !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Ab:</span>
+                <span class="sensor-label">${t('vitovent.flow_out')}</span>
                 <span class="sensor-value">${features.volumeflow_output.toFixed(0)} m³/h</span>
             </div>
         `;
@@ -434,7 +434,7 @@ function renderVitoventDevice(data) {
     if (features.current_level !== undefined) {
         html += `
             <div class="sensor-row">
-                <span class="sensor-label">Aktuell Level:</span>
+                <span class="sensor-label">${t('vitovent.current_level')}</span>
                 <span class="sensor-value">${formatLevel(features.current_level)}</span>
             </div>
         `;
@@ -445,19 +445,19 @@ function renderVitoventDevice(data) {
     // Fan Status Section
     html += `
         <div class="section fan-section">
-            <div class="section-title">🔄 Ventilatoren</div>
+            <div class="section-title">${t('vitovent.section_fans')}</div>
     `;
 
     if (features.fan_supply) {
         const statusClass = features.fan_supply.status === 'connected' ? 'active' : 'inactive';
         html += `
             <div>
-                <div class="sensor-label">Zuluft-Ventilator</div>
+                <div class="sensor-label">${t('vitovent.fan_supply')}</div>
                 <div class="fan-status">
-                    <span class="status-badge ${statusClass}">${features.fan_supply.status || 'unbekannt'}</span>
-                    <span class="rpm-display">${features.fan_supply.current_rpm} U/min</span>
+                    <span class="status-badge ${statusClass}">${features.fan_supply.status || t('vitovent.unknown')}</span>
+                    <span class="rpm-display">${features.fan_supply.current_rpm} ${t('vitovent.rpm')}</span>
                 </div>
-                ${features.fan_supply_runtime !== undefined ? `<div class="sensor-label">Laufzeit: ${(features.fan_supply_runtime / 1).toFixed(0)} h</div>` : ''}
+                ${features.fan_supply_runtime !== undefined ? `<div class="sensor-label">${t('vitovent.runtime', (features.fan_supply_runtime / 1).toFixed(0))}</div>` : ''}
             </div>
         `;
     }
@@ -466,12 +466,12 @@ function renderVitoventDevice(data) {
         const statusClass = features.fan_exhaust.status === 'connected' ? 'active' : 'inactive';
         html += `
             <div style="margin-top: 12px;">
-                <div class="sensor-label">Fortluft-Ventilator</div>
+                <div class="sensor-label">${t('vitovent.fan_exhaust')}</div>
                 <div class="fan-status">
-                    <span class="status-badge ${statusClass}">${features.fan_exhaust.status || 'unbekannt'}</span>
-                    <span class="rpm-display">${features.fan_exhaust.current_rpm} U/min</span>
+                    <span class="status-badge ${statusClass}">${features.fan_exhaust.status || t('vitovent.unknown')}</span>
+                    <span class="rpm-display">${features.fan_exhaust.current_rpm} ${t('vitovent.rpm')}</span>
                 </div>
-                ${features.fan_exhaust_runtime !== undefined ? `<div class="sensor-label">Laufzeit: ${(features.fan_exhaust_runtime / 1).toFixed(0)} h</div>` : ''}
+                ${features.fan_exhaust_runtime !== undefined ? `<div class="sensor-label">${t('vitovent.runtime', (features.fan_exhaust_runtime / 1).toFixed(0))}</div>` : ''}
             </div>
         `;
     }
@@ -487,7 +487,7 @@ function renderVitoventDevice(data) {
     if (features.filter_pollution) {
         const pollution = features.filter_pollution.pollution || 0;
         html += `
-            <div class="sensor-label">Verschmutzung: ${pollution.toFixed(0)}%</div>
+            <div class="sensor-label">${t('vitovent.filter_pollution', pollution.toFixed(0))}</div>
             <div class="filter-bar">
                 <div class="filter-fill" style="width: ${Math.min(pollution, 100)}%"></div>
             </div>
@@ -497,11 +497,11 @@ function renderVitoventDevice(data) {
     if (features.filter_runtime) {
         html += `
             <div class="sensor-row" style="margin-top: 8px;">
-                <span class="sensor-label">Verbleibend:</span>
+                <span class="sensor-label">${t('vitovent.filter_remaining')}</span>
                 <span class="sensor-value">${features.filter_runtime.remaining_hours || '-'} h</span>
             </div>
             <div class="sensor-row">
-                <span class="sensor-label">Betrieb:</span>
+                <span class="sensor-label">${t('vitovent.filter_operating')}</span>
                 <span class="sensor-value">${features.filter_runtime.operating_hours || '-'} h</span>
             </div>
         `;
@@ -516,16 +516,16 @@ function renderVitoventDevice(data) {
                 <div class="section-title">🌡️ Bypass</div>
                 <div class="bypass-section">
                     <div class="status-badge ${features.bypass_available ? 'active' : 'inactive'}">
-                        ${features.bypass_available ? 'Vorhanden' : 'Nicht vorhanden'}
+                        ${features.bypass_available ? t('vitovent.bypass_available') : t('vitovent.bypass_not_available')}
                     </div>
                     ${features.bypass_mode ? `
                         <div class="bypass-info">
                             <div class="state-item">
-                                <span class="state-label">Betriebsart:</span>
+                                <span class="state-label">${t('vitovent.bypass_state')}</span>
                                 <span class="state-value">${features.bypass_mode.state || '-'}</span>
                             </div>
                             <div class="state-item">
-                                <span class="state-label">Arbeitsweise:</span>
+                                <span class="state-label">${t('vitovent.bypass_level')}</span>
                                 <span class="state-value">${formatBypassLevel(features.bypass_mode.level)}</span>
                             </div>
                         </div>
@@ -541,7 +541,7 @@ function renderVitoventDevice(data) {
                     ${features.bypass_temp_dynamic !== undefined ? `
                         <div class="bypass-info">
                             <div class="state-item">
-                                <span class="state-label">Min. Temp (Dynamisch):</span>
+                                <span class="state-label">${t('vitovent.bypass_temp_dynamic')}</span>
                                 <span class="state-value">${features.bypass_temp_dynamic.toFixed(1)}°C</span>
                             </div>
                         </div>
@@ -549,7 +549,7 @@ function renderVitoventDevice(data) {
                     ${features.bypass_temp_smooth !== undefined ? `
                         <div class="bypass-info">
                             <div class="state-item">
-                                <span class="state-label">Min. Temp (Sanft):</span>
+                                <span class="state-label">${t('vitovent.bypass_temp_smooth')}</span>
                                 <span class="state-value">${features.bypass_temp_smooth.toFixed(1)}°C</span>
                             </div>
                         </div>
@@ -557,7 +557,7 @@ function renderVitoventDevice(data) {
                     ${features.bypass_target_temp !== undefined ? `
                         <div class="bypass-info">
                             <div class="state-item">
-                                <span class="state-label">Zieltemperatur:</span>
+                                <span class="state-label">${t('vitovent.bypass_target_temp')}</span>
                                 <span class="state-value">${features.bypass_target_temp.toFixed(1)}°C</span>
                             </div>
                         </div>
@@ -585,10 +585,10 @@ function renderVitoventDevice(data) {
 function formatOperatingMode(mode) {
     const modes = {
         // VitoAir modes
-        'permanent': 'Konstantbetrieb',
-        'ventilation': 'Zeitprogramm',
-        'sensorOverride': 'Zeitprogramm + Sensor',
-        'sensorDriven': 'Sensor-Automatikmodus',
+        'permanent': t('vitovent.mode_permanent'),
+        'ventilation': t('vitovent.mode_ventilation'),
+        'sensorOverride': t('vitovent.mode_sensor_override'),
+        'sensorDriven': t('vitovent.mode_sensor_driven'),
         // Vitovent 300F modes
         'standby': 'Standby',
         'standard': 'Standard',
@@ -598,18 +598,18 @@ function formatOperatingMode(mode) {
 
 function formatLevel(level) {
     const levels = {
-        'levelOne': 'Stufe 1',
-        'levelTwo': 'Stufe 2',
-        'levelThree': 'Stufe 3',
-        'levelFour': 'Stufe 4'
+        'levelOne': t('vitovent.level', 1),
+        'levelTwo': t('vitovent.level', 2),
+        'levelThree': t('vitovent.level', 3),
+        'levelFour': t('vitovent.level', 4)
     };
     return levels[level] || level;
 }
 
 function formatBypassLevel(level) {
     const levels = {
-        'dynamicRegulationMode': 'Dynamisch',
-        'smoothRegulation': 'Geräuschreduziert'
+        'dynamicRegulationMode': t('vitovent.bypass_dynamic'),
+        'smoothRegulation': t('vitovent.bypass_smooth')
     };
     return levels[level] || level;
 }
@@ -647,14 +647,14 @@ async function setOperatingMode(mode) {
         const result = await response.json();
 
         if (result.success) {
-            showSuccess(`Betriebsmodus zu "${formatOperatingMode(mode)}" geändert`);
+            showSuccess(t('vitovent.mode_changed', formatOperatingMode(mode)));
             // Reload data after a short delay (force refresh to clear cache)
             setTimeout(() => loadVitoventData(true), 1000);
         } else {
-            showError('Fehler: ' + (result.error || 'Unbekannter Fehler'));
+            showError(t('device.error', result.error || t('device.unknown_error')));
         }
     } catch (error) {
-        showError('Fehler beim Ändern des Modus: ' + error.message);
+        showError(t('vitovent.mode_error', error.message));
     }
 }
 
@@ -678,21 +678,20 @@ async function toggleQuickMode(mode, activate) {
         const result = await response.json();
 
         if (result.success) {
-            const action = activate ? 'aktiviert' : 'deaktiviert';
-            showSuccess(`Modus ${action}`);
+            showSuccess(activate ? t('vitovent.quickmode_activated') : t('vitovent.quickmode_deactivated'));
             // Reload data after a short delay (force refresh to clear cache)
             setTimeout(() => loadVitoventData(true), 1000);
         } else {
-            showError('Fehler: ' + (result.error || 'Unbekannter Fehler'));
+            showError(t('device.error', result.error || t('device.unknown_error')));
         }
     } catch (error) {
-        showError('Fehler: ' + error.message);
+        showError(t('device.error', error.message));
     }
 }
 
 function updateLastUpdate() {
     const now = new Date();
-    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString('de-DE', {
+    document.getElementById('lastUpdate').textContent = now.toLocaleTimeString(uiLocale(), {
         hour: '2-digit',
         minute: '2-digit',
         second: '2-digit'
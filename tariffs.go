@@ -0,0 +1,809 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time-of-use electricity tariffs
+//
+// A tariff consists of periods with an effective-from date. Each period has a base price and
+// optional time windows (e.g. night 22:00-06:00) with their own price. Dynamic tariffs get hourly
+// (or quarter-hourly) prices imported from CSV or pushed by a local script; imported prices take
+// precedence over the windows and the tariff surcharge (grid fees, taxes) is added to them.
+// Devices without a tariff keep using the flat DeviceSettings.ElectricityPrice.
+
+const defaultElectricityPrice = 0.30 // EUR/kWh, same default as the dashboard
+
+// TariffSettings holds all defined tariffs
+type TariffSettings struct {
+	Tariffs []*Tariff `json:"tariffs"`
+}
+
+// Tariff is an electricity tariff, prices in EUR/kWh
+type Tariff struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Periods   []TariffPeriod `json:"periods"`
+	Surcharge float64        `json:"surcharge,omitempty"` // Added to imported hourly prices
+}
+
+// TariffPeriod are the prices valid from a date (local time) until the next period starts
+type TariffPeriod struct {
+	EffectiveFrom string         `json:"effectiveFrom"` // YYYY-MM-DD
+	BasePrice     float64        `json:"basePrice"`     // Price outside of the windows
+	Windows       []TariffWindow `json:"windows,omitempty"`
+}
+
+// TariffWindow is a daily time window with its own price, the first matching window wins
+type TariffWindow struct {
+	Days  []int   `json:"days,omitempty"` // Weekdays 0=Sunday..6=Saturday, empty = every day
+	Start string  `json:"start"`          // HH:MM
+	End   string  `json:"end"`            // HH:MM, an end before the start wraps past midnight
+	Price float64 `json:"price"`
+}
+
+// TariffPrice is an imported price slot
+type TariffPrice struct {
+	Start string  `json:"start"` // RFC3339
+	Price float64 `json:"price"` // EUR/kWh without surcharge
+}
+
+// tariffPriceSlot is a parsed TariffPrice
+type tariffPriceSlot struct {
+	start time.Time
+	price float64
+}
+
+// GetTariffSettings returns the tariff settings
+func GetTariffSettings() (*TariffSettings, error) {
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := store.TariffSettings
+	if settings == nil {
+		settings = &TariffSettings{}
+	}
+	applyTariffDefaults(settings)
+	return settings, nil
+}
+
+// SetTariffSettings updates the tariff settings
+func SetTariffSettings(settings *TariffSettings) error {
+	store, err := LoadAccounts()
+	if err != nil {
+		return err
+	}
+
+	store.TariffSettings = settings
+	return SaveAccounts(store)
+}
+
+// GetTariff returns a tariff by ID
+func GetTariff(id string) (*Tariff, error) {
+	settings, err := GetTariffSettings()
+	if err != nil {
+		return nil, err
+	}
+	for _, tariff := range settings.Tariffs {
+		if tariff.ID == id {
+			return tariff, nil
+		}
+	}
+	return nil, fmt.Errorf("tariff %q not found", id)
+}
+
+func applyTariffDefaults(settings *TariffSettings) {
+	if settings.Tariffs == nil {
+		settings.Tariffs = []*Tariff{}
+	}
+	for _, tariff := range settings.Tariffs {
+		if tariff.ID == "" {
+			tariff.ID = newAlertID()
+		}
+		if tariff.Periods == nil {
+			tariff.Periods = []TariffPeriod{}
+		}
+		sort.SliceStable(tariff.Periods, func(i, j int) bool {
+			return tariff.Periods[i].EffectiveFrom < tariff.Periods[j].EffectiveFrom
+		})
+	}
+}
+
+// validateTariffSettings checks the tariffs before saving
+func validateTariffSettings(settings *TariffSettings) error {
+	ids := make(map[string]bool)
+	for _, tariff := range settings.Tariffs {
+		if strings.TrimSpace(tariff.Name) == "" {
+			return fmt.Errorf("tariff name is required")
+		}
+		if ids[tariff.ID] {
+			return fmt.Errorf("tariff %q: duplicate id %q", tariff.Name, tariff.ID)
+		}
+		ids[tariff.ID] = true
+		if len(tariff.Periods) == 0 {
+			return fmt.Errorf("tariff %q: at least one period is required", tariff.Name)
+		}
+		if tariff.Surcharge < 0 {
+			return fmt.Errorf("tariff %q: surcharge must not be negative", tariff.Name)
+		}
+
+		dates := make(map[string]bool)
+		for _, period := range tariff.Periods {
+			if _, err := time.Parse("2006-01-02", period.EffectiveFrom); err != nil {
+				return fmt.Errorf("tariff %q: invalid effective-from date %q (YYYY-MM-DD)", tariff.Name, period.EffectiveFrom)
+			}
+			if dates[period.EffectiveFrom] {
+				return fmt.Errorf("tariff %q: two periods start on %s", tariff.Name, period.EffectiveFrom)
+			}
+			dates[period.EffectiveFrom] = true
+			if period.BasePrice < 0 {
+				return fmt.Errorf("tariff %q: price must not be negative", tariff.Name)
+			}
+			for _, window := range period.Windows {
+				start, errStart := parseClock(window.Start)
+				end, errEnd := parseClock(window.End)
+				if errStart != nil || errEnd != nil {
+					return fmt.Errorf("tariff %q: invalid window %s-%s (HH:MM)", tariff.Name, window.Start, window.End)
+				}
+				if start == end {
+					return fmt.Errorf("tariff %q: window %s-%s is empty", tariff.Name, window.Start, window.End)
+				}
+				if window.Price < 0 {
+					return fmt.Errorf("tariff %q: price must not be negative", tariff.Name)
+				}
+				for _, day := range window.Days {
+					if day < 0 || day > 6 {
+						return fmt.Errorf("tariff %q: invalid weekday %d (0=Sunday..6=Saturday)", tariff.Name, day)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseClock parses HH:MM into minutes since midnight, 24:00 is allowed as end of day
+func parseClock(s string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, errH := strconv.Atoi(parts[0])
+	m, errM := strconv.Atoi(parts[1])
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// periodAt returns the period valid at t, nil before the first period
+func (t *Tariff) periodAt(at time.Time) *TariffPeriod {
+	day := at.In(DefaultLocation).Format("2006-01-02")
+	var found *TariffPeriod
+	for i := range t.Periods {
+		if t.Periods[i].EffectiveFrom <= day {
+			found = &t.Periods[i]
+		}
+	}
+	return found
+}
+
+// priceAt returns the window or base price of the period at t
+func (p *TariffPeriod) priceAt(at time.Time) float64 {
+	local := at.In(DefaultLocation)
+	minute := local.Hour()*60 + local.Minute()
+	weekday := int(local.Weekday())
+	for _, window := range p.Windows {
+		start, errStart := parseClock(window.Start)
+		end, errEnd := parseClock(window.End)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if start < end {
+			if minute >= start && minute < end && window.onDay(weekday) {
+				return window.Price
+			}
+			continue
+		}
+		// Window wraps past midnight, the part after midnight belongs to the previous day
+		if minute >= start && window.onDay(weekday) {
+			return window.Price
+		}
+		if minute < end && window.onDay((weekday+6)%7) {
+			return window.Price
+		}
+	}
+	return p.BasePrice
+}
+
+func (w TariffWindow) onDay(weekday int) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// createTariffTables creates the table of imported tariff prices, dbMutex must be held
+func createTariffTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS tariff_prices (
+		tariff_id TEXT NOT NULL,
+		start_time TEXT NOT NULL,
+		price REAL NOT NULL,
+		imported_at TEXT NOT NULL,
+		PRIMARY KEY (tariff_id, start_time)
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tariff tables: %v", err)
+	}
+	return nil
+}
+
+// SaveTariffPrices stores imported price slots, existing slots are overwritten
+func SaveTariffPrices(tariffID string, slots []tariffPriceSlot) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO tariff_prices (tariff_id, start_time, price, imported_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(tariff_id, start_time) DO UPDATE SET price = excluded.price, imported_at = excluded.imported_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %v", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, slot := range slots {
+		if _, err := stmt.Exec(tariffID, slot.start.UTC().Format(time.RFC3339), slot.price, now); err != nil {
+			return fmt.Errorf("failed to save tariff price: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tariff prices: %v", err)
+	}
+	return nil
+}
+
+// GetTariffPrices returns the imported prices of a tariff in [start, end)
+func GetTariffPrices(tariffID string, start, end time.Time) ([]TariffPrice, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	slots, err := tariffPriceSlotsLocked(tariffID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	prices := make([]TariffPrice, 0, len(slots))
+	for _, slot := range slots {
+		prices = append(prices, TariffPrice{Start: slot.start.In(DefaultLocation).Format(time.RFC3339), Price: slot.price})
+	}
+	return prices, nil
+}
+
+// DeleteTariffPrices removes all imported prices of a tariff
+func DeleteTariffPrices(tariffID string) error {
+	dbMutex.Lock()
+	defer dbMutex.Unlock()
+
+	if !dbInitialized || eventDB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if _, err := eventDB.Exec("DELETE FROM tariff_prices WHERE tariff_id = ?", tariffID); err != nil {
+		return fmt.Errorf("failed to delete tariff prices: %v", err)
+	}
+	return nil
+}
+
+// tariffPriceSlotsLocked reads the price slots of [start, end) ordered by start, dbMutex must be held
+func tariffPriceSlotsLocked(tariffID string, start, end time.Time) ([]tariffPriceSlot, error) {
+	rows, err := eventDB.Query(`
+		SELECT start_time, price FROM tariff_prices
+		WHERE tariff_id = ? AND start_time >= ? AND start_time < ?
+		ORDER BY start_time ASC
+	`, tariffID, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tariff prices: %v", err)
+	}
+	defer rows.Close()
+
+	var slots []tariffPriceSlot
+	for rows.Next() {
+		var startStr string
+		var price float64
+		if err := rows.Scan(&startStr, &price); err != nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			continue
+		}
+		slots = append(slots, tariffPriceSlot{start: t, price: price})
+	}
+	return slots, rows.Err()
+}
+
+// tariffPriceUnits converts imported prices to EUR/kWh
+var tariffPriceUnits = map[string]float64{
+	"":    1,
+	"eur": 1,     // EUR/kWh
+	"ct":  0.01,  // ct/kWh
+	"mwh": 0.001, // EUR/MWh (exchange prices)
+}
+
+// parseTariffTime parses the start of a price slot, times without zone are local
+func parseTariffTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05", "02.01.2006 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, DefaultLocation); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// parseTariffPriceCSV reads "start;price" or "start,price" lines. A header line is skipped,
+// with ";" as delimiter a decimal comma is accepted.
+func parseTariffPriceCSV(r io.Reader, unit string) ([]tariffPriceSlot, error) {
+	factor, ok := tariffPriceUnits[strings.ToLower(unit)]
+	if !ok {
+		return nil, fmt.Errorf("invalid unit %q (eur, ct, mwh)", unit)
+	}
+
+	var slots []tariffPriceSlot
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		delimiter := ","
+		if strings.Contains(line, ";") {
+			delimiter = ";"
+		}
+		fields := strings.Split(line, delimiter)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected start and price", lineNo)
+		}
+
+		start, err := parseTariffTime(fields[0])
+		if err != nil {
+			if len(slots) == 0 && lineNo == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		priceStr := strings.TrimSpace(fields[1])
+		if delimiter == ";" {
+			priceStr = strings.Replace(priceStr, ",", ".", 1)
+		}
+		price, err := strconv.ParseFloat(priceStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price %q", lineNo, fields[1])
+		}
+		slots = append(slots, tariffPriceSlot{start: start, price: price * factor})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %v", err)
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("no prices found")
+	}
+	return slots, nil
+}
+
+// tariffPricer returns prices of a tariff, with the imported slots of a time range preloaded
+type tariffPricer struct {
+	tariff    *Tariff // nil = flat price
+	flatPrice float64 // Device price, used without tariff or before its first period
+	slots     []tariffPriceSlot
+}
+
+// newTariffPricerLocked prepares the prices of [start, end), dbMutex must be held
+func newTariffPricerLocked(tariff *Tariff, flatPrice float64, start, end time.Time) *tariffPricer {
+	p := &tariffPricer{tariff: tariff, flatPrice: flatPrice}
+	if tariff != nil {
+		slots, err := tariffPriceSlotsLocked(tariff.ID, start.Add(-time.Hour), end)
+		if err != nil {
+			log.Printf("Warning: failed to load prices of tariff %s: %v", tariff.ID, err)
+		}
+		p.slots = slots
+	}
+	return p
+}
+
+// priceAt returns the price at t in EUR/kWh
+func (p *tariffPricer) priceAt(at time.Time) float64 {
+	if p.tariff == nil {
+		return p.flatPrice
+	}
+
+	// Imported slot covering t: a slot lasts until the next one, the last one of a series
+	// as long as its predecessor, at most one hour
+	i := sort.Search(len(p.slots), func(i int) bool { return p.slots[i].start.After(at) }) - 1
+	if i >= 0 {
+		length := time.Hour
+		if i+1 < len(p.slots) && p.slots[i+1].start.Sub(p.slots[i].start) < length {
+			length = p.slots[i+1].start.Sub(p.slots[i].start)
+		} else if i > 0 && p.slots[i].start.Sub(p.slots[i-1].start) < length {
+			length = p.slots[i].start.Sub(p.slots[i-1].start)
+		}
+		if at.Before(p.slots[i].start.Add(length)) {
+			return p.slots[i].price + p.tariff.Surcharge
+		}
+	}
+
+	if period := p.tariff.periodAt(at); period != nil {
+		return period.priceAt(at)
+	}
+	return p.flatPrice
+}
+
+// hourPrice returns the average price of the hour starting at hourStart (quarter-hour resolution)
+func (p *tariffPricer) hourPrice(hourStart time.Time) float64 {
+	sum := 0.0
+	for q := 0; q < 4; q++ {
+		sum += p.priceAt(hourStart.Add(time.Duration(q) * 15 * time.Minute))
+	}
+	return sum / 4
+}
+
+// averagePrice returns the time-weighted average price of [start, end)
+func (p *tariffPricer) averagePrice(start, end time.Time) float64 {
+	sum, n := 0.0, 0
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		sum += p.hourPrice(t)
+		n++
+	}
+	if n == 0 {
+		return p.priceAt(start)
+	}
+	return sum / float64(n)
+}
+
+// devicePricing returns the assigned tariff (nil if none), the flat price and the power
+// correction factor of a device from the device settings of the first account that has them
+func devicePricing(installationID, deviceID string) (*Tariff, float64, float64) {
+	flatPrice, factor := defaultElectricityPrice, 1.0
+	store, err := LoadAccounts()
+	if err != nil {
+		return nil, flatPrice, factor
+	}
+
+	deviceKey := fmt.Sprintf("%s_%s", installationID, deviceID)
+	for _, account := range store.Accounts {
+		settings, ok := account.DeviceSettings[deviceKey]
+		if !ok || settings == nil {
+			continue
+		}
+		if settings.ElectricityPrice > 0 {
+			flatPrice = settings.ElectricityPrice
+		}
+		if settings.CompressorPowerCorrectionFactor > 0 {
+			factor = settings.CompressorPowerCorrectionFactor
+		}
+		if settings.TariffID != "" && store.TariffSettings != nil {
+			for _, tariff := range store.TariffSettings.Tariffs {
+				if tariff.ID == settings.TariffID {
+					return tariff, flatPrice, factor
+				}
+			}
+		}
+		return nil, flatPrice, factor
+	}
+	return nil, flatPrice, factor
+}
+
+// hourlyElectricityLocked returns the electrical energy (kWh) of [start, end) per hour (unix
+// seconds of the hour start). Hours covered by the hourly rollups are read from them, the
+// rest from the snapshots. dbMutex must be held.
+func hourlyElectricityLocked(installationID, gatewayID, deviceID string, start, end time.Time, fallbackInterval int) (map[int64]float64, error) {
+	energy := make(map[int64]float64)
+	rawRanges := [][2]time.Time{{start, end}}
+
+	if from, until, ok := rollupCoverageLocked(RollupHourly); ok && from.Before(end) && until.After(start) {
+		innerStart, innerEnd := from, until
+		if innerStart.Before(start) {
+			innerStart = start
+		}
+		if innerEnd.After(end) {
+			innerEnd = end
+		}
+		rawRanges = [][2]time.Time{{start, innerStart}, {innerEnd, end}}
+
+		rows, err := eventDB.Query(`
+			SELECT bucket_start, electricity_wh FROM `+rollupTable(RollupHourly)+`
+			WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+				AND bucket_start >= ? AND bucket_start < ?
+		`, installationID, gatewayID, deviceID, innerStart.UTC().Format(time.RFC3339), innerEnd.UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hourly rollups: %v", err)
+		}
+		for rows.Next() {
+			var bucket string
+			var wh float64
+			if err := rows.Scan(&bucket, &wh); err != nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, bucket); err == nil {
+				energy[t.Unix()] += wh / 1000.0
+			}
+		}
+		rows.Close()
+	}
+
+	for _, r := range rawRanges {
+		if !r[0].Before(r[1]) {
+			continue
+		}
+		rows, err := eventDB.Query(`
+			SELECT STRFTIME('%Y-%m-%dT%H:00:00Z', timestamp) as hour,
+				SUM(COALESCE(compressor_power, 0) * COALESCE(sample_interval, ?) / 60.0) as electricity_wh
+			FROM temperature_snapshots
+			WHERE installation_id = ? AND gateway_id = ? AND device_id = ?
+				AND timestamp >= ? AND timestamp < ?
+			GROUP BY hour
+		`, fallbackInterval, installationID, gatewayID, deviceID, r[0].UTC().Format(time.RFC3339), r[1].UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hourly consumption: %v", err)
+		}
+		for rows.Next() {
+			var hour string
+			var wh *float64
+			if err := rows.Scan(&hour, &wh); err != nil || wh == nil {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, hour); err == nil {
+				energy[t.Unix()] += *wh / 1000.0
+			}
+		}
+		rows.Close()
+	}
+	return energy, nil
+}
+
+// costOfBucket prices the consumption of a bucket hour by hour. Energy of the bucket that is
+// not available per hour (hourly rollups already deleted) is priced at the average price.
+func costOfBucket(p *tariffPricer, energy map[int64]float64, start, end time.Time, electricityKWh float64) float64 {
+	cost, covered := 0.0, 0.0
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		if kwh := energy[t.Unix()]; kwh > 0 {
+			cost += kwh * p.hourPrice(t)
+			covered += kwh
+		}
+	}
+	if rest := electricityKWh - covered; rest > 0.0005 {
+		cost += rest * p.averagePrice(start, end)
+	} else if rest < -0.0005 {
+		// Bucket grouped differently (SQLite localtime vs. DefaultLocation), keep the bucket energy
+		cost *= electricityKWh / covered
+	}
+	return cost
+}
+
+// setPointCost stores cost and effective price in a data point
+func setPointCost(point *ConsumptionDataPoint, cost, fallbackPrice float64) {
+	cost = math.Round(cost*10000) / 10000
+	price := fallbackPrice
+	if point.ElectricityKWh > 0 && cost > 0 {
+		price = cost / point.ElectricityKWh
+	}
+	price = math.Round(price*10000) / 10000
+	point.CostEUR = &cost
+	point.AvgPrice = &price
+}
+
+// applyHourlyCostsLocked sets the cost of hourly points (local hours), dbMutex must be held
+func applyHourlyCostsLocked(points []ConsumptionDataPoint, installationID, deviceID string) {
+	if len(points) == 0 {
+		return
+	}
+	tariff, flatPrice, factor := devicePricing(installationID, deviceID)
+	pricer := newTariffPricerLocked(tariff, flatPrice, points[0].Timestamp, points[len(points)-1].Timestamp.Add(time.Hour))
+	for i := range points {
+		price := pricer.hourPrice(points[i].Timestamp)
+		setPointCost(&points[i], points[i].ElectricityKWh*factor*price, price)
+	}
+}
+
+// applyDailyCostsLocked sets the cost of daily points (stamped with the local date), dbMutex must be held
+func applyDailyCostsLocked(points []ConsumptionDataPoint, installationID, gatewayID, deviceID string, fallbackInterval int) {
+	if len(points) == 0 {
+		return
+	}
+	tariff, flatPrice, factor := devicePricing(installationID, deviceID)
+	start, end := dailyPointRange(points[0]), dailyPointRange(points[len(points)-1]).AddDate(0, 0, 1)
+	pricer := newTariffPricerLocked(tariff, flatPrice, start, end)
+
+	var energy map[int64]float64
+	if tariff != nil {
+		var err error
+		if energy, err = hourlyElectricityLocked(installationID, gatewayID, deviceID, start, end, fallbackInterval); err != nil {
+			log.Printf("Warning: failed to load hourly consumption for costs: %v", err)
+		}
+	}
+
+	for i := range points {
+		dayStart := dailyPointRange(points[i])
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		cost := costOfBucket(pricer, energy, dayStart, dayEnd, points[i].ElectricityKWh) * factor
+		setPointCost(&points[i], cost, pricer.averagePrice(dayStart, dayEnd))
+	}
+}
+
+// dailyPointRange returns local midnight of a daily point
+func dailyPointRange(point ConsumptionDataPoint) time.Time {
+	ts := point.Timestamp
+	return time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, DefaultLocation)
+}
+
+// sumConsumptionCosts adds up the costs of breakdown points, nil if a point has no cost
+func sumConsumptionCosts(points []ConsumptionDataPoint) *float64 {
+	if len(points) == 0 {
+		return nil
+	}
+	total := 0.0
+	for _, point := range points {
+		if point.CostEUR == nil {
+			return nil
+		}
+		total += *point.CostEUR
+	}
+	total = math.Round(total*100) / 100
+	return &total
+}
+
+// TariffComparison is the cost of past consumption under different tariffs
+type TariffComparison struct {
+	From             string                   `json:"from"`
+	To               string                   `json:"to"`
+	ElectricityKWh   float64                  `json:"electricityKWh"` // Corrected consumption
+	CorrectionFactor float64                  `json:"correctionFactor"`
+	Results          []TariffComparisonResult `json:"results"`
+}
+
+// TariffComparisonResult is the cost under one tariff, TariffID is empty for the flat device price
+type TariffComparisonResult struct {
+	TariffID string          `json:"tariffId"`
+	Name     string          `json:"name"`
+	Assigned bool            `json:"assigned"` // Currently used for the device
+	CostEUR  float64         `json:"costEur"`
+	AvgPrice float64         `json:"avgPrice"`
+	Days     []TariffCostDay `json:"days"`
+}
+
+// TariffCostDay is the cost of one day in a comparison
+type TariffCostDay struct {
+	Date           string  `json:"date"`
+	ElectricityKWh float64 `json:"electricityKWh"`
+	CostEUR        float64 `json:"costEur"`
+}
+
+// CompareTariffs calculates the cost of the consumption of the local days [startDate, endDate]
+// under each given tariff and the flat device price. Empty tariffIDs compares all tariffs.
+func CompareTariffs(installationID, gatewayID, deviceID string, startDate, endDate time.Time, tariffIDs []string) (*TariffComparison, error) {
+	settings, err := GetTariffSettings()
+	if err != nil {
+		return nil, err
+	}
+	var tariffs []*Tariff
+	if len(tariffIDs) == 0 {
+		tariffs = settings.Tariffs
+	}
+	for _, id := range tariffIDs {
+		found := false
+		for _, tariff := range settings.Tariffs {
+			if tariff.ID == id {
+				tariffs = append(tariffs, tariff)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("tariff %q not found", id)
+		}
+	}
+
+	logSettings, err := GetTemperatureLogSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get temperature log settings: %v", err)
+	}
+	assigned, flatPrice, factor := devicePricing(installationID, deviceID)
+
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, DefaultLocation)
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, DefaultLocation).AddDate(0, 0, 1)
+	points, err := dailyBreakdownLocked(installationID, gatewayID, deviceID, start, end, logSettings.SampleInterval, logSettings.RetentionDays)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := hourlyElectricityLocked(installationID, gatewayID, deviceID, start, end, logSettings.SampleInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &TariffComparison{
+		From:             start.Format("2006-01-02"),
+		To:               end.AddDate(0, 0, -1).Format("2006-01-02"),
+		CorrectionFactor: factor,
+		Results:          []TariffComparisonResult{},
+	}
+	for _, point := range points {
+		comparison.ElectricityKWh += point.ElectricityKWh * factor
+	}
+	comparison.ElectricityKWh = math.Round(comparison.ElectricityKWh*1000) / 1000
+
+	compare := func(tariff *Tariff) {
+		result := TariffComparisonResult{Name: "Flat", Assigned: assigned == nil, Days: []TariffCostDay{}}
+		if tariff != nil {
+			result.TariffID, result.Name = tariff.ID, tariff.Name
+			result.Assigned = assigned != nil && assigned.ID == tariff.ID
+		}
+		pricer := newTariffPricerLocked(tariff, flatPrice, start, end)
+		for _, point := range points {
+			dayStart := dailyPointRange(point)
+			cost := costOfBucket(pricer, energy, dayStart, dayStart.AddDate(0, 0, 1), point.ElectricityKWh) * factor
+			result.CostEUR += cost
+			result.Days = append(result.Days, TariffCostDay{
+				Date:           dayStart.Format("2006-01-02"),
+				ElectricityKWh: math.Round(point.ElectricityKWh*factor*1000) / 1000,
+				CostEUR:        math.Round(cost*100) / 100,
+			})
+		}
+		if comparison.ElectricityKWh > 0 {
+			result.AvgPrice = math.Round(result.CostEUR/comparison.ElectricityKWh*10000) / 10000
+		}
+		result.CostEUR = math.Round(result.CostEUR*100) / 100
+		comparison.Results = append(comparison.Results, result)
+	}
+
+	compare(nil)
+	for _, tariff := range tariffs {
+		compare(tariff)
+	}
+	sort.SliceStable(comparison.Results, func(i, j int) bool {
+		return comparison.Results[i].CostEUR < comparison.Results[j].CostEUR
+	})
+	return comparison, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCostOfBucket(t *testing.T) {
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	hour := func(i int) int64 { return start.Add(time.Duration(i) * time.Hour).Unix() }
+
+	// Imported hourly prices 0.20, 0.40, 0.10, 0.30 EUR/kWh, average 0.25
+	var slots []tariffPriceSlot
+	for i, price := range []float64{0.20, 0.40, 0.10, 0.30} {
+		slots = append(slots, tariffPriceSlot{start: start.Add(time.Duration(i) * time.Hour), price: price})
+	}
+	hourly := &tariffPricer{tariff: &Tariff{ID: "dynamic"}, flatPrice: 0.35, slots: slots}
+	flat := &tariffPricer{flatPrice: 0.35}
+
+	tests := []struct {
+		name           string
+		pricer         *tariffPricer
+		energy         map[int64]float64
+		electricityKWh float64
+		want           float64
+	}{
+		{"fully covered by hourly energy", hourly, map[int64]float64{hour(0): 1, hour(1): 2, hour(3): 1}, 4, 1.3},
+		{"rounding difference is ignored", hourly, map[int64]float64{hour(0): 1, hour(1): 2, hour(3): 1}, 4.0004, 1.3},
+		{"rest at the average price", hourly, map[int64]float64{hour(1): 1}, 3, 0.9},
+		{"no hourly energy", hourly, nil, 2, 0.5},
+		{"hourly energy above the bucket scaled down", hourly, map[int64]float64{hour(0): 1, hour(1): 1}, 1, 0.3},
+		{"energy outside the bucket ignored", hourly, map[int64]float64{hour(4): 5}, 1, 0.25},
+		{"flat price", flat, map[int64]float64{hour(0): 1}, 2, 0.7},
+		{"no energy", hourly, nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := costOfBucket(tt.pricer, tt.energy, start, end, tt.electricityKWh); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("costOfBucket = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
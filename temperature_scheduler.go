@@ -19,12 +19,9 @@ var (
 	tempJobMutex   sync.Mutex
 	tempJobRunning bool
 
-	// API Rate Limiting tracking
-	apiCallsMutex sync.Mutex
-	apiCalls10Min []time.Time // Track calls in 10-minute window
-	apiCalls24Hr  []time.Time // Track calls in 24-hour window
-	apiLimit10Min = 110       // Conservative limit (120 - buffer)
-	apiLimit24Hr  = 1400      // Conservative limit (1450 - buffer)
+	// API rate limits per client ID (enforced by the request broker, see api_broker.go)
+	apiLimit10Min = 110  // Conservative limit (120 - buffer)
+	apiLimit24Hr  = 1400 // Conservative limit (1450 - buffer)
 )
 
 // StartTemperatureScheduler starts the background job for periodic temperature logging
@@ -185,10 +182,25 @@ func temperatureLoggingJob() {
 		return
 	}
 
+	// Track duration and errors for /metrics
+	start := time.Now()
+	var jobErr error
+	defer func() { recordJobRun("temperature_log", start, jobErr) }()
+
+	// Saved snapshots are checked against the threshold alert rules after the run
+	var savedSnapshots []*TemperatureSnapshot
+
+	// Additional feature values selected in the feature log settings (see feature_log.go)
+	featureLogSettings, err := GetFeatureLogSettings()
+	if err != nil {
+		log.Printf("Error getting feature log settings: %v", err)
+	}
+
 	// Get active accounts
 	activeAccounts, err := GetActiveAccounts()
 	if err != nil {
 		log.Printf("Error getting active accounts: %v", err)
+		jobErr = err
 		return
 	}
 
@@ -207,13 +219,14 @@ func temperatureLoggingJob() {
 		token, err := ensureAccountAuthenticated(account)
 		if err != nil {
 			log.Printf("Failed to authenticate account %s: %v", account.Email, err)
+			jobErr = err
 			continue
 		}
 
 		// Process each installation
 		for _, installationID := range token.InstallationIDs {
 			// Check API rate limits before making calls
-			if !checkAPIRateLimit() {
+			if !checkAPIRateLimit(account.ClientID) {
 				log.Println("API rate limit reached, skipping remaining installations to avoid hitting Viessmann API limits")
 				goto cleanup
 			}
@@ -225,18 +238,22 @@ func temperatureLoggingJob() {
 				continue
 			}
 
-			lastGateway := ""
+			// The cached device list may contain a device more than once
+			seenDevices := make(map[string]bool)
 
 			// Process each gateway and device
 			for _, gateway := range installation.Gateways {
 				for _, device := range gateway.Devices {
-					// Only collect from device ID "0" to avoid duplicates
-					if device.DeviceID != "0" {
+					// Device "0" is logged by default, further devices (cascades, room controllers,
+					// climate sensors) only if selected in the settings. Every device costs one API call.
+					deviceKey := gateway.Serial + "/" + device.DeviceID
+					if seenDevices[deviceKey] || !settings.DeviceEnabled(installationID, gateway.Serial, device.DeviceID) {
 						continue
 					}
+					seenDevices[deviceKey] = true
 
 					// Check rate limit again
-					if !checkAPIRateLimit() {
+					if !checkAPIRateLimit(account.ClientID) {
 						log.Println("API rate limit reached during device processing, stopping to avoid hitting Viessmann API limits")
 						goto cleanup
 					}
@@ -245,6 +262,7 @@ func temperatureLoggingJob() {
 					features, err := fetchFeaturesForDeviceWithTracking(installationID, gateway.Serial, device.DeviceID, token.AccessToken)
 					if err != nil {
 						log.Printf("Error fetching features for device %s: %v", device.DeviceID, err)
+						jobErr = err
 						continue
 					}
 
@@ -255,6 +273,12 @@ func temperatureLoggingJob() {
 						continue
 					}
 
+					// Devices without any of the snapshot sensors only contribute to the feature log
+					if !snapshotHasValues(snapshot) {
+						logFeatureSamples(featureLogSettings, features, installationID, gateway.Serial, device.DeviceID, snapshot.Timestamp)
+						continue
+					}
+
 					// Set the sample interval for this snapshot
 					snapshot.SampleInterval = settings.SampleInterval
 
@@ -262,24 +286,62 @@ func temperatureLoggingJob() {
 					err = SaveTemperatureSnapshot(snapshot)
 					if err != nil {
 						log.Printf("Error saving temperature snapshot: %v", err)
+						jobErr = err
 						continue
 					}
+					recordLatestSnapshot(snapshot)
+					savedSnapshots = append(savedSnapshots, snapshot)
+					logFeatureSamples(featureLogSettings, features, installationID, gateway.Serial, device.DeviceID, snapshot.Timestamp)
 
-					if lastGateway != gateway.Serial {
-						snapshotCount++
-						log.Printf("Saved temperature snapshot for installation %s (account: %s)", installationID, account.Name)
-						lastGateway = gateway.Serial
-					}
+					snapshotCount++
+					log.Printf("Saved temperature snapshot for installation %s device %s (account: %s)", installationID, device.DeviceID, account.Name)
 				}
 			}
 		}
 	}
 
 cleanup:
-	// Cleanup old snapshots based on retention policy
-	err = CleanupOldTemperatureSnapshots(settings.RetentionDays)
+	evaluateSnapshotAlerts(savedSnapshots)
+
+	if len(savedSnapshots) > 0 {
+		if err := UpdateDefrostCycles(savedSnapshots, settings.SampleInterval); err != nil {
+			log.Printf("Error updating defrost cycles: %v", err)
+		}
+	}
+
+	// Roll up the completed hours and days first, snapshots are only deleted once they are rolled up
+	err = UpdateTemperatureRollups(settings.SampleInterval)
 	if err != nil {
-		log.Printf("Error cleaning up old temperature snapshots: %v", err)
+		log.Printf("Error updating temperature rollups: %v", err)
+		jobErr = err
+	} else {
+		// Cleanup old snapshots based on retention policy
+		err = CleanupOldTemperatureSnapshots(settings.RetentionDays)
+		if err != nil {
+			log.Printf("Error cleaning up old temperature snapshots: %v", err)
+			jobErr = err
+		}
+	}
+
+	err = CleanupOldTemperatureRollups(settings.HourlyRollupRetentionDays, settings.DailyRollupRetentionDays)
+	if err != nil {
+		log.Printf("Error cleaning up old temperature rollups: %v", err)
+		jobErr = err
+	}
+
+	// Defrost cycles are kept as long as the daily rollups
+	err = CleanupOldDefrostCycles(settings.DailyRollupRetentionDays)
+	if err != nil {
+		log.Printf("Error cleaning up old defrost cycles: %v", err)
+		jobErr = err
+	}
+
+	if featureLogSettings != nil {
+		err = CleanupOldFeatureSamples(featureLogSettings.RetentionDays)
+		if err != nil {
+			log.Printf("Error cleaning up old feature samples: %v", err)
+			jobErr = err
+		}
 	}
 
 	// Log statistics
@@ -312,7 +374,7 @@ func fetchFeaturesForDeviceWithTracking(installationID, gatewayID, deviceID, acc
 
 	// Use cached version with custom cache duration
 	// If cache is stale, fetchFeaturesWithCustomCache will make an API call and we track it
-	features, err := fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken, cacheDuration)
+	features, err := fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken, cacheDuration, PriorityBackground)
 
 	// Only track API call if cache was stale (indicated by fresh LastUpdate)
 	// if err == nil && time.Since(features.LastUpdate) < 1*time.Second {
@@ -325,69 +387,6 @@ func fetchFeaturesForDeviceWithTracking(installationID, gatewayID, deviceID, acc
 	return features, err
 }
 
-// checkAPIRateLimit checks if we're within API rate limits
-func checkAPIRateLimit() bool {
-	apiCallsMutex.Lock()
-	defer apiCallsMutex.Unlock()
-
-	now := time.Now()
-	cutoff10Min := now.Add(-10 * time.Minute)
-	cutoff24Hr := now.Add(-24 * time.Hour)
-
-	// Clean up old entries
-	apiCalls10Min = filterCallsSince(apiCalls10Min, cutoff10Min)
-	apiCalls24Hr = filterCallsSince(apiCalls24Hr, cutoff24Hr)
-
-	// Check limits
-	if len(apiCalls10Min) >= apiLimit10Min {
-		log.Printf("WARNING: API rate limit reached (10-minute window): %d/%d", len(apiCalls10Min), apiLimit10Min)
-		return false
-	}
-
-	if len(apiCalls24Hr) >= apiLimit24Hr {
-		log.Printf("WARNING: API rate limit reached (24-hour window): %d/%d", len(apiCalls24Hr), apiLimit24Hr)
-		return false
-	}
-
-	return true
-}
-
-// trackAPICall records an API call for rate limiting
-func trackAPICall() {
-	apiCallsMutex.Lock()
-	defer apiCallsMutex.Unlock()
-
-	now := time.Now()
-	apiCalls10Min = append(apiCalls10Min, now)
-	apiCalls24Hr = append(apiCalls24Hr, now)
-}
-
-// filterCallsSince returns calls that occurred after the given time
-func filterCallsSince(calls []time.Time, since time.Time) []time.Time {
-	filtered := make([]time.Time, 0)
-	for _, t := range calls {
-		if t.After(since) {
-			filtered = append(filtered, t)
-		}
-	}
-	return filtered
-}
-
-// getAPIUsage returns current API usage counts
-func getAPIUsage() (int, int) {
-	apiCallsMutex.Lock()
-	defer apiCallsMutex.Unlock()
-
-	now := time.Now()
-	cutoff10Min := now.Add(-10 * time.Minute)
-	cutoff24Hr := now.Add(-24 * time.Hour)
-
-	apiCalls10Min = filterCallsSince(apiCalls10Min, cutoff10Min)
-	apiCalls24Hr = filterCallsSince(apiCalls24Hr, cutoff24Hr)
-
-	return len(apiCalls10Min), len(apiCalls24Hr)
-}
-
 // extractTemperatureSnapshot extracts all relevant data from device features
 func extractTemperatureSnapshot(features *DeviceFeatures, installationID, gatewayID, deviceID string, account *Account) *TemperatureSnapshot {
 	if features == nil || len(features.RawFeatures) == 0 {
@@ -583,6 +582,12 @@ func extractFeatureIntoSnapshot(feature Feature, snapshot *TemperatureSnapshot)
 	case "heating.sensors.pressure.supply":
 		snapshot.PressureSupply = getFloatValue(feature.Properties)
 
+	// Room climate (Zigbee climate sensors, thermostats with own sensor)
+	case "device.sensors.temperature":
+		snapshot.RoomTemp = getFloatValue(feature.Properties)
+	case "device.sensors.humidity":
+		snapshot.RoomHumidity = getFloatValue(feature.Properties)
+
 	// 4/3-Way Valve position (nested properties: current and target)
 	case "heating.secondaryCircuit.valves.fourThreeWay":
 		// Extract current position
@@ -596,9 +601,9 @@ func extractFeatureIntoSnapshot(feature Feature, snapshot *TemperatureSnapshot)
 
 	// Operating state
 
-//remove, will not be used because string content
-	//case "heating.valves.fourThreeWay.position": //heating.compressors.0.refrigerant.fourWayValve":
-	//	snapshot.FourWayValve = getStringValue(feature.Properties)
+	// Position as text (heating, domesticHotWater, defrost, ...), used by the defrost detection
+	case "heating.valves.fourThreeWay.position":
+		snapshot.FourWayValve = getStringValue(feature.Properties)
 	case "heating.burners.0.modulation":
 		snapshot.BurnerModulation = getFloatValue(feature.Properties)
 
@@ -608,6 +613,16 @@ func extractFeatureIntoSnapshot(feature Feature, snapshot *TemperatureSnapshot)
 	}
 }
 
+// snapshotHasValues reports whether any sensor value was extracted into the snapshot
+func snapshotHasValues(snapshot *TemperatureSnapshot) bool {
+	for _, field := range snapshotNumericFields() {
+		if _, ok := snapshotFieldValue(snapshot, field); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // getFloatValue extracts a float64 value from feature properties
 func getFloatValue(properties map[string]interface{}) *float64 {
 	// Try properties.value.value first (standard structure)
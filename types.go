@@ -11,6 +11,12 @@ type AccountToken struct {
 	TokenExpiry     time.Time
 	InstallationIDs []string
 	Installations   map[string]*Installation
+
+	// Token state (shown on /api/accounts)
+	LastLogin       time.Time // Last full login with password
+	LastRefresh     time.Time // Last successful refresh_token grant
+	RefreshFailures int       // Consecutive failed refresh attempts
+	LastError       string    // Last authentication error
 }
 
 type Installation struct {
@@ -128,6 +134,21 @@ type AccountResponse struct {
 	ClientID    string `json:"clientId"`
 	Active      bool   `json:"active"`
 	HasPassword bool   `json:"hasPassword"` // Don't return actual password
+	AuthMethod  string `json:"authMethod"`
+
+	TokenState *AccountTokenState `json:"tokenState,omitempty"`
+}
+
+// AccountTokenState describes the OAuth token state of an account (never contains the tokens)
+type AccountTokenState struct {
+	HasAccessToken  bool   `json:"hasAccessToken"`
+	HasRefreshToken bool   `json:"hasRefreshToken"`
+	TokenExpiry     string `json:"tokenExpiry,omitempty"`
+	Expired         bool   `json:"expired"`
+	LastLogin       string `json:"lastLogin,omitempty"`
+	LastRefresh     string `json:"lastRefresh,omitempty"`
+	RefreshFailures int    `json:"refreshFailures"`
+	LastError       string `json:"lastError,omitempty"`
 }
 
 type AccountsListResponse struct {
@@ -141,11 +162,38 @@ type AccountActionResponse struct {
 
 // Feature represents a single feature from the Viessmann API
 type Feature struct {
-	Feature    string                 `json:"feature"`
-	Properties map[string]interface{} `json:"properties"`
-	GatewayID  string                 `json:"gatewayId,omitempty"`
-	DeviceID   string                 `json:"deviceId,omitempty"`
-	Timestamp  string                 `json:"timestamp,omitempty"`
+	Feature    string                    `json:"feature"`
+	Properties map[string]interface{}    `json:"properties"`
+	Commands   map[string]FeatureCommand `json:"commands,omitempty"`
+	GatewayID  string                    `json:"gatewayId,omitempty"`
+	DeviceID   string                    `json:"deviceId,omitempty"`
+	Timestamp  string                    `json:"timestamp,omitempty"`
+}
+
+// FeatureCommand describes a command advertised by a feature
+type FeatureCommand struct {
+	Name         string                         `json:"name"`
+	URI          string                         `json:"uri"`
+	IsExecutable bool                           `json:"isExecutable"`
+	Params       map[string]FeatureCommandParam `json:"params"`
+}
+
+// FeatureCommandParam describes a single command parameter
+type FeatureCommandParam struct {
+	Type        string                    `json:"type"` // number, string, boolean, ...
+	Required    bool                      `json:"required"`
+	Constraints FeatureCommandConstraints `json:"constraints"`
+}
+
+// FeatureCommandConstraints are the value constraints of a command parameter
+type FeatureCommandConstraints struct {
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Stepping  *float64 `json:"stepping,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Regex     string   `json:"regEx,omitempty"`
 }
 
 // FeatureValue represents the parsed value of a feature
@@ -337,6 +385,10 @@ type TemperatureSnapshot struct {
 	COP            *float64 `json:"cop,omitempty"`
 	PressureSupply *float64 `json:"pressure_supply,omitempty"` // Heizwasserdruck (bar)
 
+	// Room climate (room controllers and Zigbee climate sensors, logged as their own devices)
+	RoomTemp     *float64 `json:"room_temp,omitempty"`     // device.sensors.temperature
+	RoomHumidity *float64 `json:"room_humidity,omitempty"` // device.sensors.humidity (%)
+
 	// Temperature spreads (deltaT) for each heating circuit
 	// NOTE: All circuits share the same return sensor (ReturnTemp), so these deltaT values
 	// represent supply-return spread but the return is a mixture of all circuits
@@ -355,10 +407,73 @@ type TemperatureSnapshot struct {
 
 // TemperatureLogSettings holds configuration for temperature logging
 type TemperatureLogSettings struct {
+	Enabled                   bool   `json:"enabled"`
+	SampleInterval            int    `json:"sample_interval"`              // Minutes between samples
+	RetentionDays             int    `json:"retention_days"`               // How long to keep data
+	DatabasePath              string `json:"database_path"`                // SQLite database path
+	HourlyRollupRetentionDays int    `json:"hourly_rollup_retention_days"` // How long to keep hourly rollups (0 = forever)
+	DailyRollupRetentionDays  int    `json:"daily_rollup_retention_days"`  // How long to keep daily rollups (0 = forever)
+
+	// Per-device selection. Devices without an entry follow the default: only device "0" is logged.
+	// nil on save keeps the stored selection.
+	Devices []TemperatureLogDevice `json:"devices"`
+}
+
+// TemperatureLogDevice enables or disables temperature logging for one device
+type TemperatureLogDevice struct {
+	InstallationID string `json:"installation_id"`
+	GatewayID      string `json:"gateway_id"`
+	DeviceID       string `json:"device_id"`
 	Enabled        bool   `json:"enabled"`
-	SampleInterval int    `json:"sample_interval"` // Minutes between samples
-	RetentionDays  int    `json:"retention_days"`  // How long to keep data
-	DatabasePath   string `json:"database_path"`   // SQLite database path
+}
+
+// DeviceEnabled reports whether snapshots are collected for a device
+func (s *TemperatureLogSettings) DeviceEnabled(installationID, gatewayID, deviceID string) bool {
+	for _, d := range s.Devices {
+		if d.InstallationID == installationID && d.GatewayID == gatewayID && d.DeviceID == deviceID {
+			return d.Enabled
+		}
+	}
+	return deviceID == "0"
+}
+
+// TemperatureRollup aggregates the snapshots of one device over an hour or a (local) day
+type TemperatureRollup struct {
+	BucketStart      time.Time              `json:"bucket_start"`
+	Resolution       string                 `json:"resolution"` // "hourly" or "daily"
+	InstallationID   string                 `json:"installation_id"`
+	GatewayID        string                 `json:"gateway_id"`
+	DeviceID         string                 `json:"device_id"`
+	AccountID        string                 `json:"account_id"`
+	AccountName      string                 `json:"account_name"`
+	Samples          int                    `json:"samples"`
+	SampleMinutes    float64                `json:"sample_minutes"`    // Minutes covered by the samples
+	ElectricityKWh   float64                `json:"electricity_kwh"`   // Integrated compressor power
+	ThermalKWh       float64                `json:"thermal_kwh"`       // Integrated thermal power
+	RuntimeHours     float64                `json:"runtime_hours"`     // Hours compressor was active
+	CompressorStarts float64                `json:"compressor_starts"` // Increase of the start counter
+	AvgCOP           float64                `json:"avg_cop"`           // Average of the samples with COP > 0
+	Sensors          map[string]RollupValue `json:"sensors"`           // Keyed by snapshot column
+}
+
+// RollupValue holds min/avg/max of a sensor within a rollup
+type RollupValue struct {
+	Min *float64 `json:"min"`
+	Avg *float64 `json:"avg"`
+	Max *float64 `json:"max"`
+}
+
+// TemperatureLogDeviceInfo describes a device for the device picker of the temperature log settings
+type TemperatureLogDeviceInfo struct {
+	InstallationID string     `json:"installation_id"`
+	GatewayID      string     `json:"gateway_id"`
+	DeviceID       string     `json:"device_id"`
+	DeviceType     string     `json:"device_type"`
+	ModelID        string     `json:"model_id"`
+	AccountID      string     `json:"account_id"`
+	Enabled        bool       `json:"enabled"`
+	Snapshots      int64      `json:"snapshots"`
+	LastSnapshot   *time.Time `json:"last_snapshot,omitempty"`
 }
 
 // TemperatureLogStatsResponse provides statistics about temperature logging
@@ -373,6 +488,8 @@ type TemperatureLogStatsResponse struct {
 	APIUsage24Hr     int    `json:"api_usage_24hr"`
 	APILimit10Min    int    `json:"api_limit_10min"`
 	APILimit24Hr     int    `json:"api_limit_24hr"`
+	HourlyRollups    int64  `json:"hourly_rollups"`
+	DailyRollups     int64  `json:"daily_rollups"`
 }
 
 // ConsumptionStats represents aggregated consumption statistics for a time period
@@ -380,11 +497,12 @@ type ConsumptionStats struct {
 	Period          string                 `json:"period"` // "hour", "day", "week", "month", "year"
 	StartTime       time.Time              `json:"start_time"`
 	EndTime         time.Time              `json:"end_time"`
-	ElectricityKWh  float64                `json:"electricity_kwh"` // Total electrical energy consumed
-	ThermalKWh      float64                `json:"thermal_kwh"`     // Total thermal energy produced
-	AvgCOP          float64                `json:"avg_cop"`         // Average coefficient of performance
-	RuntimeHours    float64                `json:"runtime_hours"`   // Hours compressor was active
-	Samples         int                    `json:"samples"`         // Number of snapshots
+	ElectricityKWh  float64                `json:"electricity_kwh"`    // Total electrical energy consumed
+	ThermalKWh      float64                `json:"thermal_kwh"`        // Total thermal energy produced
+	AvgCOP          float64                `json:"avg_cop"`            // Average coefficient of performance
+	RuntimeHours    float64                `json:"runtime_hours"`      // Hours compressor was active
+	Samples         int                    `json:"samples"`            // Number of snapshots
+	CostEUR         *float64               `json:"cost_eur,omitempty"` // Sum of the breakdown costs (tariff of the device)
 	HourlyBreakdown []ConsumptionDataPoint `json:"hourly_breakdown,omitempty"`
 	DailyBreakdown  []ConsumptionDataPoint `json:"daily_breakdown,omitempty"`
 }
@@ -397,6 +515,8 @@ type ConsumptionDataPoint struct {
 	AvgCOP         float64   `json:"avg_cop"`
 	RuntimeHours   float64   `json:"runtime_hours"`
 	Samples        int       `json:"samples"`
+	CostEUR        *float64  `json:"cost_eur,omitempty"`  // Electricity cost incl. power correction factor
+	AvgPrice       *float64  `json:"avg_price,omitempty"` // Effective price in EUR/kWh
 }
 
 // ConsumptionComparisonResponse provides comparative consumption statistics
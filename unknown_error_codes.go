@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnknownErrorCode is a code reported by the API that the catalogue does not know (per device model)
+type UnknownErrorCode struct {
+	Code           string `json:"code"`
+	ModelID        string `json:"modelId"`
+	Category       string `json:"category"`
+	APIDescription string `json:"apiDescription"` // errorDescription of the API (usually English)
+	FirstSeen      string `json:"firstSeen"`
+	LastSeen       string `json:"lastSeen"`
+	Occurrences    int64  `json:"occurrences"`
+	Known          bool   `json:"known"` // Known by now, e.g. after adding it to error_codes.json
+}
+
+func createUnknownErrorCodeTables() error {
+	_, err := eventDB.Exec(`
+	CREATE TABLE IF NOT EXISTS unknown_error_codes (
+		error_code TEXT NOT NULL,
+		model_id TEXT NOT NULL,
+		code_category TEXT,
+		api_description TEXT,
+		first_seen TEXT NOT NULL,
+		last_seen TEXT NOT NULL,
+		occurrences INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (error_code, model_id)
+	);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create unknown error code table: %v", err)
+	}
+	return nil
+}
+
+// upsertUnknownErrorCodeSQL adds occurrences of a code, the description of the newest event wins
+const upsertUnknownErrorCodeSQL = `
+	INSERT INTO unknown_error_codes (error_code, model_id, code_category, api_description, first_seen, last_seen, occurrences)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(error_code, model_id) DO UPDATE SET
+		occurrences = occurrences + excluded.occurrences,
+		first_seen = MIN(first_seen, excluded.first_seen),
+		last_seen = MAX(last_seen, excluded.last_seen),
+		api_description = CASE
+			WHEN excluded.api_description != '' AND (COALESCE(api_description, '') = '' OR excluded.last_seen >= last_seen)
+			THEN excluded.api_description ELSE api_description END
+`
+
+// recordUnknownErrorCodesLocked records the codes of newly archived events that are missing in the
+// catalogue, dbMutex must be held
+func recordUnknownErrorCodesLocked(newEvents []Event) error {
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	recorded := 0
+	for i := range newEvents {
+		event := &newEvents[i]
+		if event.ErrorCode == "" {
+			continue
+		}
+		info := lookupErrorCode(event.ErrorCode, event.ModelID, errorCodeDefaultLanguage)
+		if info.Known {
+			continue
+		}
+		if _, err := tx.Exec(upsertUnknownErrorCodeSQL, info.Code, event.ModelID, info.Category, event.ErrorDescription,
+			event.EventTimestamp, event.EventTimestamp, 1); err != nil {
+			return fmt.Errorf("failed to record unknown error code: %v", err)
+		}
+		recorded++
+	}
+	if recorded == 0 {
+		return nil
+	}
+	return tx.Commit()
+}
+
+// backfillUnknownErrorCodesLocked collects the unknown codes of the already archived events, dbMutex must be held
+func backfillUnknownErrorCodesLocked() (int, error) {
+	rows, err := eventDB.Query(`
+		SELECT error_code, COALESCE(model_id, ''), MIN(event_timestamp), MAX(event_timestamp), COUNT(*),
+			COALESCE((SELECT e2.error_description FROM events e2
+				WHERE e2.error_code = e.error_code AND COALESCE(e2.model_id, '') = COALESCE(e.model_id, '')
+					AND COALESCE(e2.error_description, '') != ''
+				ORDER BY e2.event_timestamp DESC LIMIT 1), '')
+		FROM events e
+		WHERE COALESCE(error_code, '') != ''
+		GROUP BY error_code, COALESCE(model_id, '')
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query error codes: %v", err)
+	}
+
+	var unknown []UnknownErrorCode
+	for rows.Next() {
+		var c UnknownErrorCode
+		if err := rows.Scan(&c.Code, &c.ModelID, &c.FirstSeen, &c.LastSeen, &c.Occurrences, &c.APIDescription); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan error code: %v", err)
+		}
+		info := lookupErrorCode(c.Code, c.ModelID, errorCodeDefaultLanguage)
+		if !info.Known {
+			c.Code = info.Code
+			c.Category = info.Category
+			unknown = append(unknown, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read error codes: %v", err)
+	}
+
+	tx, err := eventDB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, c := range unknown {
+		if _, err := tx.Exec(upsertUnknownErrorCodeSQL, c.Code, c.ModelID, c.Category, c.APIDescription,
+			c.FirstSeen, c.LastSeen, c.Occurrences); err != nil {
+			return 0, fmt.Errorf("failed to record unknown error code: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return len(unknown), nil
+}
+
+// GetUnknownErrorCodes returns the collected codes, most recently seen first
+func GetUnknownErrorCodes() ([]UnknownErrorCode, error) {
+	dbMutex.RLock()
+	defer dbMutex.RUnlock()
+
+	if !dbInitialized || eventDB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := eventDB.Query(`
+		SELECT error_code, model_id, COALESCE(code_category, ''), COALESCE(api_description, ''),
+			first_seen, last_seen, occurrences
+		FROM unknown_error_codes
+		ORDER BY last_seen DESC, error_code
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unknown error codes: %v", err)
+	}
+	defer rows.Close()
+
+	codes := []UnknownErrorCode{}
+	for rows.Next() {
+		var c UnknownErrorCode
+		if err := rows.Scan(&c.Code, &c.ModelID, &c.Category, &c.APIDescription, &c.FirstSeen, &c.LastSeen, &c.Occurrences); err != nil {
+			return nil, fmt.Errorf("failed to scan unknown error code: %v", err)
+		}
+		c.Known = lookupErrorCode(c.Code, c.ModelID, errorCodeDefaultLanguage).Known
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
+
+// exportUnknownErrorCodes converts the still unknown codes into the format of error_codes.json.
+// The API description is used as English text, the German text is left empty for translation.
+// Codes whose description differs between models are exported per model.
+func exportUnknownErrorCodes(codes []UnknownErrorCode) *errorCodeFile {
+	file := &errorCodeFile{
+		Description: "Unbekannte Fehlercodes, exportiert am " + time.Now().Format("02.01.2006 15:04"),
+		Codes:       make(map[string]ErrorCodeEntry),
+		Models:      make(map[string]map[string]ErrorCodeEntry),
+	}
+
+	byCode := make(map[string][]UnknownErrorCode)
+	for _, c := range codes {
+		if !c.Known {
+			byCode[c.Code] = append(byCode[c.Code], c)
+		}
+	}
+
+	entry := func(description string) ErrorCodeEntry {
+		return ErrorCodeEntry{Text: ErrorCodeText{"de": "", "en": description}}
+	}
+	for code, seen := range byCode {
+		descriptions := make(map[string]bool)
+		for _, c := range seen {
+			if c.APIDescription != "" {
+				descriptions[c.APIDescription] = true
+			}
+		}
+
+		if len(descriptions) <= 1 {
+			description := ""
+			for d := range descriptions {
+				description = d
+			}
+			file.Codes[code] = entry(description)
+			continue
+		}
+		for _, c := range seen {
+			if file.Models[c.ModelID] == nil {
+				file.Models[c.ModelID] = make(map[string]ErrorCodeEntry)
+			}
+			file.Models[c.ModelID][code] = entry(c.APIDescription)
+		}
+	}
+	if len(file.Models) == 0 {
+		file.Models = nil
+	}
+	return file
+}
@@ -222,14 +222,6 @@ func processEvent(raw map[string]interface{}) Event {
 
 		if errorCode, ok := body["errorCode"].(string); ok {
 			event.ErrorCode = errorCode
-			event.HumanReadable = getErrorDescription(errorCode)
-			event.CodeCategory = getCodeCategory(errorCode)
-			event.Severity = getSeverity(errorCode)
-
-			// If description is "Unbekannter..." and we have errorDescription from API, use that instead
-			if strings.Contains(event.HumanReadable, "Unbekannter") && errorDescription != "" {
-				event.HumanReadable = errorCode + " - " + errorDescription
-			}
 		}
 
 		if deviceID, ok := body["deviceId"].(string); ok {
@@ -258,6 +250,19 @@ func processEvent(raw map[string]interface{}) Event {
 			event.ModelID = "Unknown"
 		}
 
+		// Texts are resolved per model, so model-specific catalogue entries apply
+		if event.ErrorCode != "" {
+			info := lookupErrorCode(event.ErrorCode, event.ModelID, errorCodeDefaultLanguage)
+			event.HumanReadable = info.Text
+			event.CodeCategory = info.Category
+			event.Severity = info.Severity
+
+			// Unknown code: use the errorDescription from the API if there is one
+			if !info.Known && errorDescription != "" {
+				event.HumanReadable = event.ErrorCode + " - " + errorDescription
+			}
+		}
+
 		if active, ok := body["active"].(bool); ok {
 			event.Active = &active
 		}
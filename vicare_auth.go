@@ -13,12 +13,21 @@ import (
 )
 
 const (
-	authorizeURL = "https://iam.viessmann-climatesolutions.com/idp/v3/authorize"
-	tokenURL     = "https://iam.viessmann-climatesolutions.com/idp/v3/token"
-	redirectURI  = "vicare://oauth-callback/everest"
+	redirectURI = "vicare://oauth-callback/everest"
 )
 
-var viessmannScope = []string{"IoT User"}
+// authorizeURL returns the IAM authorize endpoint for the configured IAM base URL
+func authorizeURL() string {
+	return iamBaseURL + "/idp/v3/authorize"
+}
+
+// tokenURL returns the IAM token endpoint for the configured IAM base URL
+func tokenURL() string {
+	return iamBaseURL + "/idp/v3/token"
+}
+
+// offline_access is required to get a refresh token
+var viessmannScope = []string{"IoT User", "offline_access"}
 
 // TokenResponse represents the OAuth2 token response
 type TokenResponse struct {
@@ -56,7 +65,7 @@ func AuthenticateWithViCare(username, password, clientID string) (*TokenResponse
 	authParams.Add("code_challenge_method", "S256")
 	authParams.Add("scope", strings.Join(viessmannScope, " "))
 
-	authURL := authorizeURL + "?" + authParams.Encode()
+	authURL := authorizeURL() + "?" + authParams.Encode()
 
 	// Step 1: POST to authorization URL with credentials
 	req, err := NewRequest("POST", authURL, nil)
@@ -68,6 +77,7 @@ func AuthenticateWithViCare(username, password, clientID string) (*TokenResponse
 	req.SetBasicAuth(username, password)
 
 	client := &http.Client{
+		Transport: viessmannTransport,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse // Don't follow redirects
 		},
@@ -103,21 +113,26 @@ func AuthenticateWithViCare(username, password, clientID string) (*TokenResponse
 	}
 
 	// Step 2: Exchange authorization code for access token
+	return exchangeAuthorizationCode(clientID, redirectURI, code, codeVerifier)
+}
+
+// exchangeAuthorizationCode exchanges an authorization code (PKCE) for access and refresh token
+func exchangeAuthorizationCode(clientID, redirect, code, codeVerifier string) (*TokenResponse, error) {
 	tokenParams := url.Values{}
 	tokenParams.Add("grant_type", "authorization_code")
 	tokenParams.Add("client_id", clientID)
-	tokenParams.Add("redirect_uri", redirectURI)
+	tokenParams.Add("redirect_uri", redirect)
 	tokenParams.Add("code", code)
 	tokenParams.Add("code_verifier", codeVerifier)
 
-	tokenReq, err := NewRequest("POST", tokenURL, strings.NewReader(tokenParams.Encode()))
+	tokenReq, err := NewRequest("POST", tokenURL(), strings.NewReader(tokenParams.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 
 	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	tokenResp, err := http.DefaultClient.Do(tokenReq)
+	tokenResp, err := viessmannHTTPClient.Do(tokenReq)
 	if err != nil {
 		return nil, fmt.Errorf("token request failed: %w", err)
 	}
@@ -133,6 +148,59 @@ func AuthenticateWithViCare(username, password, clientID string) (*TokenResponse
 		return nil, fmt.Errorf("failed to decode token response: %w", err)
 	}
 
+	registerTokenClient(tokenResponse.AccessToken, clientID)
+	return &tokenResponse, nil
+}
+
+// buildAuthorizeURL returns the URL the user's browser is sent to for the interactive login
+func buildAuthorizeURL(clientID, redirect, codeChallenge, state string) string {
+	authParams := url.Values{}
+	authParams.Add("client_id", clientID)
+	authParams.Add("redirect_uri", redirect)
+	authParams.Add("response_type", "code")
+	authParams.Add("code_challenge", codeChallenge)
+	authParams.Add("code_challenge_method", "S256")
+	authParams.Add("scope", strings.Join(viessmannScope, " "))
+	authParams.Add("state", state)
+
+	return authorizeURL() + "?" + authParams.Encode()
+}
+
+// RefreshAccessToken obtains a new access token using the refresh_token grant
+func RefreshAccessToken(clientID, refreshToken string) (*TokenResponse, error) {
+	tokenParams := url.Values{}
+	tokenParams.Add("grant_type", "refresh_token")
+	tokenParams.Add("client_id", clientID)
+	tokenParams.Add("refresh_token", refreshToken)
+
+	tokenReq, err := NewRequest("POST", tokenURL(), strings.NewReader(tokenParams.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh request: %w", err)
+	}
+
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	tokenResp, err := viessmannHTTPClient.Do(tokenReq)
+	if err != nil {
+		return nil, fmt.Errorf("refresh request failed: %w", err)
+	}
+	defer tokenResp.Body.Close()
+
+	if tokenResp.StatusCode != 200 {
+		body, _ := io.ReadAll(tokenResp.Body)
+		return nil, fmt.Errorf("refresh request failed (status %d): %s", tokenResp.StatusCode, string(body))
+	}
+
+	var tokenResponse TokenResponse
+	if err := json.NewDecoder(tokenResp.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("refresh response contains no access token")
+	}
+
+	registerTokenClient(tokenResponse.AccessToken, clientID)
 	return &tokenResponse, nil
 }
 
@@ -153,14 +221,14 @@ func AuthenticateWithPasswordGrant(username, password, clientID, clientSecret st
 	tokenParams.Add("client_secret", clientSecret)
 	tokenParams.Add("scope", "openid offline_access Internal")
 
-	tokenReq, err := NewRequest("POST", tokenURL, strings.NewReader(tokenParams.Encode()))
+	tokenReq, err := NewRequest("POST", tokenURL(), strings.NewReader(tokenParams.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token request: %w", err)
 	}
 
 	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	tokenResp, err := http.DefaultClient.Do(tokenReq)
+	tokenResp, err := viessmannHTTPClient.Do(tokenReq)
 	if err != nil {
 		return nil, fmt.Errorf("token request failed: %w", err)
 	}
@@ -176,5 +244,6 @@ func AuthenticateWithPasswordGrant(username, password, clientID, clientSecret st
 		return nil, fmt.Errorf("failed to decode token response: %w", err)
 	}
 
+	registerTokenClient(tokenResponse.AccessToken, clientID)
 	return &tokenResponse, nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,41 @@ import (
 	"time"
 )
 
+const (
+	// Default endpoints of the Viessmann cloud
+	defaultAPIBaseURL = "https://api.viessmann-climatesolutions.com"
+	defaultIAMBaseURL = "https://iam.viessmann-climatesolutions.com"
+)
+
+// API endpoint configuration
+// Can be overridden via VICARE_API_BASE_URL / VICARE_IAM_BASE_URL (e.g. for a local mock server)
+var (
+	apiBaseURL = strings.TrimRight(getEnv("VICARE_API_BASE_URL", defaultAPIBaseURL), "/")
+	iamBaseURL = strings.TrimRight(getEnv("VICARE_IAM_BASE_URL", defaultIAMBaseURL), "/")
+)
+
+// viessmannTransport passes all outbound requests to the Viessmann API through the retry layer and the broker
+var viessmannTransport http.RoundTripper = &retryTransport{base: &brokerTransport{base: http.DefaultTransport}}
+
+// viessmannHTTPClient is used for all requests to the Viessmann API and IAM
+var viessmannHTTPClient = &http.Client{Transport: viessmannTransport}
+
+// setAPIBaseURLs overrides the API and IAM base URLs at runtime
+func setAPIBaseURLs(api, iam string) {
+	apiBaseURL = strings.TrimRight(api, "/")
+	iamBaseURL = strings.TrimRight(iam, "/")
+	log.Printf("Using Viessmann API base URL: %s, IAM base URL: %s", apiBaseURL, iamBaseURL)
+}
+
+// rewriteAPIURL maps URLs pointing to the Viessmann cloud onto the configured base URL
+// This keeps hard-coded URLs (e.g. from the API test page) working against a mock server
+func rewriteAPIURL(rawURL string) string {
+	if apiBaseURL != defaultAPIBaseURL && strings.HasPrefix(rawURL, defaultAPIBaseURL) {
+		return apiBaseURL + strings.TrimPrefix(rawURL, defaultAPIBaseURL)
+	}
+	return rawURL
+}
+
 // Cache variables for API functions
 var (
 	// Features cache
@@ -20,6 +56,11 @@ var (
 
 // fetchEvents fetches events from all active accounts with cursor-based pagination
 func fetchEvents(daysBack int) ([]Event, error) {
+	return fetchEventsWithPriority(daysBack, PriorityDashboard)
+}
+
+// fetchEventsWithPriority fetches events from all active accounts using the given broker priority
+func fetchEventsWithPriority(daysBack int, priority RequestPriority) ([]Event, error) {
 	fetchMutex.Lock()
 	defer fetchMutex.Unlock()
 
@@ -37,7 +78,7 @@ func fetchEvents(daysBack int) ([]Event, error) {
 	if len(activeAccounts) == 0 {
 		// Fallback to legacy single credential
 		if currentCreds != nil {
-			return fetchEventsLegacy(daysBack)
+			return fetchEventsLegacy(daysBack, priority)
 		}
 		return nil, fmt.Errorf("no active accounts found")
 	}
@@ -57,7 +98,7 @@ func fetchEvents(daysBack int) ([]Event, error) {
 
 		// Fetch events from all installations for this account
 		for _, installationID := range token.InstallationIDs {
-			accountEvents, err := fetchEventsForInstallation(installationID, token.AccessToken, account, daysBack)
+			accountEvents, err := fetchEventsForInstallation(installationID, token.AccessToken, account, daysBack, priority)
 			if err != nil {
 				log.Printf("Error fetching events for installation %s: %v\n", installationID, err)
 				continue
@@ -78,13 +119,13 @@ func fetchEvents(daysBack int) ([]Event, error) {
 
 // fetchEventsForInstallation fetches events for a single installation with cursor pagination
 // Stops early if events already exist in SQLite database
-func fetchEventsForInstallation(installationID, accessToken string, account *Account, daysBack int) ([]Event, error) {
-	return fetchEventsForInstallationInternal(installationID, accessToken, account, daysBack, true)
+func fetchEventsForInstallation(installationID, accessToken string, account *Account, daysBack int, priority RequestPriority) ([]Event, error) {
+	return fetchEventsForInstallationInternal(installationID, accessToken, account, daysBack, true, priority)
 }
 
 // fetchEventsForInstallationFullSync fetches ALL events without early-stop logic
 func fetchEventsForInstallationFullSync(installationID, accessToken string, account *Account, daysBack int) ([]Event, error) {
-	return fetchEventsForInstallationInternal(installationID, accessToken, account, daysBack, false)
+	return fetchEventsForInstallationInternal(installationID, accessToken, account, daysBack, false, PriorityBackground)
 }
 
 // setAPICallsCount can be used to set an Ui variable
@@ -94,18 +135,20 @@ func setAPICallsCount() {
 	log.Printf("API usage ----- %d/10min ----- %d/24hr -----", usage10min, usage24hr)
 }
 
-// NewRequest wraps method http.NewRequest to track API calls
+// NewRequest wraps http.NewRequest for Viessmann API calls.
+// Calls are counted and throttled by the request broker when they are sent (see api_broker.go);
+// without explicit priority, POST requests are interactive and GET requests dashboard requests.
 func NewRequest(method, url string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
-	if err == nil {
-		trackAPICall()
-		setAPICallsCount()
-	}
-	return req, err
+	return http.NewRequest(method, url, body)
+}
+
+// NewRequestWithPriority creates a Viessmann API request with an explicit broker priority
+func NewRequestWithPriority(priority RequestPriority, method, url string, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(withRequestPriority(context.Background(), priority), method, url, body)
 }
 
 // fetchEventsForInstallationInternal is the internal implementation with optional early-stop
-func fetchEventsForInstallationInternal(installationID, accessToken string, account *Account, daysBack int, enableEarlyStop bool) ([]Event, error) {
+func fetchEventsForInstallationInternal(installationID, accessToken string, account *Account, daysBack int, enableEarlyStop bool, priority RequestPriority) ([]Event, error) {
 	var allEvents []Event
 	var cursor string
 	pageCount := 0
@@ -115,8 +158,8 @@ func fetchEventsForInstallationInternal(installationID, accessToken string, acco
 		pageCount++
 
 		// Build URL with cursor or lastNDays parameter
-		baseURL := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/events-history/installations/%s/events", installationID)
-		req, err := NewRequest("GET", baseURL, nil)
+		baseURL := fmt.Sprintf("%s/iot/v2/events-history/installations/%s/events", apiBaseURL, installationID)
+		req, err := NewRequestWithPriority(priority, "GET", baseURL, nil)
 		if err != nil {
 			return allEvents, fmt.Errorf("failed to create request: %w", err)
 		}
@@ -134,14 +177,15 @@ func fetchEventsForInstallationInternal(installationID, accessToken string, acco
 
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := viessmannHTTPClient.Do(req)
 		if err != nil {
 			return allEvents, fmt.Errorf("request failed: %w", err)
 		}
 
 		if resp.StatusCode != http.StatusOK {
+			apiErr := apiErrorFromResponse(resp)
 			resp.Body.Close()
-			return allEvents, fmt.Errorf("API returned status %d", resp.StatusCode)
+			return allEvents, apiErr
 		}
 
 		var eventsResp EventsResponse
@@ -204,7 +248,7 @@ func fetchEventsForInstallationInternal(installationID, accessToken string, acco
 }
 
 // fetchEventsLegacy fetches events from legacy single credential (backward compatibility)
-func fetchEventsLegacy(daysBack int) ([]Event, error) {
+func fetchEventsLegacy(daysBack int, priority RequestPriority) ([]Event, error) {
 	if err := ensureAuthenticated(); err != nil {
 		return eventsCache, err
 	}
@@ -218,7 +262,7 @@ func fetchEventsLegacy(daysBack int) ([]Event, error) {
 			Name: "Legacy Account",
 		}
 
-		accountEvents, err := fetchEventsForInstallation(installationID, accessToken, legacyAccount, daysBack)
+		accountEvents, err := fetchEventsForInstallation(installationID, accessToken, legacyAccount, daysBack, priority)
 		if err != nil {
 			log.Printf("Error fetching events for installation %s: %v\n", installationID, err)
 			continue
@@ -235,13 +279,18 @@ func fetchEventsLegacy(daysBack int) ([]Event, error) {
 
 // fetchFeaturesForDevice fetches features for a specific installation/gateway/device
 func fetchFeaturesForDevice(installationID, gatewayID, deviceID, accessToken string) (*DeviceFeatures, error) {
+	return fetchFeaturesForDeviceWithPriority(installationID, gatewayID, deviceID, accessToken, PriorityDashboard)
+}
+
+// fetchFeaturesForDeviceWithPriority fetches features using the given broker priority
+func fetchFeaturesForDeviceWithPriority(installationID, gatewayID, deviceID, accessToken string, priority RequestPriority) (*DeviceFeatures, error) {
 	// Build API URL with includeDeviceFeatures parameter to get array-based statistics
-	url := fmt.Sprintf("https://api.viessmann-climatesolutions.com/iot/v2/features/installations/%s/gateways/%s/devices/%s/features?includeDeviceFeatures=true",
+	url := fmt.Sprintf("%s/iot/v2/features/installations/%s/gateways/%s/devices/%s/features?includeDeviceFeatures=true", apiBaseURL,
 		installationID, gatewayID, deviceID)
 
 	log.Printf("Fetching features from API: %s\n", url)
 
-	req, err := NewRequest("GET", url, nil)
+	req, err := NewRequestWithPriority(priority, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -249,16 +298,16 @@ func fetchFeaturesForDevice(installationID, gatewayID, deviceID, accessToken str
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := viessmannHTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		log.Printf("ERROR: API returned status %d for %s\nResponse: %s\n", resp.StatusCode, url, string(bodyBytes))
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		apiErr := apiErrorFromResponse(resp)
+		log.Printf("ERROR: API returned status %d for %s\nResponse: %s\n", resp.StatusCode, url, apiErr.Body)
+		return nil, apiErr
 	}
 
 	var featuresResp FeaturesResponse
@@ -310,11 +359,11 @@ func parseFeatures(features []Feature, installationID, gatewayID, deviceID strin
 
 // fetchFeaturesWithCache fetches features with caching support (default 5 minutes)
 func fetchFeaturesWithCache(installationID, gatewayID, deviceID, accessToken string) (*DeviceFeatures, error) {
-	return fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken, 5*time.Minute)
+	return fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken, 5*time.Minute, PriorityDashboard)
 }
 
 // fetchFeaturesWithCustomCache fetches features with configurable cache duration
-func fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken string, cacheDuration time.Duration) (*DeviceFeatures, error) {
+func fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessToken string, cacheDuration time.Duration, priority RequestPriority) (*DeviceFeatures, error) {
 	cacheKey := fmt.Sprintf("%s:%s:%s", installationID, gatewayID, deviceID)
 
 	// Check cache first
@@ -329,7 +378,7 @@ func fetchFeaturesWithCustomCache(installationID, gatewayID, deviceID, accessTok
 	featuresCacheMutex.RUnlock()
 
 	// Fetch fresh data
-	features, err := fetchFeaturesForDevice(installationID, gatewayID, deviceID, accessToken)
+	features, err := fetchFeaturesForDeviceWithPriority(installationID, gatewayID, deviceID, accessToken, priority)
 	if err != nil {
 		// Return stale cache if available
 		featuresCacheMutex.RLock()
@@ -378,14 +427,14 @@ func getDeviceNameFromFeatures(installationID, gatewayID, deviceID, accessToken
 // fetchGatewayIDForInstallation fetches the gateway ID for an installation
 func fetchGatewayIDForInstallation(installationID, accessToken string) (string, error) {
 	// Fetch all installations to get gateway info
-	req, err := NewRequest("GET", "https://api.viessmann-climatesolutions.com/iot/v2/equipment/installations", nil)
+	req, err := NewRequest("GET", apiBaseURL+"/iot/v2/equipment/installations", nil)
 	if err != nil {
 		return "", err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := viessmannHTTPClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -445,7 +494,7 @@ func executeAPIRequest(method, url, accessToken string, requestBody map[string]i
 		bodyReader = strings.NewReader(string(bodyBytes))
 	}
 
-	req, err := NewRequest(method, url, bodyReader)
+	req, err := NewRequest(method, rewriteAPIURL(url), bodyReader)
 	if err != nil {
 		return 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -457,7 +506,7 @@ func executeAPIRequest(method, url, accessToken string, requestBody map[string]i
 
 	log.Printf("Executing %s request to: %s\n", method, url)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := viessmannHTTPClient.Do(req)
 	if err != nil {
 		return 0, nil, fmt.Errorf("request failed: %w", err)
 	}